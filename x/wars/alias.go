@@ -16,9 +16,46 @@ const (
 	SwapperFunction   = types.SwapperFunction
 	AugmentedFunction = types.AugmentedFunction
 
+	ExponentialFunction = types.ExponentialFunction
+	LogarithmicFunction = types.LogarithmicFunction
+	BancorFunction      = types.BancorFunction
+
+	PiecewiseLinearFunction = types.PiecewiseLinearFunction
+	WeightedSwapperFunction = types.WeightedSwapperFunction
+	StableSwapFunction      = types.StableSwapFunction
+
 	HatchState  = types.HatchState
 	OpenState   = types.OpenState
 	SettleState = types.SettleState
+	FailedState = types.FailedState
+
+	BuyOrderType        = types.BuyOrderType
+	SellOrderType       = types.SellOrderType
+	SwapOrderType       = types.SwapOrderType
+	RoutedSwapOrderType = types.RoutedSwapOrderType
+	LimitBuyOrderType   = types.LimitBuyOrderType
+	LimitSellOrderType  = types.LimitSellOrderType
+
+	CancelReasonCancelledByOwner = types.CancelReasonCancelledByOwner
+	CancelReasonWarPaused        = types.CancelReasonWarPaused
+	CancelReasonMaxPriceMove     = types.CancelReasonMaxPriceMove
+	CancelReasonHatchFailed      = types.CancelReasonHatchFailed
+	CancelReasonExpired          = types.CancelReasonExpired
+
+	SpendProposalType = types.SpendProposalType
+	EditProposalType  = types.EditProposalType
+
+	YesVoteOption = types.YesVoteOption
+	NoVoteOption  = types.NoVoteOption
+
+	ProposalResultPassed   = types.ProposalResultPassed
+	ProposalResultRejected = types.ProposalResultRejected
+	ProposalResultFailed   = types.ProposalResultFailed
+
+	PendingEditResultApplied = types.PendingEditResultApplied
+	PendingEditResultFailed  = types.PendingEditResultFailed
+
+	MaxSwapRouteHops = types.MaxSwapRouteHops
 
 	DoNotModifyField = types.DoNotModifyField
 
@@ -30,9 +67,13 @@ const (
 	DefaultParamspace = types.DefaultParamspace
 	StoreKey          = types.StoreKey
 
-	WarsMintBurnAccount       = types.WarsMintBurnAccount
+	WarsMintBurnAccount        = types.WarsMintBurnAccount
 	BatchesIntermediaryAccount = types.BatchesIntermediaryAccount
-	WarsReserveAccount        = types.WarsReserveAccount
+	WarsReserveAccount         = types.WarsReserveAccount
+	WarsVestingAccount         = types.WarsVestingAccount
+	WarsFundingPoolAccount     = types.WarsFundingPoolAccount
+	WarsVotingEscrowAccount    = types.WarsVotingEscrowAccount
+	WarsDepositAccount         = types.WarsDepositAccount
 
 	QuerierRoute = types.QuerierRoute
 	RouterKey    = types.RouterKey
@@ -51,13 +92,37 @@ var (
 
 	RegisterCodec = types.RegisterCodec
 
-	NewBatch         = types.NewBatch
-	NewBaseOrder     = types.NewBaseOrder
-	NewBuyOrder      = types.NewBuyOrder
-	NewSellOrder     = types.NewSellOrder
-	NewSwapOrder     = types.NewSwapOrder
-	NewFunctionParam = types.NewFunctionParam
-	NewWar          = types.NewWar
+	NewBatch              = types.NewBatch
+	NewBaseOrder          = types.NewBaseOrder
+	NewBuyOrder           = types.NewBuyOrder
+	NewSellOrder          = types.NewSellOrder
+	NewSwapOrder          = types.NewSwapOrder
+	NewRoutedSwapOrder    = types.NewRoutedSwapOrder
+	NewSwapHop            = types.NewSwapHop
+	NewLimitOrder         = types.NewLimitOrder
+	NewOrderBook          = types.NewOrderBook
+	NewHatchContribution  = types.NewHatchContribution
+	NewHatchContributions = types.NewHatchContributions
+	NewHatchVesting       = types.NewHatchVesting
+	NewHatchVestings      = types.NewHatchVestings
+	NewFundingPool        = types.NewFundingPool
+	NewWarEdit            = types.NewWarEdit
+	NewSignersEdit        = types.NewSignersEdit
+	NewPendingEdit        = types.NewPendingEdit
+	NewSignersPendingEdit = types.NewSignersPendingEdit
+	NewPendingEdits       = types.NewPendingEdits
+	NewVote               = types.NewVote
+	NewTallyResult        = types.NewTallyResult
+	NewVotingEscrow       = types.NewVotingEscrow
+	NewSpendProposal      = types.NewSpendProposal
+	NewEditProposal       = types.NewEditProposal
+	NewProposals          = types.NewProposals
+	NewFunctionParam      = types.NewFunctionParam
+	NewWar                = types.NewWar
+
+	RegisterCurveFunction      = types.RegisterCurveFunction
+	GetCurveFunction           = types.GetCurveFunction
+	GetRegisteredFunctionTypes = types.GetRegisteredFunctionTypes
 
 	RoundReservePrice     = types.RoundReservePrice
 	RoundReserveReturn    = types.RoundReserveReturn
@@ -76,47 +141,84 @@ var (
 	ValidateGenesis     = types.ValidateGenesis
 	DefaultGenesisState = types.DefaultGenesisState
 
-	GetWarKey      = types.GetWarKey
-	GetBatchKey     = types.GetBatchKey
-	GetLastBatchKey = types.GetLastBatchKey
+	GetWarKey                = types.GetWarKey
+	GetBatchKey              = types.GetBatchKey
+	GetLastBatchKey          = types.GetLastBatchKey
+	GetLimitOrdersKey        = types.GetLimitOrdersKey
+	GetLimitOrderKey         = types.GetLimitOrderKey
+	GetHatchContributionsKey = types.GetHatchContributionsKey
+	GetHatchContributionKey  = types.GetHatchContributionKey
+	GetHatchVestingsKey      = types.GetHatchVestingsKey
+	GetHatchVestingKey       = types.GetHatchVestingKey
+	GetFundingPoolKey        = types.GetFundingPoolKey
+	GetProposalsKey          = types.GetProposalsKey
+	GetProposalKey           = types.GetProposalKey
+	GetPendingEditsKey       = types.GetPendingEditsKey
+	GetVotesKey              = types.GetVotesKey
+	GetVotingEscrowsKey      = types.GetVotingEscrowsKey
+	GetVotingEscrowKey       = types.GetVotingEscrowKey
+	GetVoteKey               = types.GetVoteKey
+	GetNextProposalIDKey     = types.GetNextProposalIDKey
+	GetWarCountByCreatorKey  = types.GetWarCountByCreatorKey
+	GetLimitOrderExpiriesKey = types.GetLimitOrderExpiriesKey
+	GetLimitOrderExpiryKey   = types.GetLimitOrderExpiryKey
+	GetLimitOrderPricesKey   = types.GetLimitOrderPricesKey
+	GetLimitOrderPriceKey    = types.GetLimitOrderPriceKey
+	GetNextLimitOrderIDKey   = types.GetNextLimitOrderIDKey
+	GetLimitOrderCountKey    = types.GetLimitOrderCountKey
 
-	NewMsgCreateWar         = types.NewMsgCreateWar
-	NewMsgEditWar           = types.NewMsgEditWar
-	NewMsgBuy                = types.NewMsgBuy
-	NewMsgSell               = types.NewMsgSell
-	NewMsgSwap               = types.NewMsgSwap
-	NewMsgMakeOutcomePayment = types.NewMsgMakeOutcomePayment
-	NewMsgWithdrawShare      = types.NewMsgWithdrawShare
+	GetLimitOrderExpiriesUntilKey  = types.GetLimitOrderExpiriesUntilKey
+	GetLimitOrderCountByAddressKey = types.GetLimitOrderCountByAddressKey
+	GetHatchVestingStartHeightKey  = types.GetHatchVestingStartHeightKey
+
+	NewMsgCreateWar            = types.NewMsgCreateWar
+	NewMsgEditWar              = types.NewMsgEditWar
+	NewMsgUpdateWarSigners     = types.NewMsgUpdateWarSigners
+	NewMsgCancelPendingEdit    = types.NewMsgCancelPendingEdit
+	NewMsgSetWarPaused         = types.NewMsgSetWarPaused
+	NewMsgBuy                  = types.NewMsgBuy
+	NewMsgBuyWithSpend         = types.NewMsgBuyWithSpend
+	NewMsgSell                 = types.NewMsgSell
+	NewMsgSwap                 = types.NewMsgSwap
+	NewMsgRoutedSwap           = types.NewMsgRoutedSwap
+	NewMsgLimitBuy             = types.NewMsgLimitBuy
+	NewMsgLimitSell            = types.NewMsgLimitSell
+	NewMsgCancelOrder          = types.NewMsgCancelOrder
+	NewMsgCancelLimitOrder     = types.NewMsgCancelLimitOrder
+	NewMsgMakeOutcomePayment   = types.NewMsgMakeOutcomePayment
+	NewMsgWithdrawShare        = types.NewMsgWithdrawShare
+	NewMsgClaimVested          = types.NewMsgClaimVested
+	NewMsgSpendFromFundingPool = types.NewMsgSpendFromFundingPool
+	NewMsgSubmitSpendProposal  = types.NewMsgSubmitSpendProposal
+	NewMsgSubmitEditProposal   = types.NewMsgSubmitEditProposal
+	NewMsgVote                 = types.NewMsgVote
 
 	ParseFunctionParams = client.ParseFunctionParams
 	ParseSigners        = client.ParseSigners
 	ParseTwoPartCoin    = client.ParseTwoPartCoin
+	ParseSwapRoute      = client.ParseSwapRoute
 
 	// variable aliases
 
 	ModuleCdc = types.ModuleCdc
 
-	RequiredParamsForFunctionType    = types.RequiredParamsForFunctionType
-	NoOfReserveTokensForFunctionType = types.NoOfReserveTokensForFunctionType
-	ExtraParameterRestrictions       = types.ExtraParameterRestrictions
-
 	ErrArgumentMustBePositive               = types.ErrArgumentMustBePositive
 	ErrArgumentMustBeInteger                = types.ErrArgumentMustBeInteger
 	ErrArgumentMustBeBetween                = types.ErrArgumentMustBeBetween
 	ErrArgumentCannotBeEmpty                = types.ErrArgumentCannotBeEmpty
 	ErrArgumentCannotBeNegative             = types.ErrArgumentCannotBeNegative
 	ErrArgumentMissingOrNonFloat            = types.ErrArgumentMissingOrNonFloat
-	ErrWarDoesNotExist                     = types.ErrWarDoesNotExist
-	ErrWarAlreadyExists                    = types.ErrWarAlreadyExists
-	ErrWarTokenCannotBeStakingToken        = types.ErrWarTokenCannotBeStakingToken
+	ErrWarDoesNotExist                      = types.ErrWarDoesNotExist
+	ErrWarAlreadyExists                     = types.ErrWarAlreadyExists
+	ErrWarTokenCannotBeStakingToken         = types.ErrWarTokenCannotBeStakingToken
 	ErrInvalidStateForAction                = types.ErrInvalidStateForAction
 	ErrReserveDenomsMismatch                = types.ErrReserveDenomsMismatch
 	ErrOrderQuantityLimitExceeded           = types.ErrOrderQuantityLimitExceeded
 	ErrValuesViolateSanityRate              = types.ErrValuesViolateSanityRate
-	ErrWarDoesNotAllowSelling              = types.ErrWarDoesNotAllowSelling
+	ErrWarDoesNotAllowSelling               = types.ErrWarDoesNotAllowSelling
 	ErrFunctionNotAvailableForFunctionType  = types.ErrFunctionNotAvailableForFunctionType
 	ErrCannotMakeZeroOutcomePayment         = types.ErrCannotMakeZeroOutcomePayment
-	ErrNoWarTokensOwned                    = types.ErrNoWarTokensOwned
+	ErrNoWarTokensOwned                     = types.ErrNoWarTokensOwned
 	ErrCannotBurnMoreThanSupply             = types.ErrCannotBurnMoreThanSupply
 	ErrFeesCannotBeOrExceed100Percent       = types.ErrFeesCannotBeOrExceed100Percent
 	ErrFromAndToCannotBeTheSameToken        = types.ErrFromAndToCannotBeTheSameToken
@@ -132,41 +234,135 @@ var (
 	ErrInvalidCoinDenomination              = types.ErrInvalidCoinDenomination
 	ErrMaxSupplyDenomDoesNotMatchTokenDenom = types.ErrMaxSupplyDenomDoesNotMatchTokenDenom
 	ErrDidNotEditAnything                   = types.ErrDidNotEditAnything
-	ErrWarTokenCannotAlsoBeReserveToken    = types.ErrWarTokenCannotAlsoBeReserveToken
+	ErrWarTokenCannotAlsoBeReserveToken     = types.ErrWarTokenCannotAlsoBeReserveToken
 	ErrDuplicateReserveToken                = types.ErrDuplicateReserveToken
 	ErrUnrecognizedFunctionType             = types.ErrUnrecognizedFunctionType
 	ErrIncorrectNumberOfReserveTokens       = types.ErrIncorrectNumberOfReserveTokens
 	ErrInvalidFunctionParameter             = types.ErrInvalidFunctionParameter
 	ErrArgumentMissingOrNonUInteger         = types.ErrArgumentMissingOrNonUInteger
 	ErrArgumentMissingOrNonBoolean          = types.ErrArgumentMissingOrNonBoolean
+	ErrFunctionResultTooLarge               = types.ErrFunctionResultTooLarge
+	ErrFunctionDidNotConverge               = types.ErrFunctionDidNotConverge
+	ErrMinReturnsNotReached                 = types.ErrMinReturnsNotReached
+	ErrOrderDoesNotExist                    = types.ErrOrderDoesNotExist
+	ErrOrderAlreadyCancelled                = types.ErrOrderAlreadyCancelled
+	ErrInvalidSwapRoute                     = types.ErrInvalidSwapRoute
+	ErrNoSwapRouteFound                     = types.ErrNoSwapRouteFound
+	ErrExpiryHeightAlreadyPassed            = types.ErrExpiryHeightAlreadyPassed
+	ErrHatchFieldOnlyForAugmented           = types.ErrHatchFieldOnlyForAugmented
+	ErrHatchDeadlineAlreadyPassed           = types.ErrHatchDeadlineAlreadyPassed
+	ErrNotAllowedToHatch                    = types.ErrNotAllowedToHatch
+	ErrMaxHatchContributionExceeded         = types.ErrMaxHatchContributionExceeded
+	ErrVestingCliffExceedsVestingPeriod     = types.ErrVestingCliffExceedsVestingPeriod
+	ErrNoVestedTokensToClaim                = types.ErrNoVestedTokensToClaim
+	ErrWarTokensLocked                      = types.ErrWarTokensLocked
+	ErrNotFundingPoolSpender                = types.ErrNotFundingPoolSpender
+	ErrFundingPoolSpendLimitExceeded        = types.ErrFundingPoolSpendLimitExceeded
+	ErrInsufficientFundingPool              = types.ErrInsufficientFundingPool
+	ErrInvalidGovernancePercentage          = types.ErrInvalidGovernancePercentage
+	ErrGovernanceNotEnabled                 = types.ErrGovernanceNotEnabled
+	ErrProposalDoesNotExist                 = types.ErrProposalDoesNotExist
+	ErrInvalidProposalType                  = types.ErrInvalidProposalType
+	ErrInvalidVoteOption                    = types.ErrInvalidVoteOption
+	ErrNoVotingPower                        = types.ErrNoVotingPower
+	ErrAlreadyVoted                         = types.ErrAlreadyVoted
+	ErrDuplicateSigner                      = types.ErrDuplicateSigner
+	ErrInvalidSignerThreshold               = types.ErrInvalidSignerThreshold
+	ErrNotWarSigner                         = types.ErrNotWarSigner
+	ErrSignerThresholdNotMet                = types.ErrSignerThresholdNotMet
+	ErrFeeCannotBeIncreased                 = types.ErrFeeCannotBeIncreased
+	ErrMaxSupplyBelowCurrentSupply          = types.ErrMaxSupplyBelowCurrentSupply
+	ErrCannotEditAllowSellsDuringHatch      = types.ErrCannotEditAllowSellsDuringHatch
+	ErrPendingEditDoesNotExist              = types.ErrPendingEditDoesNotExist
+	ErrWarPaused                            = types.ErrWarPaused
+	ErrFeeExceedsMax                        = types.ErrFeeExceedsMax
+	ErrBatchBlocksOutOfRange                = types.ErrBatchBlocksOutOfRange
+	ErrMaxWarsPerCreatorReached             = types.ErrMaxWarsPerCreatorReached
+	ErrExpiryHeightTooFar                   = types.ErrExpiryHeightTooFar
+	ErrMaxLimitOrdersReached                = types.ErrMaxLimitOrdersReached
+	ErrMaxProposalsReached                  = types.ErrMaxProposalsReached
+
+	WarsKeyPrefix               = types.WarsKeyPrefix
+	BatchesKeyPrefix            = types.BatchesKeyPrefix
+	LastBatchesKeyPrefix        = types.LastBatchesKeyPrefix
+	LimitOrdersKeyPrefix        = types.LimitOrdersKeyPrefix
+	HatchContributionsKeyPrefix = types.HatchContributionsKeyPrefix
+	HatchVestingsKeyPrefix      = types.HatchVestingsKeyPrefix
+	FundingPoolsKeyPrefix       = types.FundingPoolsKeyPrefix
+	ProposalsKeyPrefix          = types.ProposalsKeyPrefix
+	PendingEditsKeyPrefix       = types.PendingEditsKeyPrefix
+	VotesKeyPrefix              = types.VotesKeyPrefix
+	VotingEscrowsKeyPrefix      = types.VotingEscrowsKeyPrefix
+	NextProposalIDsKeyPrefix    = types.NextProposalIDsKeyPrefix
+	WarCountsByCreatorKeyPrefix = types.WarCountsByCreatorKeyPrefix
 
-	WarsKeyPrefix       = types.WarsKeyPrefix
-	BatchesKeyPrefix     = types.BatchesKeyPrefix
-	LastBatchesKeyPrefix = types.LastBatchesKeyPrefix
+	LimitOrderExpiriesKeyPrefix        = types.LimitOrderExpiriesKeyPrefix
+	LimitOrderPricesKeyPrefix          = types.LimitOrderPricesKeyPrefix
+	NextLimitOrderIDsKeyPrefix         = types.NextLimitOrderIDsKeyPrefix
+	LimitOrderCountsKeyPrefix          = types.LimitOrderCountsKeyPrefix
+	LimitOrderCountsByAddressKeyPrefix = types.LimitOrderCountsByAddressKeyPrefix
+	HatchVestingStartHeightsKeyPrefix  = types.HatchVestingStartHeightsKeyPrefix
 )
 
 type (
 	Keeper = keeper.Keeper
 
-	Batch     = types.Batch
-	BaseOrder = types.BaseOrder
-	BuyOrder  = types.BuyOrder
-	SellOrder = types.SellOrder
-	SwapOrder = types.SwapOrder
+	Batch              = types.Batch
+	BaseOrder          = types.BaseOrder
+	BuyOrder           = types.BuyOrder
+	SellOrder          = types.SellOrder
+	SwapOrder          = types.SwapOrder
+	RoutedSwapOrder    = types.RoutedSwapOrder
+	SwapHop            = types.SwapHop
+	SwapRoute          = types.SwapRoute
+	LimitOrder         = types.LimitOrder
+	OrderBook          = types.OrderBook
+	HatchContribution  = types.HatchContribution
+	HatchContributions = types.HatchContributions
+	HatchVesting       = types.HatchVesting
+	HatchVestings      = types.HatchVestings
+	FundingPool        = types.FundingPool
+	WarEdit            = types.WarEdit
+	SignersEdit        = types.SignersEdit
+	PendingEdit        = types.PendingEdit
+	PendingEdits       = types.PendingEdits
+	Vote               = types.Vote
+	TallyResult        = types.TallyResult
+	VotingEscrow       = types.VotingEscrow
+	Proposal           = types.Proposal
+	Proposals          = types.Proposals
 
-	FunctionParamRestrictions = types.FunctionParamRestrictions
-	FunctionParam             = types.FunctionParam
-	FunctionParams            = types.FunctionParams
+	FunctionParamRestrictions   = types.FunctionParamRestrictions
+	FunctionParam               = types.FunctionParam
+	FunctionParams              = types.FunctionParams
+	CurveFunction               = types.CurveFunction
+	VariableParamsCurveFunction = types.VariableParamsCurveFunction
+	ReserveTokensCurveFunction  = types.ReserveTokensCurveFunction
+	SpotPriceCurveFunction      = types.SpotPriceCurveFunction
+	BaseCurveFunction           = types.BaseCurveFunction
 
 	War = types.War
 
 	GenesisState = types.GenesisState
 
-	MsgCreateWar         = types.MsgCreateWar
-	MsgEditWar           = types.MsgEditWar
-	MsgBuy                = types.MsgBuy
-	MsgSell               = types.MsgSell
-	MsgSwap               = types.MsgSwap
-	MsgMakeOutcomePayment = types.MsgMakeOutcomePayment
-	MsgWithdrawShare      = types.MsgWithdrawShare
+	MsgCreateWar            = types.MsgCreateWar
+	MsgEditWar              = types.MsgEditWar
+	MsgUpdateWarSigners     = types.MsgUpdateWarSigners
+	MsgCancelPendingEdit    = types.MsgCancelPendingEdit
+	MsgSetWarPaused         = types.MsgSetWarPaused
+	MsgBuy                  = types.MsgBuy
+	MsgBuyWithSpend         = types.MsgBuyWithSpend
+	MsgSell                 = types.MsgSell
+	MsgSwap                 = types.MsgSwap
+	MsgRoutedSwap           = types.MsgRoutedSwap
+	MsgLimitBuy             = types.MsgLimitBuy
+	MsgLimitSell            = types.MsgLimitSell
+	MsgCancelOrder          = types.MsgCancelOrder
+	MsgCancelLimitOrder     = types.MsgCancelLimitOrder
+	MsgMakeOutcomePayment   = types.MsgMakeOutcomePayment
+	MsgWithdrawShare        = types.MsgWithdrawShare
+	MsgClaimVested          = types.MsgClaimVested
+	MsgSpendFromFundingPool = types.MsgSpendFromFundingPool
+	MsgSubmitProposal       = types.MsgSubmitProposal
+	MsgVote                 = types.MsgVote
 )
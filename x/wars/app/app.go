@@ -22,7 +22,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/cosmos-sdk/x/supply"
-	"github.com/mage-war/wars/x/wars"
+	war "github.com/mage-war/wars/x/wars"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
@@ -69,13 +69,17 @@ var (
 		auth.FeeCollectorName:     nil,
 		distr.ModuleName:          nil,
 		mint.ModuleName:           {supply.Minter},
-		staking.WaredPoolName:    {supply.Burner, supply.Staking},
-		staking.NotWaredPoolName: {supply.Burner, supply.Staking},
+		staking.BondedPoolName:    {supply.Burner, supply.Staking},
+		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
 		gov.ModuleName:            {supply.Burner},
 
-		war.WarMintBurnAccount:       {supply.Minter, supply.Burner},
+		war.WarsMintBurnAccount:        {supply.Minter, supply.Burner},
 		war.BatchesIntermediaryAccount: nil,
-		war.WarReserveAccount:        nil,
+		war.WarsReserveAccount:         nil,
+		war.WarsVestingAccount:         nil,
+		war.WarsFundingPoolAccount:     nil,
+		war.WarsVotingEscrowAccount:    nil,
+		war.WarsDepositAccount:         nil,
 	}
 
 	// module accounts that are allowed to receive tokens
@@ -129,7 +133,7 @@ type SimApp struct {
 	paramsKeeper   params.Keeper
 	evidenceKeeper evidence.Keeper
 
-	WarKeeper war.Keeper
+	WarsKeeper war.Keeper
 
 	// Module Manager
 	mm *module.Manager
@@ -232,7 +236,7 @@ func NewSimApp(
 		staking.NewMultiStakingHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks()),
 	)
 
-	app.WarKeeper = war.NewKeeper(
+	app.WarsKeeper = war.NewKeeper(
 		app.BankKeeper,
 		app.SupplyKeeper,
 		app.AccountKeeper,
@@ -257,7 +261,7 @@ func NewSimApp(
 		staking.NewAppModule(app.StakingKeeper, app.AccountKeeper, app.SupplyKeeper),
 		upgrade.NewAppModule(app.upgradeKeeper),
 		evidence.NewAppModule(app.evidenceKeeper),
-		war.NewAppModule(app.WarKeeper, app.AccountKeeper),
+		war.NewAppModule(app.WarsKeeper, app.AccountKeeper),
 	)
 
 	// During begin block slashing happens after distr.BeginBlocker so that
@@ -289,7 +293,7 @@ func NewSimApp(
 	app.sm = module.NewSimulationManager(
 		auth.NewAppModule(app.AccountKeeper),
 		bank.NewAppModule(app.BankKeeper, app.AccountKeeper),
-		war.NewAppModule(app.WarKeeper, app.AccountKeeper),
+		war.NewAppModule(app.WarsKeeper, app.AccountKeeper),
 		supply.NewAppModule(app.SupplyKeeper, app.AccountKeeper),
 		gov.NewAppModule(app.govKeeper, app.AccountKeeper, app.SupplyKeeper),
 		distr.NewAppModule(app.distrKeeper, app.AccountKeeper, app.SupplyKeeper, app.StakingKeeper),
@@ -9,6 +9,9 @@ import (
 	db "github.com/tendermint/tm-db"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	war "github.com/mage-war/wars/x/wars"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
@@ -35,6 +38,42 @@ func TestBlackListedAddrs(t *testing.T) {
 	}
 }
 
+// ensure that the wars module params can be changed by param change proposals
+func TestWarsParamChangeProposal(t *testing.T) {
+	app := Setup(false)
+	ctx := app.BaseApp.NewContext(false, abci.Header{})
+	handler := params.NewParamChangeProposalHandler(app.paramsKeeper)
+
+	err := handler(ctx, params.NewParameterChangeProposal("title", "description",
+		[]params.ParamChange{
+			params.NewParamChange(war.DefaultParamspace, "MaxTxFeePercentage", `"5.000000000000000000"`),
+			params.NewParamChange(war.DefaultParamspace, "MinBatchBlocks", `"2"`),
+			params.NewParamChange(war.DefaultParamspace, "MaxBatchBlocks", `"10"`),
+			params.NewParamChange(war.DefaultParamspace, "MaxWarsPerCreator", `"3"`),
+			params.NewParamChange(war.DefaultParamspace, "WarCreationDeposit", `[{"denom":"stake","amount":"100"}]`),
+		}))
+	require.NoError(t, err)
+
+	warsParams := app.WarsKeeper.GetParams(ctx)
+	require.Equal(t, sdk.NewDec(5), warsParams.MaxTxFeePercentage)
+	require.Equal(t, sdk.NewUint(2), warsParams.MinBatchBlocks)
+	require.Equal(t, sdk.NewUint(10), warsParams.MaxBatchBlocks)
+	require.Equal(t, uint64(3), warsParams.MaxWarsPerCreator)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), warsParams.WarCreationDeposit)
+
+	// Invalid values are rejected
+	for _, change := range []params.ParamChange{
+		params.NewParamChange(war.DefaultParamspace, "MaxExitFeePercentage", `"101.000000000000000000"`),
+		params.NewParamChange(war.DefaultParamspace, "MinBatchBlocks", `"0"`),
+		params.NewParamChange(war.DefaultParamspace, "ReservedWarTokens", `["a"]`),
+	} {
+		err = handler(ctx, params.NewParameterChangeProposal("title", "description",
+			[]params.ParamChange{change}))
+		require.Error(t, err, change.Key)
+	}
+	require.Equal(t, warsParams, app.WarsKeeper.GetParams(ctx))
+}
+
 func setGenesis(app *SimApp) error {
 	genesisState := NewDefaultGenesisState()
 	stateBytes, err := codec.MarshalJSONIndent(app.cdc, genesisState)
@@ -6,29 +6,47 @@ import (
 )
 
 const (
-	FlagToken                  = "token"
-	FlagName                   = "name"
-	FlagDescription            = "description"
-	FlagFunctionType           = "function-type"
-	FlagFunctionParameters     = "function-parameters"
-	FlagReserveTokens          = "reserve-tokens"
-	FlagTxFeePercentage        = "tx-fee-percentage"
-	FlagExitFeePercentage      = "exit-fee-percentage"
-	FlagFeeAddress             = "fee-address"
-	FlagMaxSupply              = "max-supply"
-	FlagOrderQuantityLimits    = "order-quantity-limits"
-	FlagSanityRate             = "sanity-rate"
-	FlagSanityMarginPercentage = "sanity-margin-percentage"
-	FlagAllowSells             = "allow-sells"
-	FlagSigners                = "signers"
-	FlagBatchBlocks            = "batch-blocks"
-	FlagOutcomePayment         = "outcome-payment"
+	FlagToken                         = "token"
+	FlagName                          = "name"
+	FlagDescription                   = "description"
+	FlagFunctionType                  = "function-type"
+	FlagFunctionParameters            = "function-parameters"
+	FlagReserveTokens                 = "reserve-tokens"
+	FlagTxFeePercentage               = "tx-fee-percentage"
+	FlagExitFeePercentage             = "exit-fee-percentage"
+	FlagFeeAddress                    = "fee-address"
+	FlagMaxSupply                     = "max-supply"
+	FlagOrderQuantityLimits           = "order-quantity-limits"
+	FlagSanityRate                    = "sanity-rate"
+	FlagSanityMarginPercentage        = "sanity-margin-percentage"
+	FlagAllowSells                    = "allow-sells"
+	FlagSigners                       = "signers"
+	FlagSignerThreshold               = "signer-threshold"
+	FlagEditDelay                     = "edit-delay"
+	FlagBatchBlocks                   = "batch-blocks"
+	FlagOutcomePayment                = "outcome-payment"
+	FlagHatchDeadlineHeight           = "hatch-deadline-height"
+	FlagAllowedHatchers               = "allowed-hatchers"
+	FlagHatchMembershipDenom          = "hatch-membership-denom"
+	FlagMaxHatchContribution          = "max-hatch-contribution"
+	FlagHatchVestingCliff             = "hatch-vesting-cliff"
+	FlagHatchVestingPeriod            = "hatch-vesting-period"
+	FlagFundingPoolSpenders           = "funding-pool-spenders"
+	FlagFundingPoolSpendLimit         = "funding-pool-spend-limit"
+	FlagFundingPoolEpochBlocks        = "funding-pool-epoch-blocks"
+	FlagGovernanceQuorumPercentage    = "governance-quorum-percentage"
+	FlagGovernanceThresholdPercentage = "governance-threshold-percentage"
+	FlagGovernanceVotingPeriod        = "governance-voting-period"
+	FlagMaxPriceMovePercentage        = "max-price-move-percentage"
+	FlagPriceMoveCooldown             = "price-move-cooldown"
+	FlagAllowPartialFill              = "allow-partial-fill"
 )
 
 var (
 	fsWarGeneral = flag.NewFlagSet("", flag.ContinueOnError)
 	fsWarCreate  = flag.NewFlagSet("", flag.ContinueOnError)
 	fsWarEdit    = flag.NewFlagSet("", flag.ContinueOnError)
+	fsWarBuy     = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -49,12 +67,37 @@ func init() {
 	fsWarCreate.String(FlagSanityRate, "", "For swappers, this is the typical t1 per t2 rate")
 	fsWarCreate.String(FlagSanityMarginPercentage, "", "For swappers, this is the acceptable deviation from the sanity rate")
 	fsWarCreate.Bool(FlagAllowSells, false, "Whether or not sells will be allowed")
+	fsWarCreate.Uint64(FlagSignerThreshold, 0, "The number of signers required to edit the war (0 for all signers)")
+	fsWarCreate.Int64(FlagEditDelay, 0, "The number of blocks after which edits by the signers are applied (0 for no delay)")
 	fsWarCreate.String(FlagBatchBlocks, "", "The duration in terms of blocks of each orders batch")
 	fsWarCreate.String(FlagOutcomePayment, "", "The payment that would be required to transition the war to settlement")
+	fsWarCreate.Int64(FlagHatchDeadlineHeight, 0, "For augmented functions, the block height by which the hatch must succeed (0 for no deadline)")
+	fsWarCreate.String(FlagAllowedHatchers, "", "For augmented functions, the list of addresses allowed to buy during the hatch")
+	fsWarCreate.String(FlagHatchMembershipDenom, "", "For augmented functions, the token that addresses must hold to buy during the hatch")
+	fsWarCreate.String(FlagMaxHatchContribution, "", "For augmented functions, the max number of tokens that an address can buy during the hatch")
+	fsWarCreate.Int64(FlagHatchVestingCliff, 0, "For augmented functions, the number of blocks after the hatch before tokens bought during the hatch start vesting")
+	fsWarCreate.Int64(FlagHatchVestingPeriod, 0, "For augmented functions, the number of blocks after the hatch over which tokens bought during the hatch vest (0 for no vesting)")
+	fsWarCreate.String(FlagFundingPoolSpenders, "", "The list of addresses allowed to spend from the war's funding pool (empty for no funding pool)")
+	fsWarCreate.String(FlagFundingPoolSpendLimit, "", "The max amount that can be spent from the war's funding pool per epoch")
+	fsWarCreate.Int64(FlagFundingPoolEpochBlocks, 0, "The duration in terms of blocks of each funding pool spending epoch")
+	fsWarCreate.String(FlagGovernanceQuorumPercentage, "0", "The percentage of the war token voting power that must vote for a proposal to be valid")
+	fsWarCreate.String(FlagGovernanceThresholdPercentage, "0", "The percentage of yes votes that must be exceeded for a proposal to pass")
+	fsWarCreate.Int64(FlagGovernanceVotingPeriod, 0, "The duration in terms of blocks of each proposal's voting period (0 for no governance)")
+	fsWarCreate.String(FlagMaxPriceMovePercentage, "0", "The max percentage by which a batch can move the war's prices before the war is paused (0 for no limit)")
+	fsWarCreate.Int64(FlagPriceMoveCooldown, 0, "The number of blocks for which the war is paused if the max price move is exceeded (0 until unpaused)")
 
 	fsWarEdit.String(FlagName, types.DoNotModifyField, "The war's name")
 	fsWarEdit.String(FlagDescription, types.DoNotModifyField, "The war's description")
 	fsWarEdit.String(FlagOrderQuantityLimits, types.DoNotModifyField, "The max number of tokens bought/sold/swapped per order")
 	fsWarEdit.String(FlagSanityRate, types.DoNotModifyField, "For swappers, this is the typical t1 per t2 rate")
 	fsWarEdit.String(FlagSanityMarginPercentage, types.DoNotModifyField, "For swappers, this is the acceptable deviation from the sanity rate")
+	fsWarEdit.String(FlagTxFeePercentage, types.DoNotModifyField, "The percentage fee charged on buys and sells (can only be decreased)")
+	fsWarEdit.String(FlagExitFeePercentage, types.DoNotModifyField, "The percentage fee charged on sells (can only be decreased)")
+	fsWarEdit.String(FlagFeeAddress, types.DoNotModifyField, "The address that will hold any charged fees")
+	fsWarEdit.String(FlagMaxSupply, types.DoNotModifyField, "The maximum supply that can be achieved (cannot be less than the current supply)")
+	fsWarEdit.String(FlagAllowSells, types.DoNotModifyField, "Whether or not sells will be allowed (true or false)")
+	fsWarEdit.String(FlagBatchBlocks, types.DoNotModifyField, "The duration in terms of blocks of each orders batch (from the next batch onwards)")
+	fsWarEdit.String(FlagOutcomePayment, types.DoNotModifyField, "The payment that would be required to transition the war to settlement")
+
+	fsWarBuy.Bool(FlagAllowPartialFill, false, "Whether or not the buy can be reduced to the largest amount fulfillable within the max prices, instead of being cancelled")
 }
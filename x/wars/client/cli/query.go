@@ -28,12 +28,17 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdWar(storeKey, cdc),
 		GetCmdBatch(storeKey, cdc),
 		GetCmdLastBatch(storeKey, cdc),
+		GetCmdOrderBook(storeKey, cdc),
+		GetCmdFundingPool(storeKey, cdc),
+		GetCmdProposals(storeKey, cdc),
+		GetCmdPendingEdits(storeKey, cdc),
 		GetCmdCurrentPrice(storeKey, cdc),
 		GetCmdCurrentReserve(storeKey, cdc),
 		GetCmdCustomPrice(storeKey, cdc),
 		GetCmdBuyPrice(storeKey, cdc),
 		GetCmdSellReturn(storeKey, cdc),
 		GetCmdSwapReturn(storeKey, cdc),
+		GetCmdSwapRoute(storeKey, cdc),
 		GetCmdQueryParams(cdc),
 	)...)
 
@@ -135,6 +140,102 @@ func GetCmdLastBatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+func GetCmdOrderBook(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "order-book [war-token]",
+		Short: "Query the standing limit orders of a war",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			warToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/order_book/%s",
+					queryRoute, warToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.OrderBook
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdFundingPool(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "funding-pool [war-token]",
+		Short: "Query the funding pool balance and remaining spend allowance of a war",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			warToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/funding_pool/%s",
+					queryRoute, warToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryFundingPool
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "proposals [war-token]",
+		Short: "Query the proposals of a war that are still being voted on",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			warToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/proposals/%s",
+					queryRoute, warToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.Proposals
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdPendingEdits(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pending-edits [war-token]",
+		Short: "Query the edits of a war that are waiting for the war's edit delay to pass",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			warToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/pending_edits/%s",
+					queryRoute, warToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.PendingEdits
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
 func GetCmdCurrentPrice(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "current-price [war-token]",
@@ -314,6 +415,39 @@ func GetCmdSwapReturn(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+func GetCmdSwapRoute(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "route [from-token-with-amount] [to-token]",
+		Example: "route 10res1 res3",
+		Short:   "Query the route through swapper wars that gives the highest return on swapping an amount of tokens to another token",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			fromTokenWithAmount := args[0]
+			toToken := args[1]
+
+			fromCoinWithAmount, err := sdk.ParseCoin(fromTokenWithAmount)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/route/%s/%s/%s",
+					queryRoute, fromCoinWithAmount.Denom,
+					fromCoinWithAmount.Amount.String(), toToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QuerySwapRoute
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
 // GetCmdQueryParams implements a command to fetch wars parameters.
 func GetCmdQueryParams(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
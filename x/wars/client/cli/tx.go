@@ -15,6 +15,7 @@ import (
 	"github.com/mage-war/wars/x/wars/internal/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"strconv"
 	"strings"
 )
 
@@ -30,11 +31,25 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	warsTxCmd.AddCommand(flags.PostCommands(
 		GetCmdCreateWar(cdc),
 		GetCmdEditWar(cdc),
+		GetCmdUpdateWarSigners(cdc),
+		GetCmdCancelPendingEdit(cdc),
+		GetCmdSetWarPaused(cdc),
 		GetCmdBuy(cdc),
+		GetCmdBuyWithSpend(cdc),
 		GetCmdSell(cdc),
 		GetCmdSwap(cdc),
+		GetCmdRoutedSwap(cdc),
+		GetCmdLimitBuy(cdc),
+		GetCmdLimitSell(cdc),
+		GetCmdCancelOrder(cdc),
+		GetCmdCancelLimitOrder(cdc),
 		GetCmdMakeOutcomePayment(cdc),
 		GetCmdWithdrawShare(cdc),
+		GetCmdClaimVested(cdc),
+		GetCmdSpendFromFundingPool(cdc),
+		GetCmdSubmitSpendProposal(cdc),
+		GetCmdSubmitEditProposal(cdc),
+		GetCmdVote(cdc),
 	)...)
 
 	return warsTxCmd
@@ -60,8 +75,24 @@ func GetCmdCreateWar(cdc *codec.Codec) *cobra.Command {
 			_sanityMarginPercentage := viper.GetString(FlagSanityMarginPercentage)
 			_allowSells := viper.GetBool(FlagAllowSells)
 			_signers := viper.GetString(FlagSigners)
+			_signerThreshold := viper.GetUint64(FlagSignerThreshold)
+			_editDelay := viper.GetInt64(FlagEditDelay)
 			_batchBlocks := viper.GetString(FlagBatchBlocks)
 			_outcomePayment := viper.GetString(FlagOutcomePayment)
+			_hatchDeadlineHeight := viper.GetInt64(FlagHatchDeadlineHeight)
+			_allowedHatchers := viper.GetString(FlagAllowedHatchers)
+			_hatchMembershipDenom := viper.GetString(FlagHatchMembershipDenom)
+			_maxHatchContribution := viper.GetString(FlagMaxHatchContribution)
+			_hatchVestingCliff := viper.GetInt64(FlagHatchVestingCliff)
+			_hatchVestingPeriod := viper.GetInt64(FlagHatchVestingPeriod)
+			_fundingPoolSpenders := viper.GetString(FlagFundingPoolSpenders)
+			_fundingPoolSpendLimit := viper.GetString(FlagFundingPoolSpendLimit)
+			_fundingPoolEpochBlocks := viper.GetInt64(FlagFundingPoolEpochBlocks)
+			_governanceQuorumPercentage := viper.GetString(FlagGovernanceQuorumPercentage)
+			_governanceThresholdPercentage := viper.GetString(FlagGovernanceThresholdPercentage)
+			_governanceVotingPeriod := viper.GetInt64(FlagGovernanceVotingPeriod)
+			_maxPriceMovePercentage := viper.GetString(FlagMaxPriceMovePercentage)
+			_priceMoveCooldown := viper.GetInt64(FlagPriceMoveCooldown)
 
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
@@ -136,11 +167,68 @@ func GetCmdCreateWar(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			// Parse allowed hatchers (optional)
+			var allowedHatchers []sdk.AccAddress
+			if _allowedHatchers != "" {
+				allowedHatchers, err = client2.ParseSigners(_allowedHatchers)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Parse max hatch contribution (optional)
+			maxHatchContribution := sdk.ZeroInt()
+			if _maxHatchContribution != "" {
+				var ok bool
+				maxHatchContribution, ok = sdk.NewIntFromString(_maxHatchContribution)
+				if !ok {
+					return sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "max hatch contribution")
+				}
+			}
+
+			// Parse funding pool spenders (optional)
+			var fundingPoolSpenders []sdk.AccAddress
+			if _fundingPoolSpenders != "" {
+				fundingPoolSpenders, err = client2.ParseSigners(_fundingPoolSpenders)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Parse funding pool spend limit (optional)
+			fundingPoolSpendLimit, err := sdk.ParseCoins(_fundingPoolSpendLimit)
+			if err != nil {
+				return err
+			}
+
+			// Parse governance quorum percentage
+			governanceQuorumPercentage, err := sdk.NewDecFromStr(_governanceQuorumPercentage)
+			if err != nil {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "governance quorum percentage")
+			}
+
+			// Parse governance threshold percentage
+			governanceThresholdPercentage, err := sdk.NewDecFromStr(_governanceThresholdPercentage)
+			if err != nil {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "governance threshold percentage")
+			}
+
+			// Parse max price move percentage
+			maxPriceMovePercentage, err := sdk.NewDecFromStr(_maxPriceMovePercentage)
+			if err != nil {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "max price move percentage")
+			}
+
 			msg := types.NewMsgCreateWar(_token, _name, _description,
 				cliCtx.GetFromAddress(), _functionType, functionParams,
 				reserveTokens, txFeePercentage, exitFeePercentage, feeAddress,
 				maxSupply, orderQuantityLimits, sanityRate, sanityMarginPercentage,
-				_allowSells, signers, batchBlocks, outcomePayment)
+				_allowSells, signers, _signerThreshold, _editDelay, batchBlocks, outcomePayment,
+				_hatchDeadlineHeight, allowedHatchers, _hatchMembershipDenom,
+				maxHatchContribution, _hatchVestingCliff, _hatchVestingPeriod,
+				fundingPoolSpenders, fundingPoolSpendLimit, _fundingPoolEpochBlocks,
+				governanceQuorumPercentage, governanceThresholdPercentage,
+				_governanceVotingPeriod, maxPriceMovePercentage, _priceMoveCooldown)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -163,7 +251,18 @@ func GetCmdCreateWar(cdc *codec.Codec) *cobra.Command {
 	_ = cmd.MarkFlagRequired(FlagSanityMarginPercentage)
 	_ = cmd.MarkFlagRequired(FlagSigners)
 	_ = cmd.MarkFlagRequired(FlagBatchBlocks)
+	// _ = cmd.MarkFlagRequired(FlagSignerThreshold) // Optional
+	// _ = cmd.MarkFlagRequired(FlagEditDelay) // Optional
 	// _ = cmd.MarkFlagRequired(FlagOutcomePayment) // Optional
+	// _ = cmd.MarkFlagRequired(FlagHatchDeadlineHeight) // Optional
+	// _ = cmd.MarkFlagRequired(FlagAllowedHatchers) // Optional
+	// _ = cmd.MarkFlagRequired(FlagHatchMembershipDenom) // Optional
+	// _ = cmd.MarkFlagRequired(FlagMaxHatchContribution) // Optional
+	// _ = cmd.MarkFlagRequired(FlagHatchVestingCliff) // Optional
+	// _ = cmd.MarkFlagRequired(FlagHatchVestingPeriod) // Optional
+	// _ = cmd.MarkFlagRequired(FlagFundingPoolSpenders) // Optional
+	// _ = cmd.MarkFlagRequired(FlagFundingPoolSpendLimit) // Optional
+	// _ = cmd.MarkFlagRequired(FlagFundingPoolEpochBlocks) // Optional
 
 	return cmd
 }
@@ -179,6 +278,13 @@ func GetCmdEditWar(cdc *codec.Codec) *cobra.Command {
 			_orderQuantityLimits := viper.GetString(FlagOrderQuantityLimits)
 			_sanityRate := viper.GetString(FlagSanityRate)
 			_sanityMarginPercentage := viper.GetString(FlagSanityMarginPercentage)
+			_txFeePercentage := viper.GetString(FlagTxFeePercentage)
+			_exitFeePercentage := viper.GetString(FlagExitFeePercentage)
+			_feeAddress := viper.GetString(FlagFeeAddress)
+			_maxSupply := viper.GetString(FlagMaxSupply)
+			_allowSells := viper.GetString(FlagAllowSells)
+			_batchBlocks := viper.GetString(FlagBatchBlocks)
+			_outcomePayment := viper.GetString(FlagOutcomePayment)
 			_signers := viper.GetString(FlagSigners)
 
 			inBuf := bufio.NewReader(cmd.InOrStdin())
@@ -193,7 +299,9 @@ func GetCmdEditWar(cdc *codec.Codec) *cobra.Command {
 
 			msg := types.NewMsgEditWar(
 				_token, _name, _description, _orderQuantityLimits, _sanityRate,
-				_sanityMarginPercentage, cliCtx.GetFromAddress(), signers)
+				_sanityMarginPercentage, _txFeePercentage, _exitFeePercentage,
+				_feeAddress, _maxSupply, _allowSells, _batchBlocks,
+				_outcomePayment, cliCtx.GetFromAddress(), signers)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -208,12 +316,144 @@ func GetCmdEditWar(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+func GetCmdUpdateWarSigners(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "update-war-signers [war-token] [new-signers] [new-signer-threshold]",
+		Example: "" +
+			"update-war-signers abc addr1,addr2,addr3 2 --signers=addr1,addr2",
+		Short: "Replace the signers and signer threshold of a war",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse new signers
+			newSigners, err := client2.ParseSigners(args[1])
+			if err != nil {
+				return err
+			}
+
+			// Parse new signer threshold
+			newSignerThreshold, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "new signer threshold")
+			}
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgUpdateWarSigners(args[0], newSigners,
+				newSignerThreshold, cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSigners, "", "The list of the war's current signers signing the update")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdCancelPendingEdit(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "cancel-pending-edit [war-token] [edit-id]",
+		Example: "" +
+			"cancel-pending-edit abc 0 --signers=addr1,addr2",
+		Short: "Cancel an edit of a war before the war's edit delay has passed",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse edit ID
+			editID, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "edit ID")
+			}
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCancelPendingEdit(args[0], editID,
+				cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSigners, "", "The list of the war's signers signing the cancellation")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdSetWarPaused(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "set-war-paused [war-token] [paused]",
+		Example: "" +
+			"set-war-paused abc true --signers=addr1,addr2",
+		Short: "Pause or unpause buying, selling and swapping for a war",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_signers := viper.GetString(FlagSigners)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse paused
+			var paused bool
+			pausedStrLower := strings.ToLower(args[1])
+			if pausedStrLower == "true" {
+				paused = true
+			} else if pausedStrLower == "false" {
+				paused = false
+			} else {
+				return sdkerrors.Wrap(types.ErrArgumentMissingOrNonBoolean, "paused")
+			}
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetWarPaused(args[0], paused,
+				cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSigners, "", "The list of the war's signers, or the pause authority")
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
 func GetCmdBuy(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "buy [war-token-with-amount] [max-prices]",
 		Example: "" +
 			"buy 10abc 1000res1\n" +
-			"buy 10abc 1000res1,1000res2",
+			"buy 10abc 1000res1,1000res2\n" +
+			"buy 10abc 1000res1 --allow-partial-fill",
 		Short: "Buy from a war",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -232,8 +472,39 @@ func GetCmdBuy(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			allowPartialFill := viper.GetBool(FlagAllowPartialFill)
+
 			msg := types.NewMsgBuy(cliCtx.GetFromAddress(),
-				warCoinWithAmount, maxPrices)
+				warCoinWithAmount, maxPrices, allowPartialFill)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().AddFlagSet(fsWarBuy)
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdBuyWithSpend(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "buy-with-spend [war-token] [spend]",
+		Example: "" +
+			"buy-with-spend abc 1000res1\n" +
+			"buy-with-spend abc 1000res1,1000res2",
+		Short: "Buy as many tokens from a war as the spend allows",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			spend, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgBuyWithSpend(cliCtx.GetFromAddress(),
+				args[0], spend)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -243,10 +514,12 @@ func GetCmdBuy(cdc *codec.Codec) *cobra.Command {
 
 func GetCmdSell(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "sell [war-token-with-amount]",
-		Example: "sell 10abc",
-		Short:   "Sell from a war",
-		Args:    cobra.ExactArgs(1),
+		Use: "sell [war-token-with-amount] [min-returns]",
+		Example: "" +
+			"sell 10abc\n" +
+			"sell 10abc 900res1,900res2",
+		Short: "Sell from a war, optionally with minimum returns",
+		Args:  cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			inBuf := bufio.NewReader(cmd.InOrStdin())
@@ -258,7 +531,16 @@ func GetCmdSell(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			msg := types.NewMsgSell(cliCtx.GetFromAddress(), warCoinWithAmount)
+			var minReturns sdk.Coins
+			if len(args) == 2 {
+				minReturns, err = sdk.ParseCoins(args[1])
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgSell(cliCtx.GetFromAddress(),
+				warCoinWithAmount, minReturns)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -268,12 +550,12 @@ func GetCmdSell(cdc *codec.Codec) *cobra.Command {
 
 func GetCmdSwap(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use: "swap [war-token] [from-amount] [from-token] [to-token]",
+		Use: "swap [war-token] [from-amount] [from-token] [to-token] [min-output]",
 		Example: "" +
 			"swap abc 100 res1 res2\n" +
-			"swap abc 100 res2 res1",
-		Short: "Perform a swap between two tokens",
-		Args:  cobra.ExactArgs(4),
+			"swap abc 100 res2 res1 95",
+		Short: "Perform a swap between two tokens, optionally with a minimum output",
+		Args:  cobra.RangeArgs(4, 5),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			inBuf := bufio.NewReader(cmd.InOrStdin())
@@ -286,7 +568,197 @@ func GetCmdSwap(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			msg := types.NewMsgSwap(cliCtx.GetFromAddress(), args[0], from, args[3])
+			// Check that min output (zero if not specified) can be parsed to a coin
+			minOutputAmount := "0"
+			if len(args) == 5 {
+				minOutputAmount = args[4]
+			}
+			minOutput, err := client2.ParseTwoPartCoin(minOutputAmount, args[3])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSwap(cliCtx.GetFromAddress(), args[0], from,
+				args[3], minOutput)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdRoutedSwap(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "routed-swap [from-amount] [from-token] [route] [min-output]",
+		Example: "" +
+			"routed-swap 100 res1 abc:res2,def:res3\n" +
+			"routed-swap 100 res1 abc:res2,def:res3 95",
+		Short: "Perform a swap through a route of swapper wars, optionally with a minimum output",
+		Long: "Perform a swap through a route of swapper wars, where each hop in the route is\n" +
+			"given as [war-token]:[to-token], optionally with a minimum output in the token\n" +
+			"that the route ends at. All hops are performed at the end of the batch of the\n" +
+			"first war in the route, and if any hop fails, none of them are performed.",
+		Args: cobra.RangeArgs(3, 4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Check that from amount and token can be parsed to a coin
+			from, err := client2.ParseTwoPartCoin(args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			// Parse route
+			route, err := client2.ParseSwapRoute(args[2])
+			if err != nil {
+				return err
+			}
+
+			// Check that min output (zero if not specified) can be parsed to a coin
+			minOutputAmount := "0"
+			if len(args) == 4 {
+				minOutputAmount = args[3]
+			}
+			minOutput, err := client2.ParseTwoPartCoin(minOutputAmount, route.ToToken())
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRoutedSwap(cliCtx.GetFromAddress(), from,
+				route, minOutput)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdLimitBuy(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "limit-buy [war-token-with-amount] [max-prices] [expiry-height]",
+		Example: "" +
+			"limit-buy 10abc 1000res1 5000\n" +
+			"limit-buy 10abc 1000res1,1000res2 5000",
+		Short: "Place a limit buy order for a war that stands until its max prices are met or it expires",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			warCoinWithAmount, err := sdk.ParseCoin(args[0])
+			if err != nil {
+				return err
+			}
+
+			maxPrices, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			expiryHeight, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgLimitBuy(cliCtx.GetFromAddress(),
+				warCoinWithAmount, maxPrices, expiryHeight)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdLimitSell(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "limit-sell [war-token-with-amount] [min-returns] [expiry-height]",
+		Example: "" +
+			"limit-sell 10abc 900res1 5000\n" +
+			"limit-sell 10abc 900res1,900res2 5000",
+		Short: "Place a limit sell order for a war that stands until its min returns are met or it expires",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			warCoinWithAmount, err := sdk.ParseCoin(args[0])
+			if err != nil {
+				return err
+			}
+
+			minReturns, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			expiryHeight, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgLimitSell(cliCtx.GetFromAddress(),
+				warCoinWithAmount, minReturns, expiryHeight)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdCancelOrder(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "cancel-order [war-token] [order-type] [order-index]",
+		Example: "" +
+			"cancel-order abc buy 0\n" +
+			"cancel-order abc swap 2",
+		Short: "Cancel an own buy, sell, swap, or routed swap order in the current batch of a war",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			orderIndex, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCancelOrder(cliCtx.GetFromAddress(),
+				args[0], args[1], orderIndex)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdCancelLimitOrder(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cancel-limit-order [war-token] [order-id]",
+		Example: "cancel-limit-order abc 3",
+		Short:   "Cancel an own limit order in the order book of a war",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			orderID, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCancelLimitOrder(cliCtx.GetFromAddress(),
+				args[0], orderID)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -333,3 +805,154 @@ func GetCmdWithdrawShare(cdc *codec.Codec) *cobra.Command {
 	_ = cmd.MarkFlagRequired(flags.FlagFrom)
 	return cmd
 }
+
+func GetCmdClaimVested(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "claim-vested [war-token]",
+		Example: "claim-vested abc",
+		Short:   "Claim vested war tokens bought during the hatch phase",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgClaimVested(cliCtx.GetFromAddress(), args[0])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdSpendFromFundingPool(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "spend-from-funding-pool [war-token] [recipient] [amount]",
+		Example: "spend-from-funding-pool abc cosmos1... 100res",
+		Short:   "Spend from the funding pool of a war, within the per-epoch spend limit",
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			recipient, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoins(args[2])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSpendFromFundingPool(cliCtx.GetFromAddress(),
+				args[0], recipient, amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdSubmitSpendProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "submit-spend-proposal [war-token] [recipient] [amount]",
+		Example: "submit-spend-proposal abc cosmos1... 100res",
+		Short:   "Propose to the war's token holders to spend from the war's funding pool",
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			recipient, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoins(args[2])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSubmitSpendProposal(cliCtx.GetFromAddress(),
+				args[0], recipient, amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
+
+func GetCmdSubmitEditProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-edit-proposal",
+		Short: "Propose to the war's token holders to edit the war",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_token := viper.GetString(FlagToken)
+			_name := viper.GetString(FlagName)
+			_description := viper.GetString(FlagDescription)
+			_orderQuantityLimits := viper.GetString(FlagOrderQuantityLimits)
+			_sanityRate := viper.GetString(FlagSanityRate)
+			_sanityMarginPercentage := viper.GetString(FlagSanityMarginPercentage)
+			_txFeePercentage := viper.GetString(FlagTxFeePercentage)
+			_exitFeePercentage := viper.GetString(FlagExitFeePercentage)
+			_feeAddress := viper.GetString(FlagFeeAddress)
+			_maxSupply := viper.GetString(FlagMaxSupply)
+			_allowSells := viper.GetString(FlagAllowSells)
+			_batchBlocks := viper.GetString(FlagBatchBlocks)
+			_outcomePayment := viper.GetString(FlagOutcomePayment)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			edit := types.NewWarEdit(_name, _description,
+				_orderQuantityLimits, _sanityRate, _sanityMarginPercentage,
+				_txFeePercentage, _exitFeePercentage, _feeAddress, _maxSupply,
+				_allowSells, _batchBlocks, _outcomePayment)
+
+			msg := types.NewMsgSubmitEditProposal(cliCtx.GetFromAddress(),
+				_token, edit)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagToken, "", "The war's token")
+	cmd.Flags().AddFlagSet(fsWarEdit)
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagToken)
+
+	return cmd
+}
+
+func GetCmdVote(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "vote [war-token] [proposal-id] [option]",
+		Example: "vote abc 3 yes",
+		Short:   "Vote yes or no on a proposal of a war, weighted by the war tokens held when it was submitted",
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposalID, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgVote(cliCtx.GetFromAddress(),
+				args[0], proposalID, args[2])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	return cmd
+}
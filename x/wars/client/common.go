@@ -85,3 +85,22 @@ func ParseTwoPartCoin(amount, denom string) (coin sdk.Coin, err error) {
 	}
 	return coin, nil
 }
+
+func ParseSwapRoute(routeStr string) (route types.SwapRoute, err error) {
+	// Split (if not empty) "war1:b,war2:c" into ["war1:b","war2:c"]
+	hops := splitParameters(routeStr)
+	if len(hops) == 0 {
+		return nil, sdkerrors.Wrap(types.ErrArgumentCannotBeEmpty, "route")
+	}
+
+	for _, h := range hops {
+		// Split each "war1:b" into ["war1","b"]
+		hopArray := strings.Split(h, ":")
+		if len(hopArray) != 2 {
+			return nil, sdkerrors.Wrap(types.ErrInvalidSwapRoute, h)
+		}
+		route = append(route, types.NewSwapHop(
+			strings.TrimSpace(hopArray[0]), strings.TrimSpace(hopArray[1])))
+	}
+	return route, nil
+}
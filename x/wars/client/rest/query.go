@@ -30,6 +30,26 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute st
 		queryLastBatchHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/wars/{%s}/order_book", RestWarToken),
+		queryOrderBookHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/wars/{%s}/funding_pool", RestWarToken),
+		queryFundingPoolHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/wars/{%s}/proposals", RestWarToken),
+		queryProposalsHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/wars/{%s}/pending_edits", RestWarToken),
+		queryPendingEditsHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		fmt.Sprintf("/wars/{%s}/current_price", RestWarToken),
 		queryCurrentPriceHandler(cliCtx, queryRoute),
@@ -60,6 +80,11 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute st
 		querySwapReturnHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/wars/route/{%s}/{%s}", RestFromTokenWithAmount, RestToToken),
+		querySwapRouteHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		"/wars/params",
 		queryParamsRequestHandler(cliCtx),
@@ -129,6 +154,74 @@ func queryLastBatchHandler(cliCtx context.CLIContext, queryRoute string) http.Ha
 	}
 }
 
+func queryOrderBookHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		warToken := vars[RestWarToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/order_book/%s",
+				queryRoute, warToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryFundingPoolHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		warToken := vars[RestWarToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/funding_pool/%s",
+				queryRoute, warToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryProposalsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		warToken := vars[RestWarToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/proposals/%s",
+				queryRoute, warToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryPendingEditsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		warToken := vars[RestWarToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/pending_edits/%s",
+				queryRoute, warToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryCurrentPriceHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -243,6 +336,31 @@ func querySwapReturnHandler(cliCtx context.CLIContext, queryRoute string) http.H
 	}
 }
 
+func querySwapRouteHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		fromTokenWithAmount := vars[RestFromTokenWithAmount]
+		toToken := vars[RestToToken]
+
+		reserveCoinWithAmount, err := sdk.ParseCoin(fromTokenWithAmount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/route/%s/%s/%s",
+				queryRoute, reserveCoinWithAmount.Denom,
+				reserveCoinWithAmount.Amount.String(), toToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryParamsRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
@@ -10,38 +10,69 @@ import (
 	"github.com/mage-war/wars/x/wars/client"
 	"github.com/mage-war/wars/x/wars/internal/types"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
 func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/wars/create_war", createWarRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/wars/edit_war", editWarRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/update_war_signers", updateWarSignersRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/cancel_pending_edit", cancelPendingEditRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/set_war_paused", setWarPausedRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/wars/buy", buyRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/buy_with_spend", buyWithSpendRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/wars/sell", sellRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/wars/swap", swapRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/routed_swap", routedSwapRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/limit_buy", limitBuyRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/limit_sell", limitSellRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/cancel_order", cancelOrderRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/cancel_limit_order", cancelLimitOrderRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/wars/make_outcome_payment", makeOutcomePaymentRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/wars/withdraw_share", withdrawShareRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/claim_vested", claimVestedRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/spend_from_funding_pool", spendFromFundingPoolRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/submit_spend_proposal", submitSpendProposalRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/submit_edit_proposal", submitEditProposalRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/wars/vote", voteRequestHandler(cliCtx)).Methods("POST")
 }
 
 type createWarReq struct {
-	BaseReq                rest.BaseReq `json:"base_req" yaml:"base_req"`
-	Token                  string       `json:"token" yaml:"token"`
-	Name                   string       `json:"name" yaml:"name"`
-	Description            string       `json:"description" yaml:"description"`
-	FunctionType           string       `json:"function_type" yaml:"function_type"`
-	FunctionParameters     string       `json:"function_parameters" yaml:"function_parameters"`
-	ReserveTokens          string       `json:"reserve_tokens" yaml:"reserve_tokens"`
-	TxFeePercentage        string       `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
-	ExitFeePercentage      string       `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
-	FeeAddress             string       `json:"fee_address" yaml:"fee_address"`
-	MaxSupply              string       `json:"max_supply" yaml:"max_supply"`
-	OrderQuantityLimits    string       `json:"order_quantity_limits" yaml:"order_quantity_limits"`
-	SanityRate             string       `json:"sanity_rate" yaml:"sanity_rate"`
-	SanityMarginPercentage string       `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
-	AllowSells             string       `json:"allow_sells" yaml:"allow_sells"`
-	Signers                string       `json:"signers" yaml:"signers"`
-	BatchBlocks            string       `json:"batch_blocks" yaml:"batch_blocks"`
-	OutcomePayment         string       `json:"outcome_payment" yaml:"outcome_payment"`
+	BaseReq                       rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Token                         string       `json:"token" yaml:"token"`
+	Name                          string       `json:"name" yaml:"name"`
+	Description                   string       `json:"description" yaml:"description"`
+	FunctionType                  string       `json:"function_type" yaml:"function_type"`
+	FunctionParameters            string       `json:"function_parameters" yaml:"function_parameters"`
+	ReserveTokens                 string       `json:"reserve_tokens" yaml:"reserve_tokens"`
+	TxFeePercentage               string       `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage             string       `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	FeeAddress                    string       `json:"fee_address" yaml:"fee_address"`
+	MaxSupply                     string       `json:"max_supply" yaml:"max_supply"`
+	OrderQuantityLimits           string       `json:"order_quantity_limits" yaml:"order_quantity_limits"`
+	SanityRate                    string       `json:"sanity_rate" yaml:"sanity_rate"`
+	SanityMarginPercentage        string       `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	AllowSells                    string       `json:"allow_sells" yaml:"allow_sells"`
+	Signers                       string       `json:"signers" yaml:"signers"`
+	SignerThreshold               string       `json:"signer_threshold" yaml:"signer_threshold"`
+	EditDelay                     string       `json:"edit_delay" yaml:"edit_delay"`
+	BatchBlocks                   string       `json:"batch_blocks" yaml:"batch_blocks"`
+	OutcomePayment                string       `json:"outcome_payment" yaml:"outcome_payment"`
+	HatchDeadlineHeight           string       `json:"hatch_deadline_height" yaml:"hatch_deadline_height"`
+	AllowedHatchers               string       `json:"allowed_hatchers" yaml:"allowed_hatchers"`
+	HatchMembershipDenom          string       `json:"hatch_membership_denom" yaml:"hatch_membership_denom"`
+	MaxHatchContribution          string       `json:"max_hatch_contribution" yaml:"max_hatch_contribution"`
+	HatchVestingCliff             string       `json:"hatch_vesting_cliff" yaml:"hatch_vesting_cliff"`
+	HatchVestingPeriod            string       `json:"hatch_vesting_period" yaml:"hatch_vesting_period"`
+	FundingPoolSpenders           string       `json:"funding_pool_spenders" yaml:"funding_pool_spenders"`
+	FundingPoolSpendLimit         string       `json:"funding_pool_spend_limit" yaml:"funding_pool_spend_limit"`
+	FundingPoolEpochBlocks        string       `json:"funding_pool_epoch_blocks" yaml:"funding_pool_epoch_blocks"`
+	GovernanceQuorumPercentage    string       `json:"governance_quorum_percentage" yaml:"governance_quorum_percentage"`
+	GovernanceThresholdPercentage string       `json:"governance_threshold_percentage" yaml:"governance_threshold_percentage"`
+	GovernanceVotingPeriod        string       `json:"governance_voting_period" yaml:"governance_voting_period"`
+	MaxPriceMovePercentage        string       `json:"max_price_move_percentage" yaml:"max_price_move_percentage"`
+	PriceMoveCooldown             string       `json:"price_move_cooldown" yaml:"price_move_cooldown"`
 }
 
 func createWarRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -153,6 +184,27 @@ func createWarRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		// Parse signer threshold (optional, defaults to all signers)
+		var signerThreshold uint64
+		if req.SignerThreshold != "" {
+			signerThreshold, err2 = strconv.ParseUint(req.SignerThreshold, 10, 64)
+			if err2 != nil {
+				err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "signer threshold")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse edit delay (optional, defaults to no delay)
+		var editDelay int64
+		if req.EditDelay != "" {
+			editDelay, err2 = strconv.ParseInt(req.EditDelay, 10, 64)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+
 		// Parse outcome payment
 		outcomePayment, err2 := sdk.ParseCoins(req.OutcomePayment)
 		if err2 != nil {
@@ -160,11 +212,143 @@ func createWarRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		// Parse hatch deadline height (optional, defaults to no deadline)
+		var hatchDeadlineHeight int64
+		if req.HatchDeadlineHeight != "" {
+			hatchDeadlineHeight, err2 = strconv.ParseInt(req.HatchDeadlineHeight, 10, 64)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+
+		// Parse hatch vesting cliff and period (optional, defaults to no vesting)
+		var hatchVestingCliff, hatchVestingPeriod int64
+		if req.HatchVestingCliff != "" {
+			hatchVestingCliff, err2 = strconv.ParseInt(req.HatchVestingCliff, 10, 64)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+		if req.HatchVestingPeriod != "" {
+			hatchVestingPeriod, err2 = strconv.ParseInt(req.HatchVestingPeriod, 10, 64)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+
+		// Parse allowed hatchers (optional)
+		var allowedHatchers []sdk.AccAddress
+		if req.AllowedHatchers != "" {
+			allowedHatchers, err = client.ParseSigners(req.AllowedHatchers)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse max hatch contribution (optional, defaults to no limit)
+		maxHatchContribution := sdk.ZeroInt()
+		if req.MaxHatchContribution != "" {
+			var ok bool
+			maxHatchContribution, ok = sdk.NewIntFromString(req.MaxHatchContribution)
+			if !ok {
+				err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "max hatch contribution")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse funding pool spenders (optional, defaults to no funding pool)
+		var fundingPoolSpenders []sdk.AccAddress
+		if req.FundingPoolSpenders != "" {
+			fundingPoolSpenders, err = client.ParseSigners(req.FundingPoolSpenders)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse funding pool spend limit (optional)
+		fundingPoolSpendLimit, err2 := sdk.ParseCoins(req.FundingPoolSpendLimit)
+		if err2 != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+			return
+		}
+
+		// Parse funding pool epoch blocks (optional)
+		var fundingPoolEpochBlocks int64
+		if req.FundingPoolEpochBlocks != "" {
+			fundingPoolEpochBlocks, err2 = strconv.ParseInt(req.FundingPoolEpochBlocks, 10, 64)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+
+		// Parse governance quorum and threshold percentages (optional)
+		governanceQuorumPercentage := sdk.ZeroDec()
+		if req.GovernanceQuorumPercentage != "" {
+			governanceQuorumPercentage, err2 = sdk.NewDecFromStr(req.GovernanceQuorumPercentage)
+			if err2 != nil {
+				err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "governance quorum percentage")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		governanceThresholdPercentage := sdk.ZeroDec()
+		if req.GovernanceThresholdPercentage != "" {
+			governanceThresholdPercentage, err2 = sdk.NewDecFromStr(req.GovernanceThresholdPercentage)
+			if err2 != nil {
+				err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "governance threshold percentage")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse governance voting period (optional, defaults to no governance)
+		var governanceVotingPeriod int64
+		if req.GovernanceVotingPeriod != "" {
+			governanceVotingPeriod, err2 = strconv.ParseInt(req.GovernanceVotingPeriod, 10, 64)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+
+		// Parse max price move percentage (optional, defaults to no limit)
+		maxPriceMovePercentage := sdk.ZeroDec()
+		if req.MaxPriceMovePercentage != "" {
+			maxPriceMovePercentage, err2 = sdk.NewDecFromStr(req.MaxPriceMovePercentage)
+			if err2 != nil {
+				err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "max price move percentage")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse price move cooldown (optional, defaults to paused until unpaused)
+		var priceMoveCooldown int64
+		if req.PriceMoveCooldown != "" {
+			priceMoveCooldown, err2 = strconv.ParseInt(req.PriceMoveCooldown, 10, 64)
+			if err2 != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+				return
+			}
+		}
+
 		msg := types.NewMsgCreateWar(req.Token, req.Name, req.Description,
 			creator, req.FunctionType, functionParams, reserveTokens,
 			txFeePercentageDec, exitFeePercentageDec, feeAddress, maxSupply,
 			orderQuantityLimits, sanityRate, sanityMarginPercentage,
-			allowSells, signers, batchBlocks, outcomePayment)
+			allowSells, signers, signerThreshold, editDelay, batchBlocks, outcomePayment,
+			hatchDeadlineHeight, allowedHatchers, req.HatchMembershipDenom,
+			maxHatchContribution, hatchVestingCliff, hatchVestingPeriod,
+			fundingPoolSpenders, fundingPoolSpendLimit, fundingPoolEpochBlocks,
+			governanceQuorumPercentage, governanceThresholdPercentage,
+			governanceVotingPeriod, maxPriceMovePercentage, priceMoveCooldown)
 
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
@@ -178,6 +362,13 @@ type editWarRequestReq struct {
 	OrderQuantityLimits    string       `json:"order_quantity_limits" yaml:"order_quantity_limits"`
 	SanityRate             string       `json:"sanity_rate" yaml:"sanity_rate"`
 	SanityMarginPercentage string       `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	TxFeePercentage        string       `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage      string       `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	FeeAddress             string       `json:"fee_address" yaml:"fee_address"`
+	MaxSupply              string       `json:"max_supply" yaml:"max_supply"`
+	AllowSells             string       `json:"allow_sells" yaml:"allow_sells"`
+	BatchBlocks            string       `json:"batch_blocks" yaml:"batch_blocks"`
+	OutcomePayment         string       `json:"outcome_payment" yaml:"outcome_payment"`
 	Signers                string       `json:"signers" yaml:"signers"`
 }
 
@@ -210,17 +401,178 @@ func editWarRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 
 		msg := types.NewMsgEditWar(req.Token, req.Name, req.Description,
 			req.OrderQuantityLimits, req.SanityRate, req.SanityMarginPercentage,
+			req.TxFeePercentage, req.ExitFeePercentage, req.FeeAddress,
+			req.MaxSupply, req.AllowSells, req.BatchBlocks, req.OutcomePayment,
 			editor, signers)
 
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
 
+type updateWarSignersReq struct {
+	BaseReq            rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Token              string       `json:"token" yaml:"token"`
+	NewSigners         string       `json:"new_signers" yaml:"new_signers"`
+	NewSignerThreshold string       `json:"new_signer_threshold" yaml:"new_signer_threshold"`
+	Signers            string       `json:"signers" yaml:"signers"`
+}
+
+func updateWarSignersRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req updateWarSignersReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse new signers
+		newSigners, err := client.ParseSigners(req.NewSigners)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse new signer threshold
+		newSignerThreshold, err := strconv.ParseUint(req.NewSignerThreshold, 10, 64)
+		if err != nil {
+			err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "new signer threshold")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUpdateWarSigners(req.Token, newSigners,
+			newSignerThreshold, editor, signers)
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type cancelPendingEditReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Token   string       `json:"token" yaml:"token"`
+	EditID  string       `json:"edit_id" yaml:"edit_id"`
+	Signers string       `json:"signers" yaml:"signers"`
+}
+
+func cancelPendingEditRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req cancelPendingEditReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse edit ID
+		editID, err := strconv.ParseUint(req.EditID, 10, 64)
+		if err != nil {
+			err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonUInteger, "edit ID")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCancelPendingEdit(req.Token, editID, editor, signers)
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type setWarPausedReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Token   string       `json:"token" yaml:"token"`
+	Paused  string       `json:"paused" yaml:"paused"`
+	Signers string       `json:"signers" yaml:"signers"`
+}
+
+func setWarPausedRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req setWarPausedReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse paused
+		var paused bool
+		pausedStrLower := strings.ToLower(req.Paused)
+		if pausedStrLower == "true" {
+			paused = true
+		} else if pausedStrLower == "false" {
+			paused = false
+		} else {
+			err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonBoolean, "paused")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSetWarPaused(req.Token, paused, editor, signers)
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
 type buyReq struct {
-	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
-	WarToken  string       `json:"war_token" yaml:"war_token"`
-	WarAmount string       `json:"war_amount" yaml:"war_amount"`
-	MaxPrices  string       `json:"max_prices" yaml:"max_prices"`
+	BaseReq          rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken         string       `json:"war_token" yaml:"war_token"`
+	WarAmount        string       `json:"war_amount" yaml:"war_amount"`
+	MaxPrices        string       `json:"max_prices" yaml:"max_prices"`
+	AllowPartialFill string       `json:"allow_partial_fill" yaml:"allow_partial_fill"`
 }
 
 func buyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -255,15 +607,66 @@ func buyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgBuy(buyer, warCoin, maxPrices)
+		// Parse allowPartialFill (optional, false by default)
+		var allowPartialFill bool
+		allowPartialFillStrLower := strings.ToLower(req.AllowPartialFill)
+		if allowPartialFillStrLower == "true" {
+			allowPartialFill = true
+		} else if allowPartialFillStrLower == "false" || allowPartialFillStrLower == "" {
+			allowPartialFill = false
+		} else {
+			err := sdkerrors.Wrap(types.ErrArgumentMissingOrNonBoolean, "allow_partial_fill")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgBuy(buyer, warCoin, maxPrices, allowPartialFill)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type buyWithSpendReq struct {
+	BaseReq  rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken string       `json:"war_token" yaml:"war_token"`
+	Spend    string       `json:"spend" yaml:"spend"`
+}
+
+func buyWithSpendRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req buyWithSpendReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		buyer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		spend, err := sdk.ParseCoins(req.Spend)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgBuyWithSpend(buyer, req.WarToken, spend)
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
 
 type sellReq struct {
 	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
-	WarToken  string       `json:"war_token" yaml:"war_token"`
-	WarAmount string       `json:"war_amount" yaml:"war_amount"`
+	WarToken   string       `json:"war_token" yaml:"war_token"`
+	WarAmount  string       `json:"war_amount" yaml:"war_amount"`
+	MinReturns string       `json:"min_returns" yaml:"min_returns"`
 }
 
 func sellRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -292,17 +695,24 @@ func sellRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgSell(seller, warCoin)
+		minReturns, err := sdk.ParseCoins(req.MinReturns)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSell(seller, warCoin, minReturns)
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
 
 type swapReq struct {
 	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
-	WarToken  string       `json:"war_token" yaml:"war_token"`
+	WarToken   string       `json:"war_token" yaml:"war_token"`
 	FromAmount string       `json:"from_amount" yaml:"from_amount"`
 	FromToken  string       `json:"from_token" yaml:"from_token"`
 	ToToken    string       `json:"to_token" yaml:"to_token"`
+	MinOutput  string       `json:"min_output" yaml:"min_output"`
 }
 
 func swapRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -332,19 +742,32 @@ func swapRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgSwap(swapper, req.WarToken, fromCoin, req.ToToken)
+		// Check that min output (zero if not specified) can be parsed to a coin
+		if req.MinOutput == "" {
+			req.MinOutput = "0"
+		}
+		minOutput, err := client.ParseTwoPartCoin(req.MinOutput, req.ToToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSwap(swapper, req.WarToken, fromCoin, req.ToToken, minOutput)
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
 
-type makeOutcomePaymentReq struct {
-	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
-	WarToken string       `json:"war_token" yaml:"war_token"`
+type routedSwapReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	FromAmount string       `json:"from_amount" yaml:"from_amount"`
+	FromToken  string       `json:"from_token" yaml:"from_token"`
+	Route      string       `json:"route" yaml:"route"`
+	MinOutput  string       `json:"min_output" yaml:"min_output"`
 }
 
-func makeOutcomePaymentRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+func routedSwapRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req makeOutcomePaymentReq
+		var req routedSwapReq
 
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
@@ -356,25 +779,52 @@ func makeOutcomePaymentRequestHandler(cliCtx context.CLIContext) http.HandlerFun
 			return
 		}
 
-		sender, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		swapper, err := sdk.AccAddressFromBech32(baseReq.From)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		msg := types.NewMsgMakeOutcomePayment(sender, req.WarToken)
+		// Check that from amount and token can be parsed to a coin
+		fromCoin, err := client.ParseTwoPartCoin(req.FromAmount, req.FromToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse route
+		route, err := client.ParseSwapRoute(req.Route)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Check that min output (zero if not specified) can be parsed to a coin
+		if req.MinOutput == "" {
+			req.MinOutput = "0"
+		}
+		minOutput, err := client.ParseTwoPartCoin(req.MinOutput, route.ToToken())
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgRoutedSwap(swapper, fromCoin, route, minOutput)
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
 
-type withdrawShareReq struct {
-	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
-	WarToken string       `json:"war_token" yaml:"war_token"`
+type limitBuyReq struct {
+	BaseReq      rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken     string       `json:"war_token" yaml:"war_token"`
+	WarAmount    string       `json:"war_amount" yaml:"war_amount"`
+	MaxPrices    string       `json:"max_prices" yaml:"max_prices"`
+	ExpiryHeight string       `json:"expiry_height" yaml:"expiry_height"`
 }
 
-func withdrawShareRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+func limitBuyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req withdrawShareReq
+		var req limitBuyReq
 
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
@@ -386,13 +836,420 @@ func withdrawShareRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		recipient, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		buyer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		msg := types.NewMsgWithdrawShare(recipient, req.WarToken)
+		warCoin, err := client.ParseTwoPartCoin(req.WarAmount, req.WarToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		maxPrices, err := sdk.ParseCoins(req.MaxPrices)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		expiryHeight, err := strconv.ParseInt(req.ExpiryHeight, 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgLimitBuy(buyer, warCoin, maxPrices, expiryHeight)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type limitSellReq struct {
+	BaseReq      rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken     string       `json:"war_token" yaml:"war_token"`
+	WarAmount    string       `json:"war_amount" yaml:"war_amount"`
+	MinReturns   string       `json:"min_returns" yaml:"min_returns"`
+	ExpiryHeight string       `json:"expiry_height" yaml:"expiry_height"`
+}
+
+func limitSellRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req limitSellReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		seller, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		warCoin, err := client.ParseTwoPartCoin(req.WarAmount, req.WarToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		minReturns, err := sdk.ParseCoins(req.MinReturns)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		expiryHeight, err := strconv.ParseInt(req.ExpiryHeight, 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgLimitSell(seller, warCoin, minReturns, expiryHeight)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type cancelOrderReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken   string       `json:"war_token" yaml:"war_token"`
+	OrderType  string       `json:"order_type" yaml:"order_type"`
+	OrderIndex string       `json:"order_index" yaml:"order_index"`
+}
+
+func cancelOrderRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req cancelOrderReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		owner, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		orderIndex, err := strconv.ParseUint(req.OrderIndex, 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCancelOrder(owner, req.WarToken, req.OrderType, orderIndex)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type cancelLimitOrderReq struct {
+	BaseReq  rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken string       `json:"war_token" yaml:"war_token"`
+	OrderID  string       `json:"order_id" yaml:"order_id"`
+}
+
+func cancelLimitOrderRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req cancelLimitOrderReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		owner, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		orderID, err := strconv.ParseUint(req.OrderID, 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCancelLimitOrder(owner, req.WarToken, orderID)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type makeOutcomePaymentReq struct {
+	BaseReq  rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken string       `json:"war_token" yaml:"war_token"`
+}
+
+func makeOutcomePaymentRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req makeOutcomePaymentReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		sender, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgMakeOutcomePayment(sender, req.WarToken)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type withdrawShareReq struct {
+	BaseReq  rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken string       `json:"war_token" yaml:"war_token"`
+}
+
+func withdrawShareRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req withdrawShareReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		recipient, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgWithdrawShare(recipient, req.WarToken)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type claimVestedReq struct {
+	BaseReq  rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken string       `json:"war_token" yaml:"war_token"`
+}
+
+func claimVestedRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req claimVestedReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		claimer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgClaimVested(claimer, req.WarToken)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type spendFromFundingPoolReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken  string       `json:"war_token" yaml:"war_token"`
+	Recipient string       `json:"recipient" yaml:"recipient"`
+	Amount    string       `json:"amount" yaml:"amount"`
+}
+
+func spendFromFundingPoolRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req spendFromFundingPoolReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		spender, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		recipient, err := sdk.AccAddressFromBech32(req.Recipient)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		amount, err := sdk.ParseCoins(req.Amount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSpendFromFundingPool(spender, req.WarToken, recipient, amount)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type submitSpendProposalReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken  string       `json:"war_token" yaml:"war_token"`
+	Recipient string       `json:"recipient" yaml:"recipient"`
+	Amount    string       `json:"amount" yaml:"amount"`
+}
+
+func submitSpendProposalRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req submitSpendProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		proposer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		recipient, err := sdk.AccAddressFromBech32(req.Recipient)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		amount, err := sdk.ParseCoins(req.Amount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSubmitSpendProposal(proposer, req.WarToken, recipient, amount)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type submitEditProposalReq struct {
+	BaseReq                rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken               string       `json:"war_token" yaml:"war_token"`
+	Name                   string       `json:"name" yaml:"name"`
+	Description            string       `json:"description" yaml:"description"`
+	OrderQuantityLimits    string       `json:"order_quantity_limits" yaml:"order_quantity_limits"`
+	SanityRate             string       `json:"sanity_rate" yaml:"sanity_rate"`
+	SanityMarginPercentage string       `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	TxFeePercentage        string       `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage      string       `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	FeeAddress             string       `json:"fee_address" yaml:"fee_address"`
+	MaxSupply              string       `json:"max_supply" yaml:"max_supply"`
+	AllowSells             string       `json:"allow_sells" yaml:"allow_sells"`
+	BatchBlocks            string       `json:"batch_blocks" yaml:"batch_blocks"`
+	OutcomePayment         string       `json:"outcome_payment" yaml:"outcome_payment"`
+}
+
+func submitEditProposalRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req submitEditProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		proposer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		edit := types.NewWarEdit(req.Name, req.Description,
+			req.OrderQuantityLimits, req.SanityRate, req.SanityMarginPercentage,
+			req.TxFeePercentage, req.ExitFeePercentage, req.FeeAddress,
+			req.MaxSupply, req.AllowSells, req.BatchBlocks, req.OutcomePayment)
+
+		msg := types.NewMsgSubmitEditProposal(proposer, req.WarToken, edit)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type voteReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	WarToken   string       `json:"war_token" yaml:"war_token"`
+	ProposalID string       `json:"proposal_id" yaml:"proposal_id"`
+	Option     string       `json:"option" yaml:"option"`
+}
+
+func voteRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req voteReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		voter, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		proposalID, err := strconv.ParseUint(req.ProposalID, 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgVote(voter, req.WarToken, proposalID, req.Option)
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
@@ -19,25 +19,42 @@ var (
 	blankSanityMarginPercentage = "0"
 	reserveToken                = "res"
 	reserveToken2               = "rez"
+	reserveToken3               = "rec"
 
 	anotherAddress = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	userAddress    = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 
-	initToken                  = token
-	initName                   = "test token"
-	initDescription            = "this is a test token"
-	initCreator                = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
-	initFeeAddress             = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
-	initTxFeePercentage        = sdk.MustNewDecFromStr("0.1")
-	initExitFeePercentage      = sdk.MustNewDecFromStr("0.1")
-	initMaxSupply              = sdk.NewInt64Coin(initToken, 10000)
-	initOrderQuantityLimits    = sdk.Coins(nil)
-	initSanityRate             = sdk.MustNewDecFromStr(blankSanityRate)
-	initSanityMarginPercentage = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
-	initAllowSell              = true
-	initSigners                = []sdk.AccAddress{initCreator}
-	initBatchBlocks            = sdk.OneUint()
-	initOutcomePayment         = sdk.Coins(nil)
+	initToken                         = token
+	initName                          = "test token"
+	initDescription                   = "this is a test token"
+	initCreator                       = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	initFeeAddress                    = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	initTxFeePercentage               = sdk.MustNewDecFromStr("0.1")
+	initExitFeePercentage             = sdk.MustNewDecFromStr("0.1")
+	initMaxSupply                     = sdk.NewInt64Coin(initToken, 10000)
+	initOrderQuantityLimits           = sdk.Coins(nil)
+	initSanityRate                    = sdk.MustNewDecFromStr(blankSanityRate)
+	initSanityMarginPercentage        = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
+	initAllowSell                     = true
+	initSigners                       = []sdk.AccAddress{initCreator}
+	initSignerThreshold               = uint64(0)
+	initEditDelay                     = int64(0)
+	initBatchBlocks                   = sdk.OneUint()
+	initOutcomePayment                = sdk.Coins(nil)
+	initHatchDeadlineHeight           = int64(0)
+	initAllowedHatchers               = []sdk.AccAddress(nil)
+	initHatchMembershipDenom          = ""
+	initMaxHatchContribution          = sdk.ZeroInt()
+	initHatchVestingCliff             = int64(0)
+	initHatchVestingPeriod            = int64(0)
+	initFundingPoolSpenders           = []sdk.AccAddress(nil)
+	initFundingPoolSpendLimit         = sdk.Coins(nil)
+	initFundingPoolEpochBlocks        = int64(0)
+	initGovernanceQuorumPercentage    = sdk.ZeroDec()
+	initGovernanceThresholdPercentage = sdk.ZeroDec()
+	initGovernanceVotingPeriod        = int64(0)
+	initMaxPriceMovePercentage        = sdk.ZeroDec()
+	initPriceMoveCooldown             = int64(0)
 
 	amountLTMaxSupply = initMaxSupply.Amount.Sub(sdk.OneInt()).Int64()
 	amountGTMaxSupply = initMaxSupply.Amount.Add(sdk.OneInt()).Int64()
@@ -113,6 +130,26 @@ func newValidMsgCreateSwapperWar() types.MsgCreateWar {
 	return validMsg
 }
 
+func newValidMsgCreateWeightedSwapperWar() types.MsgCreateWar {
+	validMsg := newValidMsgCreateWar()
+	validMsg.FunctionType = types.WeightedSwapperFunction
+	validMsg.FunctionParameters = types.FunctionParams{
+		types.NewFunctionParam(reserveToken, sdk.NewDec(1)),
+		types.NewFunctionParam(reserveToken2, sdk.NewDec(1)),
+		types.NewFunctionParam(reserveToken3, sdk.NewDec(2))}
+	validMsg.ReserveTokens = []string{reserveToken, reserveToken2, reserveToken3}
+	return validMsg
+}
+
+func newValidMsgCreateStableSwapWar() types.MsgCreateWar {
+	validMsg := newValidMsgCreateWar()
+	validMsg.FunctionType = types.StableSwapFunction
+	validMsg.FunctionParameters = types.FunctionParams{
+		types.NewFunctionParam("A", sdk.NewDec(100))}
+	validMsg.ReserveTokens = swapperReserves()
+	return validMsg
+}
+
 func newValidMsgCreateAugmentedWar() types.MsgCreateWar {
 	validMsg := newValidMsgCreateWar()
 	validMsg.FunctionType = types.AugmentedFunction
@@ -129,23 +166,52 @@ func newValidMsgCreateWar() types.MsgCreateWar {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment)
+		initAllowSell, initSigners, initSignerThreshold, initEditDelay, initBatchBlocks, initOutcomePayment,
+		initHatchDeadlineHeight, initAllowedHatchers, initHatchMembershipDenom,
+		initMaxHatchContribution, initHatchVestingCliff, initHatchVestingPeriod,
+		initFundingPoolSpenders, initFundingPoolSpendLimit, initFundingPoolEpochBlocks,
+		initGovernanceQuorumPercentage, initGovernanceThresholdPercentage,
+		initGovernanceVotingPeriod, initMaxPriceMovePercentage, initPriceMoveCooldown)
 }
 
 func newValidMsgBuy(amount int64, maxPrice int64) types.MsgBuy {
 	amountCoin := sdk.NewInt64Coin(token, amount)
 	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, maxPrice))
-	return types.NewMsgBuy(userAddress, amountCoin, maxPrices)
+	return types.NewMsgBuy(userAddress, amountCoin, maxPrices, false)
+}
+
+func newValidMsgBuyWithPartialFill(amount int64, maxPrice int64) types.MsgBuy {
+	msg := newValidMsgBuy(amount, maxPrice)
+	msg.AllowPartialFill = true
+	return msg
+}
+
+func newValidMsgBuyWithSpend(spend int64) types.MsgBuyWithSpend {
+	spendCoins := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, spend))
+	return types.NewMsgBuyWithSpend(userAddress, token, spendCoins)
 }
 
 func newValidMsgSell(amount int64) types.MsgSell {
 	amountCoin := sdk.NewInt64Coin(token, amount)
-	return types.NewMsgSell(userAddress, amountCoin)
+	return types.NewMsgSell(userAddress, amountCoin, nil)
+}
+
+func newValidMsgLimitBuy(amount, maxPrice, expiryHeight int64) types.MsgLimitBuy {
+	amountCoin := sdk.NewInt64Coin(token, amount)
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, maxPrice))
+	return types.NewMsgLimitBuy(userAddress, amountCoin, maxPrices, expiryHeight)
+}
+
+func newValidMsgLimitSell(amount, minReturn, expiryHeight int64) types.MsgLimitSell {
+	amountCoin := sdk.NewInt64Coin(token, amount)
+	minReturns := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, minReturn))
+	return types.NewMsgLimitSell(userAddress, amountCoin, minReturns, expiryHeight)
 }
 
 func newValidMsgSwap(fromToken, toToken string, amount int64) types.MsgSwap {
 	fromAmount := sdk.NewInt64Coin(fromToken, amount)
-	return types.NewMsgSwap(userAddress, token, fromAmount, toToken)
+	minOutput := sdk.NewInt64Coin(toToken, 0)
+	return types.NewMsgSwap(userAddress, token, fromAmount, toToken, minOutput)
 }
 
 func newValidMsgMakeOutcomePayment() types.MsgMakeOutcomePayment {
@@ -156,6 +222,10 @@ func newValidMsgWithdrawShareFrom(from sdk.AccAddress) types.MsgWithdrawShare {
 	return types.NewMsgWithdrawShare(from, token)
 }
 
+func newValidMsgClaimVestedFrom(from sdk.AccAddress) types.MsgClaimVested {
+	return types.NewMsgClaimVested(from, token)
+}
+
 func addCoinsToUser(app *simapp.SimApp, ctx sdk.Context, coins sdk.Coins) error {
 	_, err := app.WarsKeeper.BankKeeper.AddCoins(ctx, userAddress, coins)
 	return err
@@ -6,9 +6,9 @@ import (
 )
 
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
-	// Initialise wars
+	// Initialise wars (counting the wars of each creator)
 	for _, b := range data.Wars {
-		keeper.SetWar(ctx, b.Token, b)
+		keeper.AddWar(ctx, b.Token, b)
 	}
 
 	// Initialise batches
@@ -16,28 +16,101 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 		keeper.SetBatch(ctx, b.Token, b)
 	}
 
+	// Initialise order books
+	for _, ob := range data.OrderBooks {
+		keeper.SetOrderBook(ctx, ob.Token, ob)
+	}
+
+	// Initialise hatch contributions
+	for _, hc := range data.HatchContributions {
+		keeper.SetHatchContributions(ctx, hc.Token, hc)
+	}
+
+	// Initialise hatch vestings
+	for _, hv := range data.HatchVestings {
+		keeper.SetHatchVestings(ctx, hv.Token, hv)
+	}
+
+	// Initialise funding pools
+	for _, fp := range data.FundingPools {
+		keeper.SetFundingPool(ctx, fp.Token, fp)
+	}
+
+	// Initialise proposals
+	for _, ps := range data.Proposals {
+		keeper.SetProposals(ctx, ps.Token, ps)
+	}
+
+	// Initialise pending edits
+	for _, pes := range data.PendingEdits {
+		keeper.SetPendingEdits(ctx, pes.Token, pes)
+	}
+
 	// Initialise params
 	keeper.SetParams(ctx, data.Params)
 }
 
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	// Export wars and batches
+	// Export wars, batches, and any order books, hatch contributions, hatch
+	// vestings, funding pools, proposals, and pending edits that were used
 	var wars []types.War
 	var batches []types.Batch
+	var orderBooks []types.OrderBook
+	var hatchContributions []types.HatchContributions
+	var hatchVestings []types.HatchVestings
+	var fundingPools []types.FundingPool
+	var proposals []types.Proposals
+	var pendingEdits []types.PendingEdits
 	iterator := k.GetWarIterator(ctx)
 	for ; iterator.Valid(); iterator.Next() {
 		war := k.MustGetWarByKey(ctx, iterator.Key())
 		batch := k.MustGetBatch(ctx, war.Token)
 		wars = append(wars, war)
 		batches = append(batches, batch)
+
+		orderBook := k.GetOrderBook(ctx, war.Token)
+		if orderBook.NextOrderID != 0 {
+			orderBooks = append(orderBooks, orderBook)
+		}
+
+		hatchContribs := k.GetHatchContributions(ctx, war.Token)
+		if len(hatchContribs.Contributions) != 0 {
+			hatchContributions = append(hatchContributions, hatchContribs)
+		}
+
+		hatchVests := k.GetHatchVestings(ctx, war.Token)
+		if len(hatchVests.Vestings) != 0 {
+			hatchVestings = append(hatchVestings, hatchVests)
+		}
+
+		fundingPool := k.GetFundingPool(ctx, war.Token)
+		if !fundingPool.Balance.IsZero() || !fundingPool.SpentInEpoch.IsZero() {
+			fundingPools = append(fundingPools, fundingPool)
+		}
+
+		warProposals := k.GetProposals(ctx, war.Token)
+		if warProposals.NextProposalID != 0 {
+			proposals = append(proposals, warProposals)
+		}
+
+		warPendingEdits := k.GetPendingEdits(ctx, war.Token)
+		if warPendingEdits.NextEditID != 0 {
+			pendingEdits = append(pendingEdits, warPendingEdits)
+		}
 	}
 
 	// Export params
 	params := k.GetParams(ctx)
 
 	return GenesisState{
-		Wars:   wars,
-		Batches: batches,
-		Params:  params,
+		Wars:               wars,
+		Batches:            batches,
+		OrderBooks:         orderBooks,
+		HatchContributions: hatchContributions,
+		HatchVestings:      hatchVestings,
+		FundingPools:       fundingPools,
+		Proposals:          proposals,
+		PendingEdits:       pendingEdits,
+		Params:             params,
 	}
 }
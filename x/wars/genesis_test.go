@@ -38,32 +38,109 @@ func TestInitAndExportGenesis(t *testing.T) {
 	sanityMarginPercentage := sdk.MustNewDecFromStr("0.4")
 	allowSell := true
 	signers := []sdk.AccAddress{creator}
+	signerThreshold := uint64(1)
+	editDelay := int64(0)
 	batchBlocks := sdk.NewUint(10)
 	outcomePayment := sdk.NewCoins(
 		sdk.NewInt64Coin("token1", 1),
 		sdk.NewInt64Coin("token2", 2),
 		sdk.NewInt64Coin("token3", 3),
 	)
+	hatchDeadlineHeight := int64(100)
+	allowedHatchers := []sdk.AccAddress{creator}
+	hatchMembershipDenom := "membertoken"
+	maxHatchContribution := sdk.NewInt(1000)
+	hatchVestingCliff := int64(10)
+	hatchVestingPeriod := int64(100)
+	fundingPoolSpenders := []sdk.AccAddress{creator}
+	fundingPoolSpendLimit := sdk.NewCoins(sdk.NewInt64Coin("reservetoken", 100))
+	fundingPoolEpochBlocks := int64(10)
+	governanceQuorumPercentage := sdk.MustNewDecFromStr("40")
+	governanceThresholdPercentage := sdk.MustNewDecFromStr("50")
+	governanceVotingPeriod := int64(100)
+	maxPriceMovePercentage := sdk.MustNewDecFromStr("20")
+	priceMoveCooldown := int64(10)
 	state := "dummy_state"
 
 	war := types.NewWar(token, name, description, creator, functionType,
 		functionParameters, reserveTokens, txFeePercentage, exitFeePercentage,
 		feeAddress, maxSupply, orderQuantityLimits, sanityRate, sanityMarginPercentage,
-		allowSell, signers, batchBlocks, outcomePayment, state)
+		allowSell, signers, signerThreshold, editDelay, batchBlocks, outcomePayment, hatchDeadlineHeight,
+		allowedHatchers, hatchMembershipDenom, maxHatchContribution,
+		hatchVestingCliff, hatchVestingPeriod, fundingPoolSpenders,
+		fundingPoolSpendLimit, fundingPoolEpochBlocks, governanceQuorumPercentage,
+		governanceThresholdPercentage, governanceVotingPeriod, maxPriceMovePercentage,
+		priceMoveCooldown, state)
 	batch := types.NewBatch(war.Token, war.BatchBlocks)
+	orderBook := types.NewOrderBook(war.Token)
+	orderBook.Orders = []types.LimitOrder{types.NewLimitOrder(types.LimitBuyOrderType,
+		creator, sdk.NewInt64Coin(token, 10), sdk.NewCoins(sdk.NewInt64Coin("reservetoken", 100)), 100)}
+	orderBook.NextOrderID = 1
+	hatchContributions := types.NewHatchContributions(war.Token)
+	hatchContributions.Contributions = []types.HatchContribution{
+		types.NewHatchContribution(creator, sdk.NewInt(10))}
+	hatchVestings := types.NewHatchVestings(war.Token)
+	hatchVestings.StartHeight = 5
+	hatchVestings.Vestings = []types.HatchVesting{
+		types.NewHatchVesting(creator, sdk.NewInt(10))}
+	fundingPool := types.NewFundingPool(war.Token)
+	fundingPool.Balance = sdk.NewCoins(sdk.NewInt64Coin("reservetoken", 100))
+	proposals := types.NewProposals(war.Token)
+	proposals.Proposals = []types.Proposal{types.NewSpendProposal(creator, creator,
+		sdk.NewCoins(sdk.NewInt64Coin("reservetoken", 10)), 100)}
+	proposals.Votes = []types.Vote{types.NewVote(0, creator, types.YesVoteOption, sdk.NewInt(10))}
+	proposals.NextProposalID = 1
+	pendingEdits := types.NewPendingEdits(war.Token)
+	pendingEdits.PendingEdits = []types.PendingEdit{types.NewPendingEdit(
+		types.NewWarEdit("newName", types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField),
+		creator, 100)}
+	pendingEdits.NextEditID = 1
 
-	genesisState = wars.NewGenesisState(
-		[]types.War{war}, []types.Batch{batch}, types.DefaultParams())
+	genesisState = wars.NewGenesisState([]types.War{war}, []types.Batch{batch},
+		[]types.OrderBook{orderBook},
+		[]types.HatchContributions{hatchContributions},
+		[]types.HatchVestings{hatchVestings},
+		[]types.FundingPool{fundingPool},
+		[]types.Proposals{proposals},
+		[]types.PendingEdits{pendingEdits}, types.DefaultParams())
 
 	wars.InitGenesis(ctx, app.WarsKeeper, genesisState)
 
 	returnedWar := app.WarsKeeper.MustGetWar(ctx, token)
 	require.EqualValues(t, war, returnedWar)
+	require.Equal(t, uint64(1), app.WarsKeeper.GetWarCountByCreator(ctx, creator))
 
 	returnedBatch := app.WarsKeeper.MustGetBatch(ctx, token)
 	require.Equal(t, batch, returnedBatch)
 
+	returnedOrderBook := app.WarsKeeper.GetOrderBook(ctx, token)
+	require.Equal(t, orderBook, returnedOrderBook)
+
+	returnedHatchContributions := app.WarsKeeper.GetHatchContributions(ctx, token)
+	require.Equal(t, hatchContributions, returnedHatchContributions)
+
+	returnedHatchVestings := app.WarsKeeper.GetHatchVestings(ctx, token)
+	require.Equal(t, hatchVestings, returnedHatchVestings)
+
+	returnedFundingPool := app.WarsKeeper.GetFundingPool(ctx, token)
+	require.Equal(t, fundingPool, returnedFundingPool)
+
+	returnedProposals := app.WarsKeeper.GetProposals(ctx, token)
+	require.Equal(t, proposals, returnedProposals)
+
+	returnedPendingEdits := app.WarsKeeper.GetPendingEdits(ctx, token)
+	require.Equal(t, pendingEdits, returnedPendingEdits)
+
 	exportedGenesisState := wars.ExportGenesis(ctx, app.WarsKeeper)
 	require.Equal(t, genesisState.Wars, exportedGenesisState.Wars)
 	require.Equal(t, genesisState.Batches, exportedGenesisState.Batches)
+	require.Equal(t, genesisState.OrderBooks, exportedGenesisState.OrderBooks)
+	require.Equal(t, genesisState.HatchContributions, exportedGenesisState.HatchContributions)
+	require.Equal(t, genesisState.HatchVestings, exportedGenesisState.HatchVestings)
+	require.Equal(t, genesisState.FundingPools, exportedGenesisState.FundingPools)
+	require.Equal(t, genesisState.Proposals, exportedGenesisState.Proposals)
+	require.Equal(t, genesisState.PendingEdits, exportedGenesisState.PendingEdits)
 }
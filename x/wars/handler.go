@@ -1,6 +1,7 @@
 package wars
 
 import (
+	"errors"
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -19,16 +20,42 @@ func NewHandler(keeper keeper.Keeper) sdk.Handler {
 			return handleMsgCreateWar(ctx, keeper, msg)
 		case types.MsgEditWar:
 			return handleMsgEditWar(ctx, keeper, msg)
+		case types.MsgUpdateWarSigners:
+			return handleMsgUpdateWarSigners(ctx, keeper, msg)
+		case types.MsgCancelPendingEdit:
+			return handleMsgCancelPendingEdit(ctx, keeper, msg)
+		case types.MsgSetWarPaused:
+			return handleMsgSetWarPaused(ctx, keeper, msg)
 		case types.MsgBuy:
 			return handleMsgBuy(ctx, keeper, msg)
+		case types.MsgBuyWithSpend:
+			return handleMsgBuyWithSpend(ctx, keeper, msg)
 		case types.MsgSell:
 			return handleMsgSell(ctx, keeper, msg)
 		case types.MsgSwap:
 			return handleMsgSwap(ctx, keeper, msg)
+		case types.MsgRoutedSwap:
+			return handleMsgRoutedSwap(ctx, keeper, msg)
+		case types.MsgLimitBuy:
+			return handleMsgLimitBuy(ctx, keeper, msg)
+		case types.MsgLimitSell:
+			return handleMsgLimitSell(ctx, keeper, msg)
+		case types.MsgCancelOrder:
+			return handleMsgCancelOrder(ctx, keeper, msg)
+		case types.MsgCancelLimitOrder:
+			return handleMsgCancelLimitOrder(ctx, keeper, msg)
 		case types.MsgMakeOutcomePayment:
 			return handleMsgMakeOutcomePayment(ctx, keeper, msg)
 		case types.MsgWithdrawShare:
 			return handleMsgWithdrawShare(ctx, keeper, msg)
+		case types.MsgClaimVested:
+			return handleMsgClaimVested(ctx, keeper, msg)
+		case types.MsgSpendFromFundingPool:
+			return handleMsgSpendFromFundingPool(ctx, keeper, msg)
+		case types.MsgSubmitProposal:
+			return handleMsgSubmitProposal(ctx, keeper, msg)
+		case types.MsgVote:
+			return handleMsgVote(ctx, keeper, msg)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "Unrecognized wars Msg type: %v", msg.Type())
 		}
@@ -42,38 +69,62 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) []abci.ValidatorUpdate {
 		war := keeper.MustGetWarByKey(ctx, iterator.Key())
 		batch := keeper.MustGetBatch(ctx, war.Token)
 
-		// Subtract one block
-		batch.BlocksRemaining = batch.BlocksRemaining.SubUint64(1)
-		keeper.SetBatch(ctx, war.Token, batch)
+		// Cancel limit orders that can no longer be added to a batch
+		keeper.CancelExpiredLimitOrders(ctx, war.Token)
 
-		// If blocks remaining > 0 do not perform orders
-		if !batch.BlocksRemaining.IsZero() {
-			continue
+		// Tally proposals whose voting period has ended and execute the
+		// ones that passed
+		err := keeper.TallyEndedProposals(ctx, war.Token)
+		if err != nil {
+			panic(err)
 		}
 
-		// Perform orders
-		keeper.PerformOrders(ctx, war.Token)
-
-		// Get war again just in case current supply was updated
-		// Get batch again just in case orders were cancelled
-		war = keeper.MustGetWar(ctx, war.Token)
-		batch = keeper.MustGetBatch(ctx, war.Token)
-
-		// For augmented, if hatch phase and newSupply >= S0, go to open phase
-		if war.FunctionType == types.AugmentedFunction &&
-			war.State == types.HatchState {
-			args := war.FunctionParameters.AsMap()
-			if war.CurrentSupply.Amount.ToDec().GTE(args["S0"]) {
-				keeper.SetWarState(ctx, war.Token, types.OpenState)
-				war = keeper.MustGetWar(ctx, war.Token) // get war again
-				war.AllowSells = true                     // enable sells
-				keeper.SetWar(ctx, war.Token, war)      // update war
-			}
+		// Apply pending edits whose edit delay has passed
+		keeper.ApplyDuePendingEdits(ctx, war.Token)
+
+		// Unpause the war if it was paused for exceeding its max price move
+		// and its price move cooldown has ended
+		keeper.EndPriceMoveCooldown(ctx, war.Token)
+
+		// Subtract one block, unless the war is paused, in which case its
+		// batch was refunded when it was paused and no new orders are added
+		// to it until it is unpaused
+		war = keeper.MustGetWar(ctx, war.Token) // get war again
+		batchEnded := false
+		if !war.Paused {
+			batch.BlocksRemaining = batch.BlocksRemaining.SubUint64(1)
+			keeper.SetBatch(ctx, war.Token, batch)
+			batchEnded = batch.BlocksRemaining.IsZero()
+		}
+
+		// If blocks remaining = 0, perform orders, unless performing them
+		// would move the war's prices by more than the war's max price move,
+		// in which case refund the batch and pause the war instead
+		if batchEnded && !keeper.PerformOrdersWithinMaxPriceMove(ctx, war.Token) {
+			keeper.PauseForPriceMove(ctx, war.Token)
+			batchEnded = false
+		} else if batchEnded {
+			// Get batch again just in case orders were cancelled
+			batch = keeper.MustGetBatch(ctx, war.Token)
+
+			// Save current batch as last batch and reset current batch
+			keeper.SetLastBatch(ctx, war.Token, batch)
+			keeper.SetBatch(ctx, war.Token, types.NewBatch(war.Token, war.BatchBlocks))
+		}
+
+		// For augmented, if hatch phase and supply >= S0, go to open phase.
+		// Otherwise, if the hatch deadline has been reached, go to failed
+		// phase. This is checked in every block, even if the war is paused or
+		// the batch has not ended, so that the hatch fails at its deadline
+		err = keeper.UpdateHatchState(ctx, war.Token)
+		if err != nil {
+			panic(err)
 		}
 
-		// Save current batch as last batch and reset current batch
-		keeper.SetLastBatch(ctx, war.Token, batch)
-		keeper.SetBatch(ctx, war.Token, types.NewBatch(war.Token, war.BatchBlocks))
+		// Add limit orders whose limit prices are met to the new batch
+		if batchEnded {
+			keeper.AddLimitOrdersToBatch(ctx, war.Token)
+		}
 	}
 	return []abci.ValidatorUpdate{}
 }
@@ -86,7 +137,7 @@ func handleMsgCreateWar(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCrea
 	// Check that war and war DID do not already exist
 	if keeper.WarExists(ctx, msg.Token) {
 		return nil, sdkerrors.Wrap(types.ErrWarAlreadyExists, msg.Token)
-	} else if msg.Token == keeper.StakingKeeper.GetParams(ctx).WarDenom {
+	} else if msg.Token == keeper.StakingKeeper.GetParams(ctx).BondDenom {
 		return nil, sdkerrors.Wrap(types.ErrWarTokenCannotBeStakingToken, msg.Token)
 	}
 
@@ -95,6 +146,15 @@ func handleMsgCreateWar(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCrea
 		return nil, types.ErrReservedWarToken
 	}
 
+	// Check that war is within the limits set by the module params
+	if err := keeper.CheckFeesWithinParams(ctx, msg.TxFeePercentage, msg.ExitFeePercentage); err != nil {
+		return nil, err
+	} else if err := keeper.CheckBatchBlocksWithinParams(ctx, msg.BatchBlocks); err != nil {
+		return nil, err
+	} else if err := keeper.CheckWarsPerCreatorWithinParams(ctx, msg.Creator); err != nil {
+		return nil, err
+	}
+
 	// Set state to open by default (overridden below if augmented function)
 	state := types.OpenState
 
@@ -123,6 +183,20 @@ func handleMsgCreateWar(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCrea
 		// that we start with OpenState because S0>0, since S0=d0/p0 and d0>0
 		state = types.HatchState
 		msg.AllowSells = false
+
+		// Check that hatch deadline (if any) has not already been reached
+		if msg.HatchDeadlineHeight != 0 &&
+			msg.HatchDeadlineHeight <= ctx.BlockHeight() {
+			return nil, sdkerrors.Wrapf(types.ErrHatchDeadlineAlreadyPassed,
+				"deadline %d <= current height %d",
+				msg.HatchDeadlineHeight, ctx.BlockHeight())
+		}
+	}
+
+	// Hold war creation deposit (if any) until it is refunded
+	deposit, err := keeper.DepositWarCreationDeposit(ctx, msg.Creator)
+	if err != nil {
+		return nil, err
 	}
 
 	war := types.NewWar(msg.Token, msg.Name, msg.Description, msg.Creator,
@@ -130,9 +204,16 @@ func handleMsgCreateWar(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCrea
 		msg.TxFeePercentage, msg.ExitFeePercentage, msg.FeeAddress,
 		msg.MaxSupply, msg.OrderQuantityLimits, msg.SanityRate,
 		msg.SanityMarginPercentage, msg.AllowSells, msg.Signers,
-		msg.BatchBlocks, msg.OutcomePayment, state)
-
-	keeper.SetWar(ctx, msg.Token, war)
+		msg.SignerThreshold, msg.EditDelay, msg.BatchBlocks, msg.OutcomePayment, msg.HatchDeadlineHeight,
+		msg.AllowedHatchers, msg.HatchMembershipDenom, msg.MaxHatchContribution,
+		msg.HatchVestingCliff, msg.HatchVestingPeriod, msg.FundingPoolSpenders,
+		msg.FundingPoolSpendLimit, msg.FundingPoolEpochBlocks,
+		msg.GovernanceQuorumPercentage, msg.GovernanceThresholdPercentage,
+		msg.GovernanceVotingPeriod, msg.MaxPriceMovePercentage,
+		msg.PriceMoveCooldown, state)
+	war.CreationDeposit = deposit
+
+	keeper.AddWar(ctx, msg.Token, war)
 	keeper.SetBatch(ctx, msg.Token, types.NewBatch(war.Token, msg.BatchBlocks))
 
 	logger := keeper.Logger(ctx)
@@ -157,9 +238,26 @@ func handleMsgCreateWar(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCrea
 			sdk.NewAttribute(types.AttributeKeySanityMarginPercentage, msg.SanityMarginPercentage.String()),
 			sdk.NewAttribute(types.AttributeKeyAllowSells, strconv.FormatBool(msg.AllowSells)),
 			sdk.NewAttribute(types.AttributeKeySigners, types.AccAddressesToString(msg.Signers)),
+			sdk.NewAttribute(types.AttributeKeySignerThreshold, strconv.FormatUint(msg.SignerThreshold, 10)),
+			sdk.NewAttribute(types.AttributeKeyEditDelay, strconv.FormatInt(msg.EditDelay, 10)),
 			sdk.NewAttribute(types.AttributeKeyBatchBlocks, msg.BatchBlocks.String()),
 			sdk.NewAttribute(types.AttributeKeyOutcomePayment, msg.OutcomePayment.String()),
+			sdk.NewAttribute(types.AttributeKeyHatchDeadlineHeight, strconv.FormatInt(msg.HatchDeadlineHeight, 10)),
+			sdk.NewAttribute(types.AttributeKeyAllowedHatchers, types.AccAddressesToString(msg.AllowedHatchers)),
+			sdk.NewAttribute(types.AttributeKeyHatchMembershipDenom, msg.HatchMembershipDenom),
+			sdk.NewAttribute(types.AttributeKeyMaxHatchContribution, msg.MaxHatchContribution.String()),
+			sdk.NewAttribute(types.AttributeKeyHatchVestingCliff, strconv.FormatInt(msg.HatchVestingCliff, 10)),
+			sdk.NewAttribute(types.AttributeKeyHatchVestingPeriod, strconv.FormatInt(msg.HatchVestingPeriod, 10)),
+			sdk.NewAttribute(types.AttributeKeyFundingPoolSpenders, types.AccAddressesToString(msg.FundingPoolSpenders)),
+			sdk.NewAttribute(types.AttributeKeyFundingPoolSpendLimit, msg.FundingPoolSpendLimit.String()),
+			sdk.NewAttribute(types.AttributeKeyFundingPoolEpochBlocks, strconv.FormatInt(msg.FundingPoolEpochBlocks, 10)),
+			sdk.NewAttribute(types.AttributeKeyGovernanceQuorumPercentage, msg.GovernanceQuorumPercentage.String()),
+			sdk.NewAttribute(types.AttributeKeyGovernanceThresholdPercentage, msg.GovernanceThresholdPercentage.String()),
+			sdk.NewAttribute(types.AttributeKeyGovernanceVotingPeriod, strconv.FormatInt(msg.GovernanceVotingPeriod, 10)),
+			sdk.NewAttribute(types.AttributeKeyMaxPriceMovePercentage, msg.MaxPriceMovePercentage.String()),
+			sdk.NewAttribute(types.AttributeKeyPriceMoveCooldown, strconv.FormatInt(msg.PriceMoveCooldown, 10)),
 			sdk.NewAttribute(types.AttributeKeyState, state),
+			sdk.NewAttribute(types.AttributeKeyCreationDeposit, deposit.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -178,65 +276,185 @@ func handleMsgEditWar(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgEditWa
 		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, msg.Token)
 	}
 
-	if !war.SignersEqualTo(msg.Signers) {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "list of signers does not match the one in the war")
-	}
-
-	if msg.Name != types.DoNotModifyField {
-		war.Name = msg.Name
-	}
-	if msg.Description != types.DoNotModifyField {
-		war.Description = msg.Description
+	if err := checkWarSigners(war, msg.Signers); err != nil {
+		return nil, err
 	}
 
-	if msg.OrderQuantityLimits != types.DoNotModifyField {
-		orderQuantityLimits, err := sdk.ParseCoins(msg.OrderQuantityLimits)
+	edit := msg.GetWarEdit()
+	if war.EditDelay > 0 {
+		// Check that the edit can currently be applied, even though it will
+		// only be applied (if not cancelled) once the edit delay has passed
+		edited, err := edit.Apply(war)
 		if err != nil {
-			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
-		}
-		war.OrderQuantityLimits = orderQuantityLimits
-	}
-
-	if msg.SanityRate != types.DoNotModifyField {
-		var sanityRate, sanityMarginPercentage sdk.Dec
-		if msg.SanityRate == "" {
-			sanityRate = sdk.ZeroDec()
-			sanityMarginPercentage = sdk.ZeroDec()
-		} else {
-			parsedSanityRate, err := sdk.NewDecFromStr(msg.SanityRate)
-			if err != nil {
-				return nil, sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "sanity rate")
-			} else if parsedSanityRate.IsNegative() {
-				return nil, sdkerrors.Wrap(types.ErrArgumentCannotBeNegative, "sanity rate")
+			return nil, err
+		} else if edit.BatchBlocks != types.DoNotModifyField {
+			if err := keeper.CheckBatchBlocksWithinParams(ctx, edited.BatchBlocks); err != nil {
+				return nil, err
 			}
-			parsedSanityMarginPercentage, err := sdk.NewDecFromStr(msg.SanityMarginPercentage)
-			if err != nil {
-				return nil, sdkerrors.Wrap(types.ErrArgumentMissingOrNonFloat, "sanity margin percentage")
-			} else if parsedSanityMarginPercentage.IsNegative() {
-				return nil, sdkerrors.Wrap(types.ErrArgumentCannotBeNegative, "sanity margin percentage")
-			}
-			sanityRate = parsedSanityRate
-			sanityMarginPercentage = parsedSanityMarginPercentage
 		}
-		war.SanityRate = sanityRate
-		war.SanityMarginPercentage = sanityMarginPercentage
+
+		pe := keeper.AddPendingEdit(ctx, msg.Token, types.NewPendingEdit(
+			edit, msg.Editor, ctx.BlockHeight()+war.EditDelay))
+
+		ctx.EventManager().EmitEvents(sdk.Events{
+			sdk.NewEvent(
+				types.EventTypePendingEdit,
+				sdk.NewAttribute(types.AttributeKeyWar, msg.Token),
+				sdk.NewAttribute(types.AttributeKeyEditID, strconv.FormatUint(pe.ID, 10)),
+				sdk.NewAttribute(types.AttributeKeyEffectiveHeight, strconv.FormatInt(pe.EffectiveHeight, 10)),
+			),
+			sdk.NewEvent(
+				sdk.EventTypeMessage,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+				sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+			),
+		})
+
+		return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+	}
+
+	// Note: edit_war event is emitted by EditWar
+	err := keeper.EditWar(ctx, msg.Token, edit)
+	if err != nil {
+		return nil, err
 	}
 
 	logger := keeper.Logger(ctx)
 	logger.Info(fmt.Sprintf("war %s edited by %s",
 		msg.Token, msg.Editor.String()))
 
-	keeper.SetWar(ctx, msg.Token, war)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgUpdateWarSigners(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgUpdateWarSigners) (*sdk.Result, error) {
+
+	war, found := keeper.GetWar(ctx, msg.Token)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, msg.Token)
+	}
+
+	if err := checkWarSigners(war, msg.Signers); err != nil {
+		return nil, err
+	}
+
+	// Signers updates are delayed by the edit delay in the same way as edits,
+	// so that the signers cannot bypass the delay by replacing themselves
+	signersEdit := types.NewSignersEdit(msg.NewSigners, msg.NewSignerThreshold)
+	if war.EditDelay > 0 {
+		pe := keeper.AddPendingEdit(ctx, msg.Token, types.NewSignersPendingEdit(
+			signersEdit, msg.Editor, ctx.BlockHeight()+war.EditDelay))
+
+		ctx.EventManager().EmitEvents(sdk.Events{
+			sdk.NewEvent(
+				types.EventTypePendingEdit,
+				sdk.NewAttribute(types.AttributeKeyWar, msg.Token),
+				sdk.NewAttribute(types.AttributeKeyEditID, strconv.FormatUint(pe.ID, 10)),
+				sdk.NewAttribute(types.AttributeKeyEffectiveHeight, strconv.FormatInt(pe.EffectiveHeight, 10)),
+			),
+			sdk.NewEvent(
+				sdk.EventTypeMessage,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+				sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+			),
+		})
+
+		return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+	}
+
+	// Note: update_war_signers event is emitted by UpdateWarSigners
+	keeper.UpdateWarSigners(ctx, msg.Token, signersEdit)
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("war %s signers updated by %s",
+		msg.Token, msg.Editor.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgCancelPendingEdit(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCancelPendingEdit) (*sdk.Result, error) {
+
+	war, found := keeper.GetWar(ctx, msg.Token)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, msg.Token)
+	}
+
+	if err := checkWarSigners(war, msg.Signers); err != nil {
+		return nil, err
+	}
+
+	_, err := keeper.CancelPendingEdit(ctx, msg.Token, msg.EditID)
+	if err != nil {
+		return nil, err
+	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
-			types.EventTypeEditWar,
+			types.EventTypeCancelPendingEdit,
 			sdk.NewAttribute(types.AttributeKeyWar, msg.Token),
-			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
-			sdk.NewAttribute(types.AttributeKeyDescription, msg.Description),
-			sdk.NewAttribute(types.AttributeKeyOrderQuantityLimits, msg.OrderQuantityLimits),
-			sdk.NewAttribute(types.AttributeKeySanityRate, msg.SanityRate),
-			sdk.NewAttribute(types.AttributeKeySanityMarginPercentage, msg.SanityMarginPercentage),
+			sdk.NewAttribute(types.AttributeKeyEditID, strconv.FormatUint(msg.EditID, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSetWarPaused(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSetWarPaused) (*sdk.Result, error) {
+
+	war, found := keeper.GetWar(ctx, msg.Token)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, msg.Token)
+	}
+
+	// The pause authority (if any) can pause or unpause any war on its own,
+	// otherwise the war signers need to meet the war's signer threshold
+	if !isPauseAuthority(keeper.GetParams(ctx), msg.Signers) {
+		if err := checkWarSigners(war, msg.Signers); err != nil {
+			return nil, err
+		}
+	}
+
+	// If the war is being paused, refund the orders in its batch, since they
+	// cannot be performed while the war is paused
+	if msg.Paused && !war.Paused {
+		keeper.RefundBatch(ctx, war.Token, types.CancelReasonWarPaused)
+		war = keeper.MustGetWar(ctx, war.Token) // get war again
+	}
+
+	// Pausing or unpausing the war also ends any price move cooldown, so a
+	// war paused by the signers stays paused until they unpause it
+	war.Paused = msg.Paused
+	war.PausedUntilHeight = 0
+	keeper.SetWar(ctx, war.Token, war)
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("set paused to %t for %s", war.Paused, war.Token))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSetWarPaused,
+			sdk.NewAttribute(types.AttributeKeyWar, msg.Token),
+			sdk.NewAttribute(types.AttributeKeyPaused, strconv.FormatBool(msg.Paused)),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -248,6 +466,35 @@ func handleMsgEditWar(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgEditWa
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+// isPauseAuthority returns true if the module's pause authority is set and is
+// one of the signers
+func isPauseAuthority(params types.Params, signers []sdk.AccAddress) bool {
+	if params.PauseAuthority.Empty() {
+		return false
+	}
+	for _, s := range signers {
+		if s.Equals(params.PauseAuthority) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWarSigners checks that all of the signers are war signers and that
+// there are enough of them to meet the war's signer threshold
+func checkWarSigners(war types.War, signers []sdk.AccAddress) error {
+	for _, s := range signers {
+		if !war.IsSigner(s) {
+			return sdkerrors.Wrap(types.ErrNotWarSigner, s.String())
+		}
+	}
+	if !war.SignersMeetThreshold(signers) {
+		return sdkerrors.Wrapf(types.ErrSignerThresholdNotMet,
+			"%d signers present but threshold is %d", len(signers), war.GetSignerThreshold())
+	}
+	return nil
+}
+
 func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) (*sdk.Result, error) {
 
 	token := msg.Amount.Denom
@@ -256,9 +503,16 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) (*sdk
 		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, token)
 	}
 
-	// Check current state is HATCH/OPEN, max prices, order quantity limits
-	if war.State != types.OpenState && war.State != types.HatchState {
+	// Check war not paused, current state is HATCH/OPEN, hatch deadline not
+	// reached, max prices, order quantity limits
+	if war.Paused {
+		return nil, sdkerrors.Wrap(types.ErrWarPaused, war.Token)
+	} else if war.State != types.OpenState && war.State != types.HatchState {
 		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, war.State)
+	} else if war.HatchDeadlineReached(ctx.BlockHeight()) {
+		return nil, sdkerrors.Wrapf(types.ErrHatchDeadlineAlreadyPassed,
+			"deadline %d <= current height %d",
+			war.HatchDeadlineHeight, ctx.BlockHeight())
 	} else if !war.ReserveDenomsEqualTo(msg.MaxPrices) {
 		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.MaxPrices.String(), strings.Join(war.ReserveTokens, ","))
 	} else if war.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
@@ -268,7 +522,7 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) (*sdk
 	// For the swapper, the first buy is the initialisation of the reserves
 	// The max prices are used as the actual prices and one token is minted
 	// The amount of token serves to define the price of adding more liquidity
-	if war.CurrentSupply.IsZero() && war.FunctionType == types.SwapperFunction {
+	if war.CurrentSupply.IsZero() && war.IsSwapper() {
 		return performFirstSwapperFunctionBuy(ctx, keeper, msg)
 	}
 
@@ -280,11 +534,32 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) (*sdk
 	}
 
 	// Create order
-	order := types.NewBuyOrder(msg.Buyer, msg.Amount, msg.MaxPrices)
+	order := types.NewBuyOrder(msg.Buyer, msg.Amount, msg.MaxPrices, msg.AllowPartialFill)
 
 	// Get buy price and check if can add buy order to batch
 	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterBuy(ctx, token, order)
-	if err != nil {
+	if err != nil && msg.AllowPartialFill && errors.Is(err, types.ErrMaxPriceExceeded) {
+		// Reduce order to the largest amount that can be bought within the
+		// max prices, given that the order would otherwise be rejected
+		amount, err2 := keeper.GetMaxBuyAmountForSpend(ctx, token, msg.Buyer, msg.MaxPrices)
+		if err2 != nil {
+			return nil, err
+		}
+		order.Amount = amount
+		buyPrices, sellPrices, err = keeper.GetUpdatedBatchPricesAfterBuy(ctx, token, order)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypePartialFill,
+			sdk.NewAttribute(types.AttributeKeyWar, token),
+			sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Buyer.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyUnfilledAmount, msg.Amount.Sub(amount).Amount.String()),
+		))
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -298,8 +573,9 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) (*sdk
 		sdk.NewEvent(
 			types.EventTypeBuy,
 			sdk.NewAttribute(types.AttributeKeyWar, msg.Amount.Denom),
-			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, order.Amount.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeyMaxPrices, msg.MaxPrices.String()),
+			sdk.NewAttribute(types.AttributeKeyAllowPartialFill, strconv.FormatBool(msg.AllowPartialFill)),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -366,6 +642,81 @@ func performFirstSwapperFunctionBuy(ctx sdk.Context, keeper keeper.Keeper, msg t
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+func handleMsgBuyWithSpend(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuyWithSpend) (*sdk.Result, error) {
+
+	token := msg.WarToken
+	war, found := keeper.GetWar(ctx, token)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, token)
+	}
+
+	// Check war not paused, current state is HATCH/OPEN, hatch deadline not
+	// reached and spend denoms
+	if war.Paused {
+		return nil, sdkerrors.Wrap(types.ErrWarPaused, war.Token)
+	} else if war.State != types.OpenState && war.State != types.HatchState {
+		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, war.State)
+	} else if war.HatchDeadlineReached(ctx.BlockHeight()) {
+		return nil, sdkerrors.Wrapf(types.ErrHatchDeadlineAlreadyPassed,
+			"deadline %d <= current height %d",
+			war.HatchDeadlineHeight, ctx.BlockHeight())
+	} else if !war.ReserveDenomsEqualTo(msg.Spend) {
+		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.Spend.String(), strings.Join(war.ReserveTokens, ","))
+	}
+
+	// For the swapper, the first buy defines the token price, so the amount
+	// of tokens to be bought cannot be calculated from the spend
+	if war.CurrentSupply.IsZero() && war.IsSwapper() {
+		return nil, sdkerrors.Wrap(types.ErrFunctionRequiresNonZeroCurrentSupply, war.FunctionType)
+	}
+
+	// Get largest amount that can be bought with the spend at the batch price
+	// (enforces max supply and order quantity limits)
+	amount, err := keeper.GetMaxBuyAmountForSpend(ctx, token, msg.Buyer, msg.Spend)
+	if err != nil {
+		return nil, err
+	}
+
+	// Take spend from buyer (enforces spend <= balance). Whatever is not
+	// spent is returned to the buyer when the buy order is performed.
+	err = keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Buyer,
+		types.BatchesIntermediaryAccount, msg.Spend)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create order, using the spend as the max prices
+	order := types.NewBuyOrder(msg.Buyer, amount, msg.Spend, false)
+
+	// Get buy price and check if can add buy order to batch
+	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterBuy(ctx, token, order)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add buy order to batch
+	keeper.AddBuyOrder(ctx, token, order, buyPrices, sellPrices)
+
+	// Cancel unfulfillable orders
+	keeper.CancelUnfulfillableOrders(ctx, token)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeBuyWithSpend,
+			sdk.NewAttribute(types.AttributeKeyWar, token),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeySpend, msg.Spend.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Buyer.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
 func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) (*sdk.Result, error) {
 
 	token := msg.Amount.Denom
@@ -374,8 +725,11 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) (*s
 		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, token)
 	}
 
-	// Check sells allowed, current state is OPEN, and order limits not exceeded
-	if !war.AllowSells {
+	// Check war not paused, sells allowed, current state is OPEN, and order
+	// limits not exceeded
+	if war.Paused {
+		return nil, sdkerrors.Wrap(types.ErrWarPaused, war.Token)
+	} else if !war.AllowSells {
 		return nil, sdkerrors.Wrap(types.ErrWarDoesNotAllowSelling, token)
 	} else if war.State != types.OpenState {
 		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, war.State)
@@ -383,8 +737,21 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) (*s
 		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
 	}
 
+	// Check that min returns (if any) are in reserve tokens
+	for _, r := range msg.MinReturns {
+		if !war.IsReserveToken(r.Denom) {
+			return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.MinReturns.String(), strings.Join(war.ReserveTokens, ","))
+		}
+	}
+
+	// Check that seller is not selling war tokens that are locked until vested
+	err := keeper.CheckSellNotLocked(ctx, msg.Seller, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
 	// Send coins to be burned from seller (enforces sellAmount <= balance)
-	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Seller,
+	err = keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Seller,
 		types.WarsMintBurnAccount, sdk.Coins{msg.Amount})
 	if err != nil {
 		return nil, err
@@ -398,7 +765,7 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) (*s
 	}
 
 	// Create order
-	order := types.NewSellOrder(msg.Seller, msg.Amount)
+	order := types.NewSellOrder(msg.Seller, msg.Amount, msg.MinReturns)
 
 	// Get sell price and check if can add sell order to batch
 	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterSell(ctx, token, order)
@@ -409,14 +776,15 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) (*s
 	// Add sell order to batch
 	keeper.AddSellOrder(ctx, token, order, buyPrices, sellPrices)
 
-	//// Cancel unfulfillable orders (Note: no need)
-	//keeper.CancelUnfulfillableOrders(ctx, token)
+	// Cancel unfulfillable orders
+	keeper.CancelUnfulfillableOrders(ctx, token)
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeSell,
 			sdk.NewAttribute(types.AttributeKeyWar, msg.Amount.Denom),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyMinReturns, msg.MinReturns.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -435,17 +803,19 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) (*s
 		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, msg.WarToken)
 	}
 
-	// Confirm that function type is swapper_function and state is OPEN
-	if war.FunctionType != types.SwapperFunction {
+	// Confirm that war is not paused, function type is a swapper function
+	// and state is OPEN
+	if war.Paused {
+		return nil, sdkerrors.Wrap(types.ErrWarPaused, war.Token)
+	} else if !war.IsSwapper() {
 		return nil, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, war.FunctionType)
 	} else if war.State != types.OpenState {
 		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, war.State)
 	}
 
 	// Check that from and to use reserve token names
-	fromAndTo := sdk.NewCoins(msg.From, sdk.NewCoin(msg.ToToken, sdk.OneInt()))
 	fromAndToDenoms := msg.From.Denom + "," + msg.ToToken
-	if !war.ReserveDenomsEqualTo(fromAndTo) {
+	if !war.IsReserveToken(msg.From.Denom) || !war.IsReserveToken(msg.ToToken) {
 		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", fromAndToDenoms, war.ReserveTokens)
 	}
 
@@ -462,7 +832,7 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) (*s
 	}
 
 	// Create order
-	order := types.NewSwapOrder(msg.Swapper, msg.From, msg.ToToken)
+	order := types.NewSwapOrder(msg.Swapper, msg.From, msg.ToToken, msg.MinOutput)
 
 	// Add swap order to batch
 	keeper.AddSwapOrder(ctx, msg.WarToken, order)
@@ -477,6 +847,57 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) (*s
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.From.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeySwapFromToken, msg.From.Denom),
 			sdk.NewAttribute(types.AttributeKeySwapToToken, msg.ToToken),
+			sdk.NewAttribute(types.AttributeKeyMinOutput, msg.MinOutput.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Swapper.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgRoutedSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgRoutedSwap) (*sdk.Result, error) {
+
+	// Check that each hop can be performed using the war's reserves
+	fromToken := msg.From.Denom
+	for _, hop := range msg.Hops {
+		_, err := keeper.CheckSwapHop(ctx, fromToken, hop)
+		if err != nil {
+			return nil, err
+		}
+		fromToken = hop.ToToken
+	}
+
+	// Check if order quantity limit of the first war exceeded (the limits of
+	// the other wars are checked when the hops are performed)
+	firstWar := keeper.MustGetWar(ctx, msg.Hops[0].WarToken)
+	if firstWar.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.From}) {
+		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.From.String())
+	}
+
+	// Take coins to be swapped from swapper (enforces swapAmount <= balance)
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Swapper,
+		types.BatchesIntermediaryAccount, sdk.Coins{msg.From})
+	if err != nil {
+		return nil, err
+	}
+
+	// Create order and add it to the batch of the first war
+	order := types.NewRoutedSwapOrder(msg.Swapper, msg.From, msg.Hops, msg.MinOutput)
+	keeper.AddRoutedSwapOrder(ctx, firstWar.Token, order)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRoutedSwap,
+			sdk.NewAttribute(types.AttributeKeyWar, firstWar.Token),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.From.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeySwapFromToken, msg.From.Denom),
+			sdk.NewAttribute(types.AttributeKeySwapToToken, msg.Hops.ToToken()),
+			sdk.NewAttribute(types.AttributeKeyRoute, msg.Hops.String()),
+			sdk.NewAttribute(types.AttributeKeyMinOutput, msg.MinOutput.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -488,6 +909,213 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) (*s
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+func handleMsgLimitBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgLimitBuy) (*sdk.Result, error) {
+
+	token := msg.Amount.Denom
+	war, found := keeper.GetWar(ctx, token)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, token)
+	}
+
+	// Check war not paused, current state is HATCH/OPEN, hatch deadline not
+	// reached, max prices, order quantity limits
+	if war.Paused {
+		return nil, sdkerrors.Wrap(types.ErrWarPaused, war.Token)
+	} else if war.State != types.OpenState && war.State != types.HatchState {
+		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, war.State)
+	} else if war.HatchDeadlineReached(ctx.BlockHeight()) {
+		return nil, sdkerrors.Wrapf(types.ErrHatchDeadlineAlreadyPassed,
+			"deadline %d <= current height %d",
+			war.HatchDeadlineHeight, ctx.BlockHeight())
+	} else if !war.ReserveDenomsEqualTo(msg.MaxPrices) {
+		return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.MaxPrices.String(), strings.Join(war.ReserveTokens, ","))
+	} else if war.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
+		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
+	}
+
+	// For the swapper, the first buy (initialisation) cannot be a limit buy
+	if war.CurrentSupply.IsZero() && war.IsSwapper() {
+		return nil, sdkerrors.Wrap(types.ErrFunctionRequiresNonZeroCurrentSupply, war.FunctionType)
+	}
+
+	// Check that expiry height has not already passed
+	if msg.ExpiryHeight < ctx.BlockHeight() {
+		return nil, sdkerrors.Wrapf(types.ErrExpiryHeightAlreadyPassed, "%d", msg.ExpiryHeight)
+	}
+
+	// Check that expiry height and number of limit orders are within params
+	err := keeper.CheckLimitOrderWithinParams(ctx, token, msg.Buyer, msg.ExpiryHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	// Take max that buyer is willing to pay (enforces maxPrice <= balance)
+	err = keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Buyer,
+		types.BatchesIntermediaryAccount, msg.MaxPrices)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add limit buy order to order book
+	order := keeper.AddLimitOrder(ctx, token, types.NewLimitOrder(
+		types.LimitBuyOrderType, msg.Buyer, msg.Amount, msg.MaxPrices, msg.ExpiryHeight))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeLimitBuy,
+			sdk.NewAttribute(types.AttributeKeyWar, msg.Amount.Denom),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyMaxPrices, msg.MaxPrices.String()),
+			sdk.NewAttribute(types.AttributeKeyExpiryHeight, strconv.FormatInt(msg.ExpiryHeight, 10)),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(order.ID, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Buyer.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgLimitSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgLimitSell) (*sdk.Result, error) {
+
+	token := msg.Amount.Denom
+	war, found := keeper.GetWar(ctx, token)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, token)
+	}
+
+	// Check war not paused, sells allowed, current state is OPEN, and order
+	// limits not exceeded
+	if war.Paused {
+		return nil, sdkerrors.Wrap(types.ErrWarPaused, war.Token)
+	} else if !war.AllowSells {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotAllowSelling, token)
+	} else if war.State != types.OpenState {
+		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, war.State)
+	} else if war.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
+		return nil, sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, msg.Amount.String())
+	}
+
+	// Check that min returns are in reserve tokens
+	for _, r := range msg.MinReturns {
+		if !war.IsReserveToken(r.Denom) {
+			return nil, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch, "%s do not match reserve; expected: %s", msg.MinReturns.String(), strings.Join(war.ReserveTokens, ","))
+		}
+	}
+
+	// Check that expiry height has not already passed
+	if msg.ExpiryHeight < ctx.BlockHeight() {
+		return nil, sdkerrors.Wrapf(types.ErrExpiryHeightAlreadyPassed, "%d", msg.ExpiryHeight)
+	}
+
+	// Check that expiry height and number of limit orders are within params
+	err := keeper.CheckLimitOrderWithinParams(ctx, token, msg.Seller, msg.ExpiryHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check that seller is not selling war tokens that are locked until vested
+	err = keeper.CheckSellNotLocked(ctx, msg.Seller, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Take war tokens to be sold (enforces sellAmount <= balance). These are
+	// only burned once the order is added to a batch.
+	err = keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Seller,
+		types.BatchesIntermediaryAccount, sdk.Coins{msg.Amount})
+	if err != nil {
+		return nil, err
+	}
+
+	// Add limit sell order to order book
+	order := keeper.AddLimitOrder(ctx, token, types.NewLimitOrder(
+		types.LimitSellOrderType, msg.Seller, msg.Amount, msg.MinReturns, msg.ExpiryHeight))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeLimitSell,
+			sdk.NewAttribute(types.AttributeKeyWar, msg.Amount.Denom),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyMinReturns, msg.MinReturns.String()),
+			sdk.NewAttribute(types.AttributeKeyExpiryHeight, strconv.FormatInt(msg.ExpiryHeight, 10)),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(order.ID, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Seller.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgCancelOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCancelOrder) (*sdk.Result, error) {
+
+	token := msg.WarToken
+	if !keeper.WarExists(ctx, token) {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, token)
+	}
+
+	// Cancel and refund order (checks that order exists and is owned by owner)
+	err := keeper.CancelOrder(ctx, token, msg.Owner, msg.OrderType, msg.OrderIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cancel unfulfillable orders (since the batch prices have changed)
+	keeper.CancelUnfulfillableOrders(ctx, token)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCancelOrder,
+			sdk.NewAttribute(types.AttributeKeyWar, token),
+			sdk.NewAttribute(types.AttributeKeyOrderType, msg.OrderType),
+			sdk.NewAttribute(types.AttributeKeyOrderIndex, strconv.FormatUint(msg.OrderIndex, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgCancelLimitOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCancelLimitOrder) (*sdk.Result, error) {
+
+	token := msg.WarToken
+	if !keeper.WarExists(ctx, token) {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, token)
+	}
+
+	// Cancel and refund order (checks that order exists and is owned by owner)
+	err := keeper.CancelLimitOrder(ctx, token, msg.Owner, msg.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCancelLimitOrder,
+			sdk.NewAttribute(types.AttributeKeyWar, token),
+			sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(msg.OrderID, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
 func handleMsgMakeOutcomePayment(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgMakeOutcomePayment) (*sdk.Result, error) {
 
 	war, found := keeper.GetWar(ctx, msg.WarToken)
@@ -511,6 +1139,12 @@ func handleMsgMakeOutcomePayment(ctx sdk.Context, keeper keeper.Keeper, msg type
 	// Set war state to SETTLE
 	keeper.SetWarState(ctx, war.Token, types.SettleState)
 
+	// Refund war creation deposit (if any) to the war creator
+	err = keeper.RefundWarCreationDeposit(ctx, war.Token)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeMakeOutcomePayment,
@@ -534,8 +1168,8 @@ func handleMsgWithdrawShare(ctx sdk.Context, keeper keeper.Keeper, msg types.Msg
 		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, msg.WarToken)
 	}
 
-	// Check that state is SETTLE
-	if war.State != types.SettleState {
+	// Check that state is SETTLE or FAILED
+	if war.State != types.SettleState && war.State != types.FailedState {
 		return nil, sdkerrors.Wrap(types.ErrInvalidStateForAction, war.State)
 	}
 
@@ -560,10 +1194,11 @@ func handleMsgWithdrawShare(ctx sdk.Context, keeper keeper.Keeper, msg types.Msg
 		return nil, err
 	}
 
-	// Calculate amount owned
+	// Calculate amount owned (multiplying before dividing, so that a share
+	// that is an exact fraction of the reserve is not rounded down)
 	remainingReserve := keeper.GetReserveBalances(ctx, war.Token)
-	warTokensShare := warTokensOwnedAmount.ToDec().QuoInt(war.CurrentSupply.Amount)
-	reserveOwedDec := sdk.NewDecCoinsFromCoins(remainingReserve...).MulDec(warTokensShare)
+	reserveOwedDec := sdk.NewDecCoinsFromCoins(remainingReserve...).MulDec(
+		warTokensOwnedAmount.ToDec()).QuoDec(war.CurrentSupply.Amount.ToDec())
 	reserveOwed, _ := reserveOwedDec.TruncateDecimal()
 
 	// Send coins owed to recipient
@@ -591,3 +1226,161 @@ func handleMsgWithdrawShare(ctx sdk.Context, keeper keeper.Keeper, msg types.Msg
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+func handleMsgClaimVested(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgClaimVested) (*sdk.Result, error) {
+
+	_, found := keeper.GetWar(ctx, msg.WarToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, msg.WarToken)
+	}
+
+	// Send vested war tokens that were not yet claimed to claimer
+	claimed, err := keeper.ClaimVestedHatchTokens(ctx, msg.WarToken, msg.Claimer)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeClaimVested,
+			sdk.NewAttribute(types.AttributeKeyWar, msg.WarToken),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Claimer.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, claimed.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Claimer.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSpendFromFundingPool(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSpendFromFundingPool) (*sdk.Result, error) {
+	if keeper.BankKeeper.BlacklistedAddr(msg.Recipient) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to receive transactions", msg.Recipient)
+	}
+
+	war, found := keeper.GetWar(ctx, msg.WarToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, msg.WarToken)
+	}
+
+	// Check that spender is one of the war's funding pool spenders
+	if !war.IsFundingPoolSpender(msg.Spender) {
+		return nil, sdkerrors.Wrap(types.ErrNotFundingPoolSpender, msg.Spender.String())
+	}
+
+	// Send amount from funding pool to recipient (enforces allowance and balance)
+	err := keeper.SpendFromFundingPool(ctx, msg.WarToken, msg.Recipient, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSpendFromFundingPool,
+			sdk.NewAttribute(types.AttributeKeyWar, msg.WarToken),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Spender.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipient, msg.Recipient.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Spender.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSubmitProposal(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSubmitProposal) (*sdk.Result, error) {
+	war, found := keeper.GetWar(ctx, msg.WarToken)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, msg.WarToken)
+	} else if !war.HasGovernance() {
+		return nil, sdkerrors.Wrap(types.ErrGovernanceNotEnabled, msg.WarToken)
+	}
+
+	// Check that proposer holds war tokens
+	if !keeper.GetVotingPower(ctx, msg.WarToken, msg.Proposer).IsPositive() {
+		return nil, sdkerrors.Wrap(types.ErrNoVotingPower, msg.Proposer.String())
+	}
+
+	// Check that the war has not reached the max number of proposals
+	if err := keeper.CheckProposalsPerWarWithinParams(ctx, msg.WarToken); err != nil {
+		return nil, err
+	}
+
+	votingEndHeight := ctx.BlockHeight() + war.GovernanceVotingPeriod
+
+	var proposal types.Proposal
+	switch msg.ProposalType {
+	case types.SpendProposalType:
+		if keeper.BankKeeper.BlacklistedAddr(msg.Recipient) {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to receive transactions", msg.Recipient)
+		}
+		proposal = types.NewSpendProposal(msg.Proposer, msg.Recipient,
+			msg.Amount, votingEndHeight)
+	case types.EditProposalType:
+		// Check that the edit can be applied to the war as it currently is
+		if _, err := msg.Edit.Apply(war); err != nil {
+			return nil, err
+		}
+		proposal = types.NewEditProposal(msg.Proposer, msg.Edit, votingEndHeight)
+	default:
+		return nil, sdkerrors.Wrap(types.ErrInvalidProposalType, msg.ProposalType)
+	}
+
+	proposal = keeper.AddProposal(ctx, msg.WarToken, proposal)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSubmitProposal,
+			sdk.NewAttribute(types.AttributeKeyWar, msg.WarToken),
+			sdk.NewAttribute(types.AttributeKeyProposalID, strconv.FormatUint(proposal.ID, 10)),
+			sdk.NewAttribute(types.AttributeKeyProposalType, proposal.ProposalType),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Proposer.String()),
+			sdk.NewAttribute(types.AttributeKeyVotingEndHeight, strconv.FormatInt(proposal.VotingEndHeight, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Proposer.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgVote(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgVote) (*sdk.Result, error) {
+	if !keeper.WarExists(ctx, msg.WarToken) {
+		return nil, sdkerrors.Wrap(types.ErrWarDoesNotExist, msg.WarToken)
+	}
+
+	// Add vote to proposal (enforces voting power and single vote per voter)
+	vote, err := keeper.AddVote(ctx, msg.WarToken, msg.ProposalID, msg.Voter, msg.Option)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeVote,
+			sdk.NewAttribute(types.AttributeKeyWar, msg.WarToken),
+			sdk.NewAttribute(types.AttributeKeyProposalID, strconv.FormatUint(msg.ProposalID, 10)),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Voter.String()),
+			sdk.NewAttribute(types.AttributeKeyOption, vote.Option),
+			sdk.NewAttribute(types.AttributeKeyVotingPower, vote.Power.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Voter.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/stretchr/testify/require"
 )
@@ -92,20 +93,137 @@ func TestCreatingAWarUsingStakingTokenFails(t *testing.T) {
 
 	// Create war with token set to staking token
 	msg := newValidMsgCreateWar()
-	msg.Token = app.StakingKeeper.GetParams(ctx).WarDenom
+	msg.Token = app.StakingKeeper.GetParams(ctx).BondDenom
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
 	require.False(t, app.WarsKeeper.WarExists(ctx, token))
 }
 
+func TestCreatingAnAugmentedWarWithPassedHatchDeadlineFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+	ctx = ctx.WithBlockHeight(10)
+
+	// Create war with hatch deadline set to the current block height
+	msg := newValidMsgCreateAugmentedWar()
+	msg.HatchDeadlineHeight = 10
+	_, err := h(ctx, msg)
+
+	require.Error(t, err)
+	require.False(t, app.WarsKeeper.WarExists(ctx, token))
+}
+
+func TestCreatingAWarOutsideOfParamsLimitsFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	params := app.WarsKeeper.GetParams(ctx)
+	params.MaxTxFeePercentage = sdk.MustNewDecFromStr("0.05")
+	params.MaxExitFeePercentage = sdk.MustNewDecFromStr("0.05")
+	params.MinBatchBlocks = sdk.NewUint(2)
+	params.MaxBatchBlocks = sdk.NewUint(5)
+	app.WarsKeeper.SetParams(ctx, params)
+
+	testCases := []struct {
+		edit        func(msg *types.MsgCreateWar)
+		expectedErr *sdkerrors.Error
+	}{
+		{func(msg *types.MsgCreateWar) {}, types.ErrFeeExceedsMax},
+		{func(msg *types.MsgCreateWar) { msg.TxFeePercentage = sdk.ZeroDec() }, types.ErrFeeExceedsMax},
+		{func(msg *types.MsgCreateWar) {
+			msg.TxFeePercentage = sdk.ZeroDec()
+			msg.ExitFeePercentage = sdk.ZeroDec()
+		}, types.ErrBatchBlocksOutOfRange},
+		{func(msg *types.MsgCreateWar) {
+			msg.TxFeePercentage = sdk.ZeroDec()
+			msg.ExitFeePercentage = sdk.ZeroDec()
+			msg.BatchBlocks = sdk.NewUint(6)
+		}, types.ErrBatchBlocksOutOfRange},
+	}
+	for _, tc := range testCases {
+		msg := newValidMsgCreateWar()
+		tc.edit(&msg)
+		_, err := h(ctx, msg)
+		require.Error(t, err)
+		require.True(t, tc.expectedErr.Is(err), err.Error())
+		require.False(t, app.WarsKeeper.WarExists(ctx, token))
+	}
+
+	// War within the limits is created
+	msg := newValidMsgCreateWar()
+	msg.TxFeePercentage = sdk.MustNewDecFromStr("0.05")
+	msg.ExitFeePercentage = sdk.ZeroDec()
+	msg.BatchBlocks = sdk.NewUint(5)
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+	require.True(t, app.WarsKeeper.WarExists(ctx, token))
+}
+
+func TestCreatingWarsWithCreationDepositAndMaxWarsPerCreator(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	params := app.WarsKeeper.GetParams(ctx)
+	params.WarCreationDeposit = deposit
+	params.MaxWarsPerCreator = 1
+	app.WarsKeeper.SetParams(ctx, params)
+
+	// War cannot be created without the deposit
+	_, err := h(ctx, newValidMsgCreateWar())
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrInsufficientFunds.Is(err))
+	require.False(t, app.WarsKeeper.WarExists(ctx, token))
+
+	// Deposit is held in the wars deposit account
+	_, err = app.BankKeeper.AddCoins(ctx, initCreator, deposit.Add(deposit...))
+	require.NoError(t, err)
+	res, err := h(ctx, newValidMsgCreateWar())
+	require.NoError(t, err)
+	require.True(t, app.WarsKeeper.WarExists(ctx, token))
+	require.Equal(t, deposit, app.BankKeeper.GetCoins(ctx, initCreator))
+	depositAcc := app.SupplyKeeper.GetModuleAddress(types.WarsDepositAccount)
+	require.Equal(t, deposit, app.BankKeeper.GetCoins(ctx, depositAcc))
+	require.Equal(t, deposit, app.WarsKeeper.MustGetWar(ctx, token).CreationDeposit)
+	var depositAttribute string
+	for _, e := range res.Events {
+		for _, attr := range e.Attributes {
+			if e.Type == types.EventTypeCreateWar &&
+				string(attr.Key) == types.AttributeKeyCreationDeposit {
+				depositAttribute = string(attr.Value)
+			}
+		}
+	}
+	require.Equal(t, deposit.String(), depositAttribute)
+
+	// Creator cannot create more than one war
+	msg := newValidMsgCreateWar()
+	msg.Token = token2
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrMaxWarsPerCreatorReached.Is(err))
+	require.False(t, app.WarsKeeper.WarExists(ctx, token2))
+	require.Equal(t, deposit, app.BankKeeper.GetCoins(ctx, initCreator))
+
+	// No max wars per creator
+	params.MaxWarsPerCreator = 0
+	app.WarsKeeper.SetParams(ctx, params)
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), app.WarsKeeper.GetWarCountByCreator(ctx, initCreator))
+}
+
 func TestEditingANonExistingWarFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
 
 	// Edit war
 	msg := types.NewMsgEditWar(token, initName, initDescription, "",
-		"0", "0", initCreator, initSigners)
+		"0", "0",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, initCreator, initSigners)
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
@@ -121,7 +239,10 @@ func TestEditingAWarWithDifferentCreatorAndSignersFails(t *testing.T) {
 
 	// Edit war
 	msg := types.NewMsgEditWar(token, initName, initDescription, "",
-		"0", "0", initCreator, []sdk.AccAddress{anotherAddress})
+		"0", "0",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, initCreator, []sdk.AccAddress{anotherAddress})
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
@@ -136,7 +257,10 @@ func TestEditingAWarWithNegativeOrderQuantityLimitsFails(t *testing.T) {
 
 	// Edit war
 	msg := types.NewMsgEditWar(token, initName, initDescription, "-10testtoken",
-		"0", "0", initCreator, initSigners)
+		"0", "0",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, initCreator, initSigners)
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
@@ -151,7 +275,10 @@ func TestEditingAWarWithFloatOrderQuantityLimitsFails(t *testing.T) {
 
 	// Edit war
 	msg := types.NewMsgEditWar(token, initName, initDescription, "10.5testtoken",
-		"0", "0", initCreator, initSigners)
+		"0", "0",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, initCreator, initSigners)
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
@@ -174,7 +301,10 @@ func TestEditingAWarWithSanityRateEmptyStringMakesSanityFieldsZero(t *testing.T)
 
 	// Edit war
 	msg := types.NewMsgEditWar(token, initName, initDescription, "10testtoken",
-		"", "", initCreator, initSigners)
+		"", "",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, initCreator, initSigners)
 	_, err := h(ctx, msg)
 
 	// Check sanity values after
@@ -193,7 +323,10 @@ func TestEditingAWarWithNegativeSanityRateFails(t *testing.T) {
 
 	// Edit war
 	msg := types.NewMsgEditWar(token, initName, initDescription, "10testtoken",
-		"-10", "", initCreator, initSigners)
+		"-10", "",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, initCreator, initSigners)
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
@@ -208,7 +341,10 @@ func TestEditingAWarWithNonFloatSanityRateFails(t *testing.T) {
 
 	// Edit war
 	msg := types.NewMsgEditWar(token, initName, initDescription, "10testtoken",
-		"20t", "", initCreator, initSigners)
+		"20t", "",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, initCreator, initSigners)
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
@@ -223,7 +359,10 @@ func TestEditingAWarWithNegativeSanityMarginPercentageFails(t *testing.T) {
 
 	// Edit war
 	msg := types.NewMsgEditWar(token, initName, initDescription, "10testtoken",
-		"10", "-5", initCreator, initSigners)
+		"10", "-5",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, initCreator, initSigners)
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
@@ -238,7 +377,10 @@ func TestEditingAWarWithNonFloatSanityMarginPercentageFails(t *testing.T) {
 
 	// Edit war
 	msg := types.NewMsgEditWar(token, initName, initDescription, "10testtoken",
-		"20", "20t", initCreator, initSigners)
+		"20", "20t",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, initCreator, initSigners)
 	_, err := h(ctx, msg)
 
 	require.Error(t, err)
@@ -255,7 +397,10 @@ func TestEditingAWarCorrectlyPasses(t *testing.T) {
 	newName := "a new name"
 	newDescription := "a new description"
 	msg := types.NewMsgEditWar(token, newName, newDescription, "",
-		"0", "0", initCreator, initSigners)
+		"0", "0",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, initCreator, initSigners)
 	_, err := h(ctx, msg)
 
 	require.NoError(t, err)
@@ -267,6 +412,444 @@ func TestEditingAWarCorrectlyPasses(t *testing.T) {
 	require.Equal(t, sdk.ZeroDec(), war.SanityMarginPercentage)
 }
 
+func TestEditingAWarEconomicParameters(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+	newMsgEditWar := func(txFeePercentage, maxSupply, batchBlocks string) types.MsgEditWar {
+		return types.NewMsgEditWar(token, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, txFeePercentage, types.DoNotModifyField,
+			types.DoNotModifyField, maxSupply, types.DoNotModifyField, batchBlocks,
+			types.DoNotModifyField, initCreator, initSigners)
+	}
+
+	// Create war with batch blocks 1, and buy 10 tokens in the current batch
+	_, err := h(ctx, newValidMsgCreateWar())
+	require.NoError(t, err)
+	err = addCoinsToUser(app, ctx, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10000)))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgBuy(10, 10000))
+	require.NoError(t, err)
+
+	// Fees cannot be increased
+	_, err = h(ctx, newMsgEditWar("0.2", types.DoNotModifyField, types.DoNotModifyField))
+	require.Error(t, err)
+	require.True(t, types.ErrFeeCannotBeIncreased.Is(err))
+
+	// Max supply cannot be less than the supply including pending buys
+	_, err = h(ctx, newMsgEditWar(types.DoNotModifyField, "9"+token, types.DoNotModifyField))
+	require.Error(t, err)
+	require.True(t, types.ErrMaxSupplyBelowCurrentSupply.Is(err))
+
+	// Valid edit
+	res, err := h(ctx, newMsgEditWar("0.05", "10"+token, "3"))
+	require.NoError(t, err)
+	war := app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, sdk.MustNewDecFromStr("0.05"), war.TxFeePercentage)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), war.MaxSupply)
+	require.Equal(t, sdk.NewUint(3), war.BatchBlocks)
+
+	// Event records the old and new values of the edited fields only
+	attributes := map[string]string{}
+	for _, e := range res.Events {
+		if e.Type == types.EventTypeEditWar {
+			for _, attr := range e.Attributes {
+				attributes[string(attr.Key)] = string(attr.Value)
+			}
+		}
+	}
+	require.Equal(t, map[string]string{
+		"war":                   token,
+		"old_tx_fee_percentage": initTxFeePercentage.String(),
+		"new_tx_fee_percentage": war.TxFeePercentage.String(),
+		"old_max_supply":        initMaxSupply.String(),
+		"new_max_supply":        war.MaxSupply.String(),
+		"old_batch_blocks":      initBatchBlocks.String(),
+		"new_batch_blocks":      "3",
+	}, attributes)
+
+	// New batch blocks only apply from the next batch onwards
+	require.Equal(t, initBatchBlocks, app.WarsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, sdk.NewUint(3), app.WarsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+}
+
+func TestEditingAWarBatchBlocksOutsideOfParamsLimitsFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+	newMsgEditWar := func(batchBlocks string) types.MsgEditWar {
+		return types.NewMsgEditWar(token, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+			batchBlocks, types.DoNotModifyField, initCreator, initSigners)
+	}
+
+	// Create war with edit delay
+	msg := newValidMsgCreateWar()
+	msg.EditDelay = 10
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	params := app.WarsKeeper.GetParams(ctx)
+	params.MaxBatchBlocks = sdk.NewUint(5)
+	app.WarsKeeper.SetParams(ctx, params)
+
+	// Delayed edit outside of the limits is rejected when submitted
+	_, err = h(ctx, newMsgEditWar("6"))
+	require.Error(t, err)
+	require.True(t, types.ErrBatchBlocksOutOfRange.Is(err))
+	require.Empty(t, app.WarsKeeper.GetPendingEdits(ctx, token).PendingEdits)
+
+	// Delayed edit fails to apply if the limits change in the meantime
+	_, err = h(ctx, newMsgEditWar("5"))
+	require.NoError(t, err)
+	params.MaxBatchBlocks = sdk.NewUint(4)
+	app.WarsKeeper.SetParams(ctx, params)
+	app.WarsKeeper.ApplyDuePendingEdits(ctx.WithBlockHeight(10), token)
+	require.Empty(t, app.WarsKeeper.GetPendingEdits(ctx, token).PendingEdits)
+	require.Equal(t, initBatchBlocks, app.WarsKeeper.MustGetWar(ctx, token).BatchBlocks)
+}
+
+func TestEditingAWarWithSignerThreshold(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Set war with a 2-of-3 signer threshold to simulate creation
+	war := newSimpleWar()
+	war.Signers = []sdk.AccAddress{initCreator, anotherAddress, userAddress}
+	war.SignerThreshold = 2
+	app.WarsKeeper.SetWar(ctx, token, war)
+
+	edit := func(signers ...sdk.AccAddress) error {
+		_, err := h(ctx, types.NewMsgEditWar(token, "a new name", initDescription,
+			"", "0", "0",
+			types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, initCreator, signers))
+		return err
+	}
+
+	// Edit fails if threshold not met
+	err := edit(anotherAddress)
+	require.Error(t, err)
+	require.True(t, types.ErrSignerThresholdNotMet.Is(err))
+
+	// Edit fails if any signer is not a war signer
+	err = edit(userAddress, anotherAddress, initFeeAddress)
+	require.Error(t, err)
+	require.True(t, types.ErrNotWarSigner.Is(err))
+
+	// Edit passes with any two signers, in any order
+	require.NoError(t, edit(userAddress, anotherAddress))
+	require.Equal(t, "a new name", app.WarsKeeper.MustGetWar(ctx, token).Name)
+}
+
+func TestUpdatingWarSigners(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Set war to simulate creation
+	app.WarsKeeper.SetWar(ctx, token, newSimpleWar())
+
+	// Update fails if not signed by the current signers
+	newSigners := []sdk.AccAddress{anotherAddress, userAddress}
+	_, err := h(ctx, types.NewMsgUpdateWarSigners(token, newSigners, 1,
+		anotherAddress, []sdk.AccAddress{anotherAddress}))
+	require.Error(t, err)
+	require.True(t, types.ErrNotWarSigner.Is(err))
+
+	// Update passes when signed by the current signers
+	_, err = h(ctx, types.NewMsgUpdateWarSigners(token, newSigners, 1,
+		initCreator, initSigners))
+	require.NoError(t, err)
+	war := app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, newSigners, war.Signers)
+	require.Equal(t, uint64(1), war.SignerThreshold)
+
+	// Old signers can no longer edit the war, but any one new signer can
+	_, err = h(ctx, types.NewMsgEditWar(token, "a new name", initDescription,
+		"", "0", "0",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, initCreator, initSigners))
+	require.Error(t, err)
+	_, err = h(ctx, types.NewMsgEditWar(token, "a new name", initDescription,
+		"", "0", "0",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, userAddress, []sdk.AccAddress{userAddress}))
+	require.NoError(t, err)
+}
+
+func TestEditingAWarWithEditDelay(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+	ctx = ctx.WithBlockHeight(10)
+	newMsgEditWar := func(name, txFeePercentage string) types.MsgEditWar {
+		return types.NewMsgEditWar(token, name, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+			txFeePercentage, types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, initCreator, initSigners)
+	}
+
+	// Create war with an edit delay of 5 blocks
+	createMsg := newValidMsgCreateWar()
+	createMsg.EditDelay = 5
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Edits that cannot be applied are rejected immediately
+	_, err = h(ctx, newMsgEditWar(types.DoNotModifyField, "0.2"))
+	require.Error(t, err)
+	require.True(t, types.ErrFeeCannotBeIncreased.Is(err))
+	require.Empty(t, app.WarsKeeper.GetPendingEdits(ctx, token).PendingEdits)
+
+	// Valid edits are not applied immediately
+	res, err := h(ctx, newMsgEditWar("a new name", types.DoNotModifyField))
+	require.NoError(t, err)
+	require.Equal(t, initName, app.WarsKeeper.MustGetWar(ctx, token).Name)
+	pendingEdits := app.WarsKeeper.GetPendingEdits(ctx, token).PendingEdits
+	require.Len(t, pendingEdits, 1)
+	require.Equal(t, int64(15), pendingEdits[0].EffectiveHeight)
+
+	// Event announces the edit's effective height
+	var pendingEditEvent sdk.Event
+	for _, e := range res.Events {
+		if e.Type == types.EventTypePendingEdit {
+			pendingEditEvent = e
+		}
+	}
+	require.Equal(t, sdk.NewEvent(types.EventTypePendingEdit,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeyEditID, "0"),
+		sdk.NewAttribute(types.AttributeKeyEffectiveHeight, "15"),
+	), pendingEditEvent)
+
+	// Another pending edit can be cancelled by the signers only
+	_, err = h(ctx, newMsgEditWar(types.DoNotModifyField, "0.05"))
+	require.NoError(t, err)
+	_, err = h(ctx, types.NewMsgCancelPendingEdit(token, 1,
+		anotherAddress, []sdk.AccAddress{anotherAddress}))
+	require.Error(t, err)
+	require.True(t, types.ErrNotWarSigner.Is(err))
+	_, err = h(ctx, types.NewMsgCancelPendingEdit(token, 1, initCreator, initSigners))
+	require.NoError(t, err)
+	_, err = h(ctx, types.NewMsgCancelPendingEdit(token, 1, initCreator, initSigners))
+	require.Error(t, err)
+	require.True(t, types.ErrPendingEditDoesNotExist.Is(err))
+	require.Len(t, app.WarsKeeper.GetPendingEdits(ctx, token).PendingEdits, 1)
+
+	// Pending edit is not applied before its effective height
+	wars.EndBlocker(ctx.WithBlockHeight(14), app.WarsKeeper)
+	require.Equal(t, initName, app.WarsKeeper.MustGetWar(ctx, token).Name)
+
+	// Pending edit is applied at its effective height, but not the cancelled one
+	wars.EndBlocker(ctx.WithBlockHeight(15), app.WarsKeeper)
+	war := app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, "a new name", war.Name)
+	require.Equal(t, initTxFeePercentage, war.TxFeePercentage)
+	require.Empty(t, app.WarsKeeper.GetPendingEdits(ctx, token).PendingEdits)
+}
+
+func TestUpdatingWarSignersWithEditDelay(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+	ctx = ctx.WithBlockHeight(10)
+
+	// Create war with an edit delay of 5 blocks
+	createMsg := newValidMsgCreateWar()
+	createMsg.EditDelay = 5
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Signers update is not applied immediately
+	newSigners := []sdk.AccAddress{anotherAddress, userAddress}
+	_, err = h(ctx, types.NewMsgUpdateWarSigners(token, newSigners, 1,
+		initCreator, initSigners))
+	require.NoError(t, err)
+	war := app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, initSigners, war.Signers)
+	pendingEdits := app.WarsKeeper.GetPendingEdits(ctx, token).PendingEdits
+	require.Len(t, pendingEdits, 1)
+	require.Equal(t, int64(15), pendingEdits[0].EffectiveHeight)
+	require.Equal(t, types.NewSignersEdit(newSigners, 1), *pendingEdits[0].SignersEdit)
+
+	// New signers cannot cancel the pending signers update, which is not
+	// applied before its effective height
+	_, err = h(ctx, types.NewMsgCancelPendingEdit(token, 0,
+		userAddress, []sdk.AccAddress{userAddress}))
+	require.Error(t, err)
+	require.True(t, types.ErrNotWarSigner.Is(err))
+	wars.EndBlocker(ctx.WithBlockHeight(14), app.WarsKeeper)
+	require.Equal(t, initSigners, app.WarsKeeper.MustGetWar(ctx, token).Signers)
+
+	// Signers update is applied at its effective height
+	ctx = ctx.WithBlockHeight(15).WithEventManager(sdk.NewEventManager())
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, newSigners, war.Signers)
+	require.Equal(t, uint64(1), war.SignerThreshold)
+	require.Empty(t, app.WarsKeeper.GetPendingEdits(ctx, token).PendingEdits)
+	require.Contains(t, ctx.EventManager().Events(), sdk.NewEvent(
+		types.EventTypeUpdateWarSigners,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeySigners, types.AccAddressesToString(newSigners)),
+		sdk.NewAttribute(types.AttributeKeySignerThreshold, "1"),
+	))
+
+	// A pending signers update can be cancelled by the signers
+	_, err = h(ctx, types.NewMsgUpdateWarSigners(token, initSigners, 1,
+		userAddress, []sdk.AccAddress{userAddress}))
+	require.NoError(t, err)
+	_, err = h(ctx, types.NewMsgCancelPendingEdit(token, 1,
+		userAddress, []sdk.AccAddress{userAddress}))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx.WithBlockHeight(20), app.WarsKeeper)
+	require.Equal(t, newSigners, app.WarsKeeper.MustGetWar(ctx, token).Signers)
+}
+
+func TestPausingAWar(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war
+	_, err := h(ctx, newValidMsgCreateWar())
+	require.NoError(t, err)
+
+	// Add reserve tokens to user and buy 2 tokens
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+
+	// War cannot be paused by addresses other than the signers
+	_, err = h(ctx, types.NewMsgSetWarPaused(token, true,
+		anotherAddress, []sdk.AccAddress{anotherAddress}))
+	require.Error(t, err)
+	require.True(t, types.ErrNotWarSigner.Is(err))
+
+	// War is paused by the signers
+	res, err := h(ctx, types.NewMsgSetWarPaused(token, true, initCreator, initSigners))
+	require.NoError(t, err)
+	require.True(t, app.WarsKeeper.MustGetWar(ctx, token).Paused)
+	require.Contains(t, res.Events, sdk.NewEvent(types.EventTypeSetWarPaused,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeyPaused, "true"),
+	))
+
+	// Pending buy is refunded when the war is paused instead of performed
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(4000), userBalance.AmountOf(reserveToken))
+	require.True(t, userBalance.AmountOf(token).IsZero())
+	require.True(t, app.WarsKeeper.MustGetWar(ctx, token).CurrentSupply.IsZero())
+	require.Empty(t, app.WarsKeeper.MustGetBatch(ctx, token).Buys)
+	require.Contains(t, res.Events, sdk.NewEvent(types.EventTypeOrderCancel,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
+		sdk.NewAttribute(types.AttributeKeyAddress, userAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyCancelReason, types.CancelReasonWarPaused),
+	))
+
+	// Batch is neither refunded again nor ended while paused
+	batchBlocks := app.WarsKeeper.MustGetBatch(ctx, token).BlocksRemaining
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Empty(t, ctx.EventManager().Events())
+	require.Equal(t, batchBlocks, app.WarsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+
+	// New orders are rejected while paused
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.Error(t, err)
+	require.True(t, types.ErrWarPaused.Is(err))
+	_, err = h(ctx, newValidMsgSell(1))
+	require.Error(t, err)
+	require.True(t, types.ErrWarPaused.Is(err))
+
+	// War can be unpaused by the pause authority
+	params := app.WarsKeeper.GetParams(ctx)
+	params.PauseAuthority = anotherAddress
+	app.WarsKeeper.SetParams(ctx, params)
+	_, err = h(ctx, types.NewMsgSetWarPaused(token, false,
+		anotherAddress, []sdk.AccAddress{anotherAddress}))
+	require.NoError(t, err)
+	require.False(t, app.WarsKeeper.MustGetWar(ctx, token).Paused)
+
+	// Orders are performed again once unpaused
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	userBalance = app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+}
+
+func TestPausingAWarForExceedingMaxPriceMove(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+	ctx = ctx.WithBlockHeight(10)
+
+	// Create war with a max price move of 60% and a cooldown of 5 blocks
+	msg := newValidMsgCreateWar()
+	msg.MaxPriceMovePercentage = sdk.NewDec(60)
+	msg.PriceMoveCooldown = 5
+	_, err := h(ctx, msg)
+	require.NoError(t, err)
+
+	// Buy 2 tokens (not checked, since the war has no supply yet), which
+	// gives a closing price of 12*2^2+100=148res
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, int64(2), app.WarsKeeper.MustGetWar(ctx, token).CurrentSupply.Amount.Int64())
+
+	// Buy 10 tokens, which would give a price of 12*12^2+100=1828res (1135%
+	// move from the last closing price of 148res)
+	ctx = ctx.WithBlockHeight(11)
+	balanceBeforeBuy := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	_, err = h(ctx, newValidMsgBuy(10, 9000))
+	require.NoError(t, err)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Buy is refunded instead of performed, and the war is paused
+	war := app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, int64(2), war.CurrentSupply.Amount.Int64())
+	require.True(t, war.Paused)
+	require.Equal(t, int64(16), war.PausedUntilHeight)
+	require.Empty(t, app.WarsKeeper.MustGetBatch(ctx, token).Buys)
+	require.Equal(t, balanceBeforeBuy, app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress))
+	require.Contains(t, ctx.EventManager().Events(), sdk.NewEvent(
+		types.EventTypeMaxPriceMoveExceeded,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeyPausedUntilHeight, "16"),
+	))
+
+	// New orders are rejected while paused
+	_, err = h(ctx, newValidMsgBuy(1, 4000))
+	require.Error(t, err)
+	require.True(t, types.ErrWarPaused.Is(err))
+
+	// War remains paused until the end of the cooldown
+	wars.EndBlocker(ctx.WithBlockHeight(15), app.WarsKeeper)
+	require.True(t, app.WarsKeeper.MustGetWar(ctx, token).Paused)
+	ctx = ctx.WithBlockHeight(16)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.False(t, war.Paused)
+	require.Zero(t, war.PausedUntilHeight)
+
+	// Buy 1 token, which gives a price of 12*3^2+100=208res (41% move from the
+	// last closing price of 148res)
+	_, err = h(ctx, newValidMsgBuy(1, 4000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, int64(3), app.WarsKeeper.MustGetWar(ctx, token).CurrentSupply.Amount.Int64())
+}
+
 func TestBuyingANonExistingWarFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
@@ -437,6 +1020,80 @@ func TestBuyingAWarCorrectlyPasses(t *testing.T) {
 	require.Equal(t, sdk.NewInt(2), currentSupply.Amount)
 }
 
+func TestBuyingAWarWithSpendTooLowFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war
+	h(ctx, newValidMsgCreateWar())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Spend 104 (one token costs 104 plus fee)
+	_, err = h(ctx, newValidMsgBuyWithSpend(104))
+	require.Error(t, err)
+
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(4000), userBalance.AmountOf(reserveToken))
+	require.Empty(t, app.WarsKeeper.MustGetBatch(ctx, token).Buys)
+}
+
+func TestBuyingASwapperWarWithSpendBeforeInitialisationFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create swapper war
+	h(ctx, newValidMsgCreateSwapperWar())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{
+		sdk.NewInt64Coin(reserveToken, 4000),
+		sdk.NewInt64Coin(reserveToken2, 4000),
+	})
+	require.Nil(t, err)
+
+	// Spend without there being any liquidity
+	spend := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 1000))
+	_, err = h(ctx, types.NewMsgBuyWithSpend(userAddress, token, spend))
+	require.Error(t, err)
+}
+
+func TestBuyingAWarWithSpendCorrectlyPasses(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war
+	h(ctx, newValidMsgCreateWar())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+
+	// Spend 10000, which is enough to buy 12 tokens (8112 plus 9 fee)
+	_, err = h(ctx, newValidMsgBuyWithSpend(10000))
+	require.NoError(t, err)
+	buys := app.WarsKeeper.MustGetBatch(ctx, token).Buys
+	require.Len(t, buys, 1)
+	require.Equal(t, sdk.NewInt64Coin(token, 12), buys[0].Amount)
+
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Unspent reserve tokens are returned to the user
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.WarsKeeper.GetReserveBalances(ctx, initToken)
+	feeBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, initFeeAddress)
+	currentSupply := app.WarsKeeper.MustGetWar(ctx, token).CurrentSupply
+	require.Equal(t, sdk.NewInt(1879), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(12), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(8112), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(9), feeBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(12), currentSupply.Amount)
+}
+
 func TestSellingANonExistingWarFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
@@ -615,12 +1272,72 @@ func TestSellingAWarCorrectlyPasses(t *testing.T) {
 	require.Equal(t, sdk.ZeroInt(), currentSupply.Amount)
 }
 
-func TestSwapWarDoesNotExistFails(t *testing.T) {
+func TestSellingAWarWithMinReturns(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
 
-	// Swap tokens
-	_, err := h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 1))
+	// Create war with a price that increases linearly from 1 at supply 100
+	// to 3 at supply 200, and with zero fees
+	createMsg := newValidMsgCreateWar()
+	createMsg.FunctionType = types.PiecewiseLinearFunction
+	createMsg.FunctionParameters = types.FunctionParams{
+		types.NewFunctionParam("x0", sdk.ZeroDec()),
+		types.NewFunctionParam("p0", sdk.OneDec()),
+		types.NewFunctionParam("x1", sdk.NewDec(100)),
+		types.NewFunctionParam("p1", sdk.OneDec()),
+		types.NewFunctionParam("x2", sdk.NewDec(200)),
+		types.NewFunctionParam("p2", sdk.NewDec(3))}
+	createMsg.TxFeePercentage = sdk.ZeroDec()
+	createMsg.ExitFeePercentage = sdk.ZeroDec()
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user and buy 200 tokens for 300
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(200, 1000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Selling 50 tokens returns 50*(3+2)/2 = 125, so min returns of 126 fail
+	// (cache context used since failed txs are reverted)
+	sellMsg := newValidMsgSell(50)
+	sellMsg.MinReturns = sdk.Coins{sdk.NewInt64Coin(reserveToken, 126)}
+	cacheCtx, _ := ctx.CacheContext()
+	_, err = h(cacheCtx, sellMsg)
+	require.Error(t, err)
+
+	// Min returns of 125 are reached
+	sellMsg.MinReturns = sdk.Coins{sdk.NewInt64Coin(reserveToken, 125)}
+	_, err = h(ctx, sellMsg)
+	require.NoError(t, err)
+
+	// Selling another 50 tokens lowers the returns for both sells to 100, so
+	// the first sell is cancelled and its war tokens are returned
+	_, err = h(ctx, newValidMsgSell(50))
+	require.NoError(t, err)
+	batch := app.WarsKeeper.MustGetBatch(ctx, token)
+	require.True(t, batch.Sells[0].Cancelled)
+	require.False(t, batch.Sells[1].Cancelled)
+	require.Equal(t, sdk.NewInt64Coin(token, 50), batch.TotalSellAmount)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Only the second sell is performed, for a return of 125
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.WarsKeeper.GetReserveBalances(ctx, token)
+	require.Equal(t, sdk.NewInt(825), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(150), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(175), reserveBalance.AmountOf(reserveToken))
+	_, broken := wars.AllInvariants(app.WarsKeeper)(ctx)
+	require.False(t, broken)
+}
+
+func TestSwapWarDoesNotExistFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Swap tokens
+	_, err := h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 1))
 
 	require.Error(t, err)
 	require.False(t, app.WarsKeeper.WarExists(ctx, token))
@@ -694,7 +1411,7 @@ func TestSwapOrderQuantityLimitExceededFails(t *testing.T) {
 	wars.EndBlocker(ctx, app.WarsKeeper)
 
 	// Perform swap
-	msg := types.NewMsgSwap(userAddress, token, sdk.NewInt64Coin(reserveToken, 5), reserveToken2)
+	msg := newValidMsgSwap(reserveToken, reserveToken2, 5)
 	_, err = h(ctx, msg)
 
 	userBalance := app.AccountKeeper.GetAccount(ctx, userAddress).GetCoins()
@@ -725,7 +1442,7 @@ func TestSwapInvalidAmount(t *testing.T) {
 	wars.EndBlocker(ctx, app.WarsKeeper)
 
 	// Perform swap
-	msg := types.NewMsgSwap(userAddress, token, tenReserveTokens, reserveToken2)
+	msg := newValidMsgSwap(reserveToken, reserveToken2, tenReserveTokens.Amount.Int64())
 	_, err = h(ctx, msg)
 
 	require.Error(t, err)
@@ -811,307 +1528,1360 @@ func TestSwapValidAmountReversed(t *testing.T) {
 	require.Equal(t, sdk.OneInt(), feeBalance.AmountOf(reserveToken2))
 }
 
-func TestMakeOutcomePayment(t *testing.T) {
+func TestSwapWeightedSwapperWar(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
 
-	// Create war with 100k outcome payment
-	warMsg := newValidMsgCreateWar()
-	warMsg.OutcomePayment = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
-	h(ctx, warMsg)
+	// Create war with three reserve tokens (weights 1:1:2) and zero fees
+	createMsg := newValidMsgCreateWeightedSwapperWar()
+	createMsg.TxFeePercentage = sdk.ZeroDec()
+	createMsg.ExitFeePercentage = sdk.ZeroDec()
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
 
 	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100000)})
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+		sdk.NewInt64Coin(reserveToken3, 100000),
+	)
+	err = addCoinsToUser(app, ctx, coins)
 	require.Nil(t, err)
 
-	// Make outcome payment
-	_, err = h(ctx, newValidMsgMakeOutcomePayment())
+	// Buy 10 tokens to initialise the reserves
+	buyMsg := newValidMsgBuy(10, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 2000),
+		sdk.NewInt64Coin(reserveToken3, 3000),
+	)
+	_, err = h(ctx, buyMsg)
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Swap between the first and third reserve tokens, for which the return
+	// is 3000*(1-(1000/1100)^(1/2)) = 139
+	_, err = h(ctx, newValidMsgSwap(reserveToken, reserveToken3, 100))
+	require.NoError(t, err)
 	wars.EndBlocker(ctx, app.WarsKeeper)
 
-	// Check that outcome payment is now in the war reserve
 	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
 	reserveBalance := app.WarsKeeper.GetReserveBalances(ctx, initToken)
+	require.Equal(t, sdk.NewInt(98900), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(98000), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(97139), userBalance.AmountOf(reserveToken3))
+	require.Equal(t, sdk.NewInt(1100), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(2000), reserveBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(2861), reserveBalance.AmountOf(reserveToken3))
+
+	// Sell 5 tokens to remove half of the liquidity from all reserves
+	_, err = h(ctx, newValidMsgSell(5))
 	require.NoError(t, err)
-	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(100000), reserveBalance.AmountOf(reserveToken))
+	wars.EndBlocker(ctx, app.WarsKeeper)
 
-	// Check that the war is now in SETTLE state
-	require.Equal(t, types.SettleState, app.WarsKeeper.MustGetWar(ctx, token).State)
+	reserveBalance = app.WarsKeeper.GetReserveBalances(ctx, initToken)
+	require.Equal(t, sdk.NewInt(550), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(1000), reserveBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(1431), reserveBalance.AmountOf(reserveToken3))
 }
 
-func TestWithdrawShare(t *testing.T) {
+func TestSwapStableSwapWar(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
 
-	// Create war
-	h(ctx, newValidMsgCreateWar())
-
-	// Set war current supply to 3 and state to SETTLE
-	war := app.WarsKeeper.MustGetWar(ctx, token)
-	war.CurrentSupply = sdk.NewCoin(war.Token, sdk.NewInt(3))
-	war.State = types.SettleState
-	app.WarsKeeper.SetWar(ctx, token, war)
-
-	// Mint 3 war tokens and send [2 to user 1] and [1 to user 2]
-	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount,
-		sdk.NewCoins(sdk.NewInt64Coin(token, 3)))
-	require.Nil(t, err)
-	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.WarsMintBurnAccount,
-		userAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 2)))
-	require.Nil(t, err)
-	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.WarsMintBurnAccount,
-		anotherAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 1)))
-	require.Nil(t, err)
+	// Create war with A=100 and zero fees
+	createMsg := newValidMsgCreateStableSwapWar()
+	createMsg.TxFeePercentage = sdk.ZeroDec()
+	createMsg.ExitFeePercentage = sdk.ZeroDec()
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
 
-	// Simulate outcome payment by depositing (freshly minted) 100k into reserve
-	hundredK := sdk.NewCoins(sdk.NewCoin(reserveToken, sdk.NewInt(100000)))
-	err = app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, hundredK)
-	require.Nil(t, err)
-	err = app.WarsKeeper.DepositReserveFromModule(
-		ctx, war.Token, types.WarsMintBurnAccount, hundredK)
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+	)
+	err = addCoinsToUser(app, ctx, coins)
 	require.Nil(t, err)
 
-	// User 1 withdraws share
-	_, err = h(ctx, newValidMsgWithdrawShareFrom(userAddress))
+	// Buy 10 tokens to initialise the reserves at 1000 of each token
+	buyMsg := newValidMsgBuy(10, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 1000),
+	)
+	_, err = h(ctx, buyMsg)
 	require.NoError(t, err)
 	wars.EndBlocker(ctx, app.WarsKeeper)
 
-	// User 1 had 2 tokens out of the supply of 3 tokens, so user 1 gets 2/3
-	user1Balance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
-	reserveBalance := app.WarsKeeper.GetReserveBalances(ctx, initToken)
-	require.NoError(t, err)
-	require.Equal(t, sdk.NewInt(66666), user1Balance.AmountOf(reserveToken))
-	require.Equal(t, sdk.NewInt(33334), reserveBalance.AmountOf(reserveToken))
-
-	// Note: rounding is rounded to floor, so despite user 1 being owed 66666.67
-	// tokens, user 1 gets 66666 and not 66667 tokens. Then, since user 2 now owns
-	// the entire share of the war tokens, they will get 100% of the remaining
-	// 33334 tokens, which is more than what was initially owed (33333.33).
-
-	// User 2 withdraws share
-	_, err = h(ctx, newValidMsgWithdrawShareFrom(anotherAddress))
+	// Swap 100 tokens, for which the return is 99 (close to 1:1), compared
+	// to 1000*(1-1000/1100) = 90 for the swapper function
+	_, err = h(ctx, newValidMsgSwap(reserveToken, reserveToken2, 100))
 	require.NoError(t, err)
 	wars.EndBlocker(ctx, app.WarsKeeper)
 
-	// User 2 had 1 token out of the remaining supply of 1 token, so user 2 gets all remaining
-	user2Balance := app.WarsKeeper.BankKeeper.GetCoins(ctx, anotherAddress)
-	reserveBalance = app.WarsKeeper.GetReserveBalances(ctx, initToken)
-	require.NoError(t, err)
-	require.Equal(t, sdk.NewInt(33334), user2Balance.AmountOf(reserveToken))
-	require.Equal(t, sdk.ZeroInt(), reserveBalance.AmountOf(reserveToken))
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.WarsKeeper.GetReserveBalances(ctx, initToken)
+	require.Equal(t, sdk.NewInt(98900), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(99099), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, sdk.NewInt(1100), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(901), reserveBalance.AmountOf(reserveToken2))
 }
 
-func TestDecrementRemainingBlocksCountAfterEndBlock(t *testing.T) {
+func TestRoutedSwap(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
 
-	two := sdk.NewUint(2)
-	one := sdk.NewUint(1)
+	// Create two swapper wars, res/rez and rez/rec
+	h(ctx, newValidMsgCreateSwapperWar())
+	createMsg := newValidMsgCreateSwapperWar()
+	createMsg.Token = token2
+	createMsg.MaxSupply = sdk.NewInt64Coin(token2, initMaxSupply.Amount.Int64())
+	createMsg.ReserveTokens = []string{reserveToken2, reserveToken3}
+	_, err := h(ctx, createMsg)
+	require.Nil(t, err)
 
-	// Create war
-	createMsg := newValidMsgCreateWar()
-	createMsg.BatchBlocks = two
-	h(ctx, createMsg)
+	// Add reserve tokens to user
+	coins := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100000),
+		sdk.NewInt64Coin(reserveToken2, 100000),
+		sdk.NewInt64Coin(reserveToken3, 100000),
+	)
+	err = addCoinsToUser(app, ctx, coins)
+	require.Nil(t, err)
 
-	require.Equal(t, two, app.WarsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+	// Buy 2 tokens of each war to initialise them
+	buyMsg := newValidMsgBuy(2, 0) // 0 max prices replaced below
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 10000),
+		sdk.NewInt64Coin(reserveToken2, 10000),
+	)
+	_, err = h(ctx, buyMsg)
+	require.Nil(t, err)
+	buyMsg.Amount = sdk.NewInt64Coin(token2, 2)
+	buyMsg.MaxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken2, 10000),
+		sdk.NewInt64Coin(reserveToken3, 10000),
+	)
+	_, err = h(ctx, buyMsg)
+	require.Nil(t, err)
 	wars.EndBlocker(ctx, app.WarsKeeper)
-	require.Equal(t, one, app.WarsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+
+	// Routed swap through a war that does not exist fails
+	from := sdk.NewInt64Coin(reserveToken, 10)
+	invalidRoute := types.SwapRoute{types.NewSwapHop("abc", reserveToken2)}
+	_, err = h(ctx, types.NewMsgRoutedSwap(userAddress, from, invalidRoute,
+		sdk.NewInt64Coin(reserveToken2, 0)))
+	require.Error(t, err)
+
+	// Routed swap with a min output that cannot be reached is refunded
+	route := types.SwapRoute{
+		types.NewSwapHop(token, reserveToken2),
+		types.NewSwapHop(token2, reserveToken3),
+	}
+	balanceBefore := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	_, err = h(ctx, types.NewMsgRoutedSwap(userAddress, from, route,
+		sdk.NewInt64Coin(reserveToken3, 10)))
+	require.Nil(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, balanceBefore, app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress))
+
+	// Routed swap without a min output goes through both wars
+	expectedRoute, expectedReturns, _, err := app.WarsKeeper.GetBestSwapRoute(ctx, from, reserveToken3)
+	require.Nil(t, err)
+	require.Equal(t, route, expectedRoute)
+	_, err = h(ctx, types.NewMsgRoutedSwap(userAddress, from, route,
+		sdk.NewInt64Coin(reserveToken3, 0)))
+	require.Nil(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, balanceBefore.AmountOf(reserveToken).Sub(from.Amount),
+		userBalance.AmountOf(reserveToken))
+	require.Equal(t, balanceBefore.AmountOf(reserveToken2), userBalance.AmountOf(reserveToken2))
+	require.Equal(t, balanceBefore.AmountOf(reserveToken3).Add(expectedReturns.Amount),
+		userBalance.AmountOf(reserveToken3))
+	require.True(t, expectedReturns.IsPositive())
+
+	// Intermediary account is left empty
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	require.True(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()).IsZero())
 }
 
-func TestEndBlockerDoesNotPerformOrdersBeforeASpecifiedNumberOfBlocks(t *testing.T) {
+func TestBuyingAWarWithPartialFill(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
 
-	// Create war with batch blocks set to 2
-	createMsg := newValidMsgCreateWar()
-	createMsg.BatchBlocks = sdk.NewUint(2)
-	h(ctx, createMsg)
+	// Create war
+	h(ctx, newValidMsgCreateWar())
 
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	// Add reserve tokens to users
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
 	require.Nil(t, err)
 
-	// Buy 4 tokens
-	h(ctx, newValidMsgBuy(2, 10000))
-	h(ctx, newValidMsgBuy(2, 10000))
-	wars.EndBlocker(ctx, app.WarsKeeper)
+	// Buying 10 tokens costs 4*10^3+100*10 = 5000 plus fees, so the buy fails
+	// with max prices of 3000, unless partial fills are allowed, in which case
+	// the buy is reduced to 8 tokens, for 4*8^3+100*8 = 2848 plus fees
+	cacheCtx, _ := ctx.CacheContext() // since handlers do not revert on error
+	_, err = h(cacheCtx, newValidMsgBuy(10, 3000))
+	require.Error(t, err)
+	_, err = h(ctx, newValidMsgBuyWithPartialFill(10, 3000))
+	require.Nil(t, err)
+	batch := app.WarsKeeper.MustGetBatch(ctx, token)
+	require.Equal(t, sdk.NewInt(8), batch.Buys[0].Amount.Amount)
 
-	require.Equal(t, len(app.WarsKeeper.MustGetBatch(ctx, token).Buys), 2)
+	// Reduced buy order is performed and unused max prices are returned
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(8), userBalance.AmountOf(token))
+	require.True(t, userBalance.AmountOf(reserveToken).GT(sdk.NewInt(7000)))
 }
 
-func TestEndBlockerPerformsOrdersAfterASpecifiedNumberOfBlocks(t *testing.T) {
+func TestPartialFillReducesBuyInsteadOfCancelling(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
 
 	// Create war
-	createMsg := newValidMsgCreateWar()
-	createMsg.BatchBlocks = sdk.NewUint(3)
-	h(ctx, createMsg)
+	h(ctx, newValidMsgCreateWar())
 
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	// Add reserve tokens to users
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)})
+	require.Nil(t, err)
+	err = addCoinsToUser2(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
 	require.Nil(t, err)
 
-	// Buy 4 tokens
-	h(ctx, newValidMsgBuy(2, 10000))
-	h(ctx, newValidMsgBuy(2, 10000))
+	// Buy 4 tokens for 4*4^3+100*4 = 656 plus fees, allowing partial fills
+	_, err = h(ctx, newValidMsgBuyWithPartialFill(4, 1000))
+	require.Nil(t, err)
 
-	// Run EndBlocker for N times, where N = BatchBlocks
-	batchBlocksInt := int(createMsg.BatchBlocks.Uint64())
-	for i := 0; i <= batchBlocksInt; i++ {
-		wars.EndBlocker(ctx, app.WarsKeeper)
-	}
+	// Another buy of 6 tokens makes the batch buy price 5000/10 = 500 per
+	// token, so the first buy is reduced to 1 token rather than cancelled
+	anotherBuy := newValidMsgBuy(6, 10000)
+	anotherBuy.Buyer = anotherAddress
+	_, err = h(ctx, anotherBuy)
+	require.Nil(t, err)
+	batch := app.WarsKeeper.MustGetBatch(ctx, token)
+	require.False(t, batch.Buys[0].Cancelled)
+	require.Equal(t, sdk.OneInt(), batch.Buys[0].Amount.Amount)
+	require.Equal(t, sdk.NewInt(7), batch.TotalBuyAmount.Amount)
 
-	// Buys have been performed
-	require.Equal(t, 0, len(app.WarsKeeper.MustGetBatch(ctx, token).Buys))
+	// Both buys are performed
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, sdk.OneInt(), app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token))
+	require.Equal(t, sdk.NewInt(6), app.WarsKeeper.BankKeeper.GetCoins(ctx, anotherAddress).AmountOf(token))
 }
 
-func TestEndBlockerAugmentedFunction(t *testing.T) {
+func TestCancellingOrders(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
 
-	// Create war with augmented function type
-	createMsg := newValidMsgCreateAugmentedWar()
-	h(ctx, createMsg)
+	// Create war
+	h(ctx, newValidMsgCreateWar())
 
-	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	// Add reserve tokens to users
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+	err = addCoinsToUser2(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
 	require.Nil(t, err)
 
-	// Get war to confirm allowSells==false, S0==50000, state==hatch
-	war := app.WarsKeeper.MustGetWar(ctx, token)
-	require.False(t, war.AllowSells)
-	require.Equal(t, sdk.NewDec(50000), war.FunctionParameters.AsMap()["S0"])
-	require.Equal(t, types.HatchState, war.State)
-
-	// - Buy 49999 tokens; just below S0
-	// - Cannot buy 2 tokens in the meantime, since this exceeds S0
-	// - Cannot sell tokens (not even 1) in hatch state
-	_, err = h(ctx, newValidMsgBuy(49999, 100000))
-	require.NoError(t, err)
-	_, err = h(ctx, newValidMsgBuy(2, 100000))
+	// Buy 2 tokens and cancel the buy order
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.Nil(t, err)
+	_, err = h(ctx, types.NewMsgCancelOrder(anotherAddress, token, types.BuyOrderType, 0))
 	require.Error(t, err)
-	_, err = h(ctx, newValidMsgSell(1))
+	_, err = h(ctx, types.NewMsgCancelOrder(userAddress, token, types.BuyOrderType, 0))
+	require.Nil(t, err)
+	_, err = h(ctx, types.NewMsgCancelOrder(userAddress, token, types.BuyOrderType, 0))
 	require.Error(t, err)
-	wars.EndBlocker(ctx, app.WarsKeeper)
-
-	// Confirm allowSells and state still the same
-	war = app.WarsKeeper.MustGetWar(ctx, token)
-	require.False(t, war.AllowSells)
-	require.Equal(t, types.HatchState, war.State)
 
-	// Buy 1 more token, to reach S0 => state is now open
-	h(ctx, newValidMsgBuy(1, 100000))
+	// Cancelled buy order is not performed and max prices are returned
 	wars.EndBlocker(ctx, app.WarsKeeper)
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(4000), userBalance.AmountOf(reserveToken))
+	require.True(t, userBalance.AmountOf(token).IsZero())
+	require.True(t, app.WarsKeeper.MustGetWar(ctx, token).CurrentSupply.IsZero())
 
-	// Confirm allowSells==true, state==open
-	war = app.WarsKeeper.MustGetWar(ctx, token)
-	require.True(t, war.AllowSells)
-	require.Equal(t, types.OpenState, war.State)
+	// Buy 2 tokens, this time without cancelling
+	_, err = h(ctx, newValidMsgBuy(2, 4000))
+	require.Nil(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	userBalance = app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
 
-	// Check user balance of tokens
-	balance := app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).Int64()
-	require.Equal(t, int64(50000), balance)
+	// Sell 1 token and cancel the sell order
+	_, err = h(ctx, newValidMsgSell(1))
+	require.Nil(t, err)
+	userBalance = app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.OneInt(), userBalance.AmountOf(token))
+	_, err = h(ctx, types.NewMsgCancelOrder(userAddress, token, types.SellOrderType, 0))
+	require.Nil(t, err)
 
-	// Can now sell tokens (all 50,000 of them)
-	_, err = h(ctx, newValidMsgSell(50000))
-	require.NoError(t, err)
+	// Cancelled sell order is not performed and war tokens are returned
 	wars.EndBlocker(ctx, app.WarsKeeper)
-	balance = app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).Int64()
-	require.Equal(t, int64(0), balance)
+	userBalance = app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	require.Equal(t, sdk.NewInt(2), app.WarsKeeper.MustGetWar(ctx, token).CurrentSupply.Amount)
 }
 
-func TestEndBlockerAugmentedFunctionDecimalS0(t *testing.T) {
+func TestLimitOrders(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
 
-	// Create war with augmented function type
-	createMsg := newValidMsgCreateAugmentedWar()
-	h(ctx, createMsg)
+	// Create war
+	h(ctx, newValidMsgCreateWar())
 
 	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
 	require.Nil(t, err)
 
-	// Change war's S0 parameter to 49999.5
-	decimalS0 := sdk.MustNewDecFromStr("49999.5")
-	war := app.WarsKeeper.MustGetWar(ctx, token)
-	for i, p := range war.FunctionParameters {
-		if p.Param == "S0" {
-			war.FunctionParameters[i].Value = decimalS0
-			break
-		}
-	}
-	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	// Place a limit buy that is not met (buying 2 tokens costs more than
+	// 100res) and one that is met, both expiring at height 5
+	_, err = h(ctx, newValidMsgLimitBuy(2, 100, 5))
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgLimitBuy(2, 4000, 5))
+	require.Nil(t, err)
+
+	// Max prices are escrowed and orders are not yet in the batch
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(5900), userBalance.AmountOf(reserveToken))
+	require.Len(t, app.WarsKeeper.GetOrderBook(ctx, token).Orders, 2)
+	require.Len(t, app.WarsKeeper.MustGetBatch(ctx, token).Buys, 0)
+
+	// Met order is pulled into the new batch at the end of the current batch
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Len(t, app.WarsKeeper.GetOrderBook(ctx, token).Orders, 1)
+	require.Len(t, app.WarsKeeper.MustGetBatch(ctx, token).Buys, 1)
+
+	// Met order is performed at the end of the new batch
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	userBalance = app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(2), userBalance.AmountOf(token))
+	reserveAfterBuy := userBalance.AmountOf(reserveToken)
+
+	// Unmet order expires after height 5 and its max prices are returned
+	ctx = ctx.WithBlockHeight(6)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Empty(t, app.WarsKeeper.GetOrderBook(ctx, token).Orders)
+	userBalance = app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, reserveAfterBuy.AddRaw(100), userBalance.AmountOf(reserveToken))
+
+	// Orders with an expiry height that has already passed fail
+	_, err = h(ctx, newValidMsgLimitBuy(2, 4000, 5))
+	require.Error(t, err)
+	_, err = h(ctx, newValidMsgLimitSell(1, 1, 5))
+	require.Error(t, err)
+
+	// Place a limit sell that is met, which escrows the war tokens
+	_, err = h(ctx, newValidMsgLimitSell(1, 1, 10))
+	require.Nil(t, err)
+	userBalance = app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.OneInt(), userBalance.AmountOf(token))
+
+	// Sell order is pulled into the new batch and then performed
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Len(t, app.WarsKeeper.MustGetBatch(ctx, token).Sells, 1)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, sdk.OneInt(), app.WarsKeeper.MustGetWar(ctx, token).CurrentSupply.Amount)
+	userBalance = app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.True(t, userBalance.AmountOf(reserveToken).GT(reserveAfterBuy.AddRaw(100)))
+}
+
+func TestLimitOrdersOutsideOfParamsLimitsFail(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Limit orders can expire at most 10 blocks from now, each address can
+	// have at most 2 limit orders per war, and each war at most 3
+	params := app.WarsKeeper.GetParams(ctx)
+	params.MaxLimitOrderExpiryBlocks = 10
+	params.MaxLimitOrdersPerAddress = 2
+	params.MaxLimitOrdersPerWar = 3
+	app.WarsKeeper.SetParams(ctx, params)
+
+	// Create war
+	_, err := h(ctx, newValidMsgCreateWar())
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+
+	// Expiry height more than 10 blocks from now fails
+	_, err = h(ctx, newValidMsgLimitBuy(2, 100, 11))
+	require.Error(t, err)
+	require.True(t, types.ErrExpiryHeightTooFar.Is(err))
+	_, err = h(ctx, newValidMsgLimitSell(1, 1, 11))
+	require.Error(t, err)
+	require.True(t, types.ErrExpiryHeightTooFar.Is(err))
+
+	// Third limit order by the same address fails
+	_, err = h(ctx, newValidMsgLimitBuy(2, 100, 10))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgLimitBuy(2, 100, 10))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgLimitBuy(2, 100, 10))
+	require.Error(t, err)
+	require.True(t, types.ErrMaxLimitOrdersReached.Is(err))
+	require.Len(t, app.WarsKeeper.GetOrderBook(ctx, token).Orders, 2)
+
+	// Limit order can be placed again once one of them is cancelled
+	_, err = h(ctx, types.NewMsgCancelLimitOrder(userAddress, token, 0))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgLimitBuy(2, 100, 10))
+	require.NoError(t, err)
+
+	// Fourth limit order for the war fails, even by another address
+	_, err = app.BankKeeper.AddCoins(ctx, anotherAddress,
+		sdk.Coins{sdk.NewInt64Coin(reserveToken, 10000)})
+	require.Nil(t, err)
+	msg := newValidMsgLimitBuy(2, 100, 10)
+	msg.Buyer = anotherAddress
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+	require.True(t, types.ErrMaxLimitOrdersReached.Is(err))
+	require.Equal(t, uint64(3), app.WarsKeeper.GetLimitOrderCount(ctx, token))
+	require.Equal(t, uint64(1), app.WarsKeeper.GetLimitOrderCountByAddress(ctx, token, anotherAddress))
+}
+
+func TestCancellingLimitOrders(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war
+	h(ctx, newValidMsgCreateWar())
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 4000)})
+	require.Nil(t, err)
+
+	// Place a limit buy and cancel it
+	_, err = h(ctx, newValidMsgLimitBuy(2, 4000, 5))
+	require.Nil(t, err)
+	_, err = h(ctx, types.NewMsgCancelLimitOrder(anotherAddress, token, 0))
+	require.Error(t, err)
+	_, err = h(ctx, types.NewMsgCancelLimitOrder(userAddress, token, 0))
+	require.Nil(t, err)
+	_, err = h(ctx, types.NewMsgCancelLimitOrder(userAddress, token, 0))
+	require.Error(t, err)
+
+	// Cancelled order is not pulled into the batch and max prices are returned
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(4000), userBalance.AmountOf(reserveToken))
+	require.True(t, userBalance.AmountOf(token).IsZero())
+	require.True(t, app.WarsKeeper.MustGetWar(ctx, token).CurrentSupply.IsZero())
+}
+
+func TestMakeOutcomePayment(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Set war creation deposit
+	deposit := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	params := app.WarsKeeper.GetParams(ctx)
+	params.WarCreationDeposit = deposit
+	app.WarsKeeper.SetParams(ctx, params)
+	_, err := app.BankKeeper.AddCoins(ctx, initCreator, deposit)
+	require.NoError(t, err)
+
+	// Create war with 100k outcome payment
+	warMsg := newValidMsgCreateWar()
+	warMsg.OutcomePayment = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000))
+	_, err = h(ctx, warMsg)
+	require.NoError(t, err)
+	require.True(t, app.BankKeeper.GetCoins(ctx, initCreator).IsZero())
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 100000)})
+	require.Nil(t, err)
+
+	// Make outcome payment
+	_, err = h(ctx, newValidMsgMakeOutcomePayment())
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Check that outcome payment is now in the war reserve
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.WarsKeeper.GetReserveBalances(ctx, initToken)
+	require.NoError(t, err)
+	require.Equal(t, sdk.ZeroInt(), userBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(100000), reserveBalance.AmountOf(reserveToken))
+
+	// Check that the war is now in SETTLE state
+	require.Equal(t, types.SettleState, app.WarsKeeper.MustGetWar(ctx, token).State)
+
+	// Check that the war creation deposit was refunded to the creator
+	require.Equal(t, deposit, app.BankKeeper.GetCoins(ctx, initCreator))
+	require.True(t, app.WarsKeeper.MustGetWar(ctx, token).CreationDeposit.IsZero())
+}
+
+func TestWithdrawShare(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war
+	h(ctx, newValidMsgCreateWar())
+
+	// Set war current supply to 3 and state to SETTLE
+	war := app.WarsKeeper.MustGetWar(ctx, token)
+	war.CurrentSupply = sdk.NewCoin(war.Token, sdk.NewInt(3))
+	war.State = types.SettleState
+	app.WarsKeeper.SetWar(ctx, token, war)
+
+	// Mint 3 war tokens and send [2 to user 1] and [1 to user 2]
+	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount,
+		sdk.NewCoins(sdk.NewInt64Coin(token, 3)))
+	require.Nil(t, err)
+	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.WarsMintBurnAccount,
+		userAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 2)))
+	require.Nil(t, err)
+	err = app.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.WarsMintBurnAccount,
+		anotherAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 1)))
+	require.Nil(t, err)
+
+	// Simulate outcome payment by depositing (freshly minted) 100k into reserve
+	hundredK := sdk.NewCoins(sdk.NewCoin(reserveToken, sdk.NewInt(100000)))
+	err = app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, hundredK)
+	require.Nil(t, err)
+	err = app.WarsKeeper.DepositReserveFromModule(
+		ctx, war.Token, types.WarsMintBurnAccount, hundredK)
+	require.Nil(t, err)
+
+	// User 1 withdraws share
+	_, err = h(ctx, newValidMsgWithdrawShareFrom(userAddress))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// User 1 had 2 tokens out of the supply of 3 tokens, so user 1 gets 2/3
+	user1Balance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	reserveBalance := app.WarsKeeper.GetReserveBalances(ctx, initToken)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(66666), user1Balance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(33334), reserveBalance.AmountOf(reserveToken))
+
+	// Note: rounding is rounded to floor, so despite user 1 being owed 66666.67
+	// tokens, user 1 gets 66666 and not 66667 tokens. Then, since user 2 now owns
+	// the entire share of the war tokens, they will get 100% of the remaining
+	// 33334 tokens, which is more than what was initially owed (33333.33).
+
+	// User 2 withdraws share
+	_, err = h(ctx, newValidMsgWithdrawShareFrom(anotherAddress))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// User 2 had 1 token out of the remaining supply of 1 token, so user 2 gets all remaining
+	user2Balance := app.WarsKeeper.BankKeeper.GetCoins(ctx, anotherAddress)
+	reserveBalance = app.WarsKeeper.GetReserveBalances(ctx, initToken)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(33334), user2Balance.AmountOf(reserveToken))
+	require.Equal(t, sdk.ZeroInt(), reserveBalance.AmountOf(reserveToken))
+}
+
+func TestDecrementRemainingBlocksCountAfterEndBlock(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	two := sdk.NewUint(2)
+	one := sdk.NewUint(1)
+
+	// Create war
+	createMsg := newValidMsgCreateWar()
+	createMsg.BatchBlocks = two
+	h(ctx, createMsg)
+
+	require.Equal(t, two, app.WarsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, one, app.WarsKeeper.MustGetBatch(ctx, token).BlocksRemaining)
+}
+
+func TestEndBlockerDoesNotPerformOrdersBeforeASpecifiedNumberOfBlocks(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with batch blocks set to 2
+	createMsg := newValidMsgCreateWar()
+	createMsg.BatchBlocks = sdk.NewUint(2)
+	h(ctx, createMsg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy 4 tokens
+	h(ctx, newValidMsgBuy(2, 10000))
+	h(ctx, newValidMsgBuy(2, 10000))
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	require.Equal(t, len(app.WarsKeeper.MustGetBatch(ctx, token).Buys), 2)
+}
+
+func TestEndBlockerPerformsOrdersAfterASpecifiedNumberOfBlocks(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war
+	createMsg := newValidMsgCreateWar()
+	createMsg.BatchBlocks = sdk.NewUint(3)
+	h(ctx, createMsg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy 4 tokens
+	h(ctx, newValidMsgBuy(2, 10000))
+	h(ctx, newValidMsgBuy(2, 10000))
+
+	// Run EndBlocker for N times, where N = BatchBlocks
+	batchBlocksInt := int(createMsg.BatchBlocks.Uint64())
+	for i := 0; i <= batchBlocksInt; i++ {
+		wars.EndBlocker(ctx, app.WarsKeeper)
+	}
+
+	// Buys have been performed
+	require.Equal(t, 0, len(app.WarsKeeper.MustGetBatch(ctx, token).Buys))
+}
+
+func TestEndBlockerAugmentedFunction(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with augmented function type
+	createMsg := newValidMsgCreateAugmentedWar()
+	h(ctx, createMsg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Get war to confirm allowSells==false, S0==50000, state==hatch
+	war := app.WarsKeeper.MustGetWar(ctx, token)
+	require.False(t, war.AllowSells)
+	require.Equal(t, sdk.NewDec(50000), war.FunctionParameters.AsMap()["S0"])
+	require.Equal(t, types.HatchState, war.State)
+
+	// - Buy 49999 tokens; just below S0
+	// - Cannot buy 2 tokens in the meantime, since this exceeds S0
+	// - Cannot sell tokens (not even 1) in hatch state
+	_, err = h(ctx, newValidMsgBuy(49999, 100000))
+	require.NoError(t, err)
+	_, err = h(ctx, newValidMsgBuy(2, 100000))
+	require.Error(t, err)
+	_, err = h(ctx, newValidMsgSell(1))
+	require.Error(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Confirm allowSells and state still the same
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.False(t, war.AllowSells)
+	require.Equal(t, types.HatchState, war.State)
+
+	// Buy 1 more token, to reach S0 => state is now open
+	h(ctx, newValidMsgBuy(1, 100000))
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Confirm allowSells==true, state==open
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.True(t, war.AllowSells)
+	require.Equal(t, types.OpenState, war.State)
+
+	// Check user balance of tokens
+	balance := app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).Int64()
+	require.Equal(t, int64(50000), balance)
+
+	// Can now sell tokens (all 50,000 of them)
+	_, err = h(ctx, newValidMsgSell(50000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	balance = app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).Int64()
+	require.Equal(t, int64(0), balance)
+}
+
+func TestEndBlockerAugmentedFunctionDecimalS0(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with augmented function type
+	createMsg := newValidMsgCreateAugmentedWar()
+	h(ctx, createMsg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Change war's S0 parameter to 49999.5
+	decimalS0 := sdk.MustNewDecFromStr("49999.5")
+	war := app.WarsKeeper.MustGetWar(ctx, token)
+	for i, p := range war.FunctionParameters {
+		if p.Param == "S0" {
+			war.FunctionParameters[i].Value = decimalS0
+			break
+		}
+	}
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+
+	// Get war to confirm S0==49999.5, allowSells==false, state==hatch
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, decimalS0, war.FunctionParameters.AsMap()["S0"])
+	require.False(t, war.AllowSells)
+	require.Equal(t, types.HatchState, war.State)
+
+	// Buy 49999 tokens; just below S0
+	h(ctx, newValidMsgBuy(49999, 100000))
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Confirm allowSells and state still the same
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.False(t, war.AllowSells)
+	require.Equal(t, types.HatchState, war.State)
+
+	// Buy 1 more token, to exceed S0
+	h(ctx, newValidMsgBuy(1, 100000))
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Confirm allowSells==true, state==open
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.True(t, war.AllowSells)
+	require.Equal(t, types.OpenState, war.State)
+}
+
+func TestEndBlockerAugmentedFunctionSmallBuys(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with augmented function type, small params, and zero fees
+	createMsg := newValidMsgCreateAugmentedWar()
+	createMsg.FunctionParameters = types.FunctionParams{
+		types.NewFunctionParam("d0", sdk.MustNewDecFromStr("10.0")),
+		types.NewFunctionParam("p0", sdk.MustNewDecFromStr("1.0")),
+		types.NewFunctionParam("theta", sdk.MustNewDecFromStr("0.9")),
+		types.NewFunctionParam("kappa", sdk.MustNewDecFromStr("3.0"))}
+	createMsg.TxFeePercentage = sdk.ZeroDec()
+	createMsg.ExitFeePercentage = sdk.ZeroDec()
+	h(ctx, createMsg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Get war to confirm allowSells==false, S0==10, R0==1 state==hatch
+	war := app.WarsKeeper.MustGetWar(ctx, token)
+	require.False(t, war.AllowSells)
+	require.Equal(t, sdk.NewDec(10), war.FunctionParameters.AsMap()["S0"])
+	require.Equal(t, sdk.NewDec(1), war.FunctionParameters.AsMap()["R0"])
+	require.Equal(t, types.HatchState, war.State)
+
+	// Perform 10 buys of 1 token each
+	for i := 0; i < 10; i++ {
+		_, err := h(ctx, newValidMsgBuy(1, 1))
+		require.NoError(t, err)
+	}
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Confirm allowSells==true, state==open
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.True(t, war.AllowSells)
+	require.Equal(t, types.OpenState, war.State)
+
+	// Confirm reserve balance is R0 [i.e. d0*(1-theta)] = 1
+	require.Equal(t, int64(1), war.CurrentReserve[0].Amount.Int64())
+
+	// Confirm fee address balance is d0*theta = 9
+	feeAddressBalance := app.BankKeeper.GetCoins(
+		ctx, war.FeeAddress).AmountOf(reserveToken).Int64()
+	require.Equal(t, int64(9), feeAddressBalance)
+}
+
+func TestEndBlockerAugmentedFunctionReleasesHatchFunding(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with augmented function type
+	createMsg := newValidMsgCreateAugmentedWar()
+	h(ctx, createMsg)
+
+	// Add reserve tokens to user
+	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy 49999 tokens; just below S0
+	_, err = h(ctx, newValidMsgBuy(49999, 100000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
 
-	// Get war to confirm S0==49999.5, allowSells==false, state==hatch
-	war = app.WarsKeeper.MustGetWar(ctx, token)
-	require.Equal(t, decimalS0, war.FunctionParameters.AsMap()["S0"])
-	require.False(t, war.AllowSells)
+	// Funding is held in escrow, so fee address only has the tx fees
+	war := app.WarsKeeper.MustGetWar(ctx, token)
 	require.Equal(t, types.HatchState, war.State)
+	hatchFunding := war.HatchFunding
+	require.True(t, hatchFunding.IsAllPositive())
+	txFees := app.BankKeeper.GetCoins(ctx, createMsg.FeeAddress)
 
-	// Buy 49999 tokens; just below S0
-	h(ctx, newValidMsgBuy(49999, 100000))
+	// Buy 1 more token, to reach S0 => state is now open
+	_, err = h(ctx, newValidMsgBuy(1, 100000))
+	require.NoError(t, err)
 	wars.EndBlocker(ctx, app.WarsKeeper)
 
-	// Confirm allowSells and state still the same
+	// Escrowed funding (including that of the last buy) was released
 	war = app.WarsKeeper.MustGetWar(ctx, token)
-	require.False(t, war.AllowSells)
+	require.Equal(t, types.OpenState, war.State)
+	require.True(t, war.HatchFunding.IsZero())
+	feeAddressBalance := app.BankKeeper.GetCoins(ctx, createMsg.FeeAddress)
+	require.True(t, feeAddressBalance.IsAllGT(txFees.Add(hatchFunding...)))
+}
+
+func TestEndBlockerAugmentedFunctionHatchFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Set war creation deposit
+	deposit := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	params := app.WarsKeeper.GetParams(ctx)
+	params.WarCreationDeposit = deposit
+	app.WarsKeeper.SetParams(ctx, params)
+	_, err := app.BankKeeper.AddCoins(ctx, initCreator, deposit)
+	require.NoError(t, err)
+
+	// Create war with augmented function type and hatch deadline at height 5
+	createMsg := newValidMsgCreateAugmentedWar()
+	createMsg.HatchDeadlineHeight = 5
+	_, err = h(ctx, createMsg)
+	require.NoError(t, err)
+	require.True(t, app.BankKeeper.GetCoins(ctx, initCreator).IsZero())
+
+	// Add reserve tokens to users
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+	err = addCoinsToUser2(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// User 1 buys 10000 tokens and user 2 buys 5000 tokens at p0=0.01
+	_, err = h(ctx, newValidMsgBuy(10000, 100000))
+	require.NoError(t, err)
+	_, err = h(ctx, types.NewMsgBuy(anotherAddress, sdk.NewInt64Coin(token, 5000),
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000)), false))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Deadline not reached yet, so state is still hatch
+	war := app.WarsKeeper.MustGetWar(ctx, token)
 	require.Equal(t, types.HatchState, war.State)
 
-	// Buy 1 more token, to exceed S0
-	h(ctx, newValidMsgBuy(1, 100000))
+	// Deadline reached without reaching S0 => state is now failed
+	ctx = ctx.WithBlockHeight(5)
 	wars.EndBlocker(ctx, app.WarsKeeper)
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, types.FailedState, war.State)
 
-	// Confirm allowSells==true, state==open
+	// Escrowed funding was returned to the reserve, which now holds the full
+	// contribution of 150 (15000 tokens at p0=0.01) plus the escrowed tx fees
+	// of 1 per buy
+	require.True(t, war.HatchFunding.IsZero())
+	require.Equal(t, sdk.NewInt(152), war.CurrentReserve.AmountOf(reserveToken))
+
+	// War creation deposit was refunded to the creator
+	require.True(t, war.CreationDeposit.IsZero())
+	require.Equal(t, deposit, app.BankKeeper.GetCoins(ctx, initCreator))
+
+	// Cannot buy tokens in failed state
+	_, err = h(ctx, newValidMsgBuy(1, 100000))
+	require.Error(t, err)
+
+	// Users reclaim their full contributions by withdrawing their shares
+	user1Balance := app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(reserveToken)
+	_, err = h(ctx, newValidMsgWithdrawShareFrom(userAddress))
+	require.NoError(t, err)
+	newUser1Balance := app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(reserveToken)
+	require.Equal(t, sdk.NewInt(101), newUser1Balance.Sub(user1Balance))
+
+	user2Balance := app.BankKeeper.GetCoins(ctx, anotherAddress).AmountOf(reserveToken)
+	_, err = h(ctx, newValidMsgWithdrawShareFrom(anotherAddress))
+	require.NoError(t, err)
+	newUser2Balance := app.BankKeeper.GetCoins(ctx, anotherAddress).AmountOf(reserveToken)
+	require.Equal(t, sdk.NewInt(51), newUser2Balance.Sub(user2Balance))
+
+	// All war tokens were burned and the reserve is now empty
 	war = app.WarsKeeper.MustGetWar(ctx, token)
-	require.True(t, war.AllowSells)
-	require.Equal(t, types.OpenState, war.State)
+	require.True(t, war.CurrentSupply.IsZero())
+	require.True(t, war.CurrentReserve.IsZero())
 }
 
-func TestEndBlockerAugmentedFunctionSmallBuys(t *testing.T) {
+func TestBuyingAfterHatchDeadlineFails(t *testing.T) {
 	app, ctx := createTestApp(false)
 	h := wars.NewHandler(app.WarsKeeper)
 
-	// Create war with augmented function type, small params, and zero fees
+	// Create war with augmented function type and hatch deadline at height 5
+	createMsg := newValidMsgCreateAugmentedWar()
+	createMsg.HatchDeadlineHeight = 5
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buys are rejected from the hatch deadline onwards, even though the war
+	// only fails at the end of the block
+	ctx = ctx.WithBlockHeight(5)
+	_, err = h(ctx, newValidMsgBuy(10000, 100000))
+	require.Error(t, err)
+	require.True(t, types.ErrHatchDeadlineAlreadyPassed.Is(err))
+	_, err = h(ctx, newValidMsgBuyWithSpend(1000))
+	require.Error(t, err)
+	require.True(t, types.ErrHatchDeadlineAlreadyPassed.Is(err))
+	_, err = h(ctx, newValidMsgLimitBuy(10000, 100000, 10))
+	require.Error(t, err)
+	require.True(t, types.ErrHatchDeadlineAlreadyPassed.Is(err))
+}
+
+func TestEndBlockerAugmentedFunctionHatchFailsMidBatch(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with augmented function type, hatch deadline at height 5
+	// and 3-block batches
+	createMsg := newValidMsgCreateAugmentedWar()
+	createMsg.HatchDeadlineHeight = 5
+	createMsg.BatchBlocks = sdk.NewUint(3)
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// User buys 10000 tokens before the deadline, in a batch that only ends
+	// after the deadline
+	ctx = ctx.WithBlockHeight(4)
+	_, err = h(ctx, newValidMsgBuy(10000, 100000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, types.HatchState, app.WarsKeeper.MustGetWar(ctx, token).State)
+
+	// Deadline reached mid-batch => war fails and pending buy is refunded
+	ctx = ctx.WithBlockHeight(5)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	war := app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, types.FailedState, war.State)
+	require.True(t, war.CurrentSupply.IsZero())
+	require.Empty(t, app.WarsKeeper.MustGetBatch(ctx, token).Buys)
+	require.Equal(t, sdk.NewInt(1000000),
+		app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(reserveToken))
+	require.Contains(t, ctx.EventManager().Events(), sdk.NewEvent(
+		types.EventTypeOrderCancel,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
+		sdk.NewAttribute(types.AttributeKeyAddress, userAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyCancelReason, types.CancelReasonHatchFailed),
+	))
+}
+
+func TestEndBlockerAugmentedFunctionHatchFailsCancelsLimitOrders(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with augmented function type and hatch deadline at height 5
+	createMsg := newValidMsgCreateAugmentedWar()
+	createMsg.HatchDeadlineHeight = 5
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user, who places a limit buy for 10000 tokens with
+	// max prices of 50 that are not met at p0=0.01 and expire at height 100
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgLimitBuy(10000, 50, 100))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Len(t, app.WarsKeeper.GetOrderBook(ctx, token).Orders, 1)
+
+	// Deadline reached => war fails and the limit buy is cancelled and
+	// refunded before its expiry height
+	ctx = ctx.WithBlockHeight(5)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, types.FailedState, app.WarsKeeper.MustGetWar(ctx, token).State)
+	require.Empty(t, app.WarsKeeper.GetOrderBook(ctx, token).Orders)
+	require.Zero(t, app.WarsKeeper.GetLimitOrderCount(ctx, token))
+	require.Equal(t, sdk.NewInt(1000000),
+		app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(reserveToken))
+	require.Contains(t, ctx.EventManager().Events(), sdk.NewEvent(
+		types.EventTypeOrderCancel,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, types.LimitBuyOrderType),
+		sdk.NewAttribute(types.AttributeKeyOrderID, "0"),
+		sdk.NewAttribute(types.AttributeKeyAddress, userAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyCancelReason, types.CancelReasonHatchFailed),
+	))
+}
+
+func TestEndBlockerAugmentedFunctionHatchFailsWhilePaused(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with augmented function type and hatch deadline at height 5
+	createMsg := newValidMsgCreateAugmentedWar()
+	createMsg.HatchDeadlineHeight = 5
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user, who buys 10000 tokens
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(10000, 100000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// War is paused by the signers
+	_, err = h(ctx, types.NewMsgSetWarPaused(token, true, initCreator, initSigners))
+	require.NoError(t, err)
+
+	// Deadline reached while paused => state is still updated to failed
+	ctx = ctx.WithBlockHeight(5)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	war := app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, types.FailedState, war.State)
+	require.True(t, war.HatchFunding.IsZero())
+	require.Equal(t, sdk.NewInt(101), war.CurrentReserve.AmountOf(reserveToken))
+}
+
+func TestBuyingFromHatchRestrictedAugmentedWar(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create augmented war that only allows user 2 or holders of the
+	// membership token to buy up to 10000 tokens each during the hatch
+	createMsg := newValidMsgCreateAugmentedWar()
+	createMsg.AllowedHatchers = []sdk.AccAddress{anotherAddress}
+	createMsg.HatchMembershipDenom = "membertoken"
+	createMsg.MaxHatchContribution = sdk.NewInt(10000)
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to users
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+	err = addCoinsToUser2(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// User 1 cannot buy since not allowed and not holding membership token
+	cacheCtx, _ := ctx.CacheContext()
+	_, err = h(cacheCtx, newValidMsgBuy(1000, 100000))
+	require.Error(t, err)
+	require.True(t, types.ErrNotAllowedToHatch.Is(err))
+
+	// User 2 can buy since it is an allowed hatcher
+	_, err = h(ctx, types.NewMsgBuy(anotherAddress, sdk.NewInt64Coin(token, 6000),
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000)), false))
+	require.NoError(t, err)
+
+	// User 1 can buy once it holds the membership token
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin("membertoken", 1)})
+	require.Nil(t, err)
+	_, err = h(ctx, newValidMsgBuy(1000, 100000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+
+	// Contributions were recorded for both users
+	require.Equal(t, sdk.NewInt(1000), app.WarsKeeper.GetHatchContribution(ctx, token, userAddress).Amount)
+	require.Equal(t, sdk.NewInt(6000), app.WarsKeeper.GetHatchContribution(ctx, token, anotherAddress).Amount)
+
+	// User 2 cannot exceed max contribution across batches
+	cacheCtx, _ = ctx.CacheContext()
+	_, err = h(cacheCtx, types.NewMsgBuy(anotherAddress, sdk.NewInt64Coin(token, 4001),
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000)), false))
+	require.Error(t, err)
+	require.True(t, types.ErrMaxHatchContributionExceeded.Is(err))
+
+	// User 2 cannot exceed max contribution across buys in the same batch
+	_, err = h(ctx, types.NewMsgBuy(anotherAddress, sdk.NewInt64Coin(token, 3000),
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000)), false))
+	require.NoError(t, err)
+	cacheCtx, _ = ctx.CacheContext()
+	_, err = h(cacheCtx, types.NewMsgBuy(anotherAddress, sdk.NewInt64Coin(token, 1001),
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100000)), false))
+	require.Error(t, err)
+	require.True(t, types.ErrMaxHatchContributionExceeded.Is(err))
+}
+
+func TestEndBlockerAugmentedFunctionHatchVesting(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with augmented function type, 10-block vesting cliff, and
+	// 100-block vesting period
+	createMsg := newValidMsgCreateAugmentedWar()
+	createMsg.HatchVestingCliff = 10
+	createMsg.HatchVestingPeriod = 100
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// User buys S0 tokens during hatch, which moves war to open phase
+	_, err = h(ctx, newValidMsgBuy(50000, 100000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, types.OpenState, app.WarsKeeper.MustGetWar(ctx, token).State)
+
+	// Tokens bought were locked rather than sent to the user
+	require.True(t, app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).IsZero())
+	require.Equal(t, sdk.NewInt(50000), app.WarsKeeper.GetLockedHatchTokens(ctx, token, userAddress))
+
+	// Cannot sell locked tokens
+	cacheCtx, _ := ctx.CacheContext()
+	_, err = h(cacheCtx, newValidMsgSell(1))
+	require.Error(t, err)
+	require.True(t, types.ErrWarTokensLocked.Is(err))
+
+	// Cannot claim before cliff
+	ctx = ctx.WithBlockHeight(9)
+	_, err = h(ctx, newValidMsgClaimVestedFrom(userAddress))
+	require.Error(t, err)
+	require.True(t, types.ErrNoVestedTokensToClaim.Is(err))
+
+	// Half of the tokens are vested after half of the period
+	ctx = ctx.WithBlockHeight(50)
+	_, err = h(ctx, newValidMsgClaimVestedFrom(userAddress))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(25000), app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token))
+
+	// Can sell claimed tokens but not more than those
+	cacheCtx, _ = ctx.CacheContext()
+	_, err = h(cacheCtx, newValidMsgSell(25001))
+	require.Error(t, err)
+	require.True(t, types.ErrWarTokensLocked.Is(err))
+	_, err = h(ctx, newValidMsgSell(10000))
+	require.NoError(t, err)
+
+	// All of the tokens are vested after the period
+	ctx = ctx.WithBlockHeight(100)
+	_, err = h(ctx, newValidMsgClaimVestedFrom(userAddress))
+	require.NoError(t, err)
+	require.True(t, app.WarsKeeper.GetLockedHatchTokens(ctx, token, userAddress).IsZero())
+	require.Equal(t, sdk.NewInt(40000), app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token))
+}
+
+func TestEndBlockerAugmentedFunctionHatchFailsReleasesVesting(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with augmented function type, hatch deadline at height 5,
+	// and 100-block vesting period
+	createMsg := newValidMsgCreateAugmentedWar()
+	createMsg.HatchDeadlineHeight = 5
+	createMsg.HatchVestingPeriod = 100
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// User buys 10000 tokens, which are locked
+	_, err = h(ctx, newValidMsgBuy(10000, 100000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.True(t, app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token).IsZero())
+
+	// Deadline reached without reaching S0 => locked tokens are released
+	ctx = ctx.WithBlockHeight(5)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, types.FailedState, app.WarsKeeper.MustGetWar(ctx, token).State)
+	require.Equal(t, sdk.NewInt(10000), app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(token))
+	require.True(t, app.WarsKeeper.GetLockedHatchTokens(ctx, token, userAddress).IsZero())
+
+	// User reclaims full contribution of 100 (10000 tokens at p0=0.01) plus
+	// the escrowed tx fee of 1
+	userBalance := app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(reserveToken)
+	_, err = h(ctx, newValidMsgWithdrawShareFrom(userAddress))
+	require.NoError(t, err)
+	newUserBalance := app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(reserveToken)
+	require.Equal(t, sdk.NewInt(101), newUserBalance.Sub(userBalance))
+}
+
+func TestSpendFromFundingPool(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with augmented function type and a funding pool that allows
+	// anotherAddress to spend 50 reserve tokens every 10 blocks
+	createMsg := newValidMsgCreateAugmentedWar()
+	createMsg.FundingPoolSpenders = []sdk.AccAddress{anotherAddress}
+	createMsg.FundingPoolSpendLimit = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50))
+	createMsg.FundingPoolEpochBlocks = 10
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy 50000 tokens to reach S0 => state is now open
+	_, err = h(ctx, newValidMsgBuy(50000, 100000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, types.OpenState, app.WarsKeeper.MustGetWar(ctx, token).State)
+
+	// Funding of 200 (theta=0.4 of 500 raised) and tx fees went to the
+	// funding pool instead of the fee address
+	fundingPool := app.WarsKeeper.GetFundingPool(ctx, token)
+	require.True(t, fundingPool.Balance.AmountOf(reserveToken).GT(sdk.NewInt(200)))
+	require.True(t, app.BankKeeper.GetCoins(ctx, createMsg.FeeAddress).IsZero())
+
+	// User is not a spender so cannot spend from the funding pool
+	spendMsg := types.NewMsgSpendFromFundingPool(userAddress, token, userAddress,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50)))
+	_, err = h(ctx, spendMsg)
+	require.Error(t, err)
+	require.True(t, types.ErrNotFundingPoolSpender.Is(err))
+
+	// Spender cannot spend more than the limit
+	spendMsg = types.NewMsgSpendFromFundingPool(anotherAddress, token, userAddress,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 51)))
+	_, err = h(ctx, spendMsg)
+	require.Error(t, err)
+	require.True(t, types.ErrFundingPoolSpendLimitExceeded.Is(err))
+
+	// Spender can spend up to the limit, to any recipient
+	userBalance := app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(reserveToken)
+	spendMsg = types.NewMsgSpendFromFundingPool(anotherAddress, token, userAddress,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50)))
+	_, err = h(ctx, spendMsg)
+	require.NoError(t, err)
+	newUserBalance := app.BankKeeper.GetCoins(ctx, userAddress).AmountOf(reserveToken)
+	require.Equal(t, sdk.NewInt(50), newUserBalance.Sub(userBalance))
+	require.True(t, app.WarsKeeper.GetFundingPoolAllowance(ctx, token).IsZero())
+}
+
+func TestGovernanceProposals(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with augmented function type and governance with a quorum
+	// of 50%, a threshold of 50%, and a voting period of 5 blocks
 	createMsg := newValidMsgCreateAugmentedWar()
+	createMsg.GovernanceQuorumPercentage = sdk.NewDec(50)
+	createMsg.GovernanceThresholdPercentage = sdk.NewDec(50)
+	createMsg.GovernanceVotingPeriod = 5
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
+
+	// Add reserve tokens to user
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	require.Nil(t, err)
+
+	// Buy 50000 tokens to reach S0 => state is now open
+	_, err = h(ctx, newValidMsgBuy(50000, 100000))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Equal(t, types.OpenState, app.WarsKeeper.MustGetWar(ctx, token).State)
+
+	// Funding went to the funding pool since the war has governance
+	fundingPool := app.WarsKeeper.GetFundingPool(ctx, token)
+	require.True(t, fundingPool.Balance.AmountOf(reserveToken).GT(sdk.NewInt(200)))
+
+	// Another address does not hold war tokens so cannot submit a proposal
+	spend := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 200))
+	_, err = h(ctx, types.NewMsgSubmitSpendProposal(anotherAddress, token, anotherAddress, spend))
+	require.Error(t, err)
+	require.True(t, types.ErrNoVotingPower.Is(err))
+
+	// User submits proposal to spend 200 from the funding pool
+	_, err = h(ctx, types.NewMsgSubmitSpendProposal(userAddress, token, anotherAddress, spend))
+	require.NoError(t, err)
+	proposals := app.WarsKeeper.GetProposals(ctx, token).Proposals
+	require.Len(t, proposals, 1)
+	require.Equal(t, int64(5), proposals[0].VotingEndHeight)
+
+	// User cannot submit another proposal once the war has the max proposals
+	params := app.WarsKeeper.GetParams(ctx)
+	params.MaxProposalsPerWar = 1
+	app.WarsKeeper.SetParams(ctx, params)
+	_, err = h(ctx, types.NewMsgSubmitSpendProposal(userAddress, token, anotherAddress, spend))
+	require.Error(t, err)
+	require.True(t, types.ErrMaxProposalsReached.Is(err))
+
+	// Another address cannot vote, but user can vote (once)
+	_, err = h(ctx, types.NewMsgVote(anotherAddress, token, 0, types.YesVoteOption))
+	require.Error(t, err)
+	require.True(t, types.ErrNoVotingPower.Is(err))
+	_, err = h(ctx, types.NewMsgVote(userAddress, token, 0, types.YesVoteOption))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(50000), app.WarsKeeper.GetVotesByProposal(ctx, token, 0)[0].Power)
+	_, err = h(ctx, types.NewMsgVote(userAddress, token, 0, types.NoVoteOption))
+	require.Error(t, err)
+	require.True(t, types.ErrAlreadyVoted.Is(err))
+
+	// Proposal is not executed before the voting period ends
+	ctx = ctx.WithBlockHeight(4)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Len(t, app.WarsKeeper.GetProposals(ctx, token).Proposals, 1)
+	require.True(t, app.BankKeeper.GetCoins(ctx, anotherAddress).AmountOf(reserveToken).IsZero())
+
+	// Proposal is executed at the end of the voting period
+	ctx = ctx.WithBlockHeight(5)
+	wars.EndBlocker(ctx, app.WarsKeeper)
+	require.Empty(t, app.WarsKeeper.GetProposals(ctx, token).Proposals)
+	require.Equal(t, spend, app.BankKeeper.GetCoins(ctx, anotherAddress))
+	require.Equal(t, fundingPool.Balance.Sub(spend),
+		app.WarsKeeper.GetFundingPool(ctx, token).Balance)
+
+	// Cannot vote on proposal once it has been tallied
+	_, err = h(ctx, types.NewMsgVote(userAddress, token, 0, types.YesVoteOption))
+	require.Error(t, err)
+	require.True(t, types.ErrProposalDoesNotExist.Is(err))
+}
+
+func TestEndBlockerPiecewiseLinearFunction(t *testing.T) {
+	app, ctx := createTestApp(false)
+	h := wars.NewHandler(app.WarsKeeper)
+
+	// Create war with a price plateau at 1 up to supply 100, which then
+	// increases linearly to 3 at supply 200, and with zero fees
+	createMsg := newValidMsgCreateWar()
+	createMsg.FunctionType = types.PiecewiseLinearFunction
 	createMsg.FunctionParameters = types.FunctionParams{
-		types.NewFunctionParam("d0", sdk.MustNewDecFromStr("10.0")),
-		types.NewFunctionParam("p0", sdk.MustNewDecFromStr("1.0")),
-		types.NewFunctionParam("theta", sdk.MustNewDecFromStr("0.9")),
-		types.NewFunctionParam("kappa", sdk.MustNewDecFromStr("3.0"))}
+		types.NewFunctionParam("x0", sdk.ZeroDec()),
+		types.NewFunctionParam("p0", sdk.OneDec()),
+		types.NewFunctionParam("x1", sdk.NewDec(100)),
+		types.NewFunctionParam("p1", sdk.OneDec()),
+		types.NewFunctionParam("x2", sdk.NewDec(200)),
+		types.NewFunctionParam("p2", sdk.NewDec(3))}
 	createMsg.TxFeePercentage = sdk.ZeroDec()
 	createMsg.ExitFeePercentage = sdk.ZeroDec()
-	h(ctx, createMsg)
+	_, err := h(ctx, createMsg)
+	require.NoError(t, err)
 
 	// Add reserve tokens to user
-	err := addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000000)})
+	err = addCoinsToUser(app, ctx, sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)})
 	require.Nil(t, err)
 
-	// Get war to confirm allowSells==false, S0==10, R0==1 state==hatch
-	war := app.WarsKeeper.MustGetWar(ctx, token)
-	require.False(t, war.AllowSells)
-	require.Equal(t, sdk.NewDec(10), war.FunctionParameters.AsMap()["S0"])
-	require.Equal(t, sdk.NewDec(1), war.FunctionParameters.AsMap()["R0"])
-	require.Equal(t, types.HatchState, war.State)
-
-	// Perform 10 buys of 1 token each
-	for i := 0; i < 10; i++ {
-		_, err := h(ctx, newValidMsgBuy(1, 1))
-		require.NoError(t, err)
-	}
+	// Buy 200 tokens, for which the price is 100*1 + 100*(1+3)/2 = 300
+	_, err = h(ctx, newValidMsgBuy(200, 1000))
+	require.NoError(t, err)
 	wars.EndBlocker(ctx, app.WarsKeeper)
 
-	// Confirm allowSells==true, state==open
-	war = app.WarsKeeper.MustGetWar(ctx, token)
-	require.True(t, war.AllowSells)
-	require.Equal(t, types.OpenState, war.State)
+	reserveBalance := app.WarsKeeper.GetReserveBalances(ctx, token)
+	userBalance := app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(300), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(700), userBalance.AmountOf(reserveToken))
+	_, broken := wars.AllInvariants(app.WarsKeeper)(ctx)
+	require.False(t, broken)
 
-	// Confirm reserve balance is R0 [i.e. d0*(1-theta)] = 1
-	require.Equal(t, int64(1), war.CurrentReserve[0].Amount.Int64())
+	// Sell 100 tokens, for which the return is 100*(3+1)/2 = 200
+	_, err = h(ctx, newValidMsgSell(100))
+	require.NoError(t, err)
+	wars.EndBlocker(ctx, app.WarsKeeper)
 
-	// Confirm fee address balance is d0*theta = 9
-	feeAddressBalance := app.BankKeeper.GetCoins(
-		ctx, war.FeeAddress).AmountOf(reserveToken).Int64()
-	require.Equal(t, int64(9), feeAddressBalance)
+	reserveBalance = app.WarsKeeper.GetReserveBalances(ctx, token)
+	userBalance = app.WarsKeeper.BankKeeper.GetCoins(ctx, userAddress)
+	require.Equal(t, sdk.NewInt(100), reserveBalance.AmountOf(reserveToken))
+	require.Equal(t, sdk.NewInt(900), userBalance.AmountOf(reserveToken))
+	_, broken = wars.AllInvariants(app.WarsKeeper)(ctx)
+	require.False(t, broken)
 }
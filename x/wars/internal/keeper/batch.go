@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"errors"
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -48,7 +49,19 @@ func (k Keeper) SetBatch(ctx sdk.Context, token string, batch types.Batch) {
 	store.Set(types.GetBatchKey(token), k.cdc.MustMarshalBinaryBare(batch))
 }
 
+// SetLastBatch sets the batch as the war's last batch. The war's current
+// prices, i.e. its prices once the batch's orders were performed, are stored
+// as the batch's closing prices.
 func (k Keeper) SetLastBatch(ctx sdk.Context, token string, batch types.Batch) {
+	batch.ClosingPrices = nil
+	war, found := k.GetWar(ctx, token)
+	if found && !war.CurrentSupply.IsZero() {
+		closingPrices, err := war.GetCurrentPricesPT(k.GetReserveBalances(ctx, token))
+		if err == nil {
+			batch.ClosingPrices = closingPrices
+		}
+	}
+
 	store := ctx.KVStore(k.storeKey)
 	store.Set(types.GetLastBatchKey(token), k.cdc.MustMarshalBinaryBare(batch))
 }
@@ -86,6 +99,15 @@ func (k Keeper) AddSwapOrder(ctx sdk.Context, token string, so types.SwapOrder)
 	logger.Info(fmt.Sprintf("added swap order for %s to %s from %s", so.Amount.String(), so.ToToken, so.Address.String()))
 }
 
+func (k Keeper) AddRoutedSwapOrder(ctx sdk.Context, token string, so types.RoutedSwapOrder) {
+	batch := k.MustGetBatch(ctx, token)
+	batch.RoutedSwaps = append(batch.RoutedSwaps, so)
+	k.SetBatch(ctx, token, batch)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added routed swap order for %s via %s from %s", so.Amount.String(), so.Hops, so.Address.String()))
+}
+
 func (k Keeper) GetBatchBuySellPrices(ctx sdk.Context, token string, batch types.Batch) (buyPricesPT, sellPricesPT sdk.DecCoins, err error) {
 	war := k.MustGetWar(ctx, token)
 
@@ -161,6 +183,12 @@ func (k Keeper) GetUpdatedBatchPricesAfterBuy(ctx sdk.Context, token string, bo
 		if adjustedSupplyWithBuy.Amount.ToDec().GT(args["S0"].Ceil()) {
 			return nil, nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "Buy exceeds initial supply S0. Consider buying less tokens.")
 		}
+
+		// Check that buyer is allowed to buy the amount during hatch phase
+		err = k.CheckHatchRestrictions(ctx, token, bo)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// Simulate buy by bumping up total buy amount
@@ -178,6 +206,62 @@ func (k Keeper) GetUpdatedBatchPricesAfterBuy(ctx sdk.Context, token string, bo
 	return buyPrices, sellPrices, nil
 }
 
+// GetMaxBuyAmountForSpend returns the largest whole amount of war tokens that
+// can be bought at the batch buy price (including fees) with the reserve
+// tokens to be spent. Since the batch buy price never decreases as the buy
+// amount increases, the amount is found by searching over the buy amount,
+// using the same checks that a buy order of that amount by the buyer would be
+// subject to.
+func (k Keeper) GetMaxBuyAmountForSpend(ctx sdk.Context, token string, buyer sdk.AccAddress, spend sdk.Coins) (sdk.Coin, error) {
+	war := k.MustGetWar(ctx, token)
+
+	// Upper bound is the lesser of the max possible increase in supply and
+	// the order quantity limit for the war token (if any)
+	adjustedSupply := k.GetSupplyAdjustedForBuy(ctx, token)
+	maxAmount := war.MaxSupply.Amount.Sub(adjustedSupply.Amount)
+	if limit := war.OrderQuantityLimits.AmountOf(token); limit.IsPositive() {
+		maxAmount = sdk.MinInt(maxAmount, limit)
+	}
+	if !maxAmount.IsPositive() {
+		return sdk.Coin{}, sdkerrors.Wrap(types.ErrCannotMintMoreThanMaxSupply, war.MaxSupply.String())
+	}
+
+	canBuy := func(amount sdk.Int) bool {
+		bo := types.NewBuyOrder(buyer, sdk.NewCoin(token, amount), spend, false)
+		_, _, err := k.GetUpdatedBatchPricesAfterBuy(ctx, token, bo)
+		return err == nil
+	}
+
+	// Keep doubling the amount until it cannot be bought or until the upper
+	// bound is reached, so that prices are never calculated for amounts much
+	// larger than what can actually be bought with the spend
+	low, high := sdk.ZeroInt(), sdk.OneInt()
+	for high.LT(maxAmount) && canBuy(high) {
+		low = high
+		high = high.MulRaw(2)
+	}
+	high = sdk.MinInt(high, maxAmount)
+	if canBuy(high) {
+		return sdk.NewCoin(token, high), nil
+	}
+
+	// Binary search between low (can be bought) and high (cannot be bought)
+	for high.Sub(low).GT(sdk.OneInt()) {
+		mid := low.Add(high).QuoRaw(2)
+		if canBuy(mid) {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	if low.IsZero() {
+		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrInsufficientReserveToBuy,
+			"%s is not enough to buy any %s", spend, token)
+	}
+	return sdk.NewCoin(token, low), nil
+}
+
 func (k Keeper) GetUpdatedBatchPricesAfterSell(ctx sdk.Context, token string, so types.SellOrder) (buyPrices, sellPrices sdk.DecCoins, err error) {
 	batch := k.MustGetBatch(ctx, token)
 
@@ -194,6 +278,11 @@ func (k Keeper) GetUpdatedBatchPricesAfterSell(ctx sdk.Context, token string, so
 		return nil, nil, err
 	}
 
+	err = k.CheckIfSellOrderFulfillableAtPrice(ctx, token, so, sellPrices)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return buyPrices, sellPrices, nil
 }
 
@@ -208,9 +297,15 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 		return err
 	}
 
-	// Send war tokens bought to buyer
-	err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-		types.WarsMintBurnAccount, bo.Address, sdk.Coins{bo.Amount})
+	// Send war tokens bought to buyer, unless bought during the hatch phase
+	// of a war with a vesting schedule, in which case they are locked
+	if war.State == types.HatchState && war.HasHatchVesting() {
+		err = k.LockHatchTokensFromModule(ctx, war.Token,
+			types.WarsMintBurnAccount, bo.Address, bo.Amount)
+	} else {
+		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.WarsMintBurnAccount, bo.Address, sdk.Coins{bo.Amount})
+	}
 	if err != nil {
 		return err
 	}
@@ -226,8 +321,9 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 
 	// Add new reserve to reserve (reservePricesRounded should never be zero)
 	// TODO: investigate possibility of zero reservePricesRounded
-	if war.FunctionType == types.AugmentedFunction &&
-		war.State == types.HatchState {
+	inHatch := war.FunctionType == types.AugmentedFunction &&
+		war.State == types.HatchState
+	if inHatch {
 		args := war.FunctionParameters.AsMap()
 		theta := args["theta"]
 
@@ -267,9 +363,13 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 			return err
 		}
 
-		// Send reserve tokens to funding pool
-		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-			types.BatchesIntermediaryAccount, war.FeeAddress, coinsToFundingPool)
+		// Keep track of the war tokens bought by the buyer during hatch phase
+		k.AddHatchContribution(ctx, war.Token, bo.Address, bo.Amount.Amount)
+
+		// Hold funding pool tokens in escrow until the hatch succeeds, so
+		// that they can be reclaimed by hatchers if the hatch fails
+		err = k.DepositHatchFundingFromModule(ctx, war.Token,
+			types.BatchesIntermediaryAccount, coinsToFundingPool)
 		if err != nil {
 			return err
 		}
@@ -286,10 +386,17 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 		}
 	}
 
-	// Add charged fee to fee address
+	// Add charged fee to funding pool or fee address. During the hatch phase,
+	// the fee is held in escrow together with the funding, so that it is
+	// also reclaimed by hatchers if the hatch fails
 	if !txFees.IsZero() {
-		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-			types.BatchesIntermediaryAccount, war.FeeAddress, txFees)
+		if inHatch {
+			err = k.DepositHatchFundingFromModule(ctx, war.Token,
+				types.BatchesIntermediaryAccount, txFees)
+		} else {
+			err = k.PayFundingFromModule(ctx, war.Token,
+				types.BatchesIntermediaryAccount, txFees)
+		}
 		if err != nil {
 			return err
 		}
@@ -351,9 +458,9 @@ func (k Keeper) PerformSellAtPrice(ctx sdk.Context, token string, so types.SellO
 		return err
 	}
 
-	// Send total fee to fee address
+	// Send total fee to funding pool or fee address
 	if !totalFees.IsZero() {
-		err = k.WithdrawReserve(ctx, war.Token, war.FeeAddress, totalFees)
+		err = k.PayFundingFromReserve(ctx, war.Token, totalFees)
 		if err != nil {
 			return err
 		}
@@ -382,64 +489,6 @@ func (k Keeper) PerformSellAtPrice(ctx sdk.Context, token string, so types.SellO
 	return nil
 }
 
-func (k Keeper) PerformSwap(ctx sdk.Context, token string, so types.SwapOrder) (err error, ok bool) {
-	war := k.MustGetWar(ctx, token)
-
-	// WARNING: do not return ok=true if money has already been transferred when error occurs
-
-	// Get return for swap
-	reserveBalances := k.GetReserveBalances(ctx, token)
-	reserveReturns, txFee, err := war.GetReturnsForSwap(so.Amount, so.ToToken, reserveBalances)
-	if err != nil {
-		return err, true
-	}
-	adjustedInput := so.Amount.Sub(txFee) // same as during GetReturnsForSwap
-
-	// Check if new rates violate sanity rate
-	newReserveBalances := reserveBalances.Add(adjustedInput).Sub(reserveReturns)
-	if war.ReservesViolateSanityRate(newReserveBalances) {
-		return sdkerrors.Wrap(types.ErrValuesViolateSanityRate, newReserveBalances.String()), true
-	}
-
-	// Give resultant tokens to swapper (reserveReturns should never be zero)
-	err = k.WithdrawReserve(ctx, war.Token, so.Address, reserveReturns)
-	if err != nil {
-		return err, false
-	}
-
-	// Add fee-reduced coins to be swapped to reserve (adjustedInput should never be zero)
-	err = k.DepositReserveFromModule(
-		ctx, war.Token, types.BatchesIntermediaryAccount, sdk.Coins{adjustedInput})
-	if err != nil {
-		return err, false
-	}
-
-	// Add fee (taken from swapper) to fee address
-	if !txFee.IsZero() {
-		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-			types.BatchesIntermediaryAccount, war.FeeAddress, sdk.Coins{txFee})
-		if err != nil {
-			return err, false
-		}
-	}
-
-	logger := k.Logger(ctx)
-	logger.Info(fmt.Sprintf("performed swap order for %s to %s from %s",
-		so.Amount.String(), reserveReturns, so.Address.String()))
-
-	ctx.EventManager().EmitEvent(sdk.NewEvent(
-		types.EventTypeOrderFulfill,
-		sdk.NewAttribute(types.AttributeKeyWar, war.Token),
-		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSwapOrder),
-		sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
-		sdk.NewAttribute(types.AttributeKeyTokensSwapped, adjustedInput.String()),
-		sdk.NewAttribute(types.AttributeKeyChargedFees, txFee.String()),
-		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, reserveReturns.String()),
-	))
-
-	return nil, true
-}
-
 func (k Keeper) PerformBuyOrders(ctx sdk.Context, token string) {
 	batch := k.MustGetBatch(ctx, token)
 
@@ -478,43 +527,537 @@ func (k Keeper) PerformSellOrders(ctx sdk.Context, token string) {
 	k.SetBatch(ctx, token, batch)
 }
 
+// swapSide holds the swaps from one reserve token to another that are
+// cleared together at a single price
+type swapSide struct {
+	from, to string
+	indices  []int      // indices of the swaps in the batch
+	inputs   []sdk.Int  // fee-reduced input of each swap
+	fees     []sdk.Coin // fee charged to each swap
+	minOuts  []sdk.Int  // min output of each swap
+	returns  []sdk.Int  // return of each swap at the clearing price
+	total    sdk.Int    // total fee-reduced input
+	price    sdk.Dec    // clearing price, in to tokens per from token
+}
+
+func newSwapSide(from, to string) *swapSide {
+	return &swapSide{from: from, to: to, total: sdk.ZeroInt()}
+}
+
+func (s *swapSide) totalReturns() sdk.Int {
+	total := sdk.ZeroInt()
+	for _, r := range s.returns {
+		total = total.Add(r)
+	}
+	return total
+}
+
+// setReturnsProRata gives each swap its share of the total return, so that
+// every swap in the side gets the same price
+func (s *swapSide) setReturnsProRata(totalReturn sdk.Int) {
+	s.returns = make([]sdk.Int, len(s.inputs))
+	for i, in := range s.inputs {
+		s.returns[i] = in.Mul(totalReturn).Quo(s.total)
+	}
+	s.price = totalReturn.ToDec().Quo(s.total.ToDec())
+}
+
+// setReturnsAtPrice gives each swap its input multiplied by the price
+func (s *swapSide) setReturnsAtPrice(price sdk.Dec) {
+	s.returns = make([]sdk.Int, len(s.inputs))
+	for i, in := range s.inputs {
+		s.returns[i] = in.ToDec().Mul(price).TruncateInt()
+	}
+	s.price = price
+}
+
+// getSwapReturnsAlongCurve returns the returns for swapping the total input of
+// the side against the reserves, ignoring fees, which were already deducted
+func getSwapReturnsAlongCurve(war types.War, from sdk.Coin, toToken string, reserveBalances sdk.Coins) (sdk.Int, error) {
+	war.TxFeePercentage = sdk.ZeroDec()
+	returns, _, err := war.GetReturnsForSwap(from, toToken, reserveBalances)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	return returns.AmountOf(toToken), nil
+}
+
+// getSwapSidesClearing calculates the returns of the swaps in the two sides
+// (which are in opposite directions) when cleared at a uniform price per side.
+// If the war's function type gives a spot price, the opposing swaps are first
+// matched against each other at the spot price, and only the net amount is
+// swapped along the curve, the returns of which are shared by the swaps in the
+// net side. Otherwise, each side is swapped along the curve in turn. Any swaps
+// that cannot be performed are returned with the reason, by batch index.
+func getSwapSidesClearing(war types.War, sides [2]*swapSide, reserveBalances sdk.Coins) (failed map[int]error) {
+	failed = make(map[int]error)
+	failSide := func(s *swapSide, err error) {
+		for _, i := range s.indices {
+			failed[i] = err
+		}
+	}
+
+	// Swaps that are fully used up by the fee cannot give any return
+	for _, s := range sides {
+		for j, in := range s.inputs {
+			if in.IsZero() {
+				failed[s.indices[j]] = sdkerrors.Wrapf(
+					types.ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", s.from, s.to)
+			}
+		}
+	}
+	if len(failed) != 0 {
+		return failed
+	}
+
+	a, b := sides[0], sides[1]
+	price, err := war.GetSpotPrice(a.from, a.to, reserveBalances)
+	curveSides := []*swapSide{a, b}
+	if err == nil && a.total.IsPositive() && b.total.IsPositive() && price.IsPositive() {
+		// The net side is the one with the greater value at the spot price,
+		// and the other side is fully matched against it at the spot price
+		net, other, otherPrice := a, b, sdk.OneDec().Quo(price)
+		if a.total.ToDec().Mul(price).LT(b.total.ToDec()) {
+			net, other, otherPrice = b, a, price
+		}
+		other.setReturnsAtPrice(otherPrice)
+		curveSides = []*swapSide{net}
+
+		// Swap the remainder of the net side's input along the curve
+		matched := other.total.ToDec().Mul(otherPrice).Ceil().TruncateInt()
+		netInput := net.total.Sub(matched)
+		curveReturn := sdk.ZeroInt()
+		if netInput.IsPositive() {
+			curveReturn, err = getSwapReturnsAlongCurve(
+				war, sdk.NewCoin(net.from, netInput), net.to, reserveBalances)
+			if errors.Is(err, types.ErrSwapAmountTooSmallToGiveAnyReturn) {
+				curveReturn = sdk.ZeroInt()
+			} else if err != nil {
+				failSide(net, err)
+				return failed
+			}
+		}
+		net.setReturnsProRata(other.total.Add(curveReturn))
+	} else {
+		// Swap each side along the curve in turn
+		balances := reserveBalances
+		for _, s := range curveSides {
+			if s.total.IsZero() {
+				continue
+			}
+			curveReturn, err := getSwapReturnsAlongCurve(
+				war, sdk.NewCoin(s.from, s.total), s.to, balances)
+			if err != nil {
+				failSide(s, err)
+				return failed
+			}
+			s.setReturnsProRata(curveReturn)
+			balances = balances.Add(sdk.NewCoin(s.from, s.total)).Sub(
+				sdk.Coins{sdk.NewCoin(s.to, curveReturn)})
+		}
+	}
+
+	// Check that each swap gives a return and reaches its min output
+	newReserveBalances := reserveBalances
+	for _, s := range sides {
+		for j, i := range s.indices {
+			if s.returns[j].IsZero() {
+				failed[i] = sdkerrors.Wrapf(
+					types.ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", s.from, s.to)
+			} else if s.returns[j].LT(s.minOuts[j]) {
+				failed[i] = sdkerrors.Wrapf(types.ErrMinReturnsNotReached,
+					"Actual output %s is less than min output %s",
+					sdk.NewCoin(s.to, s.returns[j]), sdk.NewCoin(s.to, s.minOuts[j]))
+			}
+		}
+		if s.total.IsPositive() {
+			newReserveBalances = newReserveBalances.Add(sdk.NewCoin(s.from, s.total))
+		}
+	}
+	for _, s := range sides {
+		if returns := s.totalReturns(); returns.IsPositive() {
+			newReserveBalances = newReserveBalances.Sub(sdk.Coins{sdk.NewCoin(s.to, returns)})
+		}
+	}
+
+	// Check if new rates violate sanity rate, in which case the swaps that
+	// moved the rate along the curve are cancelled
+	if war.ReservesViolateSanityRate(newReserveBalances) {
+		for _, s := range curveSides {
+			failSide(s, sdkerrors.Wrap(types.ErrValuesViolateSanityRate, newReserveBalances.String()))
+		}
+	}
+	return failed
+}
+
+// cancelSwapOrder cancels the swap at index i in the batch's swaps and returns
+// the from amount to the swapper
+func (k Keeper) cancelSwapOrder(ctx sdk.Context, token string, swaps []types.SwapOrder, i int, reason error) {
+	logger := k.Logger(ctx)
+	so := swaps[i]
+
+	swaps[i].Cancelled = true
+	swaps[i].CancelReason = reason.Error()
+
+	logger.Info(fmt.Sprintf("cancelled swap order for %s to %s from %s", so.Amount.String(), so.ToToken, so.Address.String()))
+	logger.Debug(fmt.Sprintf("cancellation reason: %s", reason.Error()))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderCancel,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSwapOrder),
+		sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+		sdk.NewAttribute(types.AttributeKeyCancelReason, swaps[i].CancelReason),
+	))
+
+	// Return from amount to swapper
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+		types.BatchesIntermediaryAccount, so.Address, sdk.Coins{so.Amount})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// PerformSwapsBetween clears all of the uncancelled swaps between two reserve
+// tokens at a uniform price per direction, cancelling any swaps that cannot be
+// performed at that price and clearing the rest again until none are left to
+// cancel. This means that the price received by a swap does not depend on its
+// position in the batch, which prevents swaps from being front-run.
+func (k Keeper) PerformSwapsBetween(ctx sdk.Context, token string, swaps []types.SwapOrder, token1, token2 string) {
+	logger := k.Logger(ctx)
+	war := k.MustGetWar(ctx, token)
+
+	var sides [2]*swapSide
+	for {
+		// Group the uncancelled swaps by direction
+		sides = [2]*swapSide{newSwapSide(token1, token2), newSwapSide(token2, token1)}
+		for i, so := range swaps {
+			for _, s := range sides {
+				if !so.IsCancelled() && so.Amount.Denom == s.from && so.ToToken == s.to {
+					txFee := war.GetTxFee(sdk.NewDecCoinFromCoin(so.Amount))
+					input := so.Amount.Amount.Sub(txFee.Amount)
+					s.indices = append(s.indices, i)
+					s.inputs = append(s.inputs, input)
+					s.fees = append(s.fees, txFee)
+					s.minOuts = append(s.minOuts, so.MinOutput.Amount)
+					s.total = s.total.Add(input)
+				}
+			}
+		}
+		if len(sides[0].indices) == 0 && len(sides[1].indices) == 0 {
+			return
+		}
+
+		// Cancel any swaps that cannot be performed and clear the rest again
+		reserveBalances := k.GetReserveBalances(ctx, token)
+		failed := getSwapSidesClearing(war, sides, reserveBalances)
+		if len(failed) == 0 {
+			break
+		}
+		for _, s := range sides {
+			for _, i := range s.indices {
+				if err, ok := failed[i]; ok {
+					k.cancelSwapOrder(ctx, token, swaps, i, err)
+				}
+			}
+		}
+	}
+
+	// Add fee-reduced coins to be swapped to reserve and fees (taken from
+	// swappers) to funding pool or fee address
+	for _, s := range sides {
+		if s.total.IsZero() {
+			continue
+		}
+		err := k.DepositReserveFromModule(ctx, war.Token,
+			types.BatchesIntermediaryAccount, sdk.Coins{sdk.NewCoin(s.from, s.total)})
+		if err != nil {
+			panic(err)
+		}
+
+		totalFees := sdk.NewCoins()
+		for _, fee := range s.fees {
+			totalFees = totalFees.Add(fee)
+		}
+		if !totalFees.IsZero() {
+			err = k.PayFundingFromModule(ctx, war.Token,
+				types.BatchesIntermediaryAccount, totalFees)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	// Give resultant tokens to swappers
+	for _, s := range sides {
+		if s.total.IsZero() {
+			continue
+		}
+		for j, i := range s.indices {
+			so := swaps[i]
+			adjustedInput := sdk.NewCoin(s.from, s.inputs[j])
+			reserveReturns := sdk.Coins{sdk.NewCoin(s.to, s.returns[j])}
+
+			err := k.WithdrawReserve(ctx, war.Token, so.Address, reserveReturns)
+			if err != nil {
+				panic(err)
+			}
+
+			logger.Info(fmt.Sprintf("performed swap order for %s to %s from %s",
+				so.Amount.String(), reserveReturns, so.Address.String()))
+
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeOrderFulfill,
+				sdk.NewAttribute(types.AttributeKeyWar, war.Token),
+				sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSwapOrder),
+				sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+				sdk.NewAttribute(types.AttributeKeyTokensSwapped, adjustedInput.String()),
+				sdk.NewAttribute(types.AttributeKeyChargedFees, s.fees[j].String()),
+				sdk.NewAttribute(types.AttributeKeyReturnedToAddress, reserveReturns.String()),
+			))
+		}
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeSwapClearing,
+			sdk.NewAttribute(types.AttributeKeyWar, war.Token),
+			sdk.NewAttribute(types.AttributeKeySwapFromToken, s.from),
+			sdk.NewAttribute(types.AttributeKeySwapToToken, s.to),
+			sdk.NewAttribute(types.AttributeKeyTokensSwapped, sdk.NewCoin(s.from, s.total).String()),
+			sdk.NewAttribute(types.AttributeKeyClearingPrice, s.price.String()),
+		))
+	}
+}
+
 func (k Keeper) PerformSwapOrders(ctx sdk.Context, token string) {
-	logger := ctx.Logger()
 	batch := k.MustGetBatch(ctx, token)
 
-	// Perform swaps
-	// TODO: implement swaps front-running prevention
-	for i, so := range batch.Swaps {
-		if !so.IsCancelled() {
-			err, ok := k.PerformSwap(ctx, token, so)
-			if err != nil {
-				if ok {
-					batch.Swaps[i].Cancelled = true
-					batch.Swaps[i].CancelReason = err.Error()
+	// Perform the swaps between each pair of reserve tokens together
+	for _, pair := range batch.GetSwapPairs() {
+		k.PerformSwapsBetween(ctx, token, batch.Swaps, pair[0], pair[1])
+	}
+
+	// Update batch with any new cancellations
+	k.SetBatch(ctx, token, batch)
+}
 
-					logger.Info(fmt.Sprintf("cancelled swap order for %s to %s from %s", so.Amount.String(), so.ToToken, so.Address.String()))
-					logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
+// CheckSwapHop checks that a swap from fromToken can be performed using the
+// reserves of the war given by the hop
+func (k Keeper) CheckSwapHop(ctx sdk.Context, fromToken string, hop types.SwapHop) (types.War, error) {
+	war, found := k.GetWar(ctx, hop.WarToken)
+	if !found {
+		return types.War{}, sdkerrors.Wrap(types.ErrWarDoesNotExist, hop.WarToken)
+	}
 
-					ctx.EventManager().EmitEvent(sdk.NewEvent(
-						types.EventTypeOrderCancel,
-						sdk.NewAttribute(types.AttributeKeyWar, token),
-						sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSwapOrder),
-						sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
-						sdk.NewAttribute(types.AttributeKeyCancelReason, batch.Swaps[i].CancelReason),
-					))
+	// Confirm that war is not paused, function type is a swapper function
+	// and state is OPEN
+	if war.Paused {
+		return types.War{}, sdkerrors.Wrap(types.ErrWarPaused, war.Token)
+	} else if !war.IsSwapper() {
+		return types.War{}, sdkerrors.Wrap(types.ErrFunctionNotAvailableForFunctionType, war.FunctionType)
+	} else if war.State != types.OpenState {
+		return types.War{}, sdkerrors.Wrap(types.ErrInvalidStateForAction, war.State)
+	}
 
-					// Return from amount to swapper
-					err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-						types.BatchesIntermediaryAccount, so.Address, sdk.Coins{so.Amount})
-					if err != nil {
-						panic(err)
-					}
-				} else {
-					// Panic here since all calculations should have been done
-					// correctly to prevent any errors during the swap
-					panic(err)
+	// Check that from and to use reserve token names
+	if !war.IsReserveToken(fromToken) || !war.IsReserveToken(hop.ToToken) {
+		return types.War{}, sdkerrors.Wrapf(types.ErrReserveDenomsMismatch,
+			"%s,%s do not match reserve; expected: %s", fromToken, hop.ToToken, war.ReserveTokens)
+	}
+	return war, nil
+}
+
+// GetBestSwapRoute finds the route of at most MaxSwapRouteHops hops across the
+// swapper wars that gives the highest return for swapping the from amount to
+// the to token at the current reserve balances
+func (k Keeper) GetBestSwapRoute(ctx sdk.Context, from sdk.Coin, toToken string) (
+	bestRoute types.SwapRoute, bestReturns sdk.Coin, bestFees sdk.Coins, err error) {
+
+	// Get all swapper wars that can be swapped with
+	var wars []types.War
+	iterator := k.GetWarIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		war := k.MustGetWarByKey(ctx, iterator.Key())
+		if war.IsSwapper() && war.State == types.OpenState {
+			wars = append(wars, war)
+		}
+	}
+
+	// Depth-first search through all routes that do not revisit a token
+	visited := map[string]bool{from.Denom: true}
+	var route types.SwapRoute
+	var search func(amount sdk.Coin, txFees sdk.Coins)
+	search = func(amount sdk.Coin, txFees sdk.Coins) {
+		if amount.Denom == toToken {
+			if bestRoute == nil || bestReturns.IsLT(amount) {
+				bestRoute = append(types.SwapRoute{}, route...)
+				bestReturns = amount
+				bestFees = txFees
+			}
+			return
+		} else if len(route) == types.MaxSwapRouteHops {
+			return
+		}
+
+		for _, war := range wars {
+			if !war.IsReserveToken(amount.Denom) {
+				continue
+			}
+			reserveBalances := k.GetReserveBalances(ctx, war.Token)
+			for _, next := range war.ReserveTokens {
+				if visited[next] {
+					continue
 				}
+				returns, txFee, err := war.GetReturnsForSwap(amount, next, reserveBalances)
+				if err != nil {
+					continue
+				}
+
+				visited[next] = true
+				route = append(route, types.NewSwapHop(war.Token, next))
+				search(sdk.NewCoin(next, returns.AmountOf(next)), txFees.Add(txFee))
+				route = route[:len(route)-1]
+				visited[next] = false
+			}
+		}
+	}
+	search(from, sdk.Coins{})
+
+	if bestRoute == nil {
+		return nil, sdk.Coin{}, nil, sdkerrors.Wrapf(types.ErrNoSwapRouteFound,
+			"from %s to %s", from.Denom, toToken)
+	}
+	return bestRoute, bestReturns, bestFees, nil
+}
+
+// PerformRoutedSwap performs each of the hops of the routed swap in turn at
+// the current reserve balances of the hop's war, and gives the final returns
+// to the swapper. Coins in between hops are held by the batches intermediary
+// account. Note that on error, some hops may have been performed already, so
+// this should be called using a cache context that is discarded on error.
+func (k Keeper) PerformRoutedSwap(ctx sdk.Context, so types.RoutedSwapOrder) error {
+	amount := so.Amount
+	txFees := sdk.Coins{}
+	for _, hop := range so.Hops {
+		war, err := k.CheckSwapHop(ctx, amount.Denom, hop)
+		if err != nil {
+			return err
+		}
+
+		// Check if order quantity limit exceeded
+		if war.AnyOrderQuantityLimitsExceeded(sdk.Coins{amount}) {
+			return sdkerrors.Wrap(types.ErrOrderQuantityLimitExceeded, amount.String())
+		}
+
+		// Get return for swap
+		reserveBalances := k.GetReserveBalances(ctx, war.Token)
+		reserveReturns, txFee, err := war.GetReturnsForSwap(amount, hop.ToToken, reserveBalances)
+		if err != nil {
+			return err
+		}
+		adjustedInput := amount.Sub(txFee) // same as during GetReturnsForSwap
+
+		// Check if new rates violate sanity rate
+		newReserveBalances := reserveBalances.Add(adjustedInput).Sub(reserveReturns)
+		if war.ReservesViolateSanityRate(newReserveBalances) {
+			return sdkerrors.Wrap(types.ErrValuesViolateSanityRate, newReserveBalances.String())
+		}
+
+		// Add fee-reduced coins to be swapped to reserve
+		err = k.DepositReserveFromModule(ctx, war.Token,
+			types.BatchesIntermediaryAccount, sdk.Coins{adjustedInput})
+		if err != nil {
+			return err
+		}
+
+		// Add fee (taken from swapper) to funding pool or fee address
+		if !txFee.IsZero() {
+			err = k.PayFundingFromModule(ctx, war.Token,
+				types.BatchesIntermediaryAccount, sdk.Coins{txFee})
+			if err != nil {
+				return err
 			}
+			txFees = txFees.Add(txFee)
+		}
+
+		// Take resultant tokens out of reserve to be used in the next hop
+		err = k.WithdrawReserveToModule(ctx, war.Token,
+			types.BatchesIntermediaryAccount, reserveReturns)
+		if err != nil {
+			return err
+		}
+		amount = sdk.NewCoin(hop.ToToken, reserveReturns.AmountOf(hop.ToToken))
+	}
+
+	// Check that min output reached
+	if amount.IsLT(so.MinOutput) {
+		return sdkerrors.Wrapf(types.ErrMinReturnsNotReached, "Actual output %s is less than min output %s", amount, so.MinOutput)
+	}
+
+	// Give resultant tokens to swapper
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+		types.BatchesIntermediaryAccount, so.Address, sdk.Coins{amount})
+	if err != nil {
+		return err
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("performed routed swap order for %s to %s from %s",
+		so.Amount.String(), amount, so.Address.String()))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderFulfill,
+		sdk.NewAttribute(types.AttributeKeyWar, so.Hops[0].WarToken),
+		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueRoutedSwapOrder),
+		sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+		sdk.NewAttribute(types.AttributeKeyTokensSwapped, so.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyChargedFees, txFees.String()),
+		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, amount.String()),
+	))
+
+	return nil
+}
+
+// PerformRoutedSwapOrders performs the routed swaps whose first hop uses the
+// war's reserves, in the order that they were added to the batch. Each routed
+// swap is performed atomically: if any hop fails or the min output is not
+// reached, none of the hops are performed and the swap is cancelled.
+func (k Keeper) PerformRoutedSwapOrders(ctx sdk.Context, token string) {
+	logger := k.Logger(ctx)
+	batch := k.MustGetBatch(ctx, token)
+
+	for i, so := range batch.RoutedSwaps {
+		if so.IsCancelled() {
+			continue
+		}
+
+		cacheCtx, writeCache := ctx.CacheContext()
+		err := k.PerformRoutedSwap(cacheCtx, so)
+		if err == nil {
+			writeCache()
+			ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+			continue
+		}
+
+		batch.RoutedSwaps[i].Cancelled = true
+		batch.RoutedSwaps[i].CancelReason = err.Error()
+
+		logger.Info(fmt.Sprintf("cancelled routed swap order for %s via %s from %s", so.Amount.String(), so.Hops, so.Address.String()))
+		logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeOrderCancel,
+			sdk.NewAttribute(types.AttributeKeyWar, token),
+			sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueRoutedSwapOrder),
+			sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyCancelReason, batch.RoutedSwaps[i].CancelReason),
+		))
+
+		// Return from amount to swapper
+		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, so.Address, sdk.Coins{so.Amount})
+		if err != nil {
+			panic(err)
 		}
 	}
 
@@ -526,6 +1069,7 @@ func (k Keeper) PerformOrders(ctx sdk.Context, token string) {
 	k.PerformBuyOrders(ctx, token)
 	k.PerformSellOrders(ctx, token)
 	k.PerformSwapOrders(ctx, token)
+	k.PerformRoutedSwapOrders(ctx, token)
 }
 
 func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, token string, bo types.BuyOrder, prices sdk.DecCoins) error {
@@ -544,6 +1088,38 @@ func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, token string,
 	return nil
 }
 
+// GetMaxFulfillableBuyAmountAtPrice returns the largest amount, up to the buy
+// order's amount, that the buy order can be reduced to while still being
+// fulfillable within its max prices at the specified prices. Zero is returned
+// if not even one token can be bought.
+func (k Keeper) GetMaxFulfillableBuyAmountAtPrice(ctx sdk.Context, token string, bo types.BuyOrder, prices sdk.DecCoins) sdk.Int {
+	canFill := func(amount sdk.Int) bool {
+		reduced := bo
+		reduced.Amount = sdk.NewCoin(bo.Amount.Denom, amount)
+		return k.CheckIfBuyOrderFulfillableAtPrice(ctx, token, reduced, prices) == nil
+	}
+
+	// Binary search between low (can be filled) and high (cannot be filled)
+	low, high := sdk.ZeroInt(), bo.Amount.Amount
+	if canFill(high) {
+		return high
+	}
+	for high.Sub(low).GT(sdk.OneInt()) {
+		mid := low.Add(high).QuoRaw(2)
+		if canFill(mid) {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
+
+// CancelUnfulfillableBuys cancels the buys in the batch that are not
+// fulfillable at the batch buy prices. Buys that allow partial fills are
+// instead reduced to the largest amount that is fulfillable, if any, and are
+// included in the returned number of cancelled orders, given that reducing
+// them also changes the batch prices.
 func (k Keeper) CancelUnfulfillableBuys(ctx sdk.Context, token string) (cancelledOrders int) {
 	logger := k.Logger(ctx)
 	batch := k.MustGetBatch(ctx, token)
@@ -552,6 +1128,29 @@ func (k Keeper) CancelUnfulfillableBuys(ctx sdk.Context, token string) (cancelle
 	for i, bo := range batch.Buys {
 		if !bo.IsCancelled() {
 			err := k.CheckIfBuyOrderFulfillableAtPrice(ctx, token, bo, batch.BuyPrices)
+			if err != nil && bo.AllowPartialFill {
+				amount := k.GetMaxFulfillableBuyAmountAtPrice(ctx, token, bo, batch.BuyPrices)
+				if amount.IsPositive() {
+					// Partially fill (important to use batch.Buys[i] and not bo!)
+					batch.Buys[i].Amount = sdk.NewCoin(bo.Amount.Denom, amount)
+					unfilled := bo.Amount.Sub(batch.Buys[i].Amount)
+					batch.TotalBuyAmount = batch.TotalBuyAmount.Sub(unfilled)
+					cancelledOrders += 1
+
+					logger.Info(fmt.Sprintf("reduced buy order for %s from %s to %s",
+						bo.Amount.String(), bo.Address.String(), batch.Buys[i].Amount.String()))
+
+					ctx.EventManager().EmitEvent(sdk.NewEvent(
+						types.EventTypePartialFill,
+						sdk.NewAttribute(types.AttributeKeyWar, token),
+						sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
+						sdk.NewAttribute(types.AttributeKeyAddress, bo.Address.String()),
+						sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+						sdk.NewAttribute(types.AttributeKeyUnfilledAmount, unfilled.Amount.String()),
+					))
+					continue
+				}
+			}
 			if err != nil {
 				// Cancel (important to use batch.Buys[i] and not bo!)
 				batch.Buys[i].Cancelled = true
@@ -585,23 +1184,263 @@ func (k Keeper) CancelUnfulfillableBuys(ctx sdk.Context, token string) (cancelle
 	return cancelledOrders
 }
 
+func (k Keeper) CheckIfSellOrderFulfillableAtPrice(ctx sdk.Context, token string, so types.SellOrder, prices sdk.DecCoins) error {
+	war := k.MustGetWar(ctx, token)
+
+	reserveReturns := types.MultiplyDecCoinsByInt(prices, so.Amount.Amount)
+	reserveReturnsRounded := types.RoundReserveReturns(reserveReturns)
+	txFees := war.GetTxFees(reserveReturns)
+	exitFees := war.GetExitFees(reserveReturns)
+
+	totalFees := types.AdjustFees(txFees.Add(exitFees...), reserveReturnsRounded)
+	totalReturns := reserveReturnsRounded.Sub(totalFees)
+
+	// Check that min returns reached
+	if !totalReturns.IsAllGTE(so.MinReturns) {
+		return sdkerrors.Wrapf(types.ErrMinReturnsNotReached, "Actual returns %s are less than min returns %s", totalReturns, so.MinReturns)
+	}
+
+	return nil
+}
+
+func (k Keeper) CancelUnfulfillableSells(ctx sdk.Context, token string) (cancelledOrders int) {
+	logger := k.Logger(ctx)
+	batch := k.MustGetBatch(ctx, token)
+
+	// Cancel unfulfillable sells
+	for i, so := range batch.Sells {
+		if !so.IsCancelled() {
+			err := k.CheckIfSellOrderFulfillableAtPrice(ctx, token, so, batch.SellPrices)
+			if err != nil {
+				// Cancel (important to use batch.Sells[i] and not so!)
+				batch.Sells[i].Cancelled = true
+				batch.Sells[i].CancelReason = err.Error()
+				batch.TotalSellAmount = batch.TotalSellAmount.Sub(so.Amount)
+				cancelledOrders += 1
+
+				logger.Info(fmt.Sprintf("cancelled sell order for %s from %s", so.Amount.String(), so.Address.String()))
+				logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
+
+				ctx.EventManager().EmitEvent(sdk.NewEvent(
+					types.EventTypeOrderCancel,
+					sdk.NewAttribute(types.AttributeKeyWar, token),
+					sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSellOrder),
+					sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+					sdk.NewAttribute(types.AttributeKeyCancelReason, batch.Sells[i].CancelReason),
+				))
+
+				// Re-mint war tokens burned in handleMsgSell
+				err := k.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount,
+					sdk.Coins{so.Amount})
+				if err != nil {
+					panic(err)
+				}
+
+				// Return war tokens to seller
+				err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+					types.WarsMintBurnAccount, so.Address, sdk.Coins{so.Amount})
+				if err != nil {
+					panic(err)
+				}
+			}
+		}
+	}
+
+	// Save batch and return number of cancelled orders
+	k.SetBatch(ctx, token, batch)
+	return cancelledOrders
+}
+
+// CancelOrder cancels an uncancelled order in the current batch on behalf of
+// the order's owner, refunds the order, and updates the batch buy and sell
+// prices to reflect the cancellation. The order is identified by its type and
+// its index in the batch's list of orders of that type.
+func (k Keeper) CancelOrder(ctx sdk.Context, token string, owner sdk.AccAddress,
+	orderType string, index uint64) error {
+	logger := k.Logger(ctx)
+	batch := k.MustGetBatch(ctx, token)
+
+	// Get order from the respective list of orders
+	var order *types.BaseOrder
+	switch orderType {
+	case types.BuyOrderType:
+		if index < uint64(len(batch.Buys)) {
+			order = &batch.Buys[index].BaseOrder
+		}
+	case types.SellOrderType:
+		if index < uint64(len(batch.Sells)) {
+			order = &batch.Sells[index].BaseOrder
+		}
+	case types.SwapOrderType:
+		if index < uint64(len(batch.Swaps)) {
+			order = &batch.Swaps[index].BaseOrder
+		}
+	case types.RoutedSwapOrderType:
+		if index < uint64(len(batch.RoutedSwaps)) {
+			order = &batch.RoutedSwaps[index].BaseOrder
+		}
+	}
+	if order == nil {
+		return sdkerrors.Wrapf(types.ErrOrderDoesNotExist, "%s order %d", orderType, index)
+	}
+
+	// Check that order is owned by owner and not already cancelled
+	if !order.Address.Equals(owner) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not the owner of the order", owner)
+	} else if order.IsCancelled() {
+		return sdkerrors.Wrapf(types.ErrOrderAlreadyCancelled, "%s order %d", orderType, index)
+	}
+
+	// Cancel (order points to the order in the batch)
+	order.Cancelled = true
+	order.CancelReason = types.CancelReasonCancelledByOwner
+
+	// Refund order and update total buy/sell amount
+	var err error
+	switch orderType {
+	case types.BuyOrderType:
+		batch.TotalBuyAmount = batch.TotalBuyAmount.Sub(order.Amount)
+
+		// Return reserve to buyer
+		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, owner, batch.Buys[index].MaxPrices)
+	case types.SellOrderType:
+		batch.TotalSellAmount = batch.TotalSellAmount.Sub(order.Amount)
+
+		// Re-mint war tokens burned in handleMsgSell and return to seller
+		err = k.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount,
+			sdk.Coins{order.Amount})
+		if err == nil {
+			err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+				types.WarsMintBurnAccount, owner, sdk.Coins{order.Amount})
+		}
+	case types.SwapOrderType, types.RoutedSwapOrderType:
+		// Return from amount to swapper
+		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, owner, sdk.Coins{order.Amount})
+	}
+	if err != nil {
+		return err
+	}
+
+	// Update buy and sell prices if a buy or sell was cancelled
+	if orderType == types.BuyOrderType || orderType == types.SellOrderType {
+		buyPrices, sellPrices, err := k.GetBatchBuySellPrices(ctx, token, batch)
+		if err != nil {
+			return err
+		}
+		batch.BuyPrices = buyPrices
+		batch.SellPrices = sellPrices
+	}
+	k.SetBatch(ctx, token, batch)
+
+	logger.Info(fmt.Sprintf("cancelled %s order for %s from %s", orderType, order.Amount.String(), owner.String()))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderCancel,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, orderType),
+		sdk.NewAttribute(types.AttributeKeyAddress, owner.String()),
+		sdk.NewAttribute(types.AttributeKeyCancelReason, order.CancelReason),
+	))
+
+	return nil
+}
+
+// RefundBatch cancels and refunds all of the uncancelled orders in the current
+// batch for the specified reason, and replaces the batch with a new batch
+func (k Keeper) RefundBatch(ctx sdk.Context, token string, reason string) {
+	logger := k.Logger(ctx)
+	war := k.MustGetWar(ctx, token)
+	batch := k.MustGetBatch(ctx, token)
+
+	refund := func(orderType string, order *types.BaseOrder, err error) {
+		if err != nil {
+			panic(err)
+		}
+		order.Cancelled = true
+		order.CancelReason = reason
+
+		logger.Info(fmt.Sprintf("refunded %s order for %s from %s", orderType, order.Amount.String(), order.Address.String()))
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeOrderCancel,
+			sdk.NewAttribute(types.AttributeKeyWar, token),
+			sdk.NewAttribute(types.AttributeKeyOrderType, orderType),
+			sdk.NewAttribute(types.AttributeKeyAddress, order.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyCancelReason, order.CancelReason),
+		))
+	}
+
+	for i, bo := range batch.Buys {
+		if bo.IsCancelled() {
+			continue
+		}
+		// Return reserve to buyer
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, bo.Address, bo.MaxPrices)
+		refund(types.AttributeValueBuyOrder, &batch.Buys[i].BaseOrder, err)
+	}
+	for i, so := range batch.Sells {
+		if so.IsCancelled() {
+			continue
+		}
+		// Re-mint war tokens burned in handleMsgSell and return to seller
+		err := k.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount,
+			sdk.Coins{so.Amount})
+		if err == nil {
+			err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+				types.WarsMintBurnAccount, so.Address, sdk.Coins{so.Amount})
+		}
+		refund(types.AttributeValueSellOrder, &batch.Sells[i].BaseOrder, err)
+	}
+	for i, so := range batch.Swaps {
+		if so.IsCancelled() {
+			continue
+		}
+		// Return from amount to swapper
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, so.Address, sdk.Coins{so.Amount})
+		refund(types.AttributeValueSwapOrder, &batch.Swaps[i].BaseOrder, err)
+	}
+	for i, so := range batch.RoutedSwaps {
+		if so.IsCancelled() {
+			continue
+		}
+		// Return from amount to swapper
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, so.Address, sdk.Coins{so.Amount})
+		refund(types.AttributeValueRoutedSwapOrder, &batch.RoutedSwaps[i].BaseOrder, err)
+	}
+
+	k.SetBatch(ctx, token, types.NewBatch(token, war.BatchBlocks))
+}
+
 func (k Keeper) CancelUnfulfillableOrders(ctx sdk.Context, token string) (cancelledOrders int) {
 	batch := k.MustGetBatch(ctx, token)
 	cancelledOrders = 0
 
-	cancelledOrders += k.CancelUnfulfillableBuys(ctx, token)
-	//cancelledOrders += k.CancelUnfulfillableSells(ctx, token) // Sells always fulfillable
-	//cancelledOrders += k.CancelUnfulfillableSwaps(ctx, token) // Swaps only cancelled while they are being performed
+	// Cancelling buys or sells changes the batch prices, which can make other
+	// orders unfulfillable, so keep cancelling until no cancellations occur
+	for {
+		cancelled := k.CancelUnfulfillableBuys(ctx, token)
+		cancelled += k.CancelUnfulfillableSells(ctx, token)
+		//cancelled += k.CancelUnfulfillableSwaps(ctx, token) // Swaps only cancelled while they are being performed
 
-	// Update buy and sell prices if any cancellation took place
-	if cancelledOrders > 0 {
 		batch = k.MustGetBatch(ctx, token) // get batch again
+		if cancelled == 0 {
+			break
+		}
+		cancelledOrders += cancelled
+
+		// Update buy and sell prices since a cancellation took place
 		buyPrices, sellPrices, err := k.GetBatchBuySellPrices(ctx, token, batch)
 		if err != nil {
 			panic(err)
 		}
 		batch.BuyPrices = buyPrices
 		batch.SellPrices = sellPrices
+		k.SetBatch(ctx, token, batch)
 	}
 
 	// Save batch and return number of cancelled orders
@@ -2,6 +2,7 @@ package keeper_test
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/mage-war/wars/x/wars/app"
 	"github.com/mage-war/wars/x/wars/internal/types"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -153,7 +154,7 @@ func TestGetBatchBuySellPrices(t *testing.T) {
 
 	// (Re)Create batch with buy order (nil max prices)
 	batch = getValidBatch()
-	bo := types.NewBuyOrder(buyerAddress, fiveTokens, reserveBalance)
+	bo := types.NewBuyOrder(buyerAddress, fiveTokens, reserveBalance, false)
 	batch.Buys = append(batch.Buys, bo)
 	batch.TotalBuyAmount = batch.TotalBuyAmount.Add(bo.Amount)
 
@@ -173,7 +174,7 @@ func TestGetBatchBuySellPrices(t *testing.T) {
 
 	// (Re)Create batch with sell order
 	batch = getValidBatch()
-	so := types.NewSellOrder(sellerAddress, fiveTokens)
+	so := types.NewSellOrder(sellerAddress, fiveTokens, nil)
 	batch.Sells = append(batch.Sells, so)
 	batch.TotalSellAmount = batch.TotalSellAmount.Add(so.Amount)
 
@@ -192,9 +193,9 @@ func TestGetBatchBuySellPrices(t *testing.T) {
 
 	// (Re)Create batch with buy amount > sell amount
 	batch = getValidBatch()
-	bo1 := types.NewBuyOrder(buyerAddress, fiveTokens, nil)
-	bo2 := types.NewBuyOrder(buyerAddress, fiveTokens, nil) // 5 more
-	so = types.NewSellOrder(sellerAddress, fiveTokens)
+	bo1 := types.NewBuyOrder(buyerAddress, fiveTokens, nil, false)
+	bo2 := types.NewBuyOrder(buyerAddress, fiveTokens, nil, false) // 5 more
+	so = types.NewSellOrder(sellerAddress, fiveTokens, nil)
 	batch.Buys = append(batch.Buys, bo1, bo2)
 	batch.Sells = append(batch.Sells, so)
 	batch.TotalBuyAmount = batch.TotalBuyAmount.Add(bo1.Amount).Add(bo2.Amount)
@@ -218,9 +219,9 @@ func TestGetBatchBuySellPrices(t *testing.T) {
 
 	// (Re)Create batch with sell amount > buy amount
 	batch = getValidBatch()
-	bo = types.NewBuyOrder(buyerAddress, fiveTokens, nil)
-	so1 := types.NewSellOrder(sellerAddress, fiveTokens)
-	so2 := types.NewSellOrder(sellerAddress, fiveTokens)
+	bo = types.NewBuyOrder(buyerAddress, fiveTokens, nil, false)
+	so1 := types.NewSellOrder(sellerAddress, fiveTokens, nil)
+	so2 := types.NewSellOrder(sellerAddress, fiveTokens, nil)
 	batch.Buys = append(batch.Buys, bo)
 	batch.Sells = append(batch.Sells, so1, so2)
 	batch.TotalBuyAmount = batch.TotalBuyAmount.Add(bo1.Amount)
@@ -256,19 +257,19 @@ func TestGetUpdatedBatchPricesAfterBuy(t *testing.T) {
 	// Buy order with buy amount greater than max supply not fulfillable
 	// MaxPrices is set to nil since it is not relevant in this scenario
 	maxSupplyPlus1 := war.MaxSupply.Add(sdk.NewCoin(war.Token, sdk.OneInt()))
-	bo := types.NewBuyOrder(buyerAddress, maxSupplyPlus1, nil)
+	bo := types.NewBuyOrder(buyerAddress, maxSupplyPlus1, nil, false)
 	_, _, err := app.WarsKeeper.GetUpdatedBatchPricesAfterBuy(ctx, war.Token, bo)
 	require.Error(t, err)
 
 	// Buy order with max prices lower than prices not fulfillable
 	maxPrices := sdk.NewCoins(sdk.NewCoin(war.ReserveTokens[0], sdk.OneInt()))
-	bo = types.NewBuyOrder(buyerAddress, buyAmount, maxPrices)
+	bo = types.NewBuyOrder(buyerAddress, buyAmount, maxPrices, false)
 	_, _, err = app.WarsKeeper.GetUpdatedBatchPricesAfterBuy(ctx, war.Token, bo)
 	require.Error(t, err)
 
 	// Check buy prices for fulfillable buy order
 	maxPrices = sdk.NewCoins(sdk.NewInt64Coin(war.ReserveTokens[0], 10000000))
-	bo = types.NewBuyOrder(buyerAddress, buyAmount, maxPrices)
+	bo = types.NewBuyOrder(buyerAddress, buyAmount, maxPrices, false)
 	buyPrices, sellPrices, err = app.WarsKeeper.GetUpdatedBatchPricesAfterBuy(ctx, war.Token, bo)
 	expectedBuyPrices, _ := war.GetPricesToMint(buyAmount.Amount, nil)
 	expectedSellPrices, _ := war.GetCurrentPricesPT(nil)
@@ -277,6 +278,61 @@ func TestGetUpdatedBatchPricesAfterBuy(t *testing.T) {
 	require.Equal(t, expectedSellPrices, sellPrices)
 }
 
+func TestGetMaxBuyAmountForSpend(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Create war and batch
+	war := getValidWar()
+	batch := getValidBatch()
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, batch)
+
+	// Price for x tokens is 4x^3+100x plus a 0.1% fee (rounded up), so a
+	// spend of 100 is not enough to buy any tokens (1 token costs 104+1)
+	spend := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	_, err := app.WarsKeeper.GetMaxBuyAmountForSpend(ctx, war.Token, buyerAddress, spend)
+	require.Error(t, err)
+
+	// Spend of 10000 is enough to buy 12 tokens (8112+9) but not 13 (10088+11)
+	spend = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10000))
+	amount, err := app.WarsKeeper.GetMaxBuyAmountForSpend(ctx, war.Token, buyerAddress, spend)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt64Coin(war.Token, 12), amount)
+
+	// Spend of exactly 8121 is enough to buy 12 tokens, but 8120 is not
+	spend = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 8121))
+	amount, err = app.WarsKeeper.GetMaxBuyAmountForSpend(ctx, war.Token, buyerAddress, spend)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt64Coin(war.Token, 12), amount)
+	spend = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 8120))
+	amount, err = app.WarsKeeper.GetMaxBuyAmountForSpend(ctx, war.Token, buyerAddress, spend)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt64Coin(war.Token, 11), amount)
+
+	// Buys already in the batch increase the price per token to 4(10+x)^2+100,
+	// so 7 tokens can be bought (8792+9) but not 8 (11168+12)
+	batch.TotalBuyAmount = sdk.NewInt64Coin(war.Token, 10)
+	app.WarsKeeper.SetBatch(ctx, war.Token, batch)
+	spend = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10000))
+	amount, err = app.WarsKeeper.GetMaxBuyAmountForSpend(ctx, war.Token, buyerAddress, spend)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt64Coin(war.Token, 7), amount)
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+
+	// Huge spend is capped by the max supply
+	spend = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000000000000000))
+	amount, err = app.WarsKeeper.GetMaxBuyAmountForSpend(ctx, war.Token, buyerAddress, spend)
+	require.Nil(t, err)
+	require.Equal(t, war.MaxSupply, amount)
+
+	// Huge spend is capped by the order quantity limit (if any)
+	war.OrderQuantityLimits = sdk.NewCoins(sdk.NewInt64Coin(war.Token, 5))
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	amount, err = app.WarsKeeper.GetMaxBuyAmountForSpend(ctx, war.Token, buyerAddress, spend)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt64Coin(war.Token, 5), amount)
+}
+
 func TestGetUpdatedBatchPricesAfterSell(t *testing.T) {
 	app, ctx := createTestApp(false)
 
@@ -290,7 +346,7 @@ func TestGetUpdatedBatchPricesAfterSell(t *testing.T) {
 	sellAmount := sdk.NewCoin(war.Token, sdk.OneInt())
 
 	// Sell order when current supply is zero is not fulfillable
-	so := types.NewSellOrder(sellerAddress, sellAmount)
+	so := types.NewSellOrder(sellerAddress, sellAmount, nil)
 	_, _, err := app.WarsKeeper.GetUpdatedBatchPricesAfterSell(ctx, war.Token, so)
 	require.Error(t, err)
 
@@ -304,7 +360,7 @@ func TestGetUpdatedBatchPricesAfterSell(t *testing.T) {
 		ctx, war.Token, types.WarsMintBurnAccount, reserveBalance)
 
 	// Check sell prices for fulfillable sell order
-	so = types.NewSellOrder(sellerAddress, sellAmount)
+	so = types.NewSellOrder(sellerAddress, sellAmount, nil)
 	buyPrices, sellPrices, err = app.WarsKeeper.GetUpdatedBatchPricesAfterSell(ctx, war.Token, so)
 	expectedBuyPrices, _ := war.GetCurrentPricesPT(nil)
 	expectedSellPrices := war.GetReturnsForBurn(sellAmount.Amount, reserveBalance)
@@ -347,7 +403,7 @@ func TestPerformBuyAtPrice(t *testing.T) {
 	for _, tc := range testCases {
 		// Create buy order
 		amount := sdk.NewCoin(war.Token, tc.amount)
-		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices)
+		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices, false)
 
 		// Set transaction fee
 		war.TxFeePercentage = tc.txFee
@@ -455,7 +511,7 @@ func TestPerformBuyAtPriceAugmentedFunction(t *testing.T) {
 	for _, tc := range testCases {
 		// Create buy order
 		amount := sdk.NewCoin(war.Token, tc.amount)
-		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices)
+		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices, false)
 
 		// Set transaction fee and state
 		war.TxFeePercentage = tc.txFee
@@ -484,6 +540,8 @@ func TestPerformBuyAtPriceAugmentedFunction(t *testing.T) {
 		prevFeeAddrBal := app.BankKeeper.GetCoins(ctx, war.FeeAddress)
 		prevBuyerBal := app.BankKeeper.GetCoins(ctx, buyerAddress)
 		prevReserveBal := app.WarsKeeper.GetReserveBalances(ctx, war.Token)
+		prevHatchFunding := app.WarsKeeper.MustGetWar(ctx, war.Token).HatchFunding
+		prevHatchContrib := app.WarsKeeper.GetHatchContribution(ctx, war.Token, buyerAddress).Amount
 
 		// Perform buy
 		err = app.WarsKeeper.PerformBuyAtPrice(ctx, war.Token, bo, buyPrices)
@@ -508,6 +566,8 @@ func TestPerformBuyAtPriceAugmentedFunction(t *testing.T) {
 		newFeeAddrBal := app.BankKeeper.GetCoins(ctx, war.FeeAddress)
 		newBuyerBal := app.BankKeeper.GetCoins(ctx, buyerAddress)
 		newReserveBal := app.WarsKeeper.GetReserveBalances(ctx, war.Token)
+		newHatchFunding := app.WarsKeeper.MustGetWar(ctx, war.Token).HatchFunding
+		newHatchContrib := app.WarsKeeper.GetHatchContribution(ctx, war.Token, buyerAddress).Amount
 
 		require.Equal(t, prevSupplySDK.Add(tc.amount), newSupplySDK)
 		require.Equal(t, prevSupplyWars.Add(tokensBought), newSupplyWars)
@@ -517,11 +577,14 @@ func TestPerformBuyAtPriceAugmentedFunction(t *testing.T) {
 		if tc.state == types.HatchState {
 			toInitialReserve, _ := sdk.NewDecCoinsFromCoins(reservePricesRounded...).MulDec(
 				sdk.OneDec().Sub(args["theta"])).TruncateDecimal()
-			toFundingPool := txFees.Add(reservePricesRounded.Sub(toInitialReserve)...)
+			toFundingPool := reservePricesRounded.Sub(toInitialReserve)
 			require.Equal(t, prevReserveBal.Add(toInitialReserve...), newReserveBal)
-			require.Equal(t, prevFeeAddrBal.Add(toFundingPool...), newFeeAddrBal)
+			require.True(t, prevHatchFunding.Add(toFundingPool...).Add(txFees...).IsEqual(newHatchFunding))
+			require.True(t, prevFeeAddrBal.IsEqual(newFeeAddrBal))
+			require.Equal(t, prevHatchContrib.Add(tc.amount), newHatchContrib)
 		} else {
-			require.Equal(t, prevFeeAddrBal.Add(txFees...), newFeeAddrBal)
+			require.Equal(t, prevHatchContrib, newHatchContrib)
+			require.True(t, prevFeeAddrBal.Add(txFees...).IsEqual(newFeeAddrBal))
 			require.Equal(t, prevReserveBal.Add(reservePricesRounded...), newReserveBal)
 		}
 	}
@@ -561,7 +624,7 @@ func TestPerformSellAtPrice(t *testing.T) {
 
 	for _, tc := range testCases {
 		// Create sell order
-		so := types.NewSellOrder(sellerAddress, sellAmount)
+		so := types.NewSellOrder(sellerAddress, sellAmount, nil)
 
 		// Set transaction and exit fee and current supply
 		war.TxFeePercentage = tc.txFee
@@ -615,7 +678,7 @@ func TestPerformSellAtPrice(t *testing.T) {
 	}
 }
 
-func TestPerformSwap(t *testing.T) {
+func TestPerformSingleSwap(t *testing.T) {
 	app, ctx := createTestApp(false)
 	war := getValidSwapperWar()
 
@@ -675,7 +738,7 @@ func TestPerformSwap(t *testing.T) {
 		fromAmount := sdk.NewCoin(tc.fromToken, swapAmount)
 		fromAmounts := sdk.Coins{fromAmount}
 		fromAmountsDec := sdk.DecCoins{sdk.NewDecCoinFromCoin(fromAmount)}
-		so := types.NewSwapOrder(swapperAddress, fromAmount, tc.toToken)
+		so := types.NewSwapOrder(swapperAddress, fromAmount, tc.toToken, sdk.NewInt64Coin(tc.toToken, 0))
 
 		// Set transaction fee, sanity rates, and initial reserve balances
 		war.TxFeePercentage = tc.txFee
@@ -708,15 +771,20 @@ func TestPerformSwap(t *testing.T) {
 		prevSwapperBal := app.BankKeeper.GetCoins(ctx, swapperAddress)
 
 		// Perform swap
-		err, ok := app.WarsKeeper.PerformSwap(ctx, war.Token, so)
-		require.True(t, ok)
+		app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+		app.WarsKeeper.AddSwapOrder(ctx, war.Token, so)
+		app.WarsKeeper.PerformSwapOrders(ctx, war.Token)
 
-		// Check if error due to violated sanity rate
+		// Check if cancelled due to violated sanity rate
+		batch := app.WarsKeeper.MustGetBatch(ctx, war.Token)
 		if tc.sanityRateViolated {
-			require.Error(t, err)
-			continue // app would panic at this stage
+			require.True(t, batch.Swaps[0].Cancelled)
+			require.Contains(t, batch.Swaps[0].CancelReason, "sanity rate")
+			require.Equal(t, prevSwapperBal.Add(fromAmounts...),
+				app.BankKeeper.GetCoins(ctx, swapperAddress))
+			continue
 		} else {
-			require.NoError(t, err)
+			require.False(t, batch.Swaps[0].Cancelled)
 		}
 
 		// New values
@@ -778,7 +846,7 @@ func TestPerformBuys(t *testing.T) {
 	for _, tc := range testCases {
 		// Create and add buy order
 		amount := sdk.NewCoin(war.Token, tc.amount)
-		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices)
+		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices, false)
 		app.WarsKeeper.AddBuyOrder(ctx, token, bo, buyPrices, blankSellPrices)
 
 		// Calculate total prices
@@ -842,7 +910,7 @@ func TestPerformSells(t *testing.T) {
 	for _, tc := range testCases {
 		// Create and add sell order
 		amount := sdk.NewCoin(war.Token, tc.amount)
-		so := types.NewSellOrder(sellerAddress, amount)
+		so := types.NewSellOrder(sellerAddress, amount, nil)
 		app.WarsKeeper.AddSellOrder(ctx, token, so, blankBuyPrices, sellPrices)
 
 		// Calculate total return
@@ -878,101 +946,380 @@ func TestPerformSells(t *testing.T) {
 }
 
 func TestPerformSwaps(t *testing.T) {
+	// Swaps from 100res and 300res to rez, and from 60rez to res, performed
+	// with initial reserves of 200res and 300rez (a spot price of 1.5rez per
+	// res). The 60rez are matched at the spot price for 40res, and the rest
+	// of the 400res (360res) are swapped along the curve for 192rez, giving
+	// 60rez+192rez = 252rez for 400res, i.e. 0.63rez per res for both swaps.
+	swapper1 := swapperAddress
+	swapper2 := buyerAddress
+	swapper3 := sellerAddress
+	swaps := []types.SwapOrder{
+		types.NewSwapOrder(swapper1, sdk.NewInt64Coin(reserveToken, 100),
+			reserveToken2, sdk.NewInt64Coin(reserveToken2, 0)),
+		types.NewSwapOrder(swapper2, sdk.NewInt64Coin(reserveToken, 300),
+			reserveToken2, sdk.NewInt64Coin(reserveToken2, 0)),
+		types.NewSwapOrder(swapper3, sdk.NewInt64Coin(reserveToken2, 60),
+			reserveToken, sdk.NewInt64Coin(reserveToken, 0)),
+	}
+	expectedReturns := map[string]sdk.Coins{
+		swapper1.String(): sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, 63)),
+		swapper2.String(): sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, 189)),
+		swapper3.String(): sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 40)),
+	}
+	expectedReserveBal := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 560),
+		sdk.NewInt64Coin(reserveToken2, 108))
+
+	// The swaps are performed in both orders, with the same results, since
+	// the price does not depend on the position of the swap in the batch
+	reversed := []types.SwapOrder{swaps[2], swaps[1], swaps[0]}
+	for _, orders := range [][]types.SwapOrder{swaps, reversed} {
+		app, ctx := createTestApp(false)
+
+		// Create war and batch (with no fees for simpler test)
+		war := getValidSwapperWar()
+		war.TxFeePercentage = sdk.ZeroDec()
+		war.ExitFeePercentage = sdk.ZeroDec()
+		war.SanityRate = sdk.OneDec()
+		war.SanityMarginPercentage = sdk.NewDec(1000)
+		app.WarsKeeper.SetWar(ctx, war.Token, war)
+		app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+
+		// Set initial reserves
+		initialReserves := sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 200),
+			sdk.NewInt64Coin(reserveToken2, 300))
+		err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, initialReserves)
+		require.Nil(t, err)
+		err = app.WarsKeeper.DepositReserveFromModule(
+			ctx, war.Token, types.WarsMintBurnAccount, initialReserves)
+		require.NoError(t, err)
+
+		// Add swap orders and reserve tokens sent by swappers to module account address
+		moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+		for _, so := range orders {
+			app.WarsKeeper.AddSwapOrder(ctx, war.Token, so)
+			_, err = app.BankKeeper.AddCoins(ctx, moduleAcc.GetAddress(), sdk.Coins{so.Amount})
+			require.NoError(t, err)
+		}
+
+		// Perform swaps
+		app.WarsKeeper.PerformSwapOrders(ctx, war.Token)
+
+		// No swaps cancelled
+		for _, so := range app.WarsKeeper.MustGetBatch(ctx, war.Token).Swaps {
+			require.False(t, so.Cancelled)
+		}
+
+		// Check balances
+		require.Empty(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+		require.Equal(t, expectedReserveBal, app.WarsKeeper.GetReserveBalances(ctx, war.Token))
+		for _, swapper := range []sdk.AccAddress{swapper1, swapper2, swapper3} {
+			require.Equal(t, expectedReturns[swapper.String()],
+				app.BankKeeper.GetCoins(ctx, swapper))
+		}
+
+		// Clearing prices included in swap clearing events
+		clearingPrices := make(map[string]string)
+		for _, event := range ctx.EventManager().Events() {
+			if event.Type == types.EventTypeSwapClearing {
+				var from, price string
+				for _, attr := range event.Attributes {
+					switch string(attr.Key) {
+					case types.AttributeKeySwapFromToken:
+						from = string(attr.Value)
+					case types.AttributeKeyClearingPrice:
+						price = string(attr.Value)
+					}
+				}
+				clearingPrices[from] = price
+			}
+		}
+		require.Equal(t, sdk.NewDecWithPrec(63, 2).String(), clearingPrices[reserveToken])
+		require.Equal(t, sdk.OneDec().Quo(sdk.NewDecWithPrec(15, 1)).String(), clearingPrices[reserveToken2])
+	}
+}
+
+func TestPerformSwapsWithMinOutput(t *testing.T) {
 	app, ctx := createTestApp(false)
 
 	// Create war and batch (with no fees for simpler test)
 	war := getValidSwapperWar()
-	batch := getValidBatch()
 	war.TxFeePercentage = sdk.ZeroDec()
 	war.ExitFeePercentage = sdk.ZeroDec()
-	war.SanityRate = sdk.OneDec()
-	war.SanityMarginPercentage = sdk.NewDec(1000)
 	app.WarsKeeper.SetWar(ctx, war.Token, war)
-	app.WarsKeeper.SetBatch(ctx, war.Token, batch)
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
 
 	// Set initial reserves
-	initialInReserve := sdk.NewInt64Coin(reserveToken, 200)
-	initialOutReserve := sdk.NewInt64Coin(reserveToken2, 300)
-	initialReserves := sdk.NewCoins(initialInReserve, initialOutReserve)
+	initialReserves := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 200),
+		sdk.NewInt64Coin(reserveToken2, 300))
 	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, initialReserves)
 	require.Nil(t, err)
 	err = app.WarsKeeper.DepositReserveFromModule(
 		ctx, war.Token, types.WarsMintBurnAccount, initialReserves)
 	require.NoError(t, err)
 
-	testCases := []struct {
-		fromToken        string
-		toToken          string
-		amount           sdk.Int
-		willGetCancelled bool
-	}{
-		{
-			reserveToken, reserveToken2, sdk.NewInt(100), false,
-		}, // 100res to rez
-		{
-			reserveToken2, reserveToken, sdk.NewInt(200), false,
-		}, // 200rez to res
-		{
-			reserveToken, reserveToken2, sdk.NewInt(300), false,
-		}, // 300res to rez
-		{
-			reserveToken2, reserveToken, sdk.NewInt(400), false,
-		}, // 400rez to res
-		{
-			reserveToken, reserveToken2, sdk.NewInt(1000), true,
-		}, // 1000res to rez, to violate sanity
+	// Add two swap orders of 100res to rez, for which the total return is
+	// 200*300/400 = 150rez, i.e. 75rez each at the uniform price
+	fromAmount := sdk.NewInt64Coin(reserveToken, 100)
+	so1 := types.NewSwapOrder(swapperAddress, fromAmount, reserveToken2,
+		sdk.NewInt64Coin(reserveToken2, 76))
+	so2 := types.NewSwapOrder(swapperAddress, fromAmount, reserveToken2,
+		sdk.NewInt64Coin(reserveToken2, 51))
+	app.WarsKeeper.AddSwapOrder(ctx, war.Token, so1)
+	app.WarsKeeper.AddSwapOrder(ctx, war.Token, so2)
+
+	// Add reserve tokens sent by swapper to module account address
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	err = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), sdk.Coins{fromAmount.Add(fromAmount)})
+	require.NoError(t, err)
+
+	// Perform swaps
+	app.WarsKeeper.PerformSwapOrders(ctx, war.Token)
+
+	// First swap cancelled since 75rez is less than the min output of 76rez,
+	// after which the second swap is cleared by itself for 100*300/300 = 100rez
+	batch := app.WarsKeeper.MustGetBatch(ctx, war.Token)
+	require.True(t, batch.Swaps[0].Cancelled)
+	require.False(t, batch.Swaps[1].Cancelled)
+	require.Contains(t, batch.Swaps[0].CancelReason, "min output")
+
+	// Cancel reason included in order cancel event
+	var cancelReason string
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypeOrderCancel {
+			for _, attr := range event.Attributes {
+				if string(attr.Key) == types.AttributeKeyCancelReason {
+					cancelReason = string(attr.Value)
+				}
+			}
+		}
 	}
+	require.Contains(t, cancelReason, "min output")
+
+	// Swapper got 100rez and a refund of 100res
+	expectedSwapperBal := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100),
+		sdk.NewInt64Coin(reserveToken2, 100))
+	expectedReserveBal := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 300),
+		sdk.NewInt64Coin(reserveToken2, 200))
+	require.Empty(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+	require.Equal(t, expectedSwapperBal, app.BankKeeper.GetCoins(ctx, swapperAddress))
+	require.Equal(t, expectedReserveBal, app.WarsKeeper.GetReserveBalances(ctx, war.Token))
+}
 
-	globalTotalReturns := sdk.NewCoins()
-	globalIncreaseInSwapperBal := sdk.NewCoins()
-	globalDecreaseInSwapperBal := sdk.NewCoins()
-	globalReserveBal := initialReserves
+func TestPerformSwapsChargesFeesOfEachSwap(t *testing.T) {
+	app, ctx := createTestApp(false)
 
-	// Previous values
+	// Create war (with a tx fee of 10%) and batch
+	war := getValidSwapperWar()
+	war.TxFeePercentage = sdk.NewDec(10)
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+
+	// Set initial reserves
+	initialReserves := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 200),
+		sdk.NewInt64Coin(reserveToken2, 300))
+	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, initialReserves)
+	require.Nil(t, err)
+	err = app.WarsKeeper.DepositReserveFromModule(
+		ctx, war.Token, types.WarsMintBurnAccount, initialReserves)
+	require.NoError(t, err)
+
+	// Add two swap orders of 100res to rez, each charged a fee of 10res
+	fromAmount := sdk.NewInt64Coin(reserveToken, 100)
+	zeroOutput := sdk.NewInt64Coin(reserveToken2, 0)
+	app.WarsKeeper.AddSwapOrder(ctx, war.Token,
+		types.NewSwapOrder(swapperAddress, fromAmount, reserveToken2, zeroOutput))
+	app.WarsKeeper.AddSwapOrder(ctx, war.Token,
+		types.NewSwapOrder(swapperAddress, fromAmount, reserveToken2, zeroOutput))
+
+	// Add reserve tokens sent by swapper to module account address
 	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	err = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), sdk.Coins{fromAmount.Add(fromAmount)})
+	require.NoError(t, err)
 
-	// Add swap orders
-	for _, tc := range testCases {
-		// Constant product
-		inReserve := sdk.NewCoin(tc.fromToken, globalReserveBal.AmountOf(tc.fromToken))
-		outReserve := sdk.NewCoin(tc.toToken, globalReserveBal.AmountOf(tc.toToken))
-		cp := inReserve.Amount.Mul(outReserve.Amount).ToDec()
+	// Perform swaps
+	app.WarsKeeper.PerformSwapOrders(ctx, war.Token)
+
+	// Both swaps performed and the fee address got the fees of both swaps
+	batch := app.WarsKeeper.MustGetBatch(ctx, war.Token)
+	require.False(t, batch.Swaps[0].Cancelled)
+	require.False(t, batch.Swaps[1].Cancelled)
+	require.Empty(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(reserveToken, 20)},
+		app.BankKeeper.GetCoins(ctx, war.FeeAddress))
+}
 
-		// Create and add swap order
-		fromAmount := sdk.NewCoin(tc.fromToken, tc.amount)
-		fromAmounts := sdk.Coins{fromAmount}
-		so := types.NewSwapOrder(swapperAddress, fromAmount, tc.toToken)
-		app.WarsKeeper.AddSwapOrder(ctx, token, so)
+func setUpRoutedSwapWars(t *testing.T, app *simapp.SimApp, ctx sdk.Context) (war1, war2 types.War) {
+	// Create two swapper wars (with no fees for simpler test) sharing the
+	// rez token, i.e. res->rez via the first and rez->rey via the second
+	war1 = getValidSwapperWar()
+	war1.TxFeePercentage = sdk.ZeroDec()
+	war2 = getValidSwapperWar()
+	war2.Token = token2
+	war2.ReserveTokens = []string{reserveToken2, "rey"}
+	war2.TxFeePercentage = sdk.ZeroDec()
+	for _, war := range []types.War{war1, war2} {
+		app.WarsKeeper.SetWar(ctx, war.Token, war)
+		app.WarsKeeper.SetBatch(ctx, war.Token, types.NewBatch(war.Token, batchBlocks))
+	}
 
-		// Add reserve tokens sent by swapper to module account address
-		_, err = app.BankKeeper.AddCoins(ctx, moduleAcc.GetAddress(), fromAmounts)
-		require.NoError(t, err)
+	// Set initial reserves of 200res/300rez and 300rez/600rey
+	reserves1 := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 200),
+		sdk.NewInt64Coin(reserveToken2, 300))
+	reserves2 := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken2, 300),
+		sdk.NewInt64Coin("rey", 600))
+	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, reserves1.Add(reserves2...))
+	require.Nil(t, err)
+	err = app.WarsKeeper.DepositReserveFromModule(ctx, war1.Token, types.WarsMintBurnAccount, reserves1)
+	require.Nil(t, err)
+	err = app.WarsKeeper.DepositReserveFromModule(ctx, war2.Token, types.WarsMintBurnAccount, reserves2)
+	require.Nil(t, err)
+	return war1, war2
+}
 
-		if tc.willGetCancelled {
-			// Reserve returned back to swapper
-			globalIncreaseInSwapperBal = globalIncreaseInSwapperBal.Add(fromAmounts...)
-		} else {
-			// Calculations
-			newInReserveDec := inReserve.Amount.Add(fromAmount.Amount).ToDec()
-			newOutReserveDec := sdk.NewDecCoinFromDec(tc.toToken, cp.Quo(newInReserveDec))
-			totalOuts := sdk.Coins{types.RoundReserveReturn(sdk.NewDecCoinFromCoin(outReserve).Sub(newOutReserveDec))} // out of reserves (i.e. returns)
-			globalIncreaseInSwapperBal = globalIncreaseInSwapperBal.Add(totalOuts...)
-			globalDecreaseInSwapperBal = globalDecreaseInSwapperBal.Add(fromAmounts...)
-			globalReserveBal = globalReserveBal.Add(fromAmounts...).Sub(totalOuts)
+func TestPerformRoutedSwaps(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war1, war2 := setUpRoutedSwapWars(t, app, ctx)
+
+	// Add two routed swaps of 100res to rey via rez, the first of which has a
+	// min output that cannot be reached. The returns of the second one are
+	// 300-200*300/300 = 100rez and then 600-300*600/400 = 150rey
+	fromAmount := sdk.NewInt64Coin(reserveToken, 100)
+	route := types.SwapRoute{
+		types.NewSwapHop(war1.Token, reserveToken2),
+		types.NewSwapHop(war2.Token, "rey"),
+	}
+	so1 := types.NewRoutedSwapOrder(swapperAddress, fromAmount, route, sdk.NewInt64Coin("rey", 151))
+	so2 := types.NewRoutedSwapOrder(swapperAddress, fromAmount, route, sdk.NewInt64Coin("rey", 150))
+	app.WarsKeeper.AddRoutedSwapOrder(ctx, war1.Token, so1)
+	app.WarsKeeper.AddRoutedSwapOrder(ctx, war1.Token, so2)
+
+	// Add reserve tokens sent by swapper to module account address
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	err := app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), sdk.Coins{fromAmount.Add(fromAmount)})
+	require.Nil(t, err)
+
+	// Perform routed swaps
+	app.WarsKeeper.PerformRoutedSwapOrders(ctx, war1.Token)
+
+	// First swap cancelled, with none of its hops performed
+	batch := app.WarsKeeper.MustGetBatch(ctx, war1.Token)
+	require.True(t, batch.RoutedSwaps[0].Cancelled)
+	require.False(t, batch.RoutedSwaps[1].Cancelled)
+	require.Contains(t, batch.RoutedSwaps[0].CancelReason, "min output")
+
+	// Swapper got 150rey and a refund of 100res
+	expectedSwapperBal := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 100),
+		sdk.NewInt64Coin("rey", 150))
+	require.Empty(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+	require.Equal(t, expectedSwapperBal, app.BankKeeper.GetCoins(ctx, swapperAddress))
+
+	// Reserves reflect only the second swap
+	expectedReserve1 := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 300),
+		sdk.NewInt64Coin(reserveToken2, 200))
+	expectedReserve2 := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken2, 400),
+		sdk.NewInt64Coin("rey", 450))
+	require.Equal(t, expectedReserve1, app.WarsKeeper.GetReserveBalances(ctx, war1.Token))
+	require.Equal(t, expectedReserve2, app.WarsKeeper.GetReserveBalances(ctx, war2.Token))
+
+	// One order_fulfill and one order_cancel event emitted
+	var fulfilled, cancelled int
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type == types.EventTypeOrderFulfill {
+			fulfilled++
+		} else if e.Type == types.EventTypeOrderCancel {
+			cancelled++
 		}
 	}
+	require.Equal(t, 1, fulfilled)
+	require.Equal(t, 1, cancelled)
+}
 
-	// Perform swaps
-	app.WarsKeeper.PerformSwapOrders(ctx, token)
+func TestPerformRoutedSwapsInvalidHopFails(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war1, war2 := setUpRoutedSwapWars(t, app, ctx)
 
-	// New balances
-	newModuleAccBal := app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress())
-	newReserveBal := app.WarsKeeper.GetReserveBalances(ctx, war.Token)
-	newSwapperBal := app.BankKeeper.GetCoins(ctx, sellerAddress)
+	// Second hop uses a war that is not open, so the swap is cancelled after
+	// the first hop has been performed, which must then be reverted
+	war2.State = types.HatchState
+	app.WarsKeeper.SetWar(ctx, war2.Token, war2)
 
-	require.Equal(t, sdk.Coins(nil), newModuleAccBal)
-	require.Equal(t, globalReserveBal, newReserveBal)
-	require.Equal(t, globalTotalReturns, newSwapperBal)
+	fromAmount := sdk.NewInt64Coin(reserveToken, 100)
+	route := types.SwapRoute{
+		types.NewSwapHop(war1.Token, reserveToken2),
+		types.NewSwapHop(war2.Token, "rey"),
+	}
+	so := types.NewRoutedSwapOrder(swapperAddress, fromAmount, route, sdk.NewInt64Coin("rey", 0))
+	app.WarsKeeper.AddRoutedSwapOrder(ctx, war1.Token, so)
+
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	err := app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), sdk.Coins{fromAmount})
+	require.Nil(t, err)
+
+	app.WarsKeeper.PerformRoutedSwapOrders(ctx, war1.Token)
+
+	// Swap cancelled and refunded, and reserves unchanged
+	batch := app.WarsKeeper.MustGetBatch(ctx, war1.Token)
+	require.True(t, batch.RoutedSwaps[0].Cancelled)
+	require.Empty(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+	require.Equal(t, sdk.Coins{fromAmount}, app.BankKeeper.GetCoins(ctx, swapperAddress))
+	require.Equal(t, sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 200),
+		sdk.NewInt64Coin(reserveToken2, 300)),
+		app.WarsKeeper.GetReserveBalances(ctx, war1.Token))
+}
+
+func TestGetBestSwapRoute(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war1, war2 := setUpRoutedSwapWars(t, app, ctx)
+
+	// Single hop route from rez to res gives 200-200*300/400 = 50res
+	route, returns, fees, err := app.WarsKeeper.GetBestSwapRoute(
+		ctx, sdk.NewInt64Coin(reserveToken2, 100), reserveToken)
+	require.Nil(t, err)
+	require.Equal(t, types.SwapRoute{types.NewSwapHop(war1.Token, reserveToken)}, route)
+	require.Equal(t, sdk.NewInt64Coin(reserveToken, 50), returns)
+	require.True(t, fees.IsZero())
+
+	// Add a third war with a direct but worse route from res to rey
+	war3 := getValidSwapperWar()
+	war3.Token = token3
+	war3.ReserveTokens = []string{reserveToken, "rey"}
+	war3.TxFeePercentage = sdk.ZeroDec()
+	app.WarsKeeper.SetWar(ctx, war3.Token, war3)
+	reserves3 := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin("rey", 1000))
+	err = app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, reserves3)
+	require.Nil(t, err)
+	err = app.WarsKeeper.DepositReserveFromModule(ctx, war3.Token, types.WarsMintBurnAccount, reserves3)
+	require.Nil(t, err)
+
+	// Route via rez gives 150rey, while direct route gives 1000-10^6/1100 = 90rey
+	from := sdk.NewInt64Coin(reserveToken, 100)
+	route, returns, fees, err = app.WarsKeeper.GetBestSwapRoute(ctx, from, "rey")
+	require.Nil(t, err)
+	require.Equal(t, types.SwapRoute{
+		types.NewSwapHop(war1.Token, reserveToken2),
+		types.NewSwapHop(war2.Token, "rey"),
+	}, route)
+	require.Equal(t, sdk.NewInt64Coin("rey", 150), returns)
+	require.True(t, fees.IsZero())
+
+	// No route to a token that is not a reserve token
+	_, _, _, err = app.WarsKeeper.GetBestSwapRoute(ctx, from, "abc")
+	require.Error(t, err)
 }
 
 func TestOrderCancelled(t *testing.T) {
@@ -1021,7 +1368,7 @@ func TestCheckIfBuyOrderFulfillableAtPrice(t *testing.T) {
 	for i, tc := range testCases {
 		// Create buy order
 		amount := sdk.NewCoin(war.Token, sdk.NewInt(tc.amount))
-		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices)
+		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices, false)
 
 		// Set transaction fee
 		war.TxFeePercentage = tc.txFee
@@ -1072,7 +1419,7 @@ func TestCancelUnfulfillableBuys(t *testing.T) {
 
 		// Create and add buy order
 		amount := sdk.NewCoin(war.Token, sdk.NewInt(tc.amount))
-		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices)
+		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices, false)
 		app.WarsKeeper.AddBuyOrder(ctx, war.Token, bo, buyPrices, blankSellPrices)
 
 		// Add reserve tokens to module account address for return if cancel
@@ -1122,6 +1469,313 @@ func TestCancelUnfulfillableBuys(t *testing.T) {
 	}
 }
 
+func TestGetMaxFulfillableBuyAmountAtPrice(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidWar()
+	war.TxFeePercentage = sdk.ZeroDec()
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+
+	prices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 100)}
+	maxPrices := sdk.Coins{sdk.NewInt64Coin(reserveToken, 1150)}
+
+	testCases := []struct {
+		amount         int64
+		expectedAmount int64
+	}{
+		{10, 10}, // 10 * 100 = 1000 <= 1150, so fully fulfillable
+		{11, 11}, // 11 * 100 = 1100 <= 1150, so fully fulfillable
+		{12, 11}, // 12 * 100 = 1200 > 1150, so reduced to 11
+		{50, 11}, // 50 * 100 = 5000 > 1150, so reduced to 11
+	}
+	for _, tc := range testCases {
+		amount := sdk.NewInt64Coin(war.Token, tc.amount)
+		bo := types.NewBuyOrder(buyerAddress, amount, maxPrices, true)
+		actualAmount := app.WarsKeeper.GetMaxFulfillableBuyAmountAtPrice(ctx, war.Token, bo, prices)
+		require.Equal(t, sdk.NewInt(tc.expectedAmount), actualAmount)
+	}
+
+	// Zero if not even one token is fulfillable
+	bo := types.NewBuyOrder(buyerAddress, sdk.NewInt64Coin(war.Token, 10), sdk.Coins{sdk.NewInt64Coin(reserveToken, 99)}, true)
+	require.True(t, app.WarsKeeper.GetMaxFulfillableBuyAmountAtPrice(ctx, war.Token, bo, prices).IsZero())
+}
+
+func TestCancelUnfulfillableBuysWithPartialFill(t *testing.T) {
+	app, ctx := createTestApp(false)
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	war := getValidWar()
+	war.TxFeePercentage = sdk.ZeroDec()
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+
+	buyPrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 100)}
+	blankSellPrices := sdk.NewDecCoinsFromCoins() // blank
+
+	// Add buy order of 12 tokens that allows partial fills, with max prices
+	// that are only enough for 11 tokens, and one that cannot buy any tokens
+	maxPrices1 := sdk.Coins{sdk.NewInt64Coin(reserveToken, 1100)}
+	maxPrices2 := sdk.Coins{sdk.NewInt64Coin(reserveToken, 50)}
+	bo1 := types.NewBuyOrder(buyerAddress, sdk.NewInt64Coin(war.Token, 12), maxPrices1, true)
+	bo2 := types.NewBuyOrder(sellerAddress, sdk.NewInt64Coin(war.Token, 12), maxPrices2, true)
+	app.WarsKeeper.AddBuyOrder(ctx, war.Token, bo1, buyPrices, blankSellPrices)
+	app.WarsKeeper.AddBuyOrder(ctx, war.Token, bo2, buyPrices, blankSellPrices)
+	_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), maxPrices1.Add(maxPrices2...))
+
+	// Both orders are counted, since both change the batch prices
+	cancelledOrders := app.WarsKeeper.CancelUnfulfillableBuys(ctx, war.Token)
+	require.Equal(t, 2, cancelledOrders)
+
+	// First order was reduced to 11 tokens and its max prices are still locked
+	batch := app.WarsKeeper.MustGetBatch(ctx, war.Token)
+	require.False(t, batch.Buys[0].Cancelled)
+	require.Equal(t, sdk.NewInt64Coin(war.Token, 11), batch.Buys[0].Amount)
+	require.Equal(t, sdk.NewInt64Coin(war.Token, 11), batch.TotalBuyAmount)
+	require.Equal(t, maxPrices1, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+
+	// Second order was cancelled and its max prices were returned
+	require.True(t, batch.Buys[1].Cancelled)
+	require.Equal(t, maxPrices2, app.BankKeeper.GetCoins(ctx, sellerAddress))
+
+	// A partial_fill event was emitted for the first order
+	partialFillEvents := 0
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type == types.EventTypePartialFill {
+			partialFillEvents++
+		}
+	}
+	require.Equal(t, 1, partialFillEvents)
+}
+
+func TestCheckIfSellOrderFulfillableAtPrice(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidWar()
+	war.ExitFeePercentage = sdk.ZeroDec()
+
+	sellPrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 100)}
+	minReturns := sdk.Coins{sdk.NewInt64Coin(reserveToken, 900)}
+
+	testCases := []struct {
+		amount           int64
+		minReturns       sdk.Coins
+		txFee            sdk.Dec
+		orderFulfillable bool
+	}{
+		{
+			10, minReturns, sdk.ZeroDec(), true,
+		}, // (10 * 100) - (10 * FEE) = 1000 >= 900, where FEE=0
+		{
+			9, minReturns, sdk.ZeroDec(), true,
+		}, // (9 * 100) - (9 * FEE) = 900 >= 900, where FEE=0
+		{
+			8, minReturns, sdk.ZeroDec(), false,
+		}, // (8 * 100) - (8 * FEE) = 800 < 900, where FEE=0
+		{
+			10, minReturns, sdk.NewDec(10), true,
+		}, // (10 * 100) - (10 * FEE) = 900 >= 900, where FEE=10
+		{
+			10, minReturns, sdk.NewDec(20), false,
+		}, // (10 * 100) - (10 * FEE) = 800 < 900, where FEE=20
+		{
+			10, nil, sdk.NewDec(20), true,
+		}, // no min returns
+	}
+	for i, tc := range testCases {
+		// Create sell order
+		amount := sdk.NewCoin(war.Token, sdk.NewInt(tc.amount))
+		so := types.NewSellOrder(sellerAddress, amount, tc.minReturns)
+
+		// Set transaction fee
+		war.TxFeePercentage = tc.txFee
+		app.WarsKeeper.SetWar(ctx, war.Token, war)
+
+		err := app.WarsKeeper.CheckIfSellOrderFulfillableAtPrice(
+			ctx, war.Token, so, sellPrices)
+		require.Equal(t, tc.orderFulfillable, err == nil, "unexpected result for test case #%d", i)
+	}
+}
+
+func TestCancelUnfulfillableSells(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidWar()
+	war.TxFeePercentage = sdk.ZeroDec()
+	war.ExitFeePercentage = sdk.ZeroDec()
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+
+	blankBuyPrices := sdk.NewDecCoinsFromCoins() // blank
+	sellPrices := sdk.DecCoins{sdk.NewInt64DecCoin(reserveToken, 100)}
+	zeroTokens := sdk.NewCoin(war.Token, sdk.ZeroInt())
+
+	testCases := []struct {
+		minReturns       sdk.Coins
+		orderFulfillable bool
+	}{
+		{sdk.Coins{sdk.NewInt64Coin(reserveToken, 1000)}, true},  // 10 * 100 = 1000 >= 1000
+		{sdk.Coins{sdk.NewInt64Coin(reserveToken, 1001)}, false}, // 10 * 100 = 1000 < 1001
+	}
+	for _, tc := range testCases {
+		app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+
+		// Create and add sell order (war tokens already burned)
+		amount := sdk.NewCoin(war.Token, sdk.NewInt(10))
+		so := types.NewSellOrder(sellerAddress, amount, tc.minReturns)
+		app.WarsKeeper.AddSellOrder(ctx, war.Token, so, blankBuyPrices, sellPrices)
+
+		// Get account balance before possible cancellation
+		balanceBefore := app.BankKeeper.GetCoins(ctx, sellerAddress)
+
+		// Cancel unfulfillable sells and check amount of cancellations
+		cancelledOrders := app.WarsKeeper.CancelUnfulfillableSells(ctx, war.Token)
+		batch := app.WarsKeeper.MustGetBatch(ctx, war.Token)
+		if tc.orderFulfillable {
+			require.Equal(t, 0, cancelledOrders)
+			require.Equal(t, so.Amount, batch.TotalSellAmount)
+			require.False(t, batch.Sells[0].Cancelled)
+			require.Equal(t, balanceBefore, app.BankKeeper.GetCoins(ctx, sellerAddress))
+		} else {
+			require.Equal(t, 1, cancelledOrders)
+			require.Equal(t, zeroTokens, batch.TotalSellAmount)
+			require.True(t, batch.Sells[0].Cancelled)
+
+			// Check that burned war tokens re-minted and returned to seller
+			newBalance := balanceBefore.Add(amount)
+			require.Equal(t, newBalance, app.BankKeeper.GetCoins(ctx, sellerAddress))
+		}
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	app, ctx := createTestApp(false)
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+
+	// Create war (with a supply of 10 and its reserve of 4*10^3+100*10) and batch
+	war := getValidWar()
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 10)
+	war.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+	currentPrices, _ := war.GetCurrentPricesPT(war.CurrentReserve)
+
+	// Add buy, sell, and swap orders (escrow is added to the module account
+	// and war tokens to be sold are considered to have already been burned)
+	buyAmount := sdk.NewInt64Coin(war.Token, 2)
+	bo := types.NewBuyOrder(buyerAddress, buyAmount, maxPrices, false)
+	app.WarsKeeper.AddBuyOrder(ctx, war.Token, bo, buyPrices, sellPrices)
+	so := types.NewSellOrder(sellerAddress, sellAmount, nil)
+	app.WarsKeeper.AddSellOrder(ctx, war.Token, so, buyPrices, sellPrices)
+	sw := types.NewSwapOrder(swapperAddress, swapFrom, swapTo, sdk.NewInt64Coin(swapTo, 0))
+	app.WarsKeeper.AddSwapOrder(ctx, war.Token, sw)
+	_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), maxPrices.Add(swapFrom))
+
+	// Cancelling non-existent orders fails
+	err := app.WarsKeeper.CancelOrder(ctx, war.Token, buyerAddress, types.BuyOrderType, 1)
+	require.Error(t, err)
+	err = app.WarsKeeper.CancelOrder(ctx, war.Token, buyerAddress, "invalid", 0)
+	require.Error(t, err)
+
+	// Cancelling someone else's order fails
+	err = app.WarsKeeper.CancelOrder(ctx, war.Token, sellerAddress, types.BuyOrderType, 0)
+	require.Error(t, err)
+	require.False(t, app.WarsKeeper.MustGetBatch(ctx, war.Token).Buys[0].Cancelled)
+
+	// Cancelling own buy order refunds max prices and updates batch prices
+	err = app.WarsKeeper.CancelOrder(ctx, war.Token, buyerAddress, types.BuyOrderType, 0)
+	require.Nil(t, err)
+	batch := app.WarsKeeper.MustGetBatch(ctx, war.Token)
+	require.True(t, batch.Buys[0].Cancelled)
+	require.Equal(t, types.CancelReasonCancelledByOwner, batch.Buys[0].CancelReason)
+	require.True(t, batch.TotalBuyAmount.IsZero())
+	require.Equal(t, currentPrices, batch.BuyPrices)
+	require.Equal(t, maxPrices, app.BankKeeper.GetCoins(ctx, buyerAddress))
+
+	// Cancelling an already cancelled order fails
+	err = app.WarsKeeper.CancelOrder(ctx, war.Token, buyerAddress, types.BuyOrderType, 0)
+	require.Error(t, err)
+	require.Equal(t, maxPrices, app.BankKeeper.GetCoins(ctx, buyerAddress))
+
+	// Cancelling own sell order re-mints and returns the war tokens
+	err = app.WarsKeeper.CancelOrder(ctx, war.Token, sellerAddress, types.SellOrderType, 0)
+	require.Nil(t, err)
+	batch = app.WarsKeeper.MustGetBatch(ctx, war.Token)
+	require.True(t, batch.Sells[0].Cancelled)
+	require.True(t, batch.TotalSellAmount.IsZero())
+	require.Equal(t, sdk.Coins{sellAmount}, app.BankKeeper.GetCoins(ctx, sellerAddress))
+
+	// Cancelling own swap order returns the from amount
+	err = app.WarsKeeper.CancelOrder(ctx, war.Token, swapperAddress, types.SwapOrderType, 0)
+	require.Nil(t, err)
+	batch = app.WarsKeeper.MustGetBatch(ctx, war.Token)
+	require.True(t, batch.Swaps[0].Cancelled)
+	require.Equal(t, sdk.Coins{swapFrom}, app.BankKeeper.GetCoins(ctx, swapperAddress))
+
+	// Module account is empty and all three orders emitted an order_cancel event
+	require.True(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()).IsZero())
+	cancelEvents := 0
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type == types.EventTypeOrderCancel {
+			cancelEvents++
+		}
+	}
+	require.Equal(t, 3, cancelEvents)
+}
+
+func TestRefundBatch(t *testing.T) {
+	app, ctx := createTestApp(false)
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+
+	// Create war (with a supply of 10 and its reserve of 4*10^3+100*10) and batch
+	war := getValidWar()
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 10)
+	war.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+
+	// Add buy, sell, and swap orders (escrow is added to the module account
+	// and war tokens to be sold are considered to have already been burned)
+	bo := types.NewBuyOrder(buyerAddress, sdk.NewInt64Coin(war.Token, 2), maxPrices, false)
+	app.WarsKeeper.AddBuyOrder(ctx, war.Token, bo, buyPrices, sellPrices)
+	so := types.NewSellOrder(sellerAddress, sellAmount, nil)
+	app.WarsKeeper.AddSellOrder(ctx, war.Token, so, buyPrices, sellPrices)
+	sw := types.NewSwapOrder(swapperAddress, swapFrom, swapTo, sdk.NewInt64Coin(swapTo, 0))
+	app.WarsKeeper.AddSwapOrder(ctx, war.Token, sw)
+	_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), maxPrices.Add(swapFrom))
+
+	// Already cancelled orders are not refunded again
+	err := app.WarsKeeper.CancelOrder(ctx, war.Token, swapperAddress, types.SwapOrderType, 0)
+	require.Nil(t, err)
+
+	app.WarsKeeper.RefundBatch(ctx, war.Token, types.CancelReasonWarPaused)
+
+	// All orders are refunded and the batch is replaced with a new batch
+	require.Equal(t, maxPrices, app.BankKeeper.GetCoins(ctx, buyerAddress))
+	require.Equal(t, sdk.Coins{sellAmount}, app.BankKeeper.GetCoins(ctx, sellerAddress))
+	require.Equal(t, sdk.Coins{swapFrom}, app.BankKeeper.GetCoins(ctx, swapperAddress))
+	require.True(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()).IsZero())
+	require.Equal(t, types.NewBatch(war.Token, war.BatchBlocks),
+		app.WarsKeeper.MustGetBatch(ctx, war.Token))
+
+	// Refunded orders emitted an order_cancel event with the specified reason
+	cancelReasons := map[string]string{}
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type != types.EventTypeOrderCancel {
+			continue
+		}
+		var orderType, reason string
+		for _, attr := range e.Attributes {
+			switch string(attr.Key) {
+			case types.AttributeKeyOrderType:
+				orderType = string(attr.Value)
+			case types.AttributeKeyCancelReason:
+				reason = string(attr.Value)
+			}
+		}
+		cancelReasons[orderType] = reason
+	}
+	require.Equal(t, map[string]string{
+		types.BuyOrderType:  types.CancelReasonWarPaused,
+		types.SellOrderType: types.CancelReasonWarPaused,
+		types.SwapOrderType: types.CancelReasonCancelledByOwner,
+	}, cancelReasons)
+}
+
 func TestCancelUnfulfillableOrders(t *testing.T) {
 	app, ctx := createTestApp(false)
 	war := getValidWar()
@@ -1162,7 +1816,7 @@ func TestCancelUnfulfillableOrders(t *testing.T) {
 
 		// Create and add buy order
 		amount := sdk.NewCoin(war.Token, sdk.NewInt(tc.amount))
-		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices)
+		bo := types.NewBuyOrder(buyerAddress, amount, tc.maxPrices, false)
 		app.WarsKeeper.AddBuyOrder(ctx, war.Token, bo, buyPrices, blankSellPrices)
 
 		// Add reserve tokens to module account address for return if cancel
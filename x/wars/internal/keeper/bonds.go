@@ -1,9 +1,12 @@
 package keeper
 
 import (
+	"encoding/binary"
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/mage-war/wars/x/wars/internal/types"
+	"strconv"
 )
 
 func (k Keeper) GetWarIterator(ctx sdk.Context) sdk.Iterator {
@@ -47,6 +50,14 @@ func (k Keeper) WarExists(ctx sdk.Context, token string) bool {
 	return store.Has(types.GetWarKey(token))
 }
 
+// AddWar sets a new war and adds it to the number of wars created by its
+// creator. SetWar should be used instead to update an existing war.
+func (k Keeper) AddWar(ctx sdk.Context, token string, war types.War) {
+	k.SetWar(ctx, token, war)
+	k.setWarCountByCreator(ctx, war.Creator,
+		k.GetWarCountByCreator(ctx, war.Creator)+1)
+}
+
 func (k Keeper) SetWar(ctx sdk.Context, token string, war types.War) {
 	store := ctx.KVStore(k.storeKey)
 	store.Set(types.GetWarKey(token), k.cdc.MustMarshalBinaryBare(war))
@@ -98,6 +109,64 @@ func (k Keeper) WithdrawReserve(ctx sdk.Context, token string,
 	return nil
 }
 
+func (k Keeper) WithdrawReserveToModule(ctx sdk.Context, token string,
+	toModule string, amount sdk.Coins) error {
+
+	// Send tokens from wars reserve account
+	err := k.SupplyKeeper.SendCoinsFromModuleToModule(
+		ctx, types.WarsReserveAccount, toModule, amount)
+	if err != nil {
+		return err
+	}
+
+	// Update war reserve
+	k.setReserveBalances(ctx, token,
+		k.MustGetWar(ctx, token).CurrentReserve.Sub(amount))
+	return nil
+}
+
+func (k Keeper) DepositHatchFundingFromModule(ctx sdk.Context, token string,
+	fromModule string, amount sdk.Coins) error {
+
+	// Send tokens to wars reserve account, where they are held in escrow
+	err := k.SupplyKeeper.SendCoinsFromModuleToModule(
+		ctx, fromModule, types.WarsReserveAccount, amount)
+	if err != nil {
+		return err
+	}
+
+	// Update war hatch funding
+	war := k.MustGetWar(ctx, token)
+	war.HatchFunding = war.HatchFunding.Add(amount...)
+	k.SetWar(ctx, token, war)
+	return nil
+}
+
+func (k Keeper) ReleaseHatchFunding(ctx sdk.Context, token string) error {
+	war := k.MustGetWar(ctx, token)
+	if war.HatchFunding.IsZero() {
+		return nil
+	}
+
+	// Clear war hatch funding
+	hatchFunding := war.HatchFunding
+	war.HatchFunding = nil
+	k.SetWar(ctx, token, war)
+
+	// Send escrowed funding from wars reserve account to funding pool or
+	// fee address
+	return k.PayFundingFromModule(ctx, token, types.WarsReserveAccount, hatchFunding)
+}
+
+func (k Keeper) ReturnHatchFundingToReserve(ctx sdk.Context, token string) {
+	// Escrowed funding is already in the wars reserve account, so it only
+	// needs to be moved into the war's reserve balances
+	war := k.MustGetWar(ctx, token)
+	war.CurrentReserve = war.CurrentReserve.Add(war.HatchFunding...)
+	war.HatchFunding = nil
+	k.SetWar(ctx, token, war)
+}
+
 func (k Keeper) setReserveBalances(ctx sdk.Context, token string, balance sdk.Coins) {
 	war := k.MustGetWar(ctx, token)
 	war.CurrentReserve = balance
@@ -148,6 +217,92 @@ func (k Keeper) SetWarState(ctx sdk.Context, token string, newState string) {
 	))
 }
 
+// EditWar applies the edit to the war and stores the edited war. On top of the
+// checks done by WarEdit.Apply, the max supply cannot be less than the supply
+// including any pending buys, and the fee address must be able to receive
+// funds. The edit_war event lists the old and new value of each edited field.
+func (k Keeper) EditWar(ctx sdk.Context, token string, edit types.WarEdit) error {
+	oldWar := k.MustGetWar(ctx, token)
+	war, err := edit.Apply(oldWar)
+	if err != nil {
+		return err
+	}
+
+	if edit.MaxSupply != types.DoNotModifyField {
+		adjustedSupply := k.GetSupplyAdjustedForBuy(ctx, token)
+		if war.MaxSupply.IsLT(adjustedSupply) {
+			return sdkerrors.Wrapf(types.ErrMaxSupplyBelowCurrentSupply,
+				"%s < %s (including pending buys)", war.MaxSupply, adjustedSupply)
+		}
+	}
+	if edit.BatchBlocks != types.DoNotModifyField {
+		if err := k.CheckBatchBlocksWithinParams(ctx, war.BatchBlocks); err != nil {
+			return err
+		}
+	}
+	if edit.FeeAddress != types.DoNotModifyField &&
+		k.BankKeeper.BlacklistedAddr(war.FeeAddress) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized,
+			"%s is not allowed to receive transactions", war.FeeAddress)
+	}
+
+	k.SetWar(ctx, token, war)
+
+	attributes := []sdk.Attribute{sdk.NewAttribute(types.AttributeKeyWar, token)}
+	for _, change := range []struct {
+		edit, key, oldValue, newValue string
+	}{
+		{edit.Name, types.AttributeKeyName, oldWar.Name, war.Name},
+		{edit.Description, types.AttributeKeyDescription, oldWar.Description, war.Description},
+		{edit.OrderQuantityLimits, types.AttributeKeyOrderQuantityLimits,
+			oldWar.OrderQuantityLimits.String(), war.OrderQuantityLimits.String()},
+		{edit.SanityRate, types.AttributeKeySanityRate,
+			oldWar.SanityRate.String(), war.SanityRate.String()},
+		{edit.SanityRate, types.AttributeKeySanityMarginPercentage,
+			oldWar.SanityMarginPercentage.String(), war.SanityMarginPercentage.String()},
+		{edit.TxFeePercentage, types.AttributeKeyTxFeePercentage,
+			oldWar.TxFeePercentage.String(), war.TxFeePercentage.String()},
+		{edit.ExitFeePercentage, types.AttributeKeyExitFeePercentage,
+			oldWar.ExitFeePercentage.String(), war.ExitFeePercentage.String()},
+		{edit.FeeAddress, types.AttributeKeyFeeAddress,
+			oldWar.FeeAddress.String(), war.FeeAddress.String()},
+		{edit.MaxSupply, types.AttributeKeyMaxSupply,
+			oldWar.MaxSupply.String(), war.MaxSupply.String()},
+		{edit.AllowSells, types.AttributeKeyAllowSells,
+			strconv.FormatBool(oldWar.AllowSells), strconv.FormatBool(war.AllowSells)},
+		{edit.BatchBlocks, types.AttributeKeyBatchBlocks,
+			oldWar.BatchBlocks.String(), war.BatchBlocks.String()},
+		{edit.OutcomePayment, types.AttributeKeyOutcomePayment,
+			oldWar.OutcomePayment.String(), war.OutcomePayment.String()},
+	} {
+		// Note: the sanity margin percentage is only edited with the sanity rate
+		if change.edit != types.DoNotModifyField {
+			attributes = append(attributes,
+				sdk.NewAttribute(types.AttributeKeyOldPrefix+change.key, change.oldValue),
+				sdk.NewAttribute(types.AttributeKeyNewPrefix+change.key, change.newValue))
+		}
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeEditWar, attributes...))
+
+	return nil
+}
+
+// UpdateWarSigners replaces the war's signers and signer threshold with the
+// new signers and signer threshold
+func (k Keeper) UpdateWarSigners(ctx sdk.Context, token string, edit types.SignersEdit) {
+	war := k.MustGetWar(ctx, token)
+	war.Signers = edit.Signers
+	war.SignerThreshold = edit.SignerThreshold
+	k.SetWar(ctx, token, war)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateWarSigners,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeySigners, types.AccAddressesToString(edit.Signers)),
+		sdk.NewAttribute(types.AttributeKeySignerThreshold, strconv.FormatUint(edit.SignerThreshold, 10)),
+	))
+}
+
 func (k Keeper) ReservedWarToken(ctx sdk.Context, warToken string) bool {
 	reservedWarTokens := k.GetParams(ctx).ReservedWarTokens
 	for _, rbt := range reservedWarTokens {
@@ -157,3 +312,96 @@ func (k Keeper) ReservedWarToken(ctx sdk.Context, warToken string) bool {
 	}
 	return false
 }
+
+// CheckFeesWithinParams returns an error if either of the fee percentages
+// exceeds the max fee percentages in the module params
+func (k Keeper) CheckFeesWithinParams(ctx sdk.Context, txFeePercentage, exitFeePercentage sdk.Dec) error {
+	params := k.GetParams(ctx)
+	if txFeePercentage.GT(params.MaxTxFeePercentage) {
+		return sdkerrors.Wrapf(types.ErrFeeExceedsMax, "tx fee %s > %s",
+			txFeePercentage, params.MaxTxFeePercentage)
+	} else if exitFeePercentage.GT(params.MaxExitFeePercentage) {
+		return sdkerrors.Wrapf(types.ErrFeeExceedsMax, "exit fee %s > %s",
+			exitFeePercentage, params.MaxExitFeePercentage)
+	}
+	return nil
+}
+
+// CheckBatchBlocksWithinParams returns an error if the batch blocks are below
+// the min batch blocks or above the max batch blocks (if any) in the module
+// params
+func (k Keeper) CheckBatchBlocksWithinParams(ctx sdk.Context, batchBlocks sdk.Uint) error {
+	params := k.GetParams(ctx)
+	if batchBlocks.LT(params.MinBatchBlocks) {
+		return sdkerrors.Wrapf(types.ErrBatchBlocksOutOfRange, "%s < %s",
+			batchBlocks, params.MinBatchBlocks)
+	} else if !params.MaxBatchBlocks.IsZero() && batchBlocks.GT(params.MaxBatchBlocks) {
+		return sdkerrors.Wrapf(types.ErrBatchBlocksOutOfRange, "%s > %s",
+			batchBlocks, params.MaxBatchBlocks)
+	}
+	return nil
+}
+
+// GetWarCountByCreator returns the number of wars created by the creator
+func (k Keeper) GetWarCountByCreator(ctx sdk.Context, creator sdk.AccAddress) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetWarCountByCreatorKey(creator))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) setWarCountByCreator(ctx sdk.Context, creator sdk.AccAddress, count uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetWarCountByCreatorKey(creator), sdk.Uint64ToBigEndian(count))
+}
+
+// CheckWarsPerCreatorWithinParams returns an error if the creator has already
+// created the max wars per creator (if any) in the module params
+func (k Keeper) CheckWarsPerCreatorWithinParams(ctx sdk.Context, creator sdk.AccAddress) error {
+	maxWars := k.GetParams(ctx).MaxWarsPerCreator
+	if maxWars == 0 {
+		return nil
+	}
+
+	if count := k.GetWarCountByCreator(ctx, creator); count >= maxWars {
+		return sdkerrors.Wrapf(types.ErrMaxWarsPerCreatorReached,
+			"%s has created %d wars", creator, count)
+	}
+	return nil
+}
+
+// DepositWarCreationDeposit sends the war creation deposit (if any) in the
+// module params from the creator to the wars deposit account, where it is
+// held until it is refunded to the creator
+func (k Keeper) DepositWarCreationDeposit(ctx sdk.Context, creator sdk.AccAddress) (sdk.Coins, error) {
+	deposit := k.GetParams(ctx).WarCreationDeposit
+	if deposit.IsZero() {
+		return nil, nil
+	}
+
+	err := k.SupplyKeeper.SendCoinsFromAccountToModule(
+		ctx, creator, types.WarsDepositAccount, deposit)
+	if err != nil {
+		return nil, err
+	}
+	return deposit, nil
+}
+
+// RefundWarCreationDeposit sends the war creation deposit (if any) held for
+// the war back to the war creator
+func (k Keeper) RefundWarCreationDeposit(ctx sdk.Context, token string) error {
+	war := k.MustGetWar(ctx, token)
+	if war.CreationDeposit.IsZero() {
+		return nil
+	}
+
+	// Clear war creation deposit
+	deposit := war.CreationDeposit
+	war.CreationDeposit = nil
+	k.SetWar(ctx, token, war)
+
+	return k.SupplyKeeper.SendCoinsFromModuleToAccount(
+		ctx, types.WarsDepositAccount, war.Creator, deposit)
+}
@@ -39,6 +39,24 @@ func TestWarExistsSetGet(t *testing.T) {
 	require.True(t, found)
 }
 
+func TestGetWarCountByCreator(t *testing.T) {
+	app, ctx := createTestApp(false)
+	require.Zero(t, app.WarsKeeper.GetWarCountByCreator(ctx, initCreator))
+
+	// War is counted once when it is added, and not when it is updated
+	war := getValidWar()
+	app.WarsKeeper.AddWar(ctx, token, war)
+	app.WarsKeeper.SetWar(ctx, token, war)
+	require.Equal(t, uint64(1), app.WarsKeeper.GetWarCountByCreator(ctx, initCreator))
+
+	// Wars are counted per creator
+	app.WarsKeeper.AddWar(ctx, token2, war)
+	war.Creator = buyerAddress
+	app.WarsKeeper.AddWar(ctx, token3, war)
+	require.Equal(t, uint64(2), app.WarsKeeper.GetWarCountByCreator(ctx, initCreator))
+	require.Equal(t, uint64(1), app.WarsKeeper.GetWarCountByCreator(ctx, buyerAddress))
+}
+
 func TestDepositReserve(t *testing.T) {
 	app, ctx := createTestApp(false)
 
@@ -147,6 +165,81 @@ func TestWithdrawReserve(t *testing.T) {
 	require.Empty(t, addressBalance)
 }
 
+func TestHatchFunding(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add war
+	war := getValidWar()
+	app.WarsKeeper.SetWar(ctx, token, war)
+
+	// Hatch funding is initially empty
+	require.True(t, app.WarsKeeper.MustGetWar(ctx, token).HatchFunding.IsZero())
+
+	// Mint tokens to a module
+	amount, err := sdk.ParseCoins("12res1,34res2")
+	require.Nil(t, err)
+	err = app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, amount.Add(amount...))
+	require.Nil(t, err)
+
+	// Deposit hatch funding twice
+	err = app.WarsKeeper.DepositHatchFundingFromModule(
+		ctx, token, types.WarsMintBurnAccount, amount)
+	require.Nil(t, err)
+	err = app.WarsKeeper.DepositHatchFundingFromModule(
+		ctx, token, types.WarsMintBurnAccount, amount)
+	require.Nil(t, err)
+
+	// Hatch funding now equal to amount sent, but reserve is still empty
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, amount.Add(amount...), war.HatchFunding)
+	require.True(t, war.CurrentReserve.IsZero())
+
+	// Also confirm that reserve module account has the actual amount
+	moduleAddr := app.SupplyKeeper.GetModuleAddress(types.WarsReserveAccount)
+	addressBalance := app.BankKeeper.GetCoins(ctx, moduleAddr)
+	require.Equal(t, amount.Add(amount...), addressBalance)
+
+	// Release hatch funding to fee address
+	err = app.WarsKeeper.ReleaseHatchFunding(ctx, token)
+	require.Nil(t, err)
+
+	// Hatch funding is now empty and fee address has the amount
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.True(t, war.HatchFunding.IsZero())
+	addressBalance = app.BankKeeper.GetCoins(ctx, war.FeeAddress)
+	require.Equal(t, amount.Add(amount...), addressBalance)
+}
+
+func TestReturnHatchFundingToReserve(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Add war
+	war := getValidWar()
+	app.WarsKeeper.SetWar(ctx, token, war)
+
+	// Mint tokens to a module and deposit them as hatch funding
+	amount, err := sdk.ParseCoins("12res1,34res2")
+	require.Nil(t, err)
+	err = app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, amount)
+	require.Nil(t, err)
+	err = app.WarsKeeper.DepositHatchFundingFromModule(
+		ctx, token, types.WarsMintBurnAccount, amount)
+	require.Nil(t, err)
+
+	// Return hatch funding to reserve
+	app.WarsKeeper.ReturnHatchFundingToReserve(ctx, token)
+
+	// Hatch funding is now empty and reserve is equal to amount
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.True(t, war.HatchFunding.IsZero())
+	require.Equal(t, amount, app.WarsKeeper.GetReserveBalances(ctx, token))
+
+	// Reserve module account still has the actual amount
+	moduleAddr := app.SupplyKeeper.GetModuleAddress(types.WarsReserveAccount)
+	addressBalance := app.BankKeeper.GetCoins(ctx, moduleAddr)
+	require.Equal(t, amount, addressBalance)
+}
+
 func TestGetReserveBalances(t *testing.T) {
 	app, ctx := createTestApp(false)
 
@@ -23,22 +23,38 @@ var (
 	reserveToken                = "res"
 	reserveToken2               = "rez"
 
-	initToken                  = token
-	initName                   = "test token"
-	initDescription            = "this is a test token"
-	initCreator                = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
-	initFeeAddress             = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
-	initTxFeePercentage        = sdk.MustNewDecFromStr("0.1")
-	initExitFeePercentage      = sdk.MustNewDecFromStr("0.1")
-	initMaxSupply              = sdk.NewInt64Coin(initToken, 10000)
-	initOrderQuantityLimits    = sdk.Coins(nil)
-	initSanityRate             = sdk.MustNewDecFromStr(blankSanityRate)
-	initSanityMarginPercentage = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
-	initAllowSell              = true
-	initSigners                = []sdk.AccAddress{initCreator}
-	initBatchBlocks            = sdk.NewUint(10)
-	initOutcomePayment         = sdk.Coins(nil)
-	initState                  = types.OpenState
+	initToken                         = token
+	initName                          = "test token"
+	initDescription                   = "this is a test token"
+	initCreator                       = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	initFeeAddress                    = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	initTxFeePercentage               = sdk.MustNewDecFromStr("0.1")
+	initExitFeePercentage             = sdk.MustNewDecFromStr("0.1")
+	initMaxSupply                     = sdk.NewInt64Coin(initToken, 10000)
+	initOrderQuantityLimits           = sdk.Coins(nil)
+	initSanityRate                    = sdk.MustNewDecFromStr(blankSanityRate)
+	initSanityMarginPercentage        = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
+	initAllowSell                     = true
+	initSigners                       = []sdk.AccAddress{initCreator}
+	initSignerThreshold               = uint64(0)
+	initEditDelay                     = int64(0)
+	initBatchBlocks                   = sdk.NewUint(10)
+	initOutcomePayment                = sdk.Coins(nil)
+	initHatchDeadlineHeight           = int64(0)
+	initAllowedHatchers               = []sdk.AccAddress(nil)
+	initHatchMembershipDenom          = ""
+	initMaxHatchContribution          = sdk.ZeroInt()
+	initHatchVestingCliff             = int64(0)
+	initHatchVestingPeriod            = int64(0)
+	initFundingPoolSpenders           = []sdk.AccAddress(nil)
+	initFundingPoolSpendLimit         = sdk.Coins(nil)
+	initFundingPoolEpochBlocks        = int64(0)
+	initGovernanceQuorumPercentage    = sdk.ZeroDec()
+	initGovernanceThresholdPercentage = sdk.ZeroDec()
+	initGovernanceVotingPeriod        = int64(0)
+	initMaxPriceMovePercentage        = sdk.ZeroDec()
+	initPriceMoveCooldown             = int64(0)
+	initState                         = types.OpenState
 
 	buyPrices = sdk.NewDecCoinsFromCoins(sdk.NewCoins(
 		sdk.NewInt64Coin(reserveToken, 2),
@@ -132,7 +148,13 @@ func getValidPowerFunctionWar() types.War {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initAllowSell, initSigners, initSignerThreshold, initEditDelay, initBatchBlocks, initOutcomePayment, initHatchDeadlineHeight,
+		initAllowedHatchers, initHatchMembershipDenom, initMaxHatchContribution,
+		initHatchVestingCliff, initHatchVestingPeriod, initFundingPoolSpenders,
+		initFundingPoolSpendLimit, initFundingPoolEpochBlocks,
+		initGovernanceQuorumPercentage, initGovernanceThresholdPercentage,
+		initGovernanceVotingPeriod, initMaxPriceMovePercentage, initPriceMoveCooldown,
+		initState)
 }
 
 func getValidAugmentedFunctionWar() types.War {
@@ -143,7 +165,13 @@ func getValidAugmentedFunctionWar() types.War {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initAllowSell, initSigners, initSignerThreshold, initEditDelay, initBatchBlocks, initOutcomePayment, initHatchDeadlineHeight,
+		initAllowedHatchers, initHatchMembershipDenom, initMaxHatchContribution,
+		initHatchVestingCliff, initHatchVestingPeriod, initFundingPoolSpenders,
+		initFundingPoolSpendLimit, initFundingPoolEpochBlocks,
+		initGovernanceQuorumPercentage, initGovernanceThresholdPercentage,
+		initGovernanceVotingPeriod, initMaxPriceMovePercentage, initPriceMoveCooldown,
+		initState)
 }
 
 func getValidSwapperWar() types.War {
@@ -154,7 +182,13 @@ func getValidSwapperWar() types.War {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initAllowSell, initSigners, initSignerThreshold, initEditDelay, initBatchBlocks, initOutcomePayment, initHatchDeadlineHeight,
+		initAllowedHatchers, initHatchMembershipDenom, initMaxHatchContribution,
+		initHatchVestingCliff, initHatchVestingPeriod, initFundingPoolSpenders,
+		initFundingPoolSpendLimit, initFundingPoolEpochBlocks,
+		initGovernanceQuorumPercentage, initGovernanceThresholdPercentage,
+		initGovernanceVotingPeriod, initMaxPriceMovePercentage, initPriceMoveCooldown,
+		initState)
 }
 
 func getValidWar() types.War {
@@ -170,13 +204,13 @@ func getValidBaseOrder() types.BaseOrder {
 }
 
 func getValidBuyOrder() types.BuyOrder {
-	return types.NewBuyOrder(buyerAddress, buyAmount, maxPrices)
+	return types.NewBuyOrder(buyerAddress, buyAmount, maxPrices, false)
 }
 
 func getValidSellOrder() types.SellOrder {
-	return types.NewSellOrder(sellerAddress, sellAmount)
+	return types.NewSellOrder(sellerAddress, sellAmount, nil)
 }
 
 func getValidSwapOrder() types.SwapOrder {
-	return types.NewSwapOrder(swapperAddress, swapFrom, swapTo)
+	return types.NewSwapOrder(swapperAddress, swapFrom, swapTo, sdk.NewInt64Coin(swapTo, 0))
 }
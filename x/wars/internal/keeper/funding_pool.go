@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/mage-war/wars/x/wars/internal/types"
+)
+
+// GetFundingPool returns the funding pool of the war, which is empty if no
+// funding was ever added to the war's funding pool
+func (k Keeper) GetFundingPool(ctx sdk.Context, token string) types.FundingPool {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetFundingPoolKey(token))
+	if bz == nil {
+		return types.NewFundingPool(token)
+	}
+
+	var fundingPool types.FundingPool
+	k.cdc.MustUnmarshalBinaryBare(bz, &fundingPool)
+	return fundingPool
+}
+
+func (k Keeper) SetFundingPool(ctx sdk.Context, token string, fundingPool types.FundingPool) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetFundingPoolKey(token), k.cdc.MustMarshalBinaryBare(fundingPool))
+}
+
+// DepositFundingPoolFromModule sends funding from the module to the funding
+// pool account, where it is held until spent by the war's spenders
+func (k Keeper) DepositFundingPoolFromModule(ctx sdk.Context, token string,
+	fromModule string, amount sdk.Coins) error {
+
+	err := k.SupplyKeeper.SendCoinsFromModuleToModule(
+		ctx, fromModule, types.WarsFundingPoolAccount, amount)
+	if err != nil {
+		return err
+	}
+
+	fundingPool := k.GetFundingPool(ctx, token)
+	fundingPool.Balance = fundingPool.Balance.Add(amount...)
+	k.SetFundingPool(ctx, token, fundingPool)
+	return nil
+}
+
+// PayFundingFromModule sends funding (such as fees) from the module to the
+// war's funding pool if the war has one, or to the war's fee address otherwise
+func (k Keeper) PayFundingFromModule(ctx sdk.Context, token string,
+	fromModule string, amount sdk.Coins) error {
+
+	war := k.MustGetWar(ctx, token)
+	if war.HasFundingPool() {
+		return k.DepositFundingPoolFromModule(ctx, token, fromModule, amount)
+	}
+	return k.SupplyKeeper.SendCoinsFromModuleToAccount(
+		ctx, fromModule, war.FeeAddress, amount)
+}
+
+// PayFundingFromReserve withdraws funding (such as fees) from the war's
+// reserve to the war's funding pool if the war has one, or to the war's fee
+// address otherwise
+func (k Keeper) PayFundingFromReserve(ctx sdk.Context, token string, amount sdk.Coins) error {
+	war := k.MustGetWar(ctx, token)
+	if !war.HasFundingPool() {
+		return k.WithdrawReserve(ctx, token, war.FeeAddress, amount)
+	}
+
+	err := k.WithdrawReserveToModule(ctx, token, types.WarsFundingPoolAccount, amount)
+	if err != nil {
+		return err
+	}
+
+	fundingPool := k.GetFundingPool(ctx, token)
+	fundingPool.Balance = fundingPool.Balance.Add(amount...)
+	k.SetFundingPool(ctx, token, fundingPool)
+	return nil
+}
+
+// GetFundingPoolAllowance returns the amount that can still be spent from the
+// war's funding pool by the spenders in the current epoch, regardless of the
+// pool's balance
+func (k Keeper) GetFundingPoolAllowance(ctx sdk.Context, token string) sdk.Coins {
+	war := k.MustGetWar(ctx, token)
+	if !war.HasFundingPoolSpenders() {
+		return sdk.NewCoins()
+	}
+
+	epochStart := war.GetFundingPoolEpochStart(ctx.BlockHeight())
+	return k.GetFundingPool(ctx, token).Allowance(war.FundingPoolSpendLimit, epochStart)
+}
+
+// SpendFromFundingPool sends the amount from the war's funding pool to the
+// recipient, provided that the amount does not exceed the remaining allowance
+// for the current epoch or the funding pool's balance
+func (k Keeper) SpendFromFundingPool(ctx sdk.Context, token string,
+	recipient sdk.AccAddress, amount sdk.Coins) error {
+
+	allowance := k.GetFundingPoolAllowance(ctx, token)
+	if !amount.IsAllLTE(allowance) {
+		return sdkerrors.Wrapf(types.ErrFundingPoolSpendLimitExceeded,
+			"%s exceeds remaining allowance %s", amount, allowance)
+	}
+
+	fundingPool := k.GetFundingPool(ctx, token)
+	if !amount.IsAllLTE(fundingPool.Balance) {
+		return sdkerrors.Wrapf(types.ErrInsufficientFundingPool,
+			"%s exceeds balance %s", amount, fundingPool.Balance)
+	}
+
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(
+		ctx, types.WarsFundingPoolAccount, recipient, amount)
+	if err != nil {
+		return err
+	}
+
+	war := k.MustGetWar(ctx, token)
+	epochStart := war.GetFundingPoolEpochStart(ctx.BlockHeight())
+	k.SetFundingPool(ctx, token, fundingPool.Spend(amount, epochStart))
+	return nil
+}
+
+// WithdrawFundingPool sends the amount from the war's funding pool to the
+// recipient without applying the spend limit, provided that the amount does
+// not exceed the funding pool's balance. This is used for spend proposals that
+// were passed by the war's token holders.
+func (k Keeper) WithdrawFundingPool(ctx sdk.Context, token string,
+	recipient sdk.AccAddress, amount sdk.Coins) error {
+
+	fundingPool := k.GetFundingPool(ctx, token)
+	if !amount.IsAllLTE(fundingPool.Balance) {
+		return sdkerrors.Wrapf(types.ErrInsufficientFundingPool,
+			"%s exceeds balance %s", amount, fundingPool.Balance)
+	}
+
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(
+		ctx, types.WarsFundingPoolAccount, recipient, amount)
+	if err != nil {
+		return err
+	}
+
+	fundingPool.Balance = fundingPool.Balance.Sub(amount)
+	k.SetFundingPool(ctx, token, fundingPool)
+	return nil
+}
@@ -0,0 +1,108 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/mage-war/wars/x/wars/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPayFundingFromModule(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidWar()
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+
+	funding := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, funding.Add(funding...))
+	require.Nil(t, err)
+
+	// Without a funding pool, funding is sent to the fee address
+	err = app.WarsKeeper.PayFundingFromModule(ctx, war.Token, types.WarsMintBurnAccount, funding)
+	require.Nil(t, err)
+	require.Equal(t, funding, app.BankKeeper.GetCoins(ctx, war.FeeAddress))
+	require.True(t, app.WarsKeeper.GetFundingPool(ctx, war.Token).Balance.IsZero())
+
+	// With a funding pool, funding is held by the funding pool account
+	war.FundingPoolSpenders = []sdk.AccAddress{initCreator}
+	war.FundingPoolSpendLimit = funding
+	war.FundingPoolEpochBlocks = 10
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+
+	err = app.WarsKeeper.PayFundingFromModule(ctx, war.Token, types.WarsMintBurnAccount, funding)
+	require.Nil(t, err)
+	require.Equal(t, funding, app.BankKeeper.GetCoins(ctx, war.FeeAddress))
+	require.Equal(t, funding, app.WarsKeeper.GetFundingPool(ctx, war.Token).Balance)
+
+	fundingPoolAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.WarsFundingPoolAccount)
+	require.Equal(t, funding, fundingPoolAcc.GetCoins())
+}
+
+func TestPayFundingFromReserve(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidWar()
+	war.FundingPoolSpenders = []sdk.AccAddress{initCreator}
+	war.FundingPoolSpendLimit = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+	war.FundingPoolEpochBlocks = 10
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+
+	reserve := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, reserve)
+	require.Nil(t, err)
+	err = app.WarsKeeper.DepositReserveFromModule(ctx, war.Token, types.WarsMintBurnAccount, reserve)
+	require.Nil(t, err)
+
+	// Funding is moved from the reserve to the funding pool
+	funding := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 30))
+	err = app.WarsKeeper.PayFundingFromReserve(ctx, war.Token, funding)
+	require.Nil(t, err)
+	require.Equal(t, reserve.Sub(funding), app.WarsKeeper.GetReserveBalances(ctx, war.Token))
+	require.Equal(t, funding, app.WarsKeeper.GetFundingPool(ctx, war.Token).Balance)
+	require.True(t, app.BankKeeper.GetCoins(ctx, war.FeeAddress).IsZero())
+}
+
+func TestSpendFromFundingPool(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidWar()
+	war.FundingPoolSpenders = []sdk.AccAddress{initCreator}
+	war.FundingPoolSpendLimit = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	war.FundingPoolEpochBlocks = 10
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+
+	balance := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 250))
+	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, balance)
+	require.Nil(t, err)
+	err = app.WarsKeeper.DepositFundingPoolFromModule(ctx, war.Token, types.WarsMintBurnAccount, balance)
+	require.Nil(t, err)
+
+	spend := func(amount int64) error {
+		return app.WarsKeeper.SpendFromFundingPool(ctx, war.Token, buyerAddress,
+			sdk.NewCoins(sdk.NewInt64Coin(reserveToken, amount)))
+	}
+
+	// Spend within the limit of the first epoch
+	ctx = ctx.WithBlockHeight(3)
+	require.Nil(t, spend(60))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 40)),
+		app.WarsKeeper.GetFundingPoolAllowance(ctx, war.Token))
+
+	// Cannot exceed the limit of the first epoch
+	ctx = ctx.WithBlockHeight(9)
+	err = spend(41)
+	require.Error(t, err)
+	require.True(t, types.ErrFundingPoolSpendLimitExceeded.Is(err))
+	require.Nil(t, spend(40))
+
+	// Limit is reset in the second epoch
+	ctx = ctx.WithBlockHeight(10)
+	require.Nil(t, spend(100))
+
+	// Cannot exceed the funding pool balance
+	ctx = ctx.WithBlockHeight(20)
+	err = spend(100)
+	require.Error(t, err)
+	require.True(t, types.ErrInsufficientFundingPool.Is(err))
+	require.Nil(t, spend(50))
+
+	require.Equal(t, balance, app.BankKeeper.GetCoins(ctx, buyerAddress))
+	require.True(t, app.WarsKeeper.GetFundingPool(ctx, war.Token).Balance.IsZero())
+}
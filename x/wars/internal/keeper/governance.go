@@ -0,0 +1,383 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/mage-war/wars/x/wars/internal/types"
+	"strconv"
+)
+
+func (k Keeper) GetNextProposalID(ctx sdk.Context, token string) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetNextProposalIDKey(token))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) SetNextProposalID(ctx sdk.Context, token string, id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetNextProposalIDKey(token), sdk.Uint64ToBigEndian(id))
+}
+
+func (k Keeper) GetProposalIterator(ctx sdk.Context, token string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetProposalsKey(token))
+}
+
+func (k Keeper) GetProposal(ctx sdk.Context, token string, id uint64) (proposal types.Proposal, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetProposalKey(token, id))
+	if bz == nil {
+		return
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &proposal)
+	return proposal, true
+}
+
+func (k Keeper) SetProposal(ctx sdk.Context, token string, proposal types.Proposal) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetProposalKey(token, proposal.ID), k.cdc.MustMarshalBinaryBare(proposal))
+}
+
+// GetProposalsByWar returns the proposals of the war that are still being
+// voted on, in the order that they were submitted. Since the number of
+// proposals per war is limited by the module params, this is bounded.
+func (k Keeper) GetProposalsByWar(ctx sdk.Context, token string) (proposals []types.Proposal) {
+	iterator := k.GetProposalIterator(ctx, token)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var proposal types.Proposal
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &proposal)
+		proposals = append(proposals, proposal)
+	}
+	return proposals
+}
+
+// deleteProposal removes the proposal and all of the votes cast on it
+func (k Keeper) deleteProposal(ctx sdk.Context, token string, id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetProposalKey(token, id))
+
+	var voteKeys [][]byte
+	iterator := k.GetVoteIterator(ctx, token, id)
+	for ; iterator.Valid(); iterator.Next() {
+		voteKeys = append(voteKeys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range voteKeys {
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) GetVoteIterator(ctx sdk.Context, token string, id uint64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetVotesKey(token, id))
+}
+
+func (k Keeper) HasVoted(ctx sdk.Context, token string, id uint64, voter sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetVoteKey(token, id, voter))
+}
+
+func (k Keeper) SetVote(ctx sdk.Context, token string, vote types.Vote) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetVoteKey(token, vote.ProposalID, vote.Voter), k.cdc.MustMarshalBinaryBare(vote))
+}
+
+// GetVotesByProposal returns the votes cast on the proposal
+func (k Keeper) GetVotesByProposal(ctx sdk.Context, token string, id uint64) (votes []types.Vote) {
+	iterator := k.GetVoteIterator(ctx, token, id)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var vote types.Vote
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &vote)
+		votes = append(votes, vote)
+	}
+	return votes
+}
+
+// GetProposals returns the war's next proposal ID, its proposals that are
+// still being voted on, and the votes cast on them
+func (k Keeper) GetProposals(ctx sdk.Context, token string) types.Proposals {
+	proposals := types.NewProposals(token)
+	proposals.NextProposalID = k.GetNextProposalID(ctx, token)
+	proposals.Proposals = k.GetProposalsByWar(ctx, token)
+	for _, p := range proposals.Proposals {
+		proposals.Votes = append(proposals.Votes, k.GetVotesByProposal(ctx, token, p.ID)...)
+	}
+	proposals.VotingEscrows = k.GetVotingEscrowsByWar(ctx, token)
+	return proposals
+}
+
+// SetProposals sets the war's next proposal ID, proposals, votes, and voting
+// escrows
+func (k Keeper) SetProposals(ctx sdk.Context, token string, proposals types.Proposals) {
+	k.SetNextProposalID(ctx, token, proposals.NextProposalID)
+	for _, p := range proposals.Proposals {
+		k.SetProposal(ctx, token, p)
+	}
+	for _, v := range proposals.Votes {
+		k.SetVote(ctx, token, v)
+	}
+	for _, ve := range proposals.VotingEscrows {
+		k.SetVotingEscrow(ctx, token, ve)
+	}
+}
+
+func (k Keeper) GetVotingEscrow(ctx sdk.Context, token string, voter sdk.AccAddress) (escrow types.VotingEscrow, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetVotingEscrowKey(token, voter))
+	if bz == nil {
+		return
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &escrow)
+	return escrow, true
+}
+
+func (k Keeper) SetVotingEscrow(ctx sdk.Context, token string, escrow types.VotingEscrow) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetVotingEscrowKey(token, escrow.Voter), k.cdc.MustMarshalBinaryBare(escrow))
+}
+
+// GetVotingEscrowsByWar returns the voting escrows of the war's voters
+func (k Keeper) GetVotingEscrowsByWar(ctx sdk.Context, token string) (escrows []types.VotingEscrow) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetVotingEscrowsKey(token))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var escrow types.VotingEscrow
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &escrow)
+		escrows = append(escrows, escrow)
+	}
+	return escrows
+}
+
+// escrowVotingTokens sends the war tokens held by the voter to the voting
+// escrow account, where they stay until all of the proposals that the voter
+// voted on are tallied. The voter gets a voting escrow even if it does not
+// hold any war tokens, so that its hatch tokens that vest in the meantime are
+// escrowed as well when they are claimed.
+func (k Keeper) escrowVotingTokens(ctx sdk.Context, token string, voter sdk.AccAddress) error {
+	escrow, found := k.GetVotingEscrow(ctx, token, voter)
+	if !found {
+		escrow = types.NewVotingEscrow(voter, sdk.ZeroInt())
+	}
+
+	balance := k.BankKeeper.GetCoins(ctx, voter).AmountOf(token)
+	if balance.IsPositive() {
+		err := k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, voter,
+			types.WarsVotingEscrowAccount, sdk.Coins{sdk.NewCoin(token, balance)})
+		if err != nil {
+			return err
+		}
+		escrow.Amount = escrow.Amount.Add(balance)
+	}
+
+	k.SetVotingEscrow(ctx, token, escrow)
+	return nil
+}
+
+// releaseVotingEscrow returns the escrowed war tokens to the voter and
+// removes its voting escrow, if the voter has not voted on any of the war's
+// proposals that are still being voted on
+func (k Keeper) releaseVotingEscrow(ctx sdk.Context, token string, voter sdk.AccAddress) error {
+	escrow, found := k.GetVotingEscrow(ctx, token, voter)
+	if !found {
+		return nil
+	}
+	for _, p := range k.GetProposalsByWar(ctx, token) {
+		if k.HasVoted(ctx, token, p.ID, voter) {
+			return nil
+		}
+	}
+
+	if escrow.Amount.IsPositive() {
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.WarsVotingEscrowAccount,
+			voter, sdk.Coins{sdk.NewCoin(token, escrow.Amount)})
+		if err != nil {
+			return err
+		}
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetVotingEscrowKey(token, voter))
+	return nil
+}
+
+// SendWarTokensFromModule sends war tokens from the module account to the
+// address or, if the address has a voting escrow for the war, to its voting
+// escrow, so that war tokens that were counted in a vote (e.g. locked hatch
+// tokens) cannot be counted again until the vote is tallied
+func (k Keeper) SendWarTokensFromModule(ctx sdk.Context, fromModule string,
+	address sdk.AccAddress, amount sdk.Coin) error {
+
+	escrow, found := k.GetVotingEscrow(ctx, amount.Denom, address)
+	if !found {
+		return k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			fromModule, address, sdk.Coins{amount})
+	}
+
+	err := k.SupplyKeeper.SendCoinsFromModuleToModule(ctx,
+		fromModule, types.WarsVotingEscrowAccount, sdk.Coins{amount})
+	if err != nil {
+		return err
+	}
+	escrow.Amount = escrow.Amount.Add(amount.Amount)
+	k.SetVotingEscrow(ctx, amount.Denom, escrow)
+	return nil
+}
+
+// GetVotingPower returns the war tokens of the address, which are the war
+// tokens that it holds, its war tokens in its voting escrow, and its hatch
+// tokens that are still locked in the vesting account
+func (k Keeper) GetVotingPower(ctx sdk.Context, token string, address sdk.AccAddress) sdk.Int {
+	power := k.BankKeeper.GetCoins(ctx, address).AmountOf(token)
+	if escrow, found := k.GetVotingEscrow(ctx, token, address); found {
+		power = power.Add(escrow.Amount)
+	}
+	return power.Add(k.GetLockedHatchTokens(ctx, token, address))
+}
+
+// CheckProposalsPerWarWithinParams returns an error if the war already has
+// the max proposals per war (if any) in the module params being voted on
+func (k Keeper) CheckProposalsPerWarWithinParams(ctx sdk.Context, token string) error {
+	maxProposals := k.GetParams(ctx).MaxProposalsPerWar
+	if maxProposals == 0 {
+		return nil
+	}
+
+	if count := uint64(len(k.GetProposalsByWar(ctx, token))); count >= maxProposals {
+		return sdkerrors.Wrapf(types.ErrMaxProposalsReached,
+			"%s has %d proposals", token, count)
+	}
+	return nil
+}
+
+// AddProposal adds the proposal to the war's proposals and returns the
+// proposal with its ID set
+func (k Keeper) AddProposal(ctx sdk.Context, token string, p types.Proposal) types.Proposal {
+	p.ID = k.GetNextProposalID(ctx, token)
+	k.SetNextProposalID(ctx, token, p.ID+1)
+	k.SetProposal(ctx, token, p)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added %s proposal %d for %s from %s", p.ProposalType, p.ID, token, p.Proposer.String()))
+
+	return p
+}
+
+// AddVote adds the voter's vote to the proposal, weighted by the voter's
+// current voting power, and returns the vote. The war tokens held by the voter
+// are escrowed until the proposal is tallied, so that they cannot be used by
+// another address to vote on the proposal again.
+func (k Keeper) AddVote(ctx sdk.Context, token string, id uint64,
+	voter sdk.AccAddress, option string) (types.Vote, error) {
+
+	if _, found := k.GetProposal(ctx, token, id); !found {
+		return types.Vote{}, sdkerrors.Wrapf(types.ErrProposalDoesNotExist, "proposal %d", id)
+	}
+
+	power := k.GetVotingPower(ctx, token, voter)
+	if !power.IsPositive() {
+		return types.Vote{}, sdkerrors.Wrap(types.ErrNoVotingPower, voter.String())
+	} else if k.HasVoted(ctx, token, id, voter) {
+		return types.Vote{}, sdkerrors.Wrap(types.ErrAlreadyVoted, voter.String())
+	}
+
+	err := k.escrowVotingTokens(ctx, token, voter)
+	if err != nil {
+		return types.Vote{}, err
+	}
+
+	vote := types.NewVote(id, voter, option, power)
+	k.SetVote(ctx, token, vote)
+
+	return vote, nil
+}
+
+// TallyVotes returns the total voting power of the yes and no votes
+func TallyVotes(votes []types.Vote) types.TallyResult {
+	result := types.NewTallyResult()
+	for _, v := range votes {
+		result = result.Add(v)
+	}
+	return result
+}
+
+func (k Keeper) executeProposal(ctx sdk.Context, token string, p types.Proposal) error {
+	switch p.ProposalType {
+	case types.SpendProposalType:
+		return k.WithdrawFundingPool(ctx, token, p.Recipient, p.Amount)
+	case types.EditProposalType:
+		return k.EditWar(ctx, token, p.Edit)
+	default:
+		return sdkerrors.Wrap(types.ErrInvalidProposalType, p.ProposalType)
+	}
+}
+
+// TallyEndedProposals tallies the war's proposals whose voting period has
+// ended against the war's current supply and removes them and their votes.
+// Proposals that passed are executed, unless their execution fails (e.g. if
+// the funding pool balance is insufficient), in which case none of their
+// changes are applied. The voting escrows of the voters that did not vote on
+// any of the remaining proposals are then returned to the voters.
+func (k Keeper) TallyEndedProposals(ctx sdk.Context, token string) error {
+	var ended []types.Proposal
+	for _, p := range k.GetProposalsByWar(ctx, token) {
+		if p.IsVotingEnded(ctx.BlockHeight()) {
+			ended = append(ended, p)
+		}
+	}
+	if len(ended) == 0 {
+		return nil
+	}
+
+	war := k.MustGetWar(ctx, token)
+	logger := k.Logger(ctx)
+	var voters []sdk.AccAddress
+	for _, p := range ended {
+		votes := k.GetVotesByProposal(ctx, token, p.ID)
+		for _, v := range votes {
+			voters = append(voters, v.Voter)
+		}
+		tally := TallyVotes(votes)
+		k.deleteProposal(ctx, token, p.ID)
+
+		result := types.ProposalResultRejected
+		if tally.IsPassed(war.CurrentSupply.Amount,
+			war.GovernanceQuorumPercentage, war.GovernanceThresholdPercentage) {
+			cacheCtx, writeCache := ctx.CacheContext()
+			err := k.executeProposal(cacheCtx, token, p)
+			if err != nil {
+				result = types.ProposalResultFailed
+				logger.Debug(fmt.Sprintf("proposal %d for %s failed: %s", p.ID, token, err.Error()))
+			} else {
+				result = types.ProposalResultPassed
+				writeCache()
+				ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+			}
+		}
+
+		logger.Info(fmt.Sprintf("%s proposal %d for %s %s", p.ProposalType, p.ID, token, result))
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeProposalResult,
+			sdk.NewAttribute(types.AttributeKeyWar, token),
+			sdk.NewAttribute(types.AttributeKeyProposalID, strconv.FormatUint(p.ID, 10)),
+			sdk.NewAttribute(types.AttributeKeyProposalType, p.ProposalType),
+			sdk.NewAttribute(types.AttributeKeyProposalResult, result),
+		))
+	}
+
+	for _, voter := range voters {
+		err := k.releaseVotingEscrow(ctx, token, voter)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/mage-war/wars/x/wars/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetVotingPower(t *testing.T) {
+	app, ctx := createTestApp(false)
+	app.WarsKeeper.SetWar(ctx, token, getValidWar())
+	require.True(t, app.WarsKeeper.GetVotingPower(ctx, token, buyerAddress).IsZero())
+
+	// Tokens held by the address, its tokens in its voting escrow, and its
+	// locked hatch tokens count as voting power
+	_, err := app.BankKeeper.AddCoins(ctx, buyerAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 100)))
+	require.Nil(t, err)
+	app.WarsKeeper.SetHatchVesting(ctx, token, types.NewHatchVesting(buyerAddress, sdk.NewInt(20)))
+	app.WarsKeeper.SetVotingEscrow(ctx, token, types.NewVotingEscrow(buyerAddress, sdk.NewInt(5)))
+	require.Equal(t, sdk.NewInt(125), app.WarsKeeper.GetVotingPower(ctx, token, buyerAddress))
+
+	// Tokens escrowed by limit sells and the tokens of other addresses do
+	// not count
+	app.WarsKeeper.AddLimitOrder(ctx, token, types.NewLimitOrder(types.LimitSellOrderType,
+		buyerAddress, sdk.NewInt64Coin(token, 7), sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1)), 10))
+	_, err = app.BankKeeper.AddCoins(ctx, sellerAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 50)))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(125), app.WarsKeeper.GetVotingPower(ctx, token, buyerAddress))
+	require.Equal(t, sdk.NewInt(50), app.WarsKeeper.GetVotingPower(ctx, token, sellerAddress))
+}
+
+func TestVotingEscrow(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidWar()
+	war.GovernanceVotingPeriod = 10
+	app.WarsKeeper.SetWar(ctx, token, war)
+
+	tokens := sdk.NewCoins(sdk.NewInt64Coin(token, 100))
+	_, err := app.BankKeeper.AddCoins(ctx, buyerAddress, tokens)
+	require.Nil(t, err)
+	p0 := app.WarsKeeper.AddProposal(ctx, token,
+		types.NewEditProposal(buyerAddress, types.WarEdit{}, 10))
+	p1 := app.WarsKeeper.AddProposal(ctx, token,
+		types.NewEditProposal(buyerAddress, types.WarEdit{}, 20))
+
+	// Voting escrows the voter's tokens
+	vote, err := app.WarsKeeper.AddVote(ctx, token, p0.ID, buyerAddress, types.YesVoteOption)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(100), vote.Power)
+	require.True(t, app.BankKeeper.GetCoins(ctx, buyerAddress).AmountOf(token).IsZero())
+	escrowAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.WarsVotingEscrowAccount)
+	require.Equal(t, tokens, escrowAcc.GetCoins())
+
+	// Voter cannot transfer the tokens, so another address cannot vote with
+	// the same tokens
+	err = app.BankKeeper.SendCoins(ctx, buyerAddress, sellerAddress, tokens)
+	require.Error(t, err)
+	_, err = app.WarsKeeper.AddVote(ctx, token, p0.ID, sellerAddress, types.YesVoteOption)
+	require.Error(t, err)
+	require.True(t, types.ErrNoVotingPower.Is(err))
+
+	// Escrowed tokens still count for other proposals
+	vote, err = app.WarsKeeper.AddVote(ctx, token, p1.ID, buyerAddress, types.YesVoteOption)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(100), vote.Power)
+
+	// Tokens sent from a module to the voter (e.g. claimed hatch tokens) are
+	// escrowed as well
+	err = app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, sdk.NewCoins(sdk.NewInt64Coin(token, 10)))
+	require.Nil(t, err)
+	err = app.WarsKeeper.SendWarTokensFromModule(ctx, types.WarsMintBurnAccount,
+		buyerAddress, sdk.NewInt64Coin(token, 10))
+	require.Nil(t, err)
+	require.True(t, app.BankKeeper.GetCoins(ctx, buyerAddress).AmountOf(token).IsZero())
+	escrow, found := app.WarsKeeper.GetVotingEscrow(ctx, token, buyerAddress)
+	require.True(t, found)
+	require.Equal(t, sdk.NewInt(110), escrow.Amount)
+
+	// Tokens stay escrowed while the voter has votes on remaining proposals
+	err = app.WarsKeeper.TallyEndedProposals(ctx.WithBlockHeight(10), token)
+	require.NoError(t, err)
+	_, found = app.WarsKeeper.GetVotingEscrow(ctx, token, buyerAddress)
+	require.True(t, found)
+	require.True(t, app.BankKeeper.GetCoins(ctx, buyerAddress).AmountOf(token).IsZero())
+
+	// Tokens are returned once all of the voter's proposals are tallied
+	err = app.WarsKeeper.TallyEndedProposals(ctx.WithBlockHeight(20), token)
+	require.NoError(t, err)
+	_, found = app.WarsKeeper.GetVotingEscrow(ctx, token, buyerAddress)
+	require.False(t, found)
+	require.Equal(t, sdk.NewInt(110), app.BankKeeper.GetCoins(ctx, buyerAddress).AmountOf(token))
+}
+
+func TestAddProposalAndVote(t *testing.T) {
+	app, ctx := createTestApp(false)
+	_, err := app.BankKeeper.AddCoins(ctx, buyerAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 100)))
+	require.Nil(t, err)
+
+	// Proposals are given consecutive IDs
+	p := types.NewEditProposal(buyerAddress, types.WarEdit{}, 10)
+	p0 := app.WarsKeeper.AddProposal(ctx, token, p)
+	p1 := app.WarsKeeper.AddProposal(ctx, token, p)
+	require.Equal(t, uint64(0), p0.ID)
+	require.Equal(t, uint64(1), p1.ID)
+	require.Equal(t, uint64(2), app.WarsKeeper.GetNextProposalID(ctx, token))
+	require.Equal(t, []types.Proposal{p0, p1}, app.WarsKeeper.GetProposalsByWar(ctx, token))
+
+	// Proposals of other wars are not returned, even if their token starts
+	// with the war's token
+	app.WarsKeeper.AddProposal(ctx, token+"x", p)
+	require.Len(t, app.WarsKeeper.GetProposalsByWar(ctx, token), 2)
+
+	// Cannot vote on proposal that does not exist
+	_, err = app.WarsKeeper.AddVote(ctx, token, 2, buyerAddress, types.YesVoteOption)
+	require.Error(t, err)
+	require.True(t, types.ErrProposalDoesNotExist.Is(err))
+
+	// Cannot vote without voting power
+	_, err = app.WarsKeeper.AddVote(ctx, token, 1, sellerAddress, types.YesVoteOption)
+	require.Error(t, err)
+	require.True(t, types.ErrNoVotingPower.Is(err))
+
+	// Vote is weighted by voting power when the vote is cast
+	vote, err := app.WarsKeeper.AddVote(ctx, token, 1, buyerAddress, types.YesVoteOption)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(100), vote.Power)
+	require.Empty(t, app.WarsKeeper.GetVotesByProposal(ctx, token, 0))
+	require.Equal(t, []types.Vote{vote}, app.WarsKeeper.GetVotesByProposal(ctx, token, 1))
+
+	// Cannot vote twice
+	_, err = app.WarsKeeper.AddVote(ctx, token, 1, buyerAddress, types.NoVoteOption)
+	require.Error(t, err)
+	require.True(t, types.ErrAlreadyVoted.Is(err))
+}
+
+func TestCheckProposalsPerWarWithinParams(t *testing.T) {
+	app, ctx := createTestApp(false)
+	params := types.DefaultParams()
+	params.MaxProposalsPerWar = 2
+	app.WarsKeeper.SetParams(ctx, params)
+
+	p := types.NewEditProposal(buyerAddress, types.WarEdit{}, 10)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, app.WarsKeeper.CheckProposalsPerWarWithinParams(ctx, token))
+		app.WarsKeeper.AddProposal(ctx, token, p)
+	}
+	err := app.WarsKeeper.CheckProposalsPerWarWithinParams(ctx, token)
+	require.Error(t, err)
+	require.True(t, types.ErrMaxProposalsReached.Is(err))
+
+	// Zero for no max
+	params.MaxProposalsPerWar = 0
+	app.WarsKeeper.SetParams(ctx, params)
+	require.NoError(t, app.WarsKeeper.CheckProposalsPerWarWithinParams(ctx, token))
+}
+
+func TestTallyEndedProposals(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidWar()
+	war.GovernanceQuorumPercentage = sdk.NewDec(50)
+	war.GovernanceThresholdPercentage = sdk.NewDec(50)
+	war.GovernanceVotingPeriod = 10
+	war.CurrentSupply = sdk.NewInt64Coin(token, 100)
+	app.WarsKeeper.SetWar(ctx, token, war)
+
+	balance := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, balance)
+	require.Nil(t, err)
+	err = app.WarsKeeper.DepositFundingPoolFromModule(ctx, token, types.WarsMintBurnAccount, balance)
+	require.Nil(t, err)
+
+	_, err = app.BankKeeper.AddCoins(ctx, buyerAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 60)))
+	require.Nil(t, err)
+	_, err = app.BankKeeper.AddCoins(ctx, sellerAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 40)))
+	require.Nil(t, err)
+
+	spend := func(amount int64) types.Proposal {
+		return app.WarsKeeper.AddProposal(ctx, token, types.NewSpendProposal(
+			buyerAddress, initCreator, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, amount)), 10))
+	}
+	vote := func(p types.Proposal, voter sdk.AccAddress, option string) {
+		_, err := app.WarsKeeper.AddVote(ctx, token, p.ID, voter, option)
+		require.NoError(t, err)
+	}
+
+	// Passed, rejected, and failed (insufficient balance) proposals, and one
+	// proposal that is still being voted on
+	passed := spend(30)
+	vote(passed, buyerAddress, types.YesVoteOption)
+	vote(passed, sellerAddress, types.NoVoteOption)
+	rejected := spend(30)
+	vote(rejected, buyerAddress, types.NoVoteOption)
+	failed := spend(1000)
+	vote(failed, buyerAddress, types.YesVoteOption)
+	edit := types.NewWarEdit("newName", types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField)
+	remaining := app.WarsKeeper.AddProposal(ctx, token,
+		types.NewEditProposal(buyerAddress, edit, 20))
+	vote(remaining, buyerAddress, types.YesVoteOption)
+
+	// Nothing is tallied before the voting end height
+	err = app.WarsKeeper.TallyEndedProposals(ctx.WithBlockHeight(9), token)
+	require.NoError(t, err)
+	require.Len(t, app.WarsKeeper.GetProposalsByWar(ctx, token), 4)
+
+	// Only the passed proposal is executed, and the tallied proposals and
+	// their votes are removed
+	ctx = ctx.WithBlockHeight(10)
+	err = app.WarsKeeper.TallyEndedProposals(ctx, token)
+	require.NoError(t, err)
+	proposals := app.WarsKeeper.GetProposalsByWar(ctx, token)
+	require.Len(t, proposals, 1)
+	require.Equal(t, remaining.ID, proposals[0].ID)
+	require.Empty(t, app.WarsKeeper.GetVotesByProposal(ctx, token, passed.ID))
+	require.Len(t, app.WarsKeeper.GetVotesByProposal(ctx, token, remaining.ID), 1)
+	require.Equal(t, passed.Amount, app.BankKeeper.GetCoins(ctx, initCreator))
+	require.Equal(t, balance.Sub(passed.Amount), app.WarsKeeper.GetFundingPool(ctx, token).Balance)
+
+	results := map[string]string{}
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type != types.EventTypeProposalResult {
+			continue
+		}
+		var id, result string
+		for _, attr := range e.Attributes {
+			switch string(attr.Key) {
+			case types.AttributeKeyProposalID:
+				id = string(attr.Value)
+			case types.AttributeKeyProposalResult:
+				result = string(attr.Value)
+			}
+		}
+		results[id] = result
+	}
+	require.Equal(t, map[string]string{
+		"0": types.ProposalResultPassed,
+		"1": types.ProposalResultRejected,
+		"2": types.ProposalResultFailed,
+	}, results)
+
+	// Edit proposal is executed once its voting period ends, and the events
+	// of the executed edit are emitted
+	ctx = ctx.WithBlockHeight(20).WithEventManager(sdk.NewEventManager())
+	err = app.WarsKeeper.TallyEndedProposals(ctx, token)
+	require.NoError(t, err)
+	require.Empty(t, app.WarsKeeper.GetProposalsByWar(ctx, token))
+	require.Equal(t, "newName", app.WarsKeeper.MustGetWar(ctx, token).Name)
+	require.Equal(t, types.EventTypeEditWar, ctx.EventManager().Events()[0].Type)
+
+	// Proposal is rejected if the votes cast are below the quorum of the
+	// current supply, even if all of them are yes votes
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	war.CurrentSupply = sdk.NewInt64Coin(token, 200)
+	app.WarsKeeper.SetWar(ctx, token, war)
+	belowQuorum := app.WarsKeeper.AddProposal(ctx, token,
+		types.NewEditProposal(buyerAddress, edit, 30))
+	vote(belowQuorum, buyerAddress, types.YesVoteOption)
+	ctx = ctx.WithBlockHeight(30).WithEventManager(sdk.NewEventManager())
+	err = app.WarsKeeper.TallyEndedProposals(ctx, token)
+	require.NoError(t, err)
+	events := ctx.EventManager().Events()
+	require.Equal(t, types.EventTypeProposalResult, events[0].Type)
+	require.Contains(t, events[0].Attributes, sdk.NewAttribute(
+		types.AttributeKeyProposalResult, types.ProposalResultRejected).ToKVPair())
+}
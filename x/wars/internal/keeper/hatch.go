@@ -0,0 +1,311 @@
+package keeper
+
+import (
+	"encoding/binary"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/mage-war/wars/x/wars/internal/types"
+)
+
+func (k Keeper) GetHatchContributionIterator(ctx sdk.Context, token string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetHatchContributionsKey(token))
+}
+
+// GetHatchContribution returns the hatch contribution of the address, which
+// is zero if the address did not buy any war tokens during the hatch phase
+func (k Keeper) GetHatchContribution(ctx sdk.Context, token string, address sdk.AccAddress) types.HatchContribution {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetHatchContributionKey(token, address))
+	if bz == nil {
+		return types.NewHatchContribution(address, sdk.ZeroInt())
+	}
+
+	var hatchContribution types.HatchContribution
+	k.cdc.MustUnmarshalBinaryBare(bz, &hatchContribution)
+	return hatchContribution
+}
+
+func (k Keeper) SetHatchContribution(ctx sdk.Context, token string, hatchContribution types.HatchContribution) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetHatchContributionKey(token, hatchContribution.Address),
+		k.cdc.MustMarshalBinaryBare(hatchContribution))
+}
+
+// GetHatchContributions returns the hatch contributions of the war, which are
+// empty if no war tokens were ever bought during the war's hatch phase
+func (k Keeper) GetHatchContributions(ctx sdk.Context, token string) types.HatchContributions {
+	hatchContributions := types.NewHatchContributions(token)
+
+	iterator := k.GetHatchContributionIterator(ctx, token)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var hatchContribution types.HatchContribution
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &hatchContribution)
+		hatchContributions.Contributions = append(hatchContributions.Contributions, hatchContribution)
+	}
+	return hatchContributions
+}
+
+func (k Keeper) SetHatchContributions(ctx sdk.Context, token string, hatchContributions types.HatchContributions) {
+	for _, hc := range hatchContributions.Contributions {
+		k.SetHatchContribution(ctx, token, hc)
+	}
+}
+
+func (k Keeper) AddHatchContribution(ctx sdk.Context, token string, address sdk.AccAddress, amount sdk.Int) {
+	hatchContribution := k.GetHatchContribution(ctx, token, address)
+	hatchContribution.Amount = hatchContribution.Amount.Add(amount)
+	k.SetHatchContribution(ctx, token, hatchContribution)
+}
+
+// CheckHatchRestrictions checks that the buyer is allowed to buy during the
+// hatch phase of the war, i.e. that the buyer is in the war's allow-list or
+// holds the war's membership token (if either is set), and that the buy order
+// does not take the buyer's hatch contribution above the war's max hatch
+// contribution (if set). The buyer's contribution includes both the war tokens
+// bought in previous batches and the buys in the current batch.
+func (k Keeper) CheckHatchRestrictions(ctx sdk.Context, token string, bo types.BuyOrder) error {
+	war := k.MustGetWar(ctx, token)
+
+	if war.IsHatchRestricted() && !war.IsAllowedHatcher(bo.Address) {
+		membershipBalance := sdk.ZeroInt()
+		if war.HatchMembershipDenom != "" {
+			membershipBalance = k.BankKeeper.GetCoins(ctx, bo.Address).AmountOf(war.HatchMembershipDenom)
+		}
+		if !membershipBalance.IsPositive() {
+			return sdkerrors.Wrap(types.ErrNotAllowedToHatch, bo.Address.String())
+		}
+	}
+
+	if war.MaxHatchContribution.IsPositive() {
+		contribution := k.GetHatchContribution(ctx, token, bo.Address).Amount
+		for _, b := range k.MustGetBatch(ctx, token).Buys {
+			if !b.Cancelled && b.Address.Equals(bo.Address) {
+				contribution = contribution.Add(b.Amount.Amount)
+			}
+		}
+		contribution = contribution.Add(bo.Amount.Amount)
+		if contribution.GT(war.MaxHatchContribution) {
+			return sdkerrors.Wrapf(types.ErrMaxHatchContributionExceeded,
+				"%s exceeds max hatch contribution %s", contribution, war.MaxHatchContribution)
+		}
+	}
+
+	return nil
+}
+
+func (k Keeper) GetHatchVestingIterator(ctx sdk.Context, token string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetHatchVestingsKey(token))
+}
+
+// GetHatchVesting returns the hatch vesting of the address, which is empty if
+// the address did not buy any war tokens during the hatch phase
+func (k Keeper) GetHatchVesting(ctx sdk.Context, token string, address sdk.AccAddress) types.HatchVesting {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetHatchVestingKey(token, address))
+	if bz == nil {
+		return types.NewHatchVesting(address, sdk.ZeroInt())
+	}
+
+	var hatchVesting types.HatchVesting
+	k.cdc.MustUnmarshalBinaryBare(bz, &hatchVesting)
+	return hatchVesting
+}
+
+func (k Keeper) SetHatchVesting(ctx sdk.Context, token string, hatchVesting types.HatchVesting) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetHatchVestingKey(token, hatchVesting.Address),
+		k.cdc.MustMarshalBinaryBare(hatchVesting))
+}
+
+// GetHatchVestingStartHeight returns the block height at which the war's
+// locked war tokens started vesting, which is zero if they did not start yet
+func (k Keeper) GetHatchVestingStartHeight(ctx sdk.Context, token string) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetHatchVestingStartHeightKey(token))
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+func (k Keeper) SetHatchVestingStartHeight(ctx sdk.Context, token string, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetHatchVestingStartHeightKey(token), sdk.Uint64ToBigEndian(uint64(height)))
+}
+
+// GetHatchVestings returns the hatch vestings of the war, which are empty if
+// no war tokens were ever locked during the war's hatch phase
+func (k Keeper) GetHatchVestings(ctx sdk.Context, token string) types.HatchVestings {
+	hatchVestings := types.NewHatchVestings(token)
+	hatchVestings.StartHeight = k.GetHatchVestingStartHeight(ctx, token)
+
+	iterator := k.GetHatchVestingIterator(ctx, token)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var hatchVesting types.HatchVesting
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &hatchVesting)
+		hatchVestings.Vestings = append(hatchVestings.Vestings, hatchVesting)
+	}
+	return hatchVestings
+}
+
+func (k Keeper) SetHatchVestings(ctx sdk.Context, token string, hatchVestings types.HatchVestings) {
+	k.SetHatchVestingStartHeight(ctx, token, hatchVestings.StartHeight)
+	for _, hv := range hatchVestings.Vestings {
+		k.SetHatchVesting(ctx, token, hv)
+	}
+}
+
+// LockHatchTokensFromModule sends war tokens bought during the hatch phase
+// from the module to the vesting account, where they are held until vested
+func (k Keeper) LockHatchTokensFromModule(ctx sdk.Context, token string,
+	fromModule string, address sdk.AccAddress, amount sdk.Coin) error {
+
+	err := k.SupplyKeeper.SendCoinsFromModuleToModule(ctx,
+		fromModule, types.WarsVestingAccount, sdk.Coins{amount})
+	if err != nil {
+		return err
+	}
+
+	hatchVesting := k.GetHatchVesting(ctx, token, address)
+	hatchVesting.Amount = hatchVesting.Amount.Add(amount.Amount)
+	k.SetHatchVesting(ctx, token, hatchVesting)
+	return nil
+}
+
+// StartHatchVesting sets the current block height as the height at which the
+// locked war tokens start vesting
+func (k Keeper) StartHatchVesting(ctx sdk.Context, token string) {
+	k.SetHatchVestingStartHeight(ctx, token, ctx.BlockHeight())
+}
+
+// GetLockedHatchTokens returns the number of war tokens bought by the address
+// during the hatch phase that are still held by the vesting account
+func (k Keeper) GetLockedHatchTokens(ctx sdk.Context, token string, address sdk.AccAddress) sdk.Int {
+	return k.GetHatchVesting(ctx, token, address).Locked()
+}
+
+// GetClaimableHatchTokens returns the number of war tokens bought by the
+// address during the hatch phase that are vested but not yet claimed. No
+// tokens vest before the war's hatch phase succeeds.
+func (k Keeper) GetClaimableHatchTokens(ctx sdk.Context, token string, address sdk.AccAddress) sdk.Int {
+	war := k.MustGetWar(ctx, token)
+	if war.State == types.HatchState {
+		return sdk.ZeroInt()
+	}
+
+	elapsed := ctx.BlockHeight() - k.GetHatchVestingStartHeight(ctx, token)
+	return k.GetHatchVesting(ctx, token, address).Claimable(
+		elapsed, war.HatchVestingCliff, war.HatchVestingPeriod)
+}
+
+// ClaimVestedHatchTokens sends the vested war tokens that were not yet
+// claimed by the address from the vesting account to the address, or to its
+// voting escrow if it voted on proposals that are still being voted on
+func (k Keeper) ClaimVestedHatchTokens(ctx sdk.Context, token string, address sdk.AccAddress) (sdk.Coin, error) {
+	claimable := k.GetClaimableHatchTokens(ctx, token, address)
+	if !claimable.IsPositive() {
+		return sdk.Coin{}, sdkerrors.Wrap(types.ErrNoVestedTokensToClaim, address.String())
+	}
+	claimed := sdk.NewCoin(token, claimable)
+
+	err := k.SendWarTokensFromModule(ctx, types.WarsVestingAccount, address, claimed)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	hatchVesting := k.GetHatchVesting(ctx, token, address)
+	hatchVesting.Claimed = hatchVesting.Claimed.Add(claimable)
+	k.SetHatchVesting(ctx, token, hatchVesting)
+	return claimed, nil
+}
+
+// ReleaseHatchVestings sends all of the war tokens that are still locked to
+// their owners (or their voting escrows), regardless of whether they are
+// vested or not. This is used when the hatch phase fails, so that hatchers can
+// reclaim their share.
+func (k Keeper) ReleaseHatchVestings(ctx sdk.Context, token string) error {
+	var hatchVestings []types.HatchVesting
+	iterator := k.GetHatchVestingIterator(ctx, token)
+	for ; iterator.Valid(); iterator.Next() {
+		var hatchVesting types.HatchVesting
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &hatchVesting)
+		hatchVestings = append(hatchVestings, hatchVesting)
+	}
+	iterator.Close()
+
+	for _, v := range hatchVestings {
+		locked := v.Locked()
+		if !locked.IsPositive() {
+			continue
+		}
+
+		err := k.SendWarTokensFromModule(ctx,
+			types.WarsVestingAccount, v.Address, sdk.NewCoin(token, locked))
+		if err != nil {
+			return err
+		}
+		v.Claimed = v.Claimed.Add(locked)
+		k.SetHatchVesting(ctx, token, v)
+	}
+	return nil
+}
+
+// CheckSellNotLocked checks that the seller can sell the amount without
+// selling any of its war tokens that are locked until vested
+func (k Keeper) CheckSellNotLocked(ctx sdk.Context, seller sdk.AccAddress, amount sdk.Coin) error {
+	locked := k.GetLockedHatchTokens(ctx, amount.Denom, seller)
+	if !locked.IsPositive() {
+		return nil
+	}
+
+	unlocked := k.BankKeeper.GetCoins(ctx, seller).AmountOf(amount.Denom)
+	if amount.Amount.GT(unlocked) {
+		return sdkerrors.Wrapf(types.ErrWarTokensLocked,
+			"%s exceeds unlocked balance %s (locked: %s)", amount.Amount, unlocked, locked)
+	}
+	return nil
+}
+
+// UpdateHatchState moves an augmented war out of its hatch phase. If the war
+// supply reached the initial supply S0, the war goes to open phase, releases
+// its escrowed funding and starts vesting the locked war tokens. Otherwise, if
+// its hatch deadline has been reached, the war goes to failed phase, refunds
+// the orders in its current batch and the limit orders in its order book,
+// returns the escrowed funding to the reserve, refunds the war creation
+// deposit and releases the locked war tokens, so that hatchers can reclaim
+// their share
+func (k Keeper) UpdateHatchState(ctx sdk.Context, token string) error {
+	war := k.MustGetWar(ctx, token)
+	if war.FunctionType != types.AugmentedFunction ||
+		war.State != types.HatchState {
+		return nil
+	}
+
+	args := war.FunctionParameters.AsMap()
+	if war.CurrentSupply.Amount.ToDec().GTE(args["S0"]) {
+		k.SetWarState(ctx, token, types.OpenState)
+		war = k.MustGetWar(ctx, token) // get war again
+		war.AllowSells = true          // enable sells
+		k.SetWar(ctx, token, war)      // update war
+		err := k.ReleaseHatchFunding(ctx, token)
+		if err != nil {
+			return err
+		}
+		k.StartHatchVesting(ctx, token)
+	} else if war.HatchDeadlineReached(ctx.BlockHeight()) {
+		k.RefundBatch(ctx, token, types.CancelReasonHatchFailed)
+		k.CancelAllLimitOrders(ctx, token, types.CancelReasonHatchFailed)
+		k.SetWarState(ctx, token, types.FailedState)
+		k.ReturnHatchFundingToReserve(ctx, token)
+		err := k.RefundWarCreationDeposit(ctx, token)
+		if err != nil {
+			return err
+		}
+		return k.ReleaseHatchVestings(ctx, token)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/mage-war/wars/x/wars/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestHatchContributionsSetGet(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Contributions are empty if no war tokens were ever bought during hatch
+	hatchContributions := app.WarsKeeper.GetHatchContributions(ctx, token)
+	require.Equal(t, types.NewHatchContributions(token), hatchContributions)
+
+	// Add contributions and check that they are accumulated per address
+	app.WarsKeeper.AddHatchContribution(ctx, token, buyerAddress, sdk.NewInt(10))
+	app.WarsKeeper.AddHatchContribution(ctx, token, sellerAddress, sdk.NewInt(20))
+	app.WarsKeeper.AddHatchContribution(ctx, token, buyerAddress, sdk.NewInt(5))
+
+	require.Equal(t, sdk.NewInt(15), app.WarsKeeper.GetHatchContribution(ctx, token, buyerAddress).Amount)
+	require.Equal(t, sdk.NewInt(20), app.WarsKeeper.GetHatchContribution(ctx, token, sellerAddress).Amount)
+	require.Len(t, app.WarsKeeper.GetHatchContributions(ctx, token).Contributions, 2)
+
+	// Contributions of other wars are not affected
+	require.True(t, app.WarsKeeper.GetHatchContribution(ctx, token2, buyerAddress).Amount.IsZero())
+}
+
+func TestHatchVestingsSetGet(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Vestings are empty if no war tokens were ever locked during hatch
+	hatchVestings := app.WarsKeeper.GetHatchVestings(ctx, token)
+	require.Equal(t, types.NewHatchVestings(token), hatchVestings)
+	require.True(t, app.WarsKeeper.GetHatchVesting(ctx, token, buyerAddress).Locked().IsZero())
+
+	// Set vestings and start height, and check that they are kept per address
+	hv1 := types.NewHatchVesting(buyerAddress, sdk.NewInt(10))
+	hv2 := types.NewHatchVesting(sellerAddress, sdk.NewInt(20))
+	hv2.Claimed = sdk.NewInt(5)
+	app.WarsKeeper.SetHatchVesting(ctx, token, hv1)
+	app.WarsKeeper.SetHatchVesting(ctx, token, hv2)
+	app.WarsKeeper.SetHatchVestingStartHeight(ctx, token, 7)
+
+	require.Equal(t, hv1, app.WarsKeeper.GetHatchVesting(ctx, token, buyerAddress))
+	require.Equal(t, hv2, app.WarsKeeper.GetHatchVesting(ctx, token, sellerAddress))
+	require.Equal(t, int64(7), app.WarsKeeper.GetHatchVestingStartHeight(ctx, token))
+	hatchVestings = app.WarsKeeper.GetHatchVestings(ctx, token)
+	require.Equal(t, int64(7), hatchVestings.StartHeight)
+	require.Len(t, hatchVestings.Vestings, 2)
+}
+
+func TestCheckHatchRestrictionsAllowedHatchers(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidAugmentedFunctionWar()
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, types.NewBatch(war.Token, war.BatchBlocks))
+
+	buy := types.NewBuyOrder(buyerAddress, buyAmount, maxPrices, false)
+
+	// Anyone can buy if the war has no hatch restrictions
+	require.Nil(t, app.WarsKeeper.CheckHatchRestrictions(ctx, war.Token, buy))
+
+	// Only allowed hatchers can buy if an allow-list is set
+	war.AllowedHatchers = []sdk.AccAddress{sellerAddress}
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	err := app.WarsKeeper.CheckHatchRestrictions(ctx, war.Token, buy)
+	require.Error(t, err)
+	require.True(t, types.ErrNotAllowedToHatch.Is(err))
+
+	war.AllowedHatchers = append(war.AllowedHatchers, buyerAddress)
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	require.Nil(t, app.WarsKeeper.CheckHatchRestrictions(ctx, war.Token, buy))
+}
+
+func TestCheckHatchRestrictionsMembershipDenom(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidAugmentedFunctionWar()
+	war.HatchMembershipDenom = "membertoken"
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, types.NewBatch(war.Token, war.BatchBlocks))
+
+	buy := types.NewBuyOrder(buyerAddress, buyAmount, maxPrices, false)
+
+	// Buyer cannot buy without holding the membership token
+	err := app.WarsKeeper.CheckHatchRestrictions(ctx, war.Token, buy)
+	require.Error(t, err)
+	require.True(t, types.ErrNotAllowedToHatch.Is(err))
+
+	// Buyer can buy once it holds the membership token
+	membership := sdk.NewCoins(sdk.NewInt64Coin("membertoken", 1))
+	_ = app.BankKeeper.SetCoins(ctx, buyerAddress, membership)
+	require.Nil(t, app.WarsKeeper.CheckHatchRestrictions(ctx, war.Token, buy))
+}
+
+func TestCheckHatchRestrictionsMaxHatchContribution(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidAugmentedFunctionWar()
+	war.MaxHatchContribution = sdk.NewInt(100)
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, types.NewBatch(war.Token, war.BatchBlocks))
+
+	amount := func(a int64) sdk.Coin { return sdk.NewInt64Coin(war.Token, a) }
+
+	// Buy up to the max contribution
+	buy := types.NewBuyOrder(buyerAddress, amount(100), maxPrices, false)
+	require.Nil(t, app.WarsKeeper.CheckHatchRestrictions(ctx, war.Token, buy))
+
+	// Previous contributions are taken into account
+	app.WarsKeeper.AddHatchContribution(ctx, war.Token, buyerAddress, sdk.NewInt(60))
+	buy = types.NewBuyOrder(buyerAddress, amount(41), maxPrices, false)
+	err := app.WarsKeeper.CheckHatchRestrictions(ctx, war.Token, buy)
+	require.Error(t, err)
+	require.True(t, types.ErrMaxHatchContributionExceeded.Is(err))
+
+	// Buys in the current batch are taken into account
+	app.WarsKeeper.AddBuyOrder(ctx, war.Token,
+		types.NewBuyOrder(buyerAddress, amount(30), maxPrices, false), nil, nil)
+	buy = types.NewBuyOrder(buyerAddress, amount(10), maxPrices, false)
+	require.Nil(t, app.WarsKeeper.CheckHatchRestrictions(ctx, war.Token, buy))
+	buy = types.NewBuyOrder(buyerAddress, amount(11), maxPrices, false)
+	require.Error(t, app.WarsKeeper.CheckHatchRestrictions(ctx, war.Token, buy))
+
+	// Other addresses are not affected
+	buy = types.NewBuyOrder(sellerAddress, amount(100), maxPrices, false)
+	require.Nil(t, app.WarsKeeper.CheckHatchRestrictions(ctx, war.Token, buy))
+}
+
+func TestHatchVestingLockAndClaim(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidAugmentedFunctionWar()
+	war.State = types.HatchState
+	war.HatchVestingCliff = 10
+	war.HatchVestingPeriod = 100
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+
+	// Lock minted war tokens
+	amount := sdk.NewInt64Coin(war.Token, 1000)
+	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, sdk.Coins{amount})
+	require.Nil(t, err)
+	err = app.WarsKeeper.LockHatchTokensFromModule(
+		ctx, war.Token, types.WarsMintBurnAccount, buyerAddress, amount)
+	require.Nil(t, err)
+
+	vestingAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.WarsVestingAccount)
+	require.Equal(t, sdk.Coins{amount}, vestingAcc.GetCoins())
+	require.True(t, app.BankKeeper.GetCoins(ctx, buyerAddress).IsZero())
+	require.Equal(t, amount.Amount, app.WarsKeeper.GetLockedHatchTokens(ctx, war.Token, buyerAddress))
+
+	// Nothing can be claimed during the hatch phase
+	_, err = app.WarsKeeper.ClaimVestedHatchTokens(ctx, war.Token, buyerAddress)
+	require.Error(t, err)
+	require.True(t, types.ErrNoVestedTokensToClaim.Is(err))
+
+	// Open war and start vesting at height 5
+	ctx = ctx.WithBlockHeight(5)
+	app.WarsKeeper.SetWarState(ctx, war.Token, types.OpenState)
+	app.WarsKeeper.StartHatchVesting(ctx, war.Token)
+
+	// Nothing can be claimed before the cliff
+	ctx = ctx.WithBlockHeight(14)
+	_, err = app.WarsKeeper.ClaimVestedHatchTokens(ctx, war.Token, buyerAddress)
+	require.Error(t, err)
+
+	// Vested tokens can be claimed after the cliff (elapsed=45 => 450 vested)
+	ctx = ctx.WithBlockHeight(50)
+	claimed, err := app.WarsKeeper.ClaimVestedHatchTokens(ctx, war.Token, buyerAddress)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt64Coin(war.Token, 450), claimed)
+	require.Equal(t, sdk.NewInt(550), app.WarsKeeper.GetLockedHatchTokens(ctx, war.Token, buyerAddress))
+	require.Equal(t, sdk.Coins{claimed}, app.BankKeeper.GetCoins(ctx, buyerAddress))
+
+	// Cannot claim again at the same height
+	_, err = app.WarsKeeper.ClaimVestedHatchTokens(ctx, war.Token, buyerAddress)
+	require.Error(t, err)
+
+	// Remaining tokens can be claimed after the end of the period
+	ctx = ctx.WithBlockHeight(200)
+	claimed, err = app.WarsKeeper.ClaimVestedHatchTokens(ctx, war.Token, buyerAddress)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt64Coin(war.Token, 550), claimed)
+	require.True(t, app.WarsKeeper.GetLockedHatchTokens(ctx, war.Token, buyerAddress).IsZero())
+	require.Equal(t, sdk.Coins{amount}, app.BankKeeper.GetCoins(ctx, buyerAddress))
+}
+
+func TestReleaseHatchVestings(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidAugmentedFunctionWar()
+	war.HatchVestingPeriod = 100
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+
+	// Lock war tokens for two addresses
+	amount := sdk.NewInt64Coin(war.Token, 1000)
+	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, sdk.Coins{amount.Add(amount)})
+	require.Nil(t, err)
+	err = app.WarsKeeper.LockHatchTokensFromModule(
+		ctx, war.Token, types.WarsMintBurnAccount, buyerAddress, amount)
+	require.Nil(t, err)
+	err = app.WarsKeeper.LockHatchTokensFromModule(
+		ctx, war.Token, types.WarsMintBurnAccount, sellerAddress, amount)
+	require.Nil(t, err)
+
+	// Release all locked tokens
+	err = app.WarsKeeper.ReleaseHatchVestings(ctx, war.Token)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{amount}, app.BankKeeper.GetCoins(ctx, buyerAddress))
+	require.Equal(t, sdk.Coins{amount}, app.BankKeeper.GetCoins(ctx, sellerAddress))
+	require.True(t, app.WarsKeeper.GetLockedHatchTokens(ctx, war.Token, buyerAddress).IsZero())
+	require.True(t, app.WarsKeeper.GetLockedHatchTokens(ctx, war.Token, sellerAddress).IsZero())
+
+	vestingAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.WarsVestingAccount)
+	require.True(t, vestingAcc.GetCoins().IsZero())
+}
+
+func TestCheckSellNotLocked(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidAugmentedFunctionWar()
+	war.HatchVestingPeriod = 100
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+
+	sellAmount := sdk.NewInt64Coin(war.Token, 100)
+	balance := sdk.NewInt64Coin(war.Token, 60)
+	_ = app.BankKeeper.SetCoins(ctx, sellerAddress, sdk.Coins{balance})
+
+	// Sells exceeding balance are not rejected as locked if nothing is locked
+	require.Nil(t, app.WarsKeeper.CheckSellNotLocked(ctx, sellerAddress, sellAmount))
+
+	// Sells exceeding unlocked balance are rejected if tokens are locked
+	app.WarsKeeper.SetHatchVesting(ctx, war.Token,
+		types.NewHatchVesting(sellerAddress, sdk.NewInt(40)))
+	err := app.WarsKeeper.CheckSellNotLocked(ctx, sellerAddress, sellAmount)
+	require.Error(t, err)
+	require.True(t, types.ErrWarTokensLocked.Is(err))
+
+	// Sells within unlocked balance are allowed
+	require.Nil(t, app.WarsKeeper.CheckSellNotLocked(ctx, sellerAddress, balance))
+}
@@ -88,7 +88,7 @@ func ReserveInvariant(k Keeper) sdk.Invariant {
 			denom := war.Token
 
 			if war.FunctionType == types.AugmentedFunction ||
-				war.FunctionType == types.SwapperFunction {
+				war.IsSwapper() {
 				continue // Check does not apply to augmented/swapper functions
 			}
 
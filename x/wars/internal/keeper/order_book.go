@@ -0,0 +1,394 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/mage-war/wars/x/wars/internal/types"
+	"strconv"
+)
+
+func (k Keeper) GetNextLimitOrderID(ctx sdk.Context, token string) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetNextLimitOrderIDKey(token))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) SetNextLimitOrderID(ctx sdk.Context, token string, id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetNextLimitOrderIDKey(token), sdk.Uint64ToBigEndian(id))
+}
+
+// GetLimitOrderCount returns the number of limit orders in the war's order book
+func (k Keeper) GetLimitOrderCount(ctx sdk.Context, token string) uint64 {
+	return k.getCount(ctx, types.GetLimitOrderCountKey(token))
+}
+
+// GetLimitOrderCountByAddress returns the number of limit orders in the war's
+// order book that were placed by the address
+func (k Keeper) GetLimitOrderCountByAddress(ctx sdk.Context, token string, address sdk.AccAddress) uint64 {
+	return k.getCount(ctx, types.GetLimitOrderCountByAddressKey(token, address))
+}
+
+func (k Keeper) getCount(ctx sdk.Context, key []byte) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(key)
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// setCount sets the count under the key, or deletes the key if the count is zero
+func (k Keeper) setCount(ctx sdk.Context, key []byte, count uint64) {
+	store := ctx.KVStore(k.storeKey)
+	if count == 0 {
+		store.Delete(key)
+	} else {
+		store.Set(key, sdk.Uint64ToBigEndian(count))
+	}
+}
+
+func (k Keeper) GetLimitOrderIterator(ctx sdk.Context, token string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetLimitOrdersKey(token))
+}
+
+// GetLimitOrderPriceIterator returns an iterator over the IDs of the war's
+// limit orders of the order type, from the best to the worst limit price
+func (k Keeper) GetLimitOrderPriceIterator(ctx sdk.Context, token, orderType string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetLimitOrderPricesKey(token, orderType))
+}
+
+// GetExpiredLimitOrderIterator returns an iterator over the IDs of the war's
+// limit orders whose expiry height is before the specified height
+func (k Keeper) GetExpiredLimitOrderIterator(ctx sdk.Context, token string, height int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(types.GetLimitOrderExpiriesKey(token),
+		types.GetLimitOrderExpiriesUntilKey(token, height))
+}
+
+func (k Keeper) GetLimitOrder(ctx sdk.Context, token string, id uint64) (lo types.LimitOrder, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetLimitOrderKey(token, id))
+	if bz == nil {
+		return
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &lo)
+	return lo, true
+}
+
+func (k Keeper) MustGetLimitOrder(ctx sdk.Context, token string, id uint64) types.LimitOrder {
+	lo, found := k.GetLimitOrder(ctx, token, id)
+	if !found {
+		panic(fmt.Sprintf("limit order %d not found for %s\n", id, token))
+	}
+	return lo
+}
+
+// setLimitOrder stores a new limit order, adds it to the war's expiry and
+// price indexes, and counts it towards the limit orders of the war and of the
+// order's owner
+func (k Keeper) setLimitOrder(ctx sdk.Context, token string, lo types.LimitOrder) {
+	store := ctx.KVStore(k.storeKey)
+	war := k.MustGetWar(ctx, token)
+	id := sdk.Uint64ToBigEndian(lo.ID)
+
+	store.Set(types.GetLimitOrderKey(token, lo.ID), k.cdc.MustMarshalBinaryBare(lo))
+	store.Set(types.GetLimitOrderExpiryKey(token, lo), id)
+	store.Set(types.GetLimitOrderPriceKey(token, lo, war.ReserveTokens[0]), id)
+
+	countKey := types.GetLimitOrderCountKey(token)
+	k.setCount(ctx, countKey, k.getCount(ctx, countKey)+1)
+	countKey = types.GetLimitOrderCountByAddressKey(token, lo.Address)
+	k.setCount(ctx, countKey, k.getCount(ctx, countKey)+1)
+}
+
+// deleteLimitOrder removes the limit order along with its index entries and
+// counts, without returning its escrow
+func (k Keeper) deleteLimitOrder(ctx sdk.Context, token string, lo types.LimitOrder) {
+	store := ctx.KVStore(k.storeKey)
+	war := k.MustGetWar(ctx, token)
+
+	store.Delete(types.GetLimitOrderKey(token, lo.ID))
+	store.Delete(types.GetLimitOrderExpiryKey(token, lo))
+	store.Delete(types.GetLimitOrderPriceKey(token, lo, war.ReserveTokens[0]))
+
+	countKey := types.GetLimitOrderCountKey(token)
+	k.setCount(ctx, countKey, k.getCount(ctx, countKey)-1)
+	countKey = types.GetLimitOrderCountByAddressKey(token, lo.Address)
+	k.setCount(ctx, countKey, k.getCount(ctx, countKey)-1)
+}
+
+// GetOrderBook returns the war's next limit order ID and its limit orders, in
+// the order that they were placed
+func (k Keeper) GetOrderBook(ctx sdk.Context, token string) types.OrderBook {
+	orderBook := types.NewOrderBook(token)
+	orderBook.NextOrderID = k.GetNextLimitOrderID(ctx, token)
+
+	iterator := k.GetLimitOrderIterator(ctx, token)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var lo types.LimitOrder
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &lo)
+		orderBook.Orders = append(orderBook.Orders, lo)
+	}
+	return orderBook
+}
+
+// SetOrderBook sets the war's next limit order ID and adds its limit orders.
+// The war is expected to exist and to not have any limit orders yet.
+func (k Keeper) SetOrderBook(ctx sdk.Context, token string, orderBook types.OrderBook) {
+	k.SetNextLimitOrderID(ctx, token, orderBook.NextOrderID)
+	for _, lo := range orderBook.Orders {
+		k.setLimitOrder(ctx, token, lo)
+	}
+}
+
+// AddLimitOrder adds the limit order to the war's order book and returns the
+// order with its ID set. The order's escrow is expected to already be held by
+// the batches intermediary account.
+func (k Keeper) AddLimitOrder(ctx sdk.Context, token string, lo types.LimitOrder) types.LimitOrder {
+	lo.ID = k.GetNextLimitOrderID(ctx, token)
+	k.SetNextLimitOrderID(ctx, token, lo.ID+1)
+	k.setLimitOrder(ctx, token, lo)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added %s order %d for %s from %s", lo.OrderType, lo.ID, lo.Amount.String(), lo.Address.String()))
+
+	return lo
+}
+
+// CheckLimitOrderWithinParams returns an error if the expiry height is more
+// than the max limit order expiry blocks (if any) in the module params past
+// the current block height, if the address already has the max limit orders
+// per address (if any) in the war's order book, or if the war's order book
+// already has the max limit orders per war (if any)
+func (k Keeper) CheckLimitOrderWithinParams(ctx sdk.Context, token string,
+	address sdk.AccAddress, expiryHeight int64) error {
+	params := k.GetParams(ctx)
+
+	maxExpiry := params.MaxLimitOrderExpiryBlocks
+	if maxExpiry != 0 && expiryHeight-ctx.BlockHeight() > int64(maxExpiry) {
+		return sdkerrors.Wrapf(types.ErrExpiryHeightTooFar,
+			"expiry height %d is more than %d blocks past current height %d",
+			expiryHeight, maxExpiry, ctx.BlockHeight())
+	}
+
+	maxOrders := params.MaxLimitOrdersPerAddress
+	count := k.GetLimitOrderCountByAddress(ctx, token, address)
+	if maxOrders != 0 && count >= maxOrders {
+		return sdkerrors.Wrapf(types.ErrMaxLimitOrdersReached,
+			"%s has %d limit orders for %s", address, count, token)
+	}
+
+	maxOrders = params.MaxLimitOrdersPerWar
+	count = k.GetLimitOrderCount(ctx, token)
+	if maxOrders != 0 && count >= maxOrders {
+		return sdkerrors.Wrapf(types.ErrMaxLimitOrdersReached,
+			"%s has %d limit orders", token, count)
+	}
+	return nil
+}
+
+// removeLimitOrder removes the limit order from the war's order book and
+// returns the order's escrow back to its owner
+func (k Keeper) removeLimitOrder(ctx sdk.Context, token string, lo types.LimitOrder, reason string) {
+	k.deleteLimitOrder(ctx, token, lo)
+
+	// Return escrow to owner
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+		types.BatchesIntermediaryAccount, lo.Address, lo.GetEscrow())
+	if err != nil {
+		panic(err)
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("cancelled %s order %d for %s from %s", lo.OrderType, lo.ID, lo.Amount.String(), lo.Address.String()))
+	logger.Debug(fmt.Sprintf("cancellation reason: %s", reason))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderCancel,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, lo.OrderType),
+		sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(lo.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAddress, lo.Address.String()),
+		sdk.NewAttribute(types.AttributeKeyCancelReason, reason),
+	))
+}
+
+// CancelLimitOrder removes a limit order from the war's order book on behalf
+// of the order's owner and returns the order's escrow back to the owner
+func (k Keeper) CancelLimitOrder(ctx sdk.Context, token string, owner sdk.AccAddress, id uint64) error {
+	lo, found := k.GetLimitOrder(ctx, token, id)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrOrderDoesNotExist, "limit order %d", id)
+	} else if !lo.Address.Equals(owner) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not the owner of the order", owner)
+	}
+
+	k.removeLimitOrder(ctx, token, lo, types.CancelReasonCancelledByOwner)
+
+	return nil
+}
+
+// CancelExpiredLimitOrders removes the limit orders that have expired from the
+// war's order book and returns their escrow back to their owners. Only the
+// expired orders are read, using the war's limit order expiry index.
+func (k Keeper) CancelExpiredLimitOrders(ctx sdk.Context, token string) {
+	var expired []uint64
+	iterator := k.GetExpiredLimitOrderIterator(ctx, token, ctx.BlockHeight())
+	for ; iterator.Valid(); iterator.Next() {
+		expired = append(expired, binary.BigEndian.Uint64(iterator.Value()))
+	}
+	iterator.Close()
+
+	for _, id := range expired {
+		lo := k.MustGetLimitOrder(ctx, token, id)
+		k.removeLimitOrder(ctx, token, lo, types.CancelReasonExpired)
+	}
+}
+
+// CancelAllLimitOrders removes all of the limit orders in the war's order book
+// and returns their escrow back to their owners, with the specified reason
+func (k Keeper) CancelAllLimitOrders(ctx sdk.Context, token string, reason string) {
+	var orders []types.LimitOrder
+	iterator := k.GetLimitOrderIterator(ctx, token)
+	for ; iterator.Valid(); iterator.Next() {
+		var lo types.LimitOrder
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &lo)
+		orders = append(orders, lo)
+	}
+	iterator.Close()
+
+	for _, lo := range orders {
+		k.removeLimitOrder(ctx, token, lo, reason)
+	}
+}
+
+// addLimitOrderToBatch adds the limit order to the current batch as a regular
+// buy or sell order, if the order is fulfillable at the batch prices that
+// result from adding it to the batch
+func (k Keeper) addLimitOrderToBatch(ctx sdk.Context, token string, lo types.LimitOrder) error {
+	war := k.MustGetWar(ctx, token)
+
+	if lo.IsBuy() {
+		if war.State != types.OpenState && war.State != types.HatchState {
+			return sdkerrors.Wrap(types.ErrInvalidStateForAction, war.State)
+		} else if war.HatchDeadlineReached(ctx.BlockHeight()) {
+			return sdkerrors.Wrap(types.ErrHatchDeadlineAlreadyPassed, token)
+		}
+
+		// Get buy price and check if can add buy order to batch
+		bo := lo.ToBuyOrder()
+		buyPrices, sellPrices, err := k.GetUpdatedBatchPricesAfterBuy(ctx, token, bo)
+		if err != nil {
+			return err
+		}
+
+		// Max prices are already held by the batches intermediary account
+		k.AddBuyOrder(ctx, token, bo, buyPrices, sellPrices)
+		return nil
+	}
+
+	if !war.AllowSells {
+		return sdkerrors.Wrap(types.ErrWarDoesNotAllowSelling, token)
+	} else if war.State != types.OpenState {
+		return sdkerrors.Wrap(types.ErrInvalidStateForAction, war.State)
+	}
+
+	// Get sell price and check if can add sell order to batch
+	so := lo.ToSellOrder()
+	buyPrices, sellPrices, err := k.GetUpdatedBatchPricesAfterSell(ctx, token, so)
+	if err != nil {
+		return err
+	}
+
+	// Burn war tokens to be sold, as is done for regular sell orders
+	err = k.SupplyKeeper.SendCoinsFromModuleToModule(ctx,
+		types.BatchesIntermediaryAccount, types.WarsMintBurnAccount, sdk.Coins{so.Amount})
+	if err != nil {
+		return err
+	}
+	err = k.SupplyKeeper.BurnCoins(ctx, types.WarsMintBurnAccount, sdk.Coins{so.Amount})
+	if err != nil {
+		return err
+	}
+
+	k.AddSellOrder(ctx, token, so, buyPrices, sellPrices)
+	return nil
+}
+
+// AddLimitOrdersToBatch adds the limit orders in the war's order book whose
+// limit prices are met by the current batch prices to the current batch, and
+// removes them from the order book. Limit buys are considered from the highest
+// to the lowest limit price, and then limit sells from the lowest to the
+// highest limit price, in the war's first reserve token, using the war's limit
+// order price index. Orders with the same limit price are considered in the
+// order that they were placed. Since the batch prices of buys are never lower,
+// and those of sells never higher, than the war's current prices, the
+// remaining orders of a type are no longer considered once an order's limit
+// price in the first reserve token is not met by the current prices. A limit
+// order is not added if adding it would make any of the orders already in the
+// batch unfulfillable. Once added, an order is treated as any other order in
+// the batch.
+func (k Keeper) AddLimitOrdersToBatch(ctx sdk.Context, token string) {
+	logger := k.Logger(ctx)
+	if k.GetLimitOrderCount(ctx, token) == 0 {
+		return
+	}
+
+	war := k.MustGetWar(ctx, token)
+	currentPrices, err := war.GetCurrentPricesPT(k.GetReserveBalances(ctx, token))
+	if err != nil {
+		return
+	}
+	reserveToken := war.ReserveTokens[0]
+	firstPrices := sdk.NewDecCoins(sdk.NewDecCoinFromDec(
+		reserveToken, currentPrices.AmountOf(reserveToken)))
+
+	for _, orderType := range []string{types.LimitBuyOrderType, types.LimitSellOrderType} {
+		// Collect the orders of the type up to the first one whose limit
+		// price in the first reserve token is not met
+		var orders []types.LimitOrder
+		iterator := k.GetLimitOrderPriceIterator(ctx, token, orderType)
+		for ; iterator.Valid(); iterator.Next() {
+			lo := k.MustGetLimitOrder(ctx, token, binary.BigEndian.Uint64(iterator.Value()))
+			if !lo.LimitPricesMetBy(firstPrices) {
+				break
+			}
+			orders = append(orders, lo)
+		}
+		iterator.Close()
+
+		for _, lo := range orders {
+			if !lo.LimitPricesMetBy(currentPrices) {
+				continue
+			}
+
+			cacheCtx, writeCache := ctx.CacheContext()
+			err := k.addLimitOrderToBatch(cacheCtx, token, lo)
+			if err != nil || k.CancelUnfulfillableOrders(cacheCtx, token) > 0 {
+				continue
+			}
+			k.deleteLimitOrder(cacheCtx, token, lo)
+			writeCache()
+			ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+
+			logger.Info(fmt.Sprintf("added %s order %d for %s from %s to batch", lo.OrderType, lo.ID, lo.Amount.String(), lo.Address.String()))
+
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeLimitOrderTrigger,
+				sdk.NewAttribute(types.AttributeKeyWar, token),
+				sdk.NewAttribute(types.AttributeKeyOrderType, lo.OrderType),
+				sdk.NewAttribute(types.AttributeKeyOrderID, strconv.FormatUint(lo.ID, 10)),
+				sdk.NewAttribute(types.AttributeKeyAddress, lo.Address.String()),
+			))
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package keeper_test
+
+import (
+	"encoding/binary"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/mage-war/wars/x/wars/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOrderBookSetGet(t *testing.T) {
+	app, ctx := createTestApp(false)
+	app.WarsKeeper.SetWar(ctx, token, getValidWar())
+
+	// Order book is empty if no limit order was ever placed
+	orderBook := app.WarsKeeper.GetOrderBook(ctx, token)
+	require.Equal(t, types.NewOrderBook(token), orderBook)
+
+	// Add limit orders and check that they were given consecutive IDs
+	lo := types.NewLimitOrder(types.LimitBuyOrderType, buyerAddress, buyAmount, maxPrices, 10)
+	lo1 := app.WarsKeeper.AddLimitOrder(ctx, token, lo)
+	lo2 := app.WarsKeeper.AddLimitOrder(ctx, token, lo)
+	require.Equal(t, uint64(0), lo1.ID)
+	require.Equal(t, uint64(1), lo2.ID)
+
+	orderBook = app.WarsKeeper.GetOrderBook(ctx, token)
+	require.Equal(t, uint64(2), orderBook.NextOrderID)
+	require.Equal(t, []types.LimitOrder{lo1, lo2}, orderBook.Orders)
+}
+
+func TestLimitOrderPriceIterator(t *testing.T) {
+	app, ctx := createTestApp(false)
+	app.WarsKeeper.SetWar(ctx, token, getValidWar())
+
+	// Limit buys with max prices per token of 2000, 3000, and 2000res, and
+	// limit sells with min returns per token of 1100 and 1000res
+	amount := sdk.NewInt64Coin(token, 2)
+	prices := func(amount int64) sdk.Coins {
+		return sdk.NewCoins(sdk.NewInt64Coin(reserveToken, amount))
+	}
+	for _, lo := range []types.LimitOrder{
+		types.NewLimitOrder(types.LimitBuyOrderType, buyerAddress, amount, prices(4000), 10),
+		types.NewLimitOrder(types.LimitBuyOrderType, buyerAddress, amount, prices(6000), 10),
+		types.NewLimitOrder(types.LimitBuyOrderType, sellerAddress, amount, prices(4000), 10),
+		types.NewLimitOrder(types.LimitSellOrderType, sellerAddress, amount, prices(2200), 10),
+		types.NewLimitOrder(types.LimitSellOrderType, sellerAddress, amount, prices(2000), 10),
+	} {
+		app.WarsKeeper.AddLimitOrder(ctx, token, lo)
+	}
+	require.Equal(t, uint64(5), app.WarsKeeper.GetLimitOrderCount(ctx, token))
+	require.Equal(t, uint64(2), app.WarsKeeper.GetLimitOrderCountByAddress(ctx, token, buyerAddress))
+	require.Equal(t, uint64(3), app.WarsKeeper.GetLimitOrderCountByAddress(ctx, token, sellerAddress))
+
+	ids := func(orderType string) (ids []uint64) {
+		iterator := app.WarsKeeper.GetLimitOrderPriceIterator(ctx, token, orderType)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			ids = append(ids, binary.BigEndian.Uint64(iterator.Value()))
+		}
+		return ids
+	}
+
+	// Limit buys are ordered from the highest to the lowest price and limit
+	// sells from the lowest to the highest price, and then by ID
+	require.Equal(t, []uint64{1, 0, 2}, ids(types.LimitBuyOrderType))
+	require.Equal(t, []uint64{4, 3}, ids(types.LimitSellOrderType))
+}
+
+func TestCancelLimitOrder(t *testing.T) {
+	app, ctx := createTestApp(false)
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	app.WarsKeeper.SetWar(ctx, token, getValidWar())
+
+	// Add limit buy (escrow is added to the module account)
+	lo := types.NewLimitOrder(types.LimitBuyOrderType, buyerAddress, buyAmount, maxPrices, 10)
+	lo = app.WarsKeeper.AddLimitOrder(ctx, token, lo)
+	_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), maxPrices)
+
+	// Cancelling non-existent order fails
+	err := app.WarsKeeper.CancelLimitOrder(ctx, token, buyerAddress, lo.ID+1)
+	require.Error(t, err)
+
+	// Cancelling someone else's order fails
+	err = app.WarsKeeper.CancelLimitOrder(ctx, token, sellerAddress, lo.ID)
+	require.Error(t, err)
+	require.Len(t, app.WarsKeeper.GetOrderBook(ctx, token).Orders, 1)
+
+	// Cancelling own order refunds max prices and removes order
+	err = app.WarsKeeper.CancelLimitOrder(ctx, token, buyerAddress, lo.ID)
+	require.Nil(t, err)
+	require.Empty(t, app.WarsKeeper.GetOrderBook(ctx, token).Orders)
+	require.Zero(t, app.WarsKeeper.GetLimitOrderCount(ctx, token))
+	require.Zero(t, app.WarsKeeper.GetLimitOrderCountByAddress(ctx, token, buyerAddress))
+	require.Equal(t, maxPrices, app.BankKeeper.GetCoins(ctx, buyerAddress))
+	require.True(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()).IsZero())
+
+	// Cancelling an already cancelled order fails
+	err = app.WarsKeeper.CancelLimitOrder(ctx, token, buyerAddress, lo.ID)
+	require.Error(t, err)
+}
+
+func TestCancelExpiredLimitOrders(t *testing.T) {
+	app, ctx := createTestApp(false)
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	app.WarsKeeper.SetWar(ctx, token, getValidWar())
+
+	// Add limit buys expiring at heights 10 and 20
+	lo1 := types.NewLimitOrder(types.LimitBuyOrderType, buyerAddress, buyAmount, maxPrices, 10)
+	lo2 := types.NewLimitOrder(types.LimitBuyOrderType, sellerAddress, buyAmount, maxPrices, 20)
+	lo1 = app.WarsKeeper.AddLimitOrder(ctx, token, lo1)
+	lo2 = app.WarsKeeper.AddLimitOrder(ctx, token, lo2)
+	_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), maxPrices.Add(maxPrices...))
+
+	// Nothing expires at the first order's expiry height
+	ctx = ctx.WithBlockHeight(10)
+	app.WarsKeeper.CancelExpiredLimitOrders(ctx, token)
+	require.Len(t, app.WarsKeeper.GetOrderBook(ctx, token).Orders, 2)
+
+	// First order expires after its expiry height and is refunded
+	ctx = ctx.WithBlockHeight(11)
+	app.WarsKeeper.CancelExpiredLimitOrders(ctx, token)
+	require.Equal(t, []types.LimitOrder{lo2}, app.WarsKeeper.GetOrderBook(ctx, token).Orders)
+	require.Equal(t, maxPrices, app.BankKeeper.GetCoins(ctx, buyerAddress))
+	require.True(t, app.BankKeeper.GetCoins(ctx, sellerAddress).IsZero())
+	require.Equal(t, maxPrices, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+}
+
+func TestAddLimitOrdersToBatch(t *testing.T) {
+	app, ctx := createTestApp(false)
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+
+	// Create war (with a supply of 10 and its reserve of 4*10^3+100*10) and batch
+	war := getValidWar()
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 10)
+	war.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+
+	// Buying 2 tokens costs (4*12^3+100*12)-5000 = 3112 plus a 0.1% fee, so
+	// a limit buy with max prices of 3000res is not met while one with
+	// max prices of 4000res is met (escrow is added to the module account)
+	amount := sdk.NewInt64Coin(war.Token, 2)
+	lowPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 3000))
+	highPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 4000))
+	lo1 := types.NewLimitOrder(types.LimitBuyOrderType, buyerAddress, amount, lowPrices, 10)
+	lo2 := types.NewLimitOrder(types.LimitBuyOrderType, buyerAddress, amount, highPrices, 10)
+	lo1 = app.WarsKeeper.AddLimitOrder(ctx, war.Token, lo1)
+	lo2 = app.WarsKeeper.AddLimitOrder(ctx, war.Token, lo2)
+	_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), lowPrices.Add(highPrices...))
+
+	app.WarsKeeper.AddLimitOrdersToBatch(ctx, war.Token)
+
+	// Met order was added to the batch and removed from the order book
+	batch := app.WarsKeeper.MustGetBatch(ctx, war.Token)
+	require.Len(t, batch.Buys, 1)
+	require.Equal(t, lo2.ToBuyOrder(), batch.Buys[0])
+	require.Equal(t, amount, batch.TotalBuyAmount)
+	require.Equal(t, []types.LimitOrder{lo1}, app.WarsKeeper.GetOrderBook(ctx, war.Token).Orders)
+
+	// Escrow was not moved and the met order emitted a limit_order_trigger event
+	require.Equal(t, lowPrices.Add(highPrices...), app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+	triggerEvents := 0
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type == types.EventTypeLimitOrderTrigger {
+			triggerEvents++
+		}
+	}
+	require.Equal(t, 1, triggerEvents)
+}
+
+func TestAddLimitOrdersToBatchByLimitPrice(t *testing.T) {
+	app, ctx := createTestApp(false)
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+
+	// Create war (with a supply of 10 and its reserve of 4*10^3+100*10) and batch
+	war := getValidWar()
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 10)
+	war.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+
+	// Buying 1 token costs (4*11^3+100*11)-5000 = 1424 plus a 0.1% fee, so
+	// limit buys with max prices of 2000res and 3000res are met, but are
+	// added to the batch from the highest max prices to the lowest
+	amount := sdk.NewInt64Coin(war.Token, 1)
+	lowPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 2000))
+	highPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 3000))
+	lo1 := types.NewLimitOrder(types.LimitBuyOrderType, buyerAddress, amount, lowPrices, 10)
+	lo2 := types.NewLimitOrder(types.LimitBuyOrderType, buyerAddress, amount, highPrices, 10)
+	lo1 = app.WarsKeeper.AddLimitOrder(ctx, war.Token, lo1)
+	lo2 = app.WarsKeeper.AddLimitOrder(ctx, war.Token, lo2)
+	_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), lowPrices.Add(highPrices...))
+
+	app.WarsKeeper.AddLimitOrdersToBatch(ctx, war.Token)
+
+	batch := app.WarsKeeper.MustGetBatch(ctx, war.Token)
+	require.Equal(t, []types.BuyOrder{lo2.ToBuyOrder(), lo1.ToBuyOrder()}, batch.Buys)
+	require.Empty(t, app.WarsKeeper.GetOrderBook(ctx, war.Token).Orders)
+}
+
+func TestAddLimitSellOrdersToBatch(t *testing.T) {
+	app, ctx := createTestApp(false)
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+
+	// Create war (with a supply of 10 and its reserve of 4*10^3+100*10) and batch
+	war := getValidWar()
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 10)
+	war.CurrentReserve = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 5000))
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+
+	// Selling 1 token returns 5000-(4*9^3+100*9) = 1184 minus 0.1% tx and
+	// exit fees, so a limit sell with min returns of 1184res is not met while
+	// one with min returns of 1100res is met (war tokens are escrowed unburned)
+	amount := sdk.NewInt64Coin(war.Token, 1)
+	highReturns := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1184))
+	lowReturns := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1100))
+	lo1 := types.NewLimitOrder(types.LimitSellOrderType, sellerAddress, amount, highReturns, 10)
+	lo2 := types.NewLimitOrder(types.LimitSellOrderType, sellerAddress, amount, lowReturns, 10)
+	lo1 = app.WarsKeeper.AddLimitOrder(ctx, war.Token, lo1)
+	lo2 = app.WarsKeeper.AddLimitOrder(ctx, war.Token, lo2)
+	escrow := sdk.Coins{amount.Add(amount)}
+	require.Nil(t, app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, escrow))
+	require.Nil(t, app.SupplyKeeper.SendCoinsFromModuleToModule(ctx,
+		types.WarsMintBurnAccount, types.BatchesIntermediaryAccount, escrow))
+
+	app.WarsKeeper.AddLimitOrdersToBatch(ctx, war.Token)
+
+	// Met order was added to the batch and removed from the order book
+	batch := app.WarsKeeper.MustGetBatch(ctx, war.Token)
+	require.Len(t, batch.Sells, 1)
+	require.Equal(t, lo2.ToSellOrder(), batch.Sells[0])
+	require.Equal(t, amount, batch.TotalSellAmount)
+	require.Equal(t, []types.LimitOrder{lo1}, app.WarsKeeper.GetOrderBook(ctx, war.Token).Orders)
+
+	// War tokens of the met order were burned
+	require.Equal(t, sdk.Coins{amount}, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()))
+	require.Equal(t, amount.Amount, app.SupplyKeeper.GetSupply(ctx).GetTotal().AmountOf(war.Token))
+}
@@ -0,0 +1,114 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/mage-war/wars/x/wars/internal/types"
+	"strconv"
+)
+
+// GetPendingEdits returns the edits of the war that have not yet been applied,
+// which are empty if no delayed edit was ever submitted for the war
+func (k Keeper) GetPendingEdits(ctx sdk.Context, token string) types.PendingEdits {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetPendingEditsKey(token))
+	if bz == nil {
+		return types.NewPendingEdits(token)
+	}
+
+	var pendingEdits types.PendingEdits
+	k.cdc.MustUnmarshalBinaryBare(bz, &pendingEdits)
+	return pendingEdits
+}
+
+func (k Keeper) SetPendingEdits(ctx sdk.Context, token string, pendingEdits types.PendingEdits) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPendingEditsKey(token), k.cdc.MustMarshalBinaryBare(pendingEdits))
+}
+
+// AddPendingEdit adds the pending edit to the war's pending edits and returns
+// the pending edit with its ID set
+func (k Keeper) AddPendingEdit(ctx sdk.Context, token string, pe types.PendingEdit) types.PendingEdit {
+	pendingEdits := k.GetPendingEdits(ctx, token)
+	pe.ID = pendingEdits.NextEditID
+	pendingEdits.NextEditID += 1
+	pendingEdits.PendingEdits = append(pendingEdits.PendingEdits, pe)
+	k.SetPendingEdits(ctx, token, pendingEdits)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added pending edit %d for %s effective at height %d",
+		pe.ID, token, pe.EffectiveHeight))
+
+	return pe
+}
+
+// CancelPendingEdit removes the pending edit from the war's pending edits
+// without applying it, and returns the removed pending edit
+func (k Keeper) CancelPendingEdit(ctx sdk.Context, token string, id uint64) (types.PendingEdit, error) {
+	pendingEdits := k.GetPendingEdits(ctx, token)
+	i, found := pendingEdits.GetPendingEditIndex(id)
+	if !found {
+		return types.PendingEdit{}, sdkerrors.Wrapf(types.ErrPendingEditDoesNotExist, "pending edit %d", id)
+	}
+	pe := pendingEdits.PendingEdits[i]
+	pendingEdits.RemovePendingEdit(i)
+	k.SetPendingEdits(ctx, token, pendingEdits)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("cancelled pending edit %d for %s", id, token))
+
+	return pe, nil
+}
+
+// ApplyDuePendingEdits applies the war's pending edits whose effective height
+// has been reached and removes them from the war's pending edits. Since the
+// war may have changed since an edit was submitted, an edit can fail to apply
+// (e.g. if the max supply is now below the current supply), in which case
+// none of its changes are applied. Signers edits always apply.
+func (k Keeper) ApplyDuePendingEdits(ctx sdk.Context, token string) {
+	pendingEdits := k.GetPendingEdits(ctx, token)
+
+	var due []types.PendingEdit
+	var remaining []types.PendingEdit
+	for _, pe := range pendingEdits.PendingEdits {
+		if pe.IsDue(ctx.BlockHeight()) {
+			due = append(due, pe)
+		} else {
+			remaining = append(remaining, pe)
+		}
+	}
+	if len(due) == 0 {
+		return
+	}
+	pendingEdits.PendingEdits = remaining
+	k.SetPendingEdits(ctx, token, pendingEdits)
+
+	logger := k.Logger(ctx)
+	for _, pe := range due {
+		result := types.PendingEditResultApplied
+		cacheCtx, writeCache := ctx.CacheContext()
+		var err error
+		if pe.SignersEdit != nil {
+			k.UpdateWarSigners(cacheCtx, token, *pe.SignersEdit)
+		} else {
+			err = k.EditWar(cacheCtx, token, pe.Edit)
+		}
+		if err != nil {
+			result = types.PendingEditResultFailed
+			logger.Debug(fmt.Sprintf("pending edit %d for %s failed: %s", pe.ID, token, err.Error()))
+		} else {
+			writeCache()
+			ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+		}
+
+		logger.Info(fmt.Sprintf("pending edit %d for %s %s", pe.ID, token, result))
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypePendingEditResult,
+			sdk.NewAttribute(types.AttributeKeyWar, token),
+			sdk.NewAttribute(types.AttributeKeyEditID, strconv.FormatUint(pe.ID, 10)),
+			sdk.NewAttribute(types.AttributeKeyPendingEditResult, result),
+		))
+	}
+}
@@ -0,0 +1,103 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/mage-war/wars/x/wars/internal/types"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+)
+
+func newTestWarEdit(name, txFeePercentage string) types.WarEdit {
+	return types.NewWarEdit(name, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, txFeePercentage,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField)
+}
+
+func TestAddAndCancelPendingEdit(t *testing.T) {
+	app, ctx := createTestApp(false)
+	edit := newTestWarEdit("newName", types.DoNotModifyField)
+
+	// Pending edits are given consecutive IDs
+	pe0 := app.WarsKeeper.AddPendingEdit(ctx, token, types.NewPendingEdit(edit, initCreator, 10))
+	pe1 := app.WarsKeeper.AddPendingEdit(ctx, token, types.NewPendingEdit(edit, initCreator, 10))
+	require.Equal(t, uint64(0), pe0.ID)
+	require.Equal(t, uint64(1), pe1.ID)
+	require.Len(t, app.WarsKeeper.GetPendingEdits(ctx, token).PendingEdits, 2)
+
+	// Cancelled pending edit is removed
+	cancelled, err := app.WarsKeeper.CancelPendingEdit(ctx, token, 0)
+	require.NoError(t, err)
+	require.Equal(t, pe0, cancelled)
+	pendingEdits := app.WarsKeeper.GetPendingEdits(ctx, token)
+	require.Equal(t, []types.PendingEdit{pe1}, pendingEdits.PendingEdits)
+	require.Equal(t, uint64(2), pendingEdits.NextEditID)
+
+	// Cannot cancel pending edit that does not exist
+	_, err = app.WarsKeeper.CancelPendingEdit(ctx, token, 0)
+	require.Error(t, err)
+	require.True(t, types.ErrPendingEditDoesNotExist.Is(err))
+}
+
+func TestApplyDuePendingEdits(t *testing.T) {
+	app, ctx := createTestApp(false)
+	war := getValidWar()
+	app.WarsKeeper.SetWar(ctx, token, war)
+
+	// Pending edits that will apply, fail, and still be pending
+	applied := app.WarsKeeper.AddPendingEdit(ctx, token, types.NewPendingEdit(
+		newTestWarEdit("newName", types.DoNotModifyField), initCreator, 10))
+	failed := app.WarsKeeper.AddPendingEdit(ctx, token, types.NewPendingEdit(
+		newTestWarEdit(types.DoNotModifyField, war.TxFeePercentage.String()), initCreator, 10))
+	remaining := app.WarsKeeper.AddPendingEdit(ctx, token, types.NewPendingEdit(
+		newTestWarEdit("anotherName", types.DoNotModifyField), initCreator, 20))
+
+	// The war's fee is decreased after the pending edits were submitted, so
+	// the edit to the previous fee can no longer be applied
+	war.TxFeePercentage = sdk.ZeroDec()
+	app.WarsKeeper.SetWar(ctx, token, war)
+
+	// Nothing is applied before the effective height
+	app.WarsKeeper.ApplyDuePendingEdits(ctx.WithBlockHeight(9), token)
+	require.Len(t, app.WarsKeeper.GetPendingEdits(ctx, token).PendingEdits, 3)
+
+	// Only the due edits are removed, and only the valid one is applied
+	ctx = ctx.WithBlockHeight(10)
+	app.WarsKeeper.ApplyDuePendingEdits(ctx, token)
+	pendingEdits := app.WarsKeeper.GetPendingEdits(ctx, token).PendingEdits
+	require.Equal(t, []types.PendingEdit{remaining}, pendingEdits)
+	war = app.WarsKeeper.MustGetWar(ctx, token)
+	require.Equal(t, "newName", war.Name)
+	require.Equal(t, sdk.ZeroDec(), war.TxFeePercentage)
+
+	results := map[string]string{}
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type != types.EventTypePendingEditResult {
+			continue
+		}
+		var id, result string
+		for _, attr := range e.Attributes {
+			switch string(attr.Key) {
+			case types.AttributeKeyEditID:
+				id = string(attr.Value)
+			case types.AttributeKeyPendingEditResult:
+				result = string(attr.Value)
+			}
+		}
+		results[id] = result
+	}
+	require.Equal(t, map[string]string{
+		strconv.FormatUint(applied.ID, 10): types.PendingEditResultApplied,
+		strconv.FormatUint(failed.ID, 10):  types.PendingEditResultFailed,
+	}, results)
+
+	// Events of the applied edit are emitted, but not those of the failed one
+	editEvents := 0
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type == types.EventTypeEditWar {
+			editEvents++
+		}
+	}
+	require.Equal(t, 1, editEvents)
+}
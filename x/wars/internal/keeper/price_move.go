@@ -0,0 +1,122 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/mage-war/wars/x/wars/internal/types"
+	"strconv"
+)
+
+// PerformOrdersWithinMaxPriceMove performs the orders in the war's current
+// batch and returns true, unless performing them would move the war's prices
+// by more than the war's max price move percentage, in which case none of the
+// orders are performed and false is returned. The orders are performed in a
+// cache context, so that they are only performed once, and the war's prices
+// once they are performed are compared to its prices before the batch. For
+// swappers, the spot prices between the reserve tokens are compared. For other
+// wars, the prices are compared to the closing prices of the war's last batch,
+// or to the war's current prices if there is no last batch.
+func (k Keeper) PerformOrdersWithinMaxPriceMove(ctx sdk.Context, token string) bool {
+	war := k.MustGetWar(ctx, token)
+	if !war.HasMaxPriceMove() || war.CurrentSupply.IsZero() {
+		k.PerformOrders(ctx, token)
+		return true
+	}
+
+	referencePrices, err := k.getPriceMoveReferencePrices(ctx, token)
+	if err != nil {
+		k.PerformOrders(ctx, token)
+		return true
+	}
+
+	cacheCtx, writeCache := ctx.CacheContext()
+	k.PerformOrders(cacheCtx, token)
+
+	newPrices, err := k.getPriceMovePrices(cacheCtx, token)
+	if err == nil && war.PricesExceedMaxPriceMove(newPrices, referencePrices) {
+		return false
+	}
+
+	writeCache()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return true
+}
+
+// getPriceMoveReferencePrices returns the prices that the war's prices are
+// compared to once the orders in its current batch are performed. These are
+// the war's current spot prices for swappers. For other wars, these are the
+// closing prices of the war's last batch, or the war's current prices if there
+// is no last batch or if it has no closing prices.
+func (k Keeper) getPriceMoveReferencePrices(ctx sdk.Context, token string) (sdk.DecCoins, error) {
+	if !k.MustGetWar(ctx, token).IsSwapper() && k.LastBatchExists(ctx, token) {
+		lastBatch := k.MustGetLastBatch(ctx, token)
+		if !lastBatch.ClosingPrices.Empty() {
+			return lastBatch.ClosingPrices, nil
+		}
+	}
+
+	return k.getPriceMovePrices(ctx, token)
+}
+
+// getPriceMovePrices returns the war's current spot prices for swappers, or
+// its current prices for other wars. No prices are returned if the war has
+// no supply.
+func (k Keeper) getPriceMovePrices(ctx sdk.Context, token string) (sdk.DecCoins, error) {
+	war := k.MustGetWar(ctx, token)
+	if war.CurrentSupply.IsZero() {
+		return nil, nil
+	}
+
+	reserveBalances := k.GetReserveBalances(ctx, token)
+	if war.IsSwapper() {
+		return war.GetSpotPrices(reserveBalances)
+	}
+	return war.GetCurrentPricesPT(reserveBalances)
+}
+
+// PauseForPriceMove refunds the war's current batch and pauses the war for the
+// war's price move cooldown, or until it is unpaused if it has no cooldown
+func (k Keeper) PauseForPriceMove(ctx sdk.Context, token string) {
+	k.RefundBatch(ctx, token, types.CancelReasonMaxPriceMove)
+
+	war := k.MustGetWar(ctx, token)
+	war.Paused = true
+	war.PausedUntilHeight = 0
+	if war.PriceMoveCooldown > 0 {
+		war.PausedUntilHeight = ctx.BlockHeight() + war.PriceMoveCooldown
+	}
+	k.SetWar(ctx, token, war)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("paused %s until height %d for exceeding max price move",
+		token, war.PausedUntilHeight))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeMaxPriceMoveExceeded,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeyPausedUntilHeight, strconv.FormatInt(war.PausedUntilHeight, 10)),
+	))
+}
+
+// EndPriceMoveCooldown unpauses the war if it was paused for exceeding its max
+// price move and its price move cooldown has ended
+func (k Keeper) EndPriceMoveCooldown(ctx sdk.Context, token string) {
+	war := k.MustGetWar(ctx, token)
+	if !war.Paused || war.PausedUntilHeight == 0 ||
+		ctx.BlockHeight() < war.PausedUntilHeight {
+		return
+	}
+
+	war.Paused = false
+	war.PausedUntilHeight = 0
+	k.SetWar(ctx, token, war)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("unpaused %s after price move cooldown", token))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetWarPaused,
+		sdk.NewAttribute(types.AttributeKeyWar, token),
+		sdk.NewAttribute(types.AttributeKeyPaused, strconv.FormatBool(war.Paused)),
+	))
+}
@@ -0,0 +1,156 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/mage-war/wars/x/wars/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPerformOrdersWithinMaxPriceMove(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Create war (with a supply of 2, i.e. a current price of 12*2^2+100=148)
+	war := getValidWar()
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 2)
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+	setSupply := func(supply int64) {
+		war := app.WarsKeeper.MustGetWar(ctx, war.Token)
+		war.CurrentSupply = sdk.NewInt64Coin(war.Token, supply)
+		app.WarsKeeper.SetWar(ctx, war.Token, war)
+	}
+	setBuyOrder := func(amount int64) {
+		maxPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10))
+		bo := types.NewBuyOrder(buyerAddress, sdk.NewInt64Coin(war.Token, amount), maxPrices, false)
+		app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+		app.WarsKeeper.AddBuyOrder(ctx, war.Token, bo,
+			sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, 1)), nil)
+		_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), maxPrices)
+	}
+	getSupply := func() int64 {
+		return app.WarsKeeper.MustGetWar(ctx, war.Token).CurrentSupply.Amount.Int64()
+	}
+
+	// No max price move
+	setBuyOrder(1)
+	require.True(t, app.WarsKeeper.PerformOrdersWithinMaxPriceMove(ctx, war.Token))
+	require.Equal(t, int64(3), getSupply())
+
+	// Without a last batch, the prices once the orders are performed are
+	// compared to the current prices (buying 1 token moves the price from
+	// 148 to 208, and buying 2 tokens moves it from 148 to 292)
+	war.MaxPriceMovePercentage = sdk.NewDec(50)
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	setBuyOrder(1)
+	require.True(t, app.WarsKeeper.PerformOrdersWithinMaxPriceMove(ctx, war.Token))
+	require.Equal(t, int64(3), getSupply())
+	setSupply(2)
+	setBuyOrder(2)
+	require.False(t, app.WarsKeeper.PerformOrdersWithinMaxPriceMove(ctx, war.Token))
+	require.Equal(t, int64(2), getSupply())
+	require.Len(t, app.WarsKeeper.MustGetBatch(ctx, war.Token).Buys, 1)
+
+	// Otherwise, they are compared to the last batch's closing prices, which
+	// are the war's prices when the last batch was set (at a supply of 1,
+	// i.e. a price of 12*1^2+100=112), even if the last batch was empty
+	setSupply(1)
+	app.WarsKeeper.SetLastBatch(ctx, war.Token, getValidBatch())
+	require.Equal(t, sdk.NewDecCoins(sdk.NewInt64DecCoin(reserveToken, 112)),
+		app.WarsKeeper.MustGetLastBatch(ctx, war.Token).ClosingPrices)
+	setSupply(2)
+	setBuyOrder(1)
+	require.False(t, app.WarsKeeper.PerformOrdersWithinMaxPriceMove(ctx, war.Token))
+	require.Equal(t, int64(2), getSupply())
+	setSupply(1)
+	setBuyOrder(1)
+	require.True(t, app.WarsKeeper.PerformOrdersWithinMaxPriceMove(ctx, war.Token))
+	require.Equal(t, int64(2), getSupply())
+}
+
+func TestPerformOrdersWithinMaxPriceMoveSwapper(t *testing.T) {
+	app, ctx := createTestApp(false)
+
+	// Create swapper war (with a supply of 10 and reserves of 200res and
+	// 300rez) and batch
+	war := getValidSwapperWar()
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 10)
+	war.MaxPriceMovePercentage = sdk.NewDec(20)
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+	reserves := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 200),
+		sdk.NewInt64Coin(reserveToken2, 300))
+	err := app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, reserves)
+	require.Nil(t, err)
+	err = app.WarsKeeper.DepositReserveFromModule(
+		ctx, war.Token, types.WarsMintBurnAccount, reserves)
+	require.NoError(t, err)
+	moduleAcc := app.SupplyKeeper.GetModuleAccount(ctx, types.BatchesIntermediaryAccount)
+
+	// Swap of 90res moves the spot price of res in terms of rez by about 52%
+	// (from 300/200=1.5 to about 207/290=0.71)
+	swapFrom := sdk.NewInt64Coin(reserveToken, 90)
+	sw := types.NewSwapOrder(swapperAddress, swapFrom, swapTo, sdk.NewInt64Coin(swapTo, 0))
+	app.WarsKeeper.AddSwapOrder(ctx, war.Token, sw)
+	_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), sdk.NewCoins(swapFrom))
+	events := ctx.EventManager().Events()
+	require.False(t, app.WarsKeeper.PerformOrdersWithinMaxPriceMove(ctx, war.Token))
+
+	// Orders were not performed
+	require.Equal(t, reserves, app.WarsKeeper.GetReserveBalances(ctx, war.Token))
+	require.Len(t, app.WarsKeeper.MustGetBatch(ctx, war.Token).Swaps, 1)
+	require.Equal(t, events, ctx.EventManager().Events())
+
+	// Swap of 10res moves the spot price by about 9% (to about 286/210=1.36)
+	swapFrom = sdk.NewInt64Coin(reserveToken, 10)
+	sw = types.NewSwapOrder(swapperAddress, swapFrom, swapTo, sdk.NewInt64Coin(swapTo, 0))
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+	app.WarsKeeper.AddSwapOrder(ctx, war.Token, sw)
+	_ = app.BankKeeper.SetCoins(ctx, moduleAcc.GetAddress(), sdk.NewCoins(swapFrom))
+	require.True(t, app.WarsKeeper.PerformOrdersWithinMaxPriceMove(ctx, war.Token))
+
+	// Orders were performed once, with their events
+	newReserves := app.WarsKeeper.GetReserveBalances(ctx, war.Token)
+	require.True(t, newReserves.AmountOf(reserveToken).GT(sdk.NewInt(200)))
+	require.True(t, newReserves.AmountOf(reserveToken2).LT(sdk.NewInt(300)))
+	require.True(t, app.BankKeeper.GetCoins(ctx, moduleAcc.GetAddress()).IsZero())
+	require.Greater(t, len(ctx.EventManager().Events()), len(events))
+}
+
+func TestPauseForPriceMoveAndEndPriceMoveCooldown(t *testing.T) {
+	app, ctx := createTestApp(false)
+	ctx = ctx.WithBlockHeight(10)
+
+	// Create war with a price move cooldown of 5 blocks
+	war := getValidWar()
+	war.MaxPriceMovePercentage = sdk.NewDec(50)
+	war.PriceMoveCooldown = 5
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.SetBatch(ctx, war.Token, getValidBatch())
+
+	// War is paused until height 15
+	app.WarsKeeper.PauseForPriceMove(ctx, war.Token)
+	war = app.WarsKeeper.MustGetWar(ctx, war.Token)
+	require.True(t, war.Paused)
+	require.Equal(t, int64(15), war.PausedUntilHeight)
+
+	// War is not unpaused before height 15
+	app.WarsKeeper.EndPriceMoveCooldown(ctx.WithBlockHeight(14), war.Token)
+	require.True(t, app.WarsKeeper.MustGetWar(ctx, war.Token).Paused)
+
+	// War is unpaused at height 15
+	app.WarsKeeper.EndPriceMoveCooldown(ctx.WithBlockHeight(15), war.Token)
+	war = app.WarsKeeper.MustGetWar(ctx, war.Token)
+	require.False(t, war.Paused)
+	require.Zero(t, war.PausedUntilHeight)
+
+	// Without a cooldown, war is paused until it is unpaused
+	war.PriceMoveCooldown = 0
+	app.WarsKeeper.SetWar(ctx, war.Token, war)
+	app.WarsKeeper.PauseForPriceMove(ctx, war.Token)
+	app.WarsKeeper.EndPriceMoveCooldown(ctx.WithBlockHeight(1000), war.Token)
+	war = app.WarsKeeper.MustGetWar(ctx, war.Token)
+	require.True(t, war.Paused)
+	require.Zero(t, war.PausedUntilHeight)
+}
@@ -11,16 +11,21 @@ import (
 )
 
 const (
-	QueryWars          = "wars"
-	QueryWar           = "war"
+	QueryWars           = "wars"
+	QueryWar            = "war"
 	QueryBatch          = "batch"
 	QueryLastBatch      = "last_batch"
+	QueryOrderBook      = "order_book"
+	QueryFundingPool    = "funding_pool"
+	QueryProposals      = "proposals"
+	QueryPendingEdits   = "pending_edits"
 	QueryCurrentPrice   = "current_price"
 	QueryCurrentReserve = "current_reserve"
 	QueryCustomPrice    = "custom_price"
 	QueryBuyPrice       = "buy_price"
 	QuerySellReturn     = "sell_return"
 	QuerySwapReturn     = "swap_return"
+	QuerySwapRoute      = "route"
 	QueryParams         = "params"
 )
 
@@ -36,6 +41,14 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryBatch(ctx, path[1:], keeper)
 		case QueryLastBatch:
 			return queryLastBatch(ctx, path[1:], keeper)
+		case QueryOrderBook:
+			return queryOrderBook(ctx, path[1:], keeper)
+		case QueryFundingPool:
+			return queryFundingPool(ctx, path[1:], keeper)
+		case QueryProposals:
+			return queryProposals(ctx, path[1:], keeper)
+		case QueryPendingEdits:
+			return queryPendingEdits(ctx, path[1:], keeper)
 		case QueryCurrentPrice:
 			return queryCurrentPrice(ctx, path[1:], keeper)
 		case QueryCurrentReserve:
@@ -48,6 +61,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return querySellReturn(ctx, path[1:], keeper)
 		case QuerySwapReturn:
 			return querySwapReturn(ctx, path[1:], keeper)
+		case QuerySwapRoute:
+			return querySwapRoute(ctx, path[1:], keeper)
 		case QueryParams:
 			return queryParams(ctx, keeper)
 		default:
@@ -145,6 +160,89 @@ func queryLastBatch(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 	return bz, nil
 }
 
+func queryOrderBook(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	warToken := path[0]
+
+	if !keeper.WarExists(ctx, warToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "war '%s' does not exist", warToken)
+	}
+
+	orderBook := keeper.GetOrderBook(ctx, warToken)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, orderBook)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryFundingPool(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	warToken := path[0]
+
+	war, found := keeper.GetWar(ctx, warToken)
+	if !found {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "war '%s' does not exist", warToken)
+	} else if !war.HasFundingPool() {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "war '%s' does not have a funding pool", warToken)
+	}
+
+	fundingPool := keeper.GetFundingPool(ctx, warToken)
+
+	var result types.QueryFundingPool
+	result.Balance = fundingPool.Balance
+	result.RemainingAllowance = keeper.GetFundingPoolAllowance(ctx, warToken)
+
+	// Spending epochs only apply if the war has funding pool spenders, since
+	// the funding pool can otherwise only be spent from through proposals
+	if war.HasFundingPoolSpenders() {
+		epochStart := war.GetFundingPoolEpochStart(ctx.BlockHeight())
+		result.SpentInEpoch = fundingPool.SpentInEpochStartingAt(epochStart)
+		result.NextEpochHeight = epochStart + war.FundingPoolEpochBlocks
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryProposals(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	warToken := path[0]
+
+	if !keeper.WarExists(ctx, warToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "war '%s' does not exist", warToken)
+	}
+
+	proposals := keeper.GetProposals(ctx, warToken)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, proposals)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryPendingEdits(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	warToken := path[0]
+
+	if !keeper.WarExists(ctx, warToken) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "war '%s' does not exist", warToken)
+	}
+
+	pendingEdits := keeper.GetPendingEdits(ctx, warToken)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, pendingEdits)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
 func queryCurrentPrice(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
 	warToken := path[0]
 
@@ -342,6 +440,36 @@ func querySwapReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 	return bz, nil
 }
 
+func querySwapRoute(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err error) {
+	fromToken := path[0]
+	fromAmount := path[1]
+	toToken := path[2]
+
+	fromCoin, err2 := client.ParseTwoPartCoin(fromAmount, fromToken)
+	if err2 != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err2.Error())
+	} else if fromCoin.Denom == toToken {
+		return nil, sdkerrors.Wrap(types.ErrFromAndToCannotBeTheSameToken, toToken)
+	}
+
+	route, returns, txFees, err := keeper.GetBestSwapRoute(ctx, fromCoin, toToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.QuerySwapRoute
+	result.Hops = route
+	result.TotalReturns = sdk.Coins{returns}
+	result.TotalFees = txFees
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
 func queryParams(ctx sdk.Context, k Keeper) ([]byte, error) {
 	params := k.GetParams(ctx)
 
@@ -91,6 +91,136 @@ func TestQueryBatch(t *testing.T) {
 	require.Equal(t, queryResult, batch)
 }
 
+func TestQueryOrderBook(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.WarsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.OrderBook
+
+	// Initially error since no war
+	res, err := querier(ctx, []string{keeper.QueryOrderBook, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add war and limit order
+	app.WarsKeeper.SetWar(ctx, token, getValidWar())
+	lo := types.NewLimitOrder(types.LimitBuyOrderType, buyerAddress, buyAmount, maxPrices, 10)
+	app.WarsKeeper.AddLimitOrder(ctx, token, lo)
+
+	// No error because of new war
+	res, err = querier(ctx, []string{keeper.QueryOrderBook, token}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, queryResult, app.WarsKeeper.GetOrderBook(ctx, token))
+	require.Len(t, queryResult.Orders, 1)
+}
+
+func TestQueryFundingPool(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.WarsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QueryFundingPool
+
+	// Initially error since no war
+	res, err := querier(ctx, []string{keeper.QueryFundingPool, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Still error since war does not have a funding pool
+	war := getValidWar()
+	app.WarsKeeper.SetWar(ctx, token, war)
+	res, err = querier(ctx, []string{keeper.QueryFundingPool, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add funding pool to war, with a spend in the current epoch
+	war.FundingPoolSpenders = []sdk.AccAddress{initCreator}
+	war.FundingPoolSpendLimit = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	war.FundingPoolEpochBlocks = 10
+	app.WarsKeeper.SetWar(ctx, token, war)
+	ctx = ctx.WithBlockHeight(15)
+	fundingPool := types.NewFundingPool(token)
+	fundingPool.Balance = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000))
+	fundingPool = fundingPool.Spend(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 30)), 10)
+	app.WarsKeeper.SetFundingPool(ctx, token, fundingPool)
+
+	// No error because of new funding pool
+	res, err = querier(ctx, []string{keeper.QueryFundingPool, token}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 970)), queryResult.Balance)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 30)), queryResult.SpentInEpoch)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 70)), queryResult.RemainingAllowance)
+	require.Equal(t, int64(20), queryResult.NextEpochHeight)
+}
+
+func TestQueryProposals(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.WarsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.Proposals
+
+	// Initially error since no war
+	res, err := querier(ctx, []string{keeper.QueryProposals, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// No error and no proposals after adding war
+	app.WarsKeeper.SetWar(ctx, token, getValidWar())
+	res, err = querier(ctx, []string{keeper.QueryProposals, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Empty(t, queryResult.Proposals)
+
+	// Proposal and its votes are returned after they are added
+	_, err = app.BankKeeper.AddCoins(ctx, buyerAddress, sdk.NewCoins(sdk.NewInt64Coin(token, 100)))
+	require.Nil(t, err)
+	p := app.WarsKeeper.AddProposal(ctx, token, types.NewSpendProposal(buyerAddress,
+		buyerAddress, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10)), 10))
+	_, err = app.WarsKeeper.AddVote(ctx, token, p.ID, buyerAddress, types.YesVoteOption)
+	require.NoError(t, err)
+	res, err = querier(ctx, []string{keeper.QueryProposals, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Len(t, queryResult.Proposals, 1)
+	require.Equal(t, uint64(1), queryResult.NextProposalID)
+	require.Equal(t, types.SpendProposalType, queryResult.Proposals[0].ProposalType)
+	require.Len(t, queryResult.Votes, 1)
+	require.Equal(t, sdk.NewInt(100), queryResult.Votes[0].Power)
+}
+
+func TestQueryPendingEdits(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.WarsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.PendingEdits
+
+	// Initially error since no war
+	res, err := querier(ctx, []string{keeper.QueryPendingEdits, token}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// No error and no pending edits after adding war
+	app.WarsKeeper.SetWar(ctx, token, getValidWar())
+	res, err = querier(ctx, []string{keeper.QueryPendingEdits, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Empty(t, queryResult.PendingEdits)
+
+	// Pending edit is returned after it is added
+	edit := types.WarEdit{Name: "newName"}
+	app.WarsKeeper.AddPendingEdit(ctx, token, types.NewPendingEdit(edit, initCreator, 10))
+	res, err = querier(ctx, []string{keeper.QueryPendingEdits, token}, req)
+	require.NoError(t, err)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Len(t, queryResult.PendingEdits, 1)
+	require.Equal(t, uint64(1), queryResult.NextEditID)
+	require.Equal(t, edit, queryResult.PendingEdits[0].Edit)
+	require.Equal(t, int64(10), queryResult.PendingEdits[0].EffectiveHeight)
+}
+
 func TestQueryLastBatch(t *testing.T) {
 	app, ctx := createTestApp(false)
 	querier := keeper.NewQuerier(app.WarsKeeper)
@@ -581,3 +711,51 @@ func TestQuerySwapReturn(t *testing.T) {
 	require.Equal(t, queryResult.TotalReturns, manualSwapReturns)
 	require.Equal(t, queryResult.TotalFees, sdk.Coins{txFee})
 }
+
+func TestQuerySwapRoute(t *testing.T) {
+	app, ctx := createTestApp(false)
+	querier := keeper.NewQuerier(app.WarsKeeper)
+	req := abci.RequestQuery{}
+	var queryResult types.QuerySwapRoute
+
+	// Initially error since no wars
+	dummy1, dummy2, dummy3 := reserveToken, "100", reserveToken2
+	res, err := querier(ctx,
+		[]string{keeper.QuerySwapRoute, dummy1, dummy2, dummy3}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Error if from and to token are the same
+	res, err = querier(ctx,
+		[]string{keeper.QuerySwapRoute, dummy1, dummy2, dummy1}, req)
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	// Add swapper war and send 200res,300rez to reserve
+	war := getValidSwapperWar()
+	app.WarsKeeper.SetWar(ctx, token, war)
+	newReserve := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 200),
+		sdk.NewInt64Coin(reserveToken2, 300),
+	)
+	_ = app.SupplyKeeper.MintCoins(ctx, types.WarsMintBurnAccount, newReserve)
+	_ = app.WarsKeeper.DepositReserveFromModule(
+		ctx, war.Token, types.WarsMintBurnAccount, newReserve)
+
+	// Get swap return directly
+	fromCoin := sdk.NewInt64Coin(reserveToken, 100)
+	toToken := reserveToken2
+	reserveBalances := app.WarsKeeper.GetReserveBalances(ctx, token)
+	swapReturns, txFee, _ := war.GetReturnsForSwap(fromCoin, toToken, reserveBalances)
+
+	// Check that the route is the single hop through the swapper war and
+	// that returns and fees match the direct swap returns
+	res, err = querier(ctx, []string{keeper.QuerySwapRoute,
+		fromCoin.Denom, fromCoin.Amount.String(), toToken}, req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	types.ModuleCdc.MustUnmarshalJSON(res, &queryResult)
+	require.Equal(t, types.SwapRoute{types.NewSwapHop(token, toToken)}, queryResult.Hops)
+	require.Equal(t, swapReturns, queryResult.TotalReturns)
+	require.Equal(t, sdk.Coins{txFee}, queryResult.TotalFees)
+}
@@ -1,25 +1,81 @@
 package types
 
 import (
+	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"sort"
+	"strings"
 )
 
+const (
+	BuyOrderType        = "buy"
+	SellOrderType       = "sell"
+	SwapOrderType       = "swap"
+	RoutedSwapOrderType = "routed_swap"
+
+	CancelReasonCancelledByOwner = "cancelled by owner"
+	CancelReasonWarPaused        = "war paused"
+	CancelReasonMaxPriceMove     = "max price move exceeded"
+	CancelReasonHatchFailed      = "hatch failed"
+
+	MaxSwapRouteHops = 4
+)
+
+func IsValidOrderType(orderType string) bool {
+	switch orderType {
+	case BuyOrderType, SellOrderType, SwapOrderType, RoutedSwapOrderType:
+		return true
+	default:
+		return false
+	}
+}
+
 type Batch struct {
-	Token           string       `json:"token" yaml:"token"`
-	BlocksRemaining sdk.Uint     `json:"blocks_remaining" yaml:"blocks_remaining"`
-	TotalBuyAmount  sdk.Coin     `json:"total_buy_amount" yaml:"total_buy_amount"`
-	TotalSellAmount sdk.Coin     `json:"total_sell_amount" yaml:"total_sell_amount"`
-	BuyPrices       sdk.DecCoins `json:"buy_prices" yaml:"buy_prices"`
-	SellPrices      sdk.DecCoins `json:"sell_prices" yaml:"sell_prices"`
-	Buys            []BuyOrder   `json:"buys" yaml:"buys"`
-	Sells           []SellOrder  `json:"sells" yaml:"sells"`
-	Swaps           []SwapOrder  `json:"swaps" yaml:"swaps"`
+	Token           string            `json:"token" yaml:"token"`
+	BlocksRemaining sdk.Uint          `json:"blocks_remaining" yaml:"blocks_remaining"`
+	TotalBuyAmount  sdk.Coin          `json:"total_buy_amount" yaml:"total_buy_amount"`
+	TotalSellAmount sdk.Coin          `json:"total_sell_amount" yaml:"total_sell_amount"`
+	BuyPrices       sdk.DecCoins      `json:"buy_prices" yaml:"buy_prices"`
+	SellPrices      sdk.DecCoins      `json:"sell_prices" yaml:"sell_prices"`
+	ClosingPrices   sdk.DecCoins      `json:"closing_prices" yaml:"closing_prices"`
+	Buys            []BuyOrder        `json:"buys" yaml:"buys"`
+	Sells           []SellOrder       `json:"sells" yaml:"sells"`
+	Swaps           []SwapOrder       `json:"swaps" yaml:"swaps"`
+	RoutedSwaps     []RoutedSwapOrder `json:"routed_swaps" yaml:"routed_swaps"`
 }
 
 func (b Batch) MoreBuysThanSells() bool { return b.TotalSellAmount.IsLT(b.TotalBuyAmount) }
 func (b Batch) MoreSellsThanBuys() bool { return b.TotalBuyAmount.IsLT(b.TotalSellAmount) }
 func (b Batch) EqualBuysAndSells() bool { return b.TotalBuyAmount.IsEqual(b.TotalSellAmount) }
 
+// GetSwapPairs returns the pairs of reserve tokens that the uncancelled swaps
+// in the batch are between, each in alphabetical order, sorted so that the
+// pairs are always processed in the same order
+func (b Batch) GetSwapPairs() (pairs [][2]string) {
+	seen := make(map[[2]string]bool)
+	for _, so := range b.Swaps {
+		if so.IsCancelled() {
+			continue
+		}
+		pair := [2]string{so.Amount.Denom, so.ToToken}
+		if pair[1] < pair[0] {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+		if !seen[pair] {
+			seen[pair] = true
+			pairs = append(pairs, pair)
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+	return pairs
+}
+
 func NewBatch(token string, blocks sdk.Uint) Batch {
 	return Batch{
 		Token:           token,
@@ -51,34 +107,120 @@ func (bo BaseOrder) IsCancelled() bool {
 
 type BuyOrder struct {
 	BaseOrder
-	MaxPrices sdk.Coins `json:"max_prices" yaml:"max_prices"`
+	MaxPrices        sdk.Coins `json:"max_prices" yaml:"max_prices"`
+	AllowPartialFill bool      `json:"allow_partial_fill" yaml:"allow_partial_fill"`
 }
 
-func NewBuyOrder(address sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins) BuyOrder {
+func NewBuyOrder(address sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins,
+	allowPartialFill bool) BuyOrder {
 	return BuyOrder{
-		BaseOrder: NewBaseOrder(address, amount),
-		MaxPrices: maxPrices,
+		BaseOrder:        NewBaseOrder(address, amount),
+		MaxPrices:        maxPrices,
+		AllowPartialFill: allowPartialFill,
 	}
 }
 
 type SellOrder struct {
 	BaseOrder
+	MinReturns sdk.Coins `json:"min_returns" yaml:"min_returns"`
 }
 
-func NewSellOrder(address sdk.AccAddress, amount sdk.Coin) SellOrder {
+func NewSellOrder(address sdk.AccAddress, amount sdk.Coin, minReturns sdk.Coins) SellOrder {
 	return SellOrder{
-		BaseOrder: NewBaseOrder(address, amount),
+		BaseOrder:  NewBaseOrder(address, amount),
+		MinReturns: minReturns,
 	}
 }
 
 type SwapOrder struct {
 	BaseOrder
-	ToToken string `json:"to_token" yaml:"to_token"`
+	ToToken   string   `json:"to_token" yaml:"to_token"`
+	MinOutput sdk.Coin `json:"min_output" yaml:"min_output"`
 }
 
-func NewSwapOrder(address sdk.AccAddress, from sdk.Coin, toToken string) SwapOrder {
+func NewSwapOrder(address sdk.AccAddress, from sdk.Coin, toToken string, minOutput sdk.Coin) SwapOrder {
 	return SwapOrder{
 		BaseOrder: NewBaseOrder(address, from),
 		ToToken:   toToken,
+		MinOutput: minOutput,
+	}
+}
+
+// SwapHop is a single swap within a swap route, from the token given by the
+// previous hop (or the from token, for the first hop) to ToToken, using the
+// reserves of the swapper war WarToken
+type SwapHop struct {
+	WarToken string `json:"war_token" yaml:"war_token"`
+	ToToken  string `json:"to_token" yaml:"to_token"`
+}
+
+func NewSwapHop(warToken, toToken string) SwapHop {
+	return SwapHop{
+		WarToken: warToken,
+		ToToken:  toToken,
+	}
+}
+
+func (h SwapHop) String() string {
+	return fmt.Sprintf("%s:%s", h.WarToken, h.ToToken)
+}
+
+type SwapRoute []SwapHop
+
+// String returns the route in the form "war1:token2,war2:token3"
+func (r SwapRoute) String() string {
+	hops := make([]string, len(r))
+	for i, h := range r {
+		hops[i] = h.String()
+	}
+	return strings.Join(hops, ",")
+}
+
+// ToToken returns the token that the route ends at
+func (r SwapRoute) ToToken() string {
+	if len(r) == 0 {
+		return ""
+	}
+	return r[len(r)-1].ToToken
+}
+
+// Validate checks that the route is a valid path of at most MaxSwapRouteHops
+// hops starting from fromToken, in which no hop swaps a token to itself
+func (r SwapRoute) Validate(fromToken string) error {
+	if len(r) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Route")
+	} else if len(r) > MaxSwapRouteHops {
+		return sdkerrors.Wrapf(ErrInvalidSwapRoute, "route cannot have more than %d hops", MaxSwapRouteHops)
+	}
+
+	token := fromToken
+	for _, h := range r {
+		if err := CheckCoinDenom(h.WarToken); err != nil {
+			return err
+		} else if err := CheckCoinDenom(h.ToToken); err != nil {
+			return err
+		} else if h.ToToken == token {
+			return sdkerrors.Wrap(ErrFromAndToCannotBeTheSameToken, token)
+		}
+		token = h.ToToken
+	}
+
+	if token == fromToken {
+		return sdkerrors.Wrapf(ErrInvalidSwapRoute, "route cannot end at %s", fromToken)
+	}
+	return nil
+}
+
+type RoutedSwapOrder struct {
+	BaseOrder
+	Hops      SwapRoute `json:"hops" yaml:"hops"`
+	MinOutput sdk.Coin  `json:"min_output" yaml:"min_output"`
+}
+
+func NewRoutedSwapOrder(address sdk.AccAddress, from sdk.Coin, hops SwapRoute, minOutput sdk.Coin) RoutedSwapOrder {
+	return RoutedSwapOrder{
+		BaseOrder: NewBaseOrder(address, from),
+		Hops:      hops,
+		MinOutput: minOutput,
 	}
 }
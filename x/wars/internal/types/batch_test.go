@@ -81,7 +81,7 @@ func TestNewBuyOrderDefaultValues(t *testing.T) {
 	amount2 := sdk.NewInt64Coin("token2", 2000)
 	amount3 := sdk.NewInt64Coin("token3", 3000)
 	maxPrices := sdk.NewCoins(amount2, amount3)
-	order := NewBuyOrder(address, amount1, maxPrices)
+	order := NewBuyOrder(address, amount1, maxPrices, false)
 
 	require.Equal(t, address, order.Address)
 	require.Equal(t, amount1, order.Amount)
@@ -93,23 +93,70 @@ func TestNewBuyOrderDefaultValues(t *testing.T) {
 func TestNewSellOrderDefaultValues(t *testing.T) {
 	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	amount := sdk.NewInt64Coin("token", 1000)
-	order := NewSellOrder(address, amount)
+	minReturns := sdk.NewCoins(sdk.NewInt64Coin("reservetoken", 50))
+	order := NewSellOrder(address, amount, minReturns)
 
 	require.Equal(t, address, order.Address)
 	require.Equal(t, amount, order.Amount)
 	require.False(t, order.Cancelled)
 	require.Empty(t, order.CancelReason)
+	require.Equal(t, minReturns, order.MinReturns)
 }
 
 func TestNewSwapOrderDefaultValues(t *testing.T) {
 	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	fromAmount := sdk.NewInt64Coin("token1", 1000)
 	toToken := "token2"
-	order := NewSwapOrder(address, fromAmount, toToken)
+	minOutput := sdk.NewInt64Coin(toToken, 900)
+	order := NewSwapOrder(address, fromAmount, toToken, minOutput)
 
 	require.Equal(t, address, order.Address)
 	require.Equal(t, fromAmount, order.Amount)
 	require.Equal(t, toToken, order.ToToken)
+	require.Equal(t, minOutput, order.MinOutput)
 	require.False(t, order.Cancelled)
 	require.Empty(t, order.CancelReason)
 }
+
+func TestSwapRoute(t *testing.T) {
+	route := SwapRoute{
+		NewSwapHop("war1", "token2"),
+		NewSwapHop("war2", "token3"),
+	}
+	require.Equal(t, "war1:token2,war2:token3", route.String())
+	require.Equal(t, "token3", route.ToToken())
+	require.Nil(t, route.Validate("token1"))
+
+	// Hop to the token being swapped from
+	require.Error(t, route.Validate("token2"))
+
+	// Route ending at the token being swapped from
+	require.Error(t, route.Validate("token3"))
+
+	// Empty route
+	require.Error(t, SwapRoute{}.Validate("token1"))
+}
+
+func TestBatchGetSwapPairs(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	newSwap := func(from, to string) SwapOrder {
+		return NewSwapOrder(address, sdk.NewInt64Coin(from, 10), to, sdk.NewInt64Coin(to, 0))
+	}
+
+	batch := NewBatch("token", sdk.NewUint(10))
+	require.Empty(t, batch.GetSwapPairs())
+
+	cancelled := newSwap("token4", "token1")
+	cancelled.Cancelled = true
+	batch.Swaps = []SwapOrder{
+		newSwap("token3", "token1"),
+		newSwap("token1", "token2"),
+		newSwap("token1", "token3"),
+		newSwap("token2", "token1"),
+		cancelled,
+	}
+
+	// Each pair appears once, in alphabetical order, ignoring cancelled swaps
+	expected := [][2]string{{"token1", "token2"}, {"token1", "token3"}}
+	require.Equal(t, expected, batch.GetSwapPairs())
+}
@@ -14,9 +14,18 @@ const (
 	SwapperFunction   = "swapper_function"
 	AugmentedFunction = "augmented_function"
 
+	ExponentialFunction = "exponential_function"
+	LogarithmicFunction = "logarithmic_function"
+	BancorFunction      = "bancor_function"
+
+	PiecewiseLinearFunction = "piecewise_linear_function"
+	WeightedSwapperFunction = "weighted_swapper_function"
+	StableSwapFunction      = "stableswap_function"
+
 	HatchState  = "HATCH"
 	OpenState   = "OPEN"
 	SettleState = "SETTLE"
+	FailedState = "FAILED"
 
 	DoNotModifyField = "[do-not-modify]"
 
@@ -25,29 +34,6 @@ const (
 
 type FunctionParamRestrictions func(paramsMap map[string]sdk.Dec) error
 
-var (
-	RequiredParamsForFunctionType = map[string][]string{
-		PowerFunction:     {"m", "n", "c"},
-		SigmoidFunction:   {"a", "b", "c"},
-		SwapperFunction:   nil,
-		AugmentedFunction: {"d0", "p0", "theta", "kappa"},
-	}
-
-	NoOfReserveTokensForFunctionType = map[string]int{
-		PowerFunction:     AnyNumberOfReserveTokens,
-		SigmoidFunction:   AnyNumberOfReserveTokens,
-		SwapperFunction:   2,
-		AugmentedFunction: AnyNumberOfReserveTokens,
-	}
-
-	ExtraParameterRestrictions = map[string]FunctionParamRestrictions{
-		PowerFunction:     powerParameterRestrictions,
-		SigmoidFunction:   sigmoidParameterRestrictions,
-		SwapperFunction:   nil,
-		AugmentedFunction: augmentedParameterRestrictions,
-	}
-)
-
 type FunctionParam struct {
 	Param string  `json:"param" yaml:"param"`
 	Value sdk.Dec `json:"value" yaml:"value"`
@@ -64,7 +50,7 @@ type FunctionParams []FunctionParam
 
 func (fps FunctionParams) Validate(functionType string) error {
 	// Come up with list of expected parameters
-	expectedParams, err := GetRequiredParamsForFunctionType(functionType)
+	expectedParams, err := GetRequiredParamsForFunctionParams(functionType, fps)
 	if err != nil {
 		return err
 	}
@@ -138,6 +124,105 @@ func sigmoidParameterRestrictions(paramsMap map[string]sdk.Dec) error {
 	return nil
 }
 
+func exponentialParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	// Exponential exception 1: b != 0, otherwise we run into divisions by zero
+	val, ok := paramsMap["b"]
+	if !ok {
+		panic("did not find parameter b for exponential function")
+	} else if !val.IsPositive() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FunctionParams:b")
+	}
+	return nil
+}
+
+func logarithmicParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	// Logarithmic exception 1: b != 0, otherwise we run into divisions by zero
+	val, ok := paramsMap["b"]
+	if !ok {
+		panic("did not find parameter b for logarithmic function")
+	} else if !val.IsPositive() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FunctionParams:b")
+	}
+	return nil
+}
+
+func bancorParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	// Bancor exception 1: reserve ratio r must be from 0 to 1 (excluding 0)
+	val, ok := paramsMap["r"]
+	if !ok {
+		panic("did not find parameter r for bancor function")
+	} else if !val.IsPositive() || val.GT(sdk.OneDec()) {
+		return sdkerrors.Wrapf(ErrArgumentMustBeBetween, "%s argument must be between %s and %s", "FunctionParams:r", "0 (exclusive)", "1")
+	}
+
+	// Bancor exception 2: p0 != 0, otherwise all prices are zero
+	val, ok = paramsMap["p0"]
+	if !ok {
+		panic("did not find parameter p0 for bancor function")
+	} else if !val.IsPositive() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FunctionParams:p0")
+	}
+
+	// Bancor exception 3: s0 != 0, otherwise we run into divisions by zero
+	val, ok = paramsMap["s0"]
+	if !ok {
+		panic("did not find parameter s0 for bancor function")
+	} else if !val.IsPositive() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FunctionParams:s0")
+	}
+	return nil
+}
+
+func piecewiseLinearParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	xs, _ := piecewiseLinearBreakpoints(paramsMap)
+	if len(xs) < 2 {
+		panic("did not find at least two breakpoints for piecewise linear function")
+	}
+
+	// Piecewise linear exception 1: x0 must be 0, so that the reserve function
+	// (the integral of the price function from zero supply) is well-defined
+	if !xs[0].IsZero() {
+		return sdkerrors.Wrap(ErrInvalidFunctionParameter, "FunctionParams:x0 must be 0")
+	}
+
+	// Piecewise linear exception 2: breakpoint supplies must be increasing,
+	// otherwise we run into divisions by zero when interpolating
+	for i := 1; i < len(xs); i++ {
+		if !xs[i].GT(xs[i-1]) {
+			return sdkerrors.Wrapf(ErrInvalidFunctionParameter,
+				"FunctionParams:x%d must be greater than x%d", i, i-1)
+		}
+	}
+	return nil
+}
+
+func weightedSwapperParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	// Weighted swapper exception 1: at least two weights (reserve tokens)
+	if len(paramsMap) < 2 {
+		return sdkerrors.Wrap(ErrIncorrectNumberOfFunctionParameters, "expected at least 2")
+	}
+
+	// Weighted swapper exception 2: weights != 0, otherwise we run into
+	// divisions by zero when calculating swap returns
+	for r, w := range paramsMap {
+		if !w.IsPositive() {
+			return sdkerrors.Wrap(ErrArgumentMustBePositive, "FunctionParams:"+r)
+		}
+	}
+	return nil
+}
+
+func stableSwapParameterRestrictions(paramsMap map[string]sdk.Dec) error {
+	// StableSwap exception 1: A != 0, otherwise we run into divisions by zero
+	val, ok := paramsMap["A"]
+	if !ok {
+		panic("did not find parameter A for stableswap function")
+	} else if !val.IsPositive() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FunctionParams:A")
+	}
+	return nil
+}
+
 func augmentedParameterRestrictions(paramsMap map[string]sdk.Dec) error {
 	// Augmented exception 1.1: d0 must be an integer, since it is a token amount
 	// Augmented exception 1.2: d0 != 0, otherwise we run into divisions by zero
@@ -181,27 +266,47 @@ func augmentedParameterRestrictions(paramsMap map[string]sdk.Dec) error {
 }
 
 type War struct {
-	Token                  string           `json:"token" yaml:"token"`
-	Name                   string           `json:"name" yaml:"name"`
-	Description            string           `json:"description" yaml:"description"`
-	Creator                sdk.AccAddress   `json:"creator" yaml:"creator"`
-	FunctionType           string           `json:"function_type" yaml:"function_type"`
-	FunctionParameters     FunctionParams   `json:"function_parameters" yaml:"function_parameters"`
-	ReserveTokens          []string         `json:"reserve_tokens" yaml:"reserve_tokens"`
-	TxFeePercentage        sdk.Dec          `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
-	ExitFeePercentage      sdk.Dec          `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
-	FeeAddress             sdk.AccAddress   `json:"fee_address" yaml:"fee_address"`
-	MaxSupply              sdk.Coin         `json:"max_supply" yaml:"max_supply"`
-	OrderQuantityLimits    sdk.Coins        `json:"order_quantity_limits" yaml:"order_quantity_limits"`
-	SanityRate             sdk.Dec          `json:"sanity_rate" yaml:"sanity_rate"`
-	SanityMarginPercentage sdk.Dec          `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
-	CurrentSupply          sdk.Coin         `json:"current_supply" yaml:"current_supply"`
-	CurrentReserve         sdk.Coins        `json:"current_reserve" yaml:"current_reserve"`
-	AllowSells             bool             `json:"allow_sells" yaml:"allow_sells"`
-	Signers                []sdk.AccAddress `json:"signers" yaml:"signers"`
-	BatchBlocks            sdk.Uint         `json:"batch_blocks" yaml:"batch_blocks"`
-	OutcomePayment         sdk.Coins        `json:"outcome_payment" yaml:"outcome_payment"`
-	State                  string           `json:"state" yaml:"state"`
+	Token                         string           `json:"token" yaml:"token"`
+	Name                          string           `json:"name" yaml:"name"`
+	Description                   string           `json:"description" yaml:"description"`
+	Creator                       sdk.AccAddress   `json:"creator" yaml:"creator"`
+	FunctionType                  string           `json:"function_type" yaml:"function_type"`
+	FunctionParameters            FunctionParams   `json:"function_parameters" yaml:"function_parameters"`
+	ReserveTokens                 []string         `json:"reserve_tokens" yaml:"reserve_tokens"`
+	TxFeePercentage               sdk.Dec          `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage             sdk.Dec          `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	FeeAddress                    sdk.AccAddress   `json:"fee_address" yaml:"fee_address"`
+	MaxSupply                     sdk.Coin         `json:"max_supply" yaml:"max_supply"`
+	OrderQuantityLimits           sdk.Coins        `json:"order_quantity_limits" yaml:"order_quantity_limits"`
+	SanityRate                    sdk.Dec          `json:"sanity_rate" yaml:"sanity_rate"`
+	SanityMarginPercentage        sdk.Dec          `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	CurrentSupply                 sdk.Coin         `json:"current_supply" yaml:"current_supply"`
+	CurrentReserve                sdk.Coins        `json:"current_reserve" yaml:"current_reserve"`
+	AllowSells                    bool             `json:"allow_sells" yaml:"allow_sells"`
+	Signers                       []sdk.AccAddress `json:"signers" yaml:"signers"`
+	SignerThreshold               uint64           `json:"signer_threshold" yaml:"signer_threshold"`
+	EditDelay                     int64            `json:"edit_delay" yaml:"edit_delay"`
+	BatchBlocks                   sdk.Uint         `json:"batch_blocks" yaml:"batch_blocks"`
+	OutcomePayment                sdk.Coins        `json:"outcome_payment" yaml:"outcome_payment"`
+	HatchDeadlineHeight           int64            `json:"hatch_deadline_height" yaml:"hatch_deadline_height"`
+	HatchFunding                  sdk.Coins        `json:"hatch_funding" yaml:"hatch_funding"`
+	CreationDeposit               sdk.Coins        `json:"creation_deposit" yaml:"creation_deposit"`
+	AllowedHatchers               []sdk.AccAddress `json:"allowed_hatchers" yaml:"allowed_hatchers"`
+	HatchMembershipDenom          string           `json:"hatch_membership_denom" yaml:"hatch_membership_denom"`
+	MaxHatchContribution          sdk.Int          `json:"max_hatch_contribution" yaml:"max_hatch_contribution"`
+	HatchVestingCliff             int64            `json:"hatch_vesting_cliff" yaml:"hatch_vesting_cliff"`
+	HatchVestingPeriod            int64            `json:"hatch_vesting_period" yaml:"hatch_vesting_period"`
+	FundingPoolSpenders           []sdk.AccAddress `json:"funding_pool_spenders" yaml:"funding_pool_spenders"`
+	FundingPoolSpendLimit         sdk.Coins        `json:"funding_pool_spend_limit" yaml:"funding_pool_spend_limit"`
+	FundingPoolEpochBlocks        int64            `json:"funding_pool_epoch_blocks" yaml:"funding_pool_epoch_blocks"`
+	GovernanceQuorumPercentage    sdk.Dec          `json:"governance_quorum_percentage" yaml:"governance_quorum_percentage"`
+	GovernanceThresholdPercentage sdk.Dec          `json:"governance_threshold_percentage" yaml:"governance_threshold_percentage"`
+	GovernanceVotingPeriod        int64            `json:"governance_voting_period" yaml:"governance_voting_period"`
+	MaxPriceMovePercentage        sdk.Dec          `json:"max_price_move_percentage" yaml:"max_price_move_percentage"`
+	PriceMoveCooldown             int64            `json:"price_move_cooldown" yaml:"price_move_cooldown"`
+	State                         string           `json:"state" yaml:"state"`
+	Paused                        bool             `json:"paused" yaml:"paused"`
+	PausedUntilHeight             int64            `json:"paused_until_height" yaml:"paused_until_height"`
 }
 
 func NewWar(token, name, description string, creator sdk.AccAddress,
@@ -209,38 +314,63 @@ func NewWar(token, name, description string, creator sdk.AccAddress,
 	txFeePercentage, exitFeePercentage sdk.Dec, feeAddress sdk.AccAddress,
 	maxSupply sdk.Coin, orderQuantityLimits sdk.Coins, sanityRate,
 	sanityMarginPercentage sdk.Dec, allowSells bool, signers []sdk.AccAddress,
-	batchBlocks sdk.Uint, outcomePayment sdk.Coins, state string) War {
+	signerThreshold uint64, editDelay int64, batchBlocks sdk.Uint, outcomePayment sdk.Coins, hatchDeadlineHeight int64,
+	allowedHatchers []sdk.AccAddress, hatchMembershipDenom string,
+	maxHatchContribution sdk.Int, hatchVestingCliff, hatchVestingPeriod int64,
+	fundingPoolSpenders []sdk.AccAddress, fundingPoolSpendLimit sdk.Coins,
+	fundingPoolEpochBlocks int64, governanceQuorumPercentage,
+	governanceThresholdPercentage sdk.Dec, governanceVotingPeriod int64,
+	maxPriceMovePercentage sdk.Dec, priceMoveCooldown int64, state string) War {
 
 	// Ensure tokens and coins are sorted
 	sort.Strings(reserveTokens)
 	orderQuantityLimits = orderQuantityLimits.Sort()
+	fundingPoolSpendLimit = fundingPoolSpendLimit.Sort()
 
 	return War{
-		Token:                  token,
-		Name:                   name,
-		Description:            description,
-		Creator:                creator,
-		FunctionType:           functionType,
-		FunctionParameters:     functionParameters,
-		ReserveTokens:          reserveTokens,
-		TxFeePercentage:        txFeePercentage,
-		ExitFeePercentage:      exitFeePercentage,
-		FeeAddress:             feeAddress,
-		MaxSupply:              maxSupply,
-		OrderQuantityLimits:    orderQuantityLimits,
-		SanityRate:             sanityRate,
-		SanityMarginPercentage: sanityMarginPercentage,
-		CurrentSupply:          sdk.NewCoin(token, sdk.ZeroInt()),
-		CurrentReserve:         nil,
-		AllowSells:             allowSells,
-		Signers:                signers,
-		BatchBlocks:            batchBlocks,
-		OutcomePayment:         outcomePayment,
-		State:                  state,
-	}
-}
-
-//noinspection GoNilness
+		Token:                         token,
+		Name:                          name,
+		Description:                   description,
+		Creator:                       creator,
+		FunctionType:                  functionType,
+		FunctionParameters:            functionParameters,
+		ReserveTokens:                 reserveTokens,
+		TxFeePercentage:               txFeePercentage,
+		ExitFeePercentage:             exitFeePercentage,
+		FeeAddress:                    feeAddress,
+		MaxSupply:                     maxSupply,
+		OrderQuantityLimits:           orderQuantityLimits,
+		SanityRate:                    sanityRate,
+		SanityMarginPercentage:        sanityMarginPercentage,
+		CurrentSupply:                 sdk.NewCoin(token, sdk.ZeroInt()),
+		CurrentReserve:                nil,
+		AllowSells:                    allowSells,
+		Signers:                       signers,
+		SignerThreshold:               signerThreshold,
+		EditDelay:                     editDelay,
+		BatchBlocks:                   batchBlocks,
+		OutcomePayment:                outcomePayment,
+		HatchDeadlineHeight:           hatchDeadlineHeight,
+		HatchFunding:                  nil,
+		CreationDeposit:               nil,
+		AllowedHatchers:               allowedHatchers,
+		HatchMembershipDenom:          hatchMembershipDenom,
+		MaxHatchContribution:          maxHatchContribution,
+		HatchVestingCliff:             hatchVestingCliff,
+		HatchVestingPeriod:            hatchVestingPeriod,
+		FundingPoolSpenders:           fundingPoolSpenders,
+		FundingPoolSpendLimit:         fundingPoolSpendLimit,
+		FundingPoolEpochBlocks:        fundingPoolEpochBlocks,
+		GovernanceQuorumPercentage:    governanceQuorumPercentage,
+		GovernanceThresholdPercentage: governanceThresholdPercentage,
+		GovernanceVotingPeriod:        governanceVotingPeriod,
+		MaxPriceMovePercentage:        maxPriceMovePercentage,
+		PriceMoveCooldown:             priceMoveCooldown,
+		State:                         state,
+	}
+}
+
+// noinspection GoNilness
 func (war War) GetNewReserveDecCoins(amount sdk.Dec) (coins sdk.DecCoins) {
 	for _, r := range war.ReserveTokens {
 		coins = coins.Add(sdk.NewDecCoinFromDec(r, amount))
@@ -248,55 +378,26 @@ func (war War) GetNewReserveDecCoins(amount sdk.Dec) (coins sdk.DecCoins) {
 	return coins
 }
 
+func (war War) mustGetCurveFunction() CurveFunction {
+	fn, err := GetCurveFunction(war.FunctionType)
+	if err != nil {
+		panic("unrecognized function type")
+	}
+	return fn
+}
+
+func (war War) IsSwapper() bool {
+	return war.mustGetCurveFunction().IsSwapper()
+}
+
 func (war War) GetPricesAtSupply(supply sdk.Int) (result sdk.DecCoins, err error) {
 	if supply.IsNegative() {
 		panic(fmt.Sprintf("negative supply for war %s", war.Token))
 	}
 
-	args := war.FunctionParameters.AsMap()
-	x := supply.ToDec()
-	switch war.FunctionType {
-	case PowerFunction:
-		m := args["m"]
-		n64 := args["n"].TruncateInt64() // enforced by powerParameterRestrictions
-		c := args["c"]
-		result = war.GetNewReserveDecCoins(
-			x.Power(uint64(n64)).Mul(m).Add(c))
-	case SigmoidFunction:
-		a := args["a"]
-		b := args["b"]
-		c := args["c"]
-		temp1 := x.Sub(b)
-		temp2 := temp1.Mul(temp1).Add(c)
-		temp3, err := temp2.ApproxSqrt()
-		if err != nil {
-			panic(err) // TODO: consider error handling
-		}
-		result = war.GetNewReserveDecCoins(
-			a.Mul(temp1.Quo(temp3).Add(sdk.OneDec())))
-	case AugmentedFunction:
-		// Note: during the hatch phase, this function returns the hatch price
-		// p0 even if the supply argument is greater than the initial supply S0
-		switch war.State {
-		case HatchState:
-			result = war.GetNewReserveDecCoins(args["p0"])
-		case OpenState:
-			kappa := args["kappa"].TruncateInt64()
-			res := Reserve(x, kappa, args["V0"])
-			// If reserve < 1, default to zero price to avoid calculation issues
-			if res.LT(sdk.OneDec()) {
-				result = war.GetNewReserveDecCoins(sdk.ZeroDec())
-			} else {
-				spotPriceDec := SpotPrice(res, kappa, args["V0"])
-				result = war.GetNewReserveDecCoins(spotPriceDec)
-			}
-		default:
-			panic("unrecognized war state")
-		}
-	case SwapperFunction:
-		return nil, sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, war.FunctionType)
-	default:
-		panic("unrecognized function type")
+	result, err = war.mustGetCurveFunction().GetPricesAtSupply(war, supply)
+	if err != nil {
+		return nil, err
 	}
 
 	if result.IsAnyNegative() {
@@ -308,18 +409,10 @@ func (war War) GetPricesAtSupply(supply sdk.Int) (result sdk.DecCoins, err error
 
 func (war War) GetCurrentPricesPT(reserveBalances sdk.Coins) (sdk.DecCoins, error) {
 	// Note: PT stands for "per token"
-	switch war.FunctionType {
-	case PowerFunction:
-		fallthrough
-	case SigmoidFunction:
-		fallthrough
-	case AugmentedFunction:
-		return war.GetPricesAtSupply(war.CurrentSupply.Amount)
-	case SwapperFunction:
+	if war.IsSwapper() {
 		return war.GetPricesToMint(sdk.OneInt(), reserveBalances)
-	default:
-		panic("unrecognized function type")
 	}
+	return war.GetPricesAtSupply(war.CurrentSupply.Amount)
 }
 
 func (war War) ReserveAtSupply(supply sdk.Int) (result sdk.Dec) {
@@ -327,44 +420,7 @@ func (war War) ReserveAtSupply(supply sdk.Int) (result sdk.Dec) {
 		panic(fmt.Sprintf("negative supply for war %s", war.Token))
 	}
 
-	args := war.FunctionParameters.AsMap()
-	x := supply.ToDec()
-	switch war.FunctionType {
-	case PowerFunction:
-		m := args["m"]
-		n, n64 := args["n"], args["n"].TruncateInt64() // enforced by powerParameterRestrictions
-		c := args["c"]
-		temp1 := x.Power(uint64(n64 + 1))
-		temp2 := temp1.Mul(m).Quo(n.Add(sdk.OneDec()))
-		temp3 := x.Mul(c)
-		result = temp2.Add(temp3)
-	case SigmoidFunction:
-		a := args["a"]
-		b := args["b"]
-		c := args["c"]
-		temp1 := x.Sub(b)
-		temp2 := temp1.Mul(temp1).Add(c)
-		temp3, err := temp2.ApproxSqrt()
-		if err != nil {
-			panic(err) // TODO: consider error handling
-		}
-		temp5 := a.Mul(temp3.Add(x))
-		approx, err := (b.Mul(b).Add(c)).ApproxSqrt()
-		if err != nil {
-			panic(err) // TODO: consider error handling
-		}
-		constant := a.Mul(approx)
-
-		result = temp5.Sub(constant)
-	case AugmentedFunction:
-		kappa := args["kappa"].TruncateInt64()
-		V0 := args["V0"]
-		result = Reserve(x, kappa, V0)
-	case SwapperFunction:
-		panic("invalid function for function type")
-	default:
-		panic("unrecognized function type")
-	}
+	result = war.mustGetCurveFunction().ReserveAtSupply(war, supply)
 
 	if result.IsNegative() {
 		// For vanilla waring curves, we assume that the curve does not
@@ -379,38 +435,26 @@ func (war War) GetReserveDeltaForLiquidityDelta(mintOrBurn sdk.Int, reserveBalan
 		panic(fmt.Sprintf("negative liquidity delta for war %s", war.Token))
 	} else if reserveBalances.IsAnyNegative() {
 		panic(fmt.Sprintf("negative reserve balance for war %s", war.Token))
+	} else if !war.IsSwapper() {
+		panic("invalid function for function type")
 	}
 
-	switch war.FunctionType {
-	case PowerFunction:
-		fallthrough
-	case SigmoidFunction:
-		fallthrough
-	case AugmentedFunction:
-		panic("invalid function for function type")
-	case SwapperFunction:
-		resToken1 := war.ReserveTokens[0]
-		resToken2 := war.ReserveTokens[1]
-		resBalance1 := reserveBalances.AmountOf(resToken1).ToDec()
-		resBalance2 := reserveBalances.AmountOf(resToken2).ToDec()
-
-		// Using Uniswap formulae: x' = (1+-α)x = x +- Δx, where α = Δx/x
-		// Where x is any of the two reserve balances or the current supply
-		// and x' is any of the updated reserve balances or the updated supply
-		// By making Δx subject of the formula: Δx = αx
-		alpha := mintOrBurn.ToDec().Quo(war.CurrentSupply.Amount.ToDec())
-
-		result := sdk.DecCoins{
-			sdk.NewDecCoinFromDec(resToken1, alpha.Mul(resBalance1)),
-			sdk.NewDecCoinFromDec(resToken2, alpha.Mul(resBalance2)),
-		}
-		if result.IsAnyNegative() {
-			panic(fmt.Sprintf("negative reserve delta result for war %s", war.Token))
-		}
-		return result
-	default:
-		panic("unrecognized function type")
+	// Using Uniswap formulae: x' = (1+-α)x = x +- Δx, where α = Δx/x
+	// Where x is any of the reserve balances or the current supply
+	// and x' is any of the updated reserve balances or the updated supply
+	// By making Δx subject of the formula: Δx = αx
+	alpha := mintOrBurn.ToDec().Quo(war.CurrentSupply.Amount.ToDec())
+
+	result := sdk.DecCoins{}
+	for _, resToken := range war.ReserveTokens {
+		resBalance := reserveBalances.AmountOf(resToken).ToDec()
+		result = append(result, sdk.NewDecCoinFromDec(resToken, alpha.Mul(resBalance)))
 	}
+	result = result.Sort()
+	if result.IsAnyNegative() {
+		panic(fmt.Sprintf("negative reserve delta result for war %s", war.Token))
+	}
+	return result
 }
 
 func (war War) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.DecCoins, error) {
@@ -420,47 +464,7 @@ func (war War) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.Dec
 		panic(fmt.Sprintf("negative reserve balance for war %s", war.Token))
 	}
 
-	// If hatch phase for augmented function, use fixed p0 price
-	if war.FunctionType == AugmentedFunction && war.State == HatchState {
-		args := war.FunctionParameters.AsMap()
-		if war.State == HatchState {
-			price := args["p0"].Mul(mint.ToDec())
-			return war.GetNewReserveDecCoins(price), nil
-		}
-	}
-
-	switch war.FunctionType {
-	case PowerFunction:
-		fallthrough
-	case SigmoidFunction:
-		fallthrough
-	case AugmentedFunction:
-		var priceToMint sdk.Dec
-		result := war.ReserveAtSupply(war.CurrentSupply.Amount.Add(mint))
-		if reserveBalances.Empty() {
-			priceToMint = result
-		} else {
-			// Reserve balances should all be equal given that we are always
-			// applying the same additions/subtractions to all reserve balances.
-			// Thus we can pick the first reserve balance as the global balance.
-			commonReserveBalance := reserveBalances[0].Amount.ToDec()
-			priceToMint = result.Sub(commonReserveBalance)
-		}
-		if priceToMint.IsNegative() {
-			// Negative priceToMint means that the previous buyer overpaid
-			// to the point that the price for this buyer is covered. However,
-			// we still charge this buyer at least one token.
-			priceToMint = sdk.OneDec()
-		}
-		return war.GetNewReserveDecCoins(priceToMint), nil
-	case SwapperFunction:
-		if war.CurrentSupply.Amount.IsZero() {
-			return nil, sdkerrors.Wrap(ErrFunctionRequiresNonZeroCurrentSupply, war.CurrentSupply.Amount.String())
-		}
-		return war.GetReserveDeltaForLiquidityDelta(mint, reserveBalances), nil
-	default:
-		panic("unrecognized function type")
-	}
+	return war.mustGetCurveFunction().GetPricesToMint(war, mint, reserveBalances)
 	// Note: fees have to be added to these prices to get actual prices
 }
 
@@ -471,36 +475,7 @@ func (war War) GetReturnsForBurn(burn sdk.Int, reserveBalances sdk.Coins) sdk.De
 		panic(fmt.Sprintf("negative reserve balance for war %s", war.Token))
 	}
 
-	switch war.FunctionType {
-	case PowerFunction:
-		fallthrough
-	case SigmoidFunction:
-		fallthrough
-	case AugmentedFunction:
-		result := war.ReserveAtSupply(war.CurrentSupply.Amount.Sub(burn))
-
-		var reserveBalance sdk.Dec
-		if reserveBalances.Empty() {
-			reserveBalance = sdk.ZeroDec()
-		} else {
-			// Reserve balances should all be equal given that we are always
-			// applying the same additions/subtractions to all reserve balances.
-			// Thus we can pick the first reserve balance as the global balance.
-			reserveBalance = reserveBalances[0].Amount.ToDec()
-		}
-
-		if result.GT(reserveBalance) {
-			panic("not enough reserve available for burn")
-		} else {
-			returnForBurn := reserveBalance.Sub(result)
-			return war.GetNewReserveDecCoins(returnForBurn)
-			// TODO: investigate possibility of negative returnForBurn
-		}
-	case SwapperFunction:
-		return war.GetReserveDeltaForLiquidityDelta(burn, reserveBalances)
-	default:
-		panic("unrecognized function type")
-	}
+	return war.mustGetCurveFunction().GetReturnsForBurn(war, burn, reserveBalances)
 	// Note: fees have to be deducted from these returns to get actual returns
 }
 
@@ -511,50 +486,34 @@ func (war War) GetReturnsForSwap(from sdk.Coin, toToken string, reserveBalances
 		panic(fmt.Sprintf("negative reserve balance for war %s", war.Token))
 	}
 
-	switch war.FunctionType {
-	case PowerFunction:
-		fallthrough
-	case SigmoidFunction:
-		fallthrough
-	case AugmentedFunction:
-		return nil, sdk.Coin{}, sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, war.FunctionType)
-	case SwapperFunction:
-		// Check that from and to are reserve tokens
-		if from.Denom != war.ReserveTokens[0] && from.Denom != war.ReserveTokens[1] {
-			return nil, sdk.Coin{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, from.Denom)
-		} else if toToken != war.ReserveTokens[0] && toToken != war.ReserveTokens[1] {
-			return nil, sdk.Coin{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, toToken)
-		}
-
-		inAmt := from.Amount
-		inRes := reserveBalances.AmountOf(from.Denom)
-		outRes := reserveBalances.AmountOf(toToken)
-
-		// Calculate fee to get the adjusted input amount
-		txFee = war.GetTxFee(sdk.NewDecCoinFromCoin(from))
-		inAmt = inAmt.Sub(txFee.Amount) // adjusted input
+	return war.mustGetCurveFunction().GetReturnsForSwap(war, from, toToken, reserveBalances)
+}
 
-		// Check that at least 1 token is going in
-		if inAmt.IsZero() {
-			return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", from.Denom, toToken)
-		}
+func (war War) GetSpotPrice(fromToken, toToken string, reserveBalances sdk.Coins) (sdk.Dec, error) {
+	if reserveBalances.IsAnyNegative() {
+		panic(fmt.Sprintf("negative reserve balance for war %s", war.Token))
+	}
 
-		// Calculate output amount using Uniswap formula: Δy = (Δx*y)/(x+Δx)
-		outAmt := inAmt.Mul(outRes).Quo(inRes.Add(inAmt))
+	fn, ok := war.mustGetCurveFunction().(SpotPriceCurveFunction)
+	if !ok {
+		return sdk.Dec{}, sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, war.FunctionType)
+	}
+	return fn.GetSpotPrice(war, fromToken, toToken, reserveBalances)
+}
 
-		// Check that not giving out all of the available outRes or nothing at all
-		if outAmt.Equal(outRes) {
-			return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountCausesReserveDepletion, "%s - %s", from.Denom, toToken)
-		} else if outAmt.IsZero() {
-			return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", from.Denom, toToken)
-		} else if outAmt.IsNegative() {
-			panic(fmt.Sprintf("negative return for swap result for war %s", war.Token))
+// GetSpotPrices returns the spot price of the war's first reserve token in
+// terms of each of its other reserve tokens
+func (war War) GetSpotPrices(reserveBalances sdk.Coins) (sdk.DecCoins, error) {
+	fromToken := war.ReserveTokens[0]
+	var prices []sdk.DecCoin
+	for _, toToken := range war.ReserveTokens[1:] {
+		price, err := war.GetSpotPrice(fromToken, toToken, reserveBalances)
+		if err != nil {
+			return nil, err
 		}
-
-		return sdk.Coins{sdk.NewCoin(toToken, outAmt)}, txFee, nil
-	default:
-		panic("unrecognized function type")
+		prices = append(prices, sdk.NewDecCoinFromDec(toToken, price))
 	}
+	return sdk.NewDecCoins(prices...), nil
 }
 
 func (war War) GetFee(reserveAmount sdk.DecCoin, percentage sdk.Dec) sdk.Coin {
@@ -577,29 +536,115 @@ func (war War) GetFees(reserveAmounts sdk.DecCoins, percentage sdk.Dec) (fees sd
 	return fees
 }
 
-//noinspection GoNilness
+// noinspection GoNilness
 func (war War) GetTxFees(reserveAmounts sdk.DecCoins) (fees sdk.Coins) {
 	return war.GetFees(reserveAmounts, war.TxFeePercentage)
 }
 
-//noinspection GoNilness
+// noinspection GoNilness
 func (war War) GetExitFees(reserveAmounts sdk.DecCoins) (fees sdk.Coins) {
 	return war.GetFees(reserveAmounts, war.ExitFeePercentage)
 }
 
-func (war War) SignersEqualTo(signers []sdk.AccAddress) bool {
-	if len(war.Signers) != len(signers) {
-		return false
+func (war War) IsSigner(address sdk.AccAddress) bool {
+	for _, s := range war.Signers {
+		if s.Equals(address) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Note: this also enforces ORDER of signatures to be the same
-	for i := range signers {
-		if !war.Signers[i].Equals(signers[i]) {
-			return false
+// GetSignerThreshold returns the number of the war's signers that need to sign
+// to edit the war, which is all of the signers if no threshold was specified
+func (war War) GetSignerThreshold() uint64 {
+	if war.SignerThreshold == 0 {
+		return uint64(len(war.Signers))
+	}
+	return war.SignerThreshold
+}
+
+// SignersMeetThreshold returns true if the number of distinct war signers in
+// the specified signers is at least the war's signer threshold. The order of
+// the signers does not matter, and signers that are not war signers are not
+// counted.
+func (war War) SignersMeetThreshold(signers []sdk.AccAddress) bool {
+	present := make(map[string]bool)
+	for _, s := range signers {
+		if war.IsSigner(s) {
+			present[s.String()] = true
 		}
 	}
+	return uint64(len(present)) >= war.GetSignerThreshold()
+}
 
-	return true
+func (war War) IsReserveToken(denom string) bool {
+	for _, r := range war.ReserveTokens {
+		if r == denom {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHatchRestricted returns true if only approved addresses can buy during
+// the hatch phase, i.e. if an allow-list or a membership denom is set
+func (war War) IsHatchRestricted() bool {
+	return len(war.AllowedHatchers) != 0 || war.HatchMembershipDenom != ""
+}
+
+// HatchDeadlineReached returns true if the war is in its hatch phase and has
+// a hatch deadline that is less than or equal to the specified block height
+func (war War) HatchDeadlineReached(height int64) bool {
+	return war.State == HatchState && war.HatchDeadlineHeight != 0 &&
+		height >= war.HatchDeadlineHeight
+}
+
+func (war War) IsAllowedHatcher(address sdk.AccAddress) bool {
+	for _, h := range war.AllowedHatchers {
+		if h.Equals(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasHatchVesting returns true if the war tokens bought during the hatch
+// phase are locked and vest over time, i.e. if a vesting period is set
+func (war War) HasHatchVesting() bool {
+	return war.HatchVestingPeriod > 0
+}
+
+// HasFundingPool returns true if the funding raised by the war is held in a
+// funding pool, rather than being sent to the fee address, i.e. if any funding
+// pool spenders are set or if the war's token holders can vote on spending it
+func (war War) HasFundingPool() bool {
+	return war.HasFundingPoolSpenders() || war.HasGovernance()
+}
+
+func (war War) HasFundingPoolSpenders() bool {
+	return len(war.FundingPoolSpenders) != 0
+}
+
+func (war War) IsFundingPoolSpender(address sdk.AccAddress) bool {
+	for _, s := range war.FundingPoolSpenders {
+		if s.Equals(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasGovernance returns true if the war's token holders can vote on proposals
+// to spend from the war's funding pool and to edit the war
+func (war War) HasGovernance() bool {
+	return war.GovernanceVotingPeriod > 0
+}
+
+// GetFundingPoolEpochStart returns the height at which the funding pool
+// spending epoch that includes the specified height started
+func (war War) GetFundingPoolEpochStart(height int64) int64 {
+	return height - height%war.FundingPoolEpochBlocks
 }
 
 func (war War) ReserveDenomsEqualTo(coins sdk.Coins) bool {
@@ -622,7 +667,8 @@ func (war War) AnyOrderQuantityLimitsExceeded(amounts sdk.Coins) bool {
 
 func (war War) ReservesViolateSanityRate(newReserves sdk.Coins) bool {
 
-	if war.SanityRate.IsZero() {
+	// Sanity rates are only supported for wars with two reserve tokens
+	if war.SanityRate.IsZero() || len(war.ReserveTokens) != 2 {
 		return false
 	}
 
@@ -647,3 +693,32 @@ func (war War) ReservesViolateSanityRate(newReserves sdk.Coins) bool {
 
 	return exchangeRate.LT(minRate) || exchangeRate.GT(maxRate)
 }
+
+// HasMaxPriceMove returns true if the war is paused when a batch moves its
+// prices by more than a max percentage
+func (war War) HasMaxPriceMove() bool {
+	return !war.MaxPriceMovePercentage.IsZero()
+}
+
+// PricesExceedMaxPriceMove returns true if any of the prices differs from the
+// reference price in the same denomination by more than the war's max price
+// move percentage of the reference price. Prices without a positive reference
+// price are not compared.
+func (war War) PricesExceedMaxPriceMove(prices, referencePrices sdk.DecCoins) bool {
+	if !war.HasMaxPriceMove() {
+		return false
+	}
+
+	maxMoveDecimal := war.MaxPriceMovePercentage.Quo(sdk.NewDec(100))
+	for _, p := range prices {
+		reference := referencePrices.AmountOf(p.Denom)
+		if !reference.IsPositive() {
+			continue
+		}
+		move := p.Amount.Sub(reference).Abs().Quo(reference)
+		if move.GT(maxMoveDecimal) {
+			return true
+		}
+	}
+	return false
+}
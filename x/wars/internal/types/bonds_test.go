@@ -9,7 +9,7 @@ import (
 )
 
 func TestExtraParameterRestrictions_Power(t *testing.T) {
-	paramRestrictions := ExtraParameterRestrictions[PowerFunction]
+	paramRestrictions := curveFunctions[PowerFunction].ValidateParams
 
 	testCases := []struct {
 		m           string
@@ -42,7 +42,7 @@ func TestExtraParameterRestrictions_Power(t *testing.T) {
 }
 
 func TestExtraParameterRestrictions_Sigmoid(t *testing.T) {
-	paramRestrictions := ExtraParameterRestrictions[SigmoidFunction]
+	paramRestrictions := curveFunctions[SigmoidFunction].ValidateParams
 
 	testCases := []struct {
 		a           string
@@ -74,8 +74,107 @@ func TestExtraParameterRestrictions_Sigmoid(t *testing.T) {
 	}
 }
 
+func TestExtraParameterRestrictions_Exponential(t *testing.T) {
+	paramRestrictions := curveFunctions[ExponentialFunction].ValidateParams
+
+	testCases := []struct {
+		a           string
+		b           string
+		expectError bool
+	}{
+		{"10", "10", false},      // integers allowed for all
+		{"0", "10", false},       // zero allowed for a
+		{"10", "0", true},        // zero not allowed for b
+		{"10.10", "0.01", false}, // floats allowed for all
+	}
+
+	for _, tc := range testCases {
+		aDec := sdk.MustNewDecFromStr(tc.a)
+		bDec := sdk.MustNewDecFromStr(tc.b)
+		err := paramRestrictions(FunctionParams{
+			NewFunctionParam("a", aDec),
+			NewFunctionParam("b", bDec),
+		}.AsMap())
+
+		if tc.expectError {
+			require.Error(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
+func TestExtraParameterRestrictions_Logarithmic(t *testing.T) {
+	paramRestrictions := curveFunctions[LogarithmicFunction].ValidateParams
+
+	testCases := []struct {
+		a           string
+		b           string
+		c           string
+		expectError bool
+	}{
+		{"10", "10", "10", false},         // integers allowed for all
+		{"0", "10", "0", false},           // zeroes allowed for a and c
+		{"10", "0", "10", true},           // zero not allowed for b
+		{"10.10", "0.01", "10.10", false}, // floats allowed for all
+	}
+
+	for _, tc := range testCases {
+		aDec := sdk.MustNewDecFromStr(tc.a)
+		bDec := sdk.MustNewDecFromStr(tc.b)
+		cDec := sdk.MustNewDecFromStr(tc.c)
+		err := paramRestrictions(FunctionParams{
+			NewFunctionParam("a", aDec),
+			NewFunctionParam("b", bDec),
+			NewFunctionParam("c", cDec),
+		}.AsMap())
+
+		if tc.expectError {
+			require.Error(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
+func TestExtraParameterRestrictions_Bancor(t *testing.T) {
+	paramRestrictions := curveFunctions[BancorFunction].ValidateParams
+
+	testCases := []struct {
+		r           string
+		p0          string
+		s0          string
+		expectError bool
+	}{
+		{"0.3", "1", "100", false},     // fractional r allowed
+		{"1", "1", "100", false},       // r=1 allowed
+		{"0", "1", "100", true},        // zero not allowed for r
+		{"1.1", "1", "100", true},      // r > 1 not allowed
+		{"0.3", "0", "100", true},      // zero not allowed for p0
+		{"0.3", "1", "0", true},        // zero not allowed for s0
+		{"0.3", "0.01", "10.5", false}, // floats allowed for p0 and s0
+	}
+
+	for _, tc := range testCases {
+		rDec := sdk.MustNewDecFromStr(tc.r)
+		p0Dec := sdk.MustNewDecFromStr(tc.p0)
+		s0Dec := sdk.MustNewDecFromStr(tc.s0)
+		err := paramRestrictions(FunctionParams{
+			NewFunctionParam("r", rDec),
+			NewFunctionParam("p0", p0Dec),
+			NewFunctionParam("s0", s0Dec),
+		}.AsMap())
+
+		if tc.expectError {
+			require.Error(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
 func TestExtraParameterRestrictions_Augmented(t *testing.T) {
-	paramRestrictions := ExtraParameterRestrictions[AugmentedFunction]
+	paramRestrictions := curveFunctions[AugmentedFunction].ValidateParams
 
 	testCases := []struct {
 		d0          string
@@ -166,7 +265,13 @@ func TestNewWarDefaultValuesAndSorting(t *testing.T) {
 		PowerFunction, functionParametersPower(), customReserveTokens,
 		initTxFeePercentage, initExitFeePercentage, initFeeAddress, initMaxSupply,
 		customOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initAllowSell, initSigners, initSignerThreshold, initEditDelay, initBatchBlocks, initOutcomePayment, initHatchDeadlineHeight,
+		initAllowedHatchers, initHatchMembershipDenom, initMaxHatchContribution,
+		initHatchVestingCliff, initHatchVestingPeriod, initFundingPoolSpenders,
+		initFundingPoolSpendLimit, initFundingPoolEpochBlocks,
+		initGovernanceQuorumPercentage, initGovernanceThresholdPercentage,
+		initGovernanceVotingPeriod, initMaxPriceMovePercentage, initPriceMoveCooldown,
+		initState)
 
 	expectedCurrentSupply := sdk.NewInt64Coin(war.Token, 0)
 
@@ -550,7 +655,7 @@ func TestGetReturnsForSwap(t *testing.T) {
 		zeroPoint1Percent).Ceil().TruncateInt()
 
 	testCases := []struct {
-		warTxFee           string
+		warTxFee            string
 		from                string
 		to                  string
 		amount              sdk.Int
@@ -718,26 +823,32 @@ func TestWarGetExitFees(t *testing.T) {
 	require.Equal(t, expected, war.GetExitFees(inputTokens))
 }
 
-func TestSignersEqualTo(t *testing.T) {
+func TestSignersMeetThreshold(t *testing.T) {
 	war := getValidWar()
 
 	addr1 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	addr2 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	addr3 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
-	war.Signers = []sdk.AccAddress{addr1, addr2}
+	addr4 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	war.Signers = []sdk.AccAddress{addr1, addr2, addr3}
 
 	testCases := []struct {
+		threshold     uint64
 		toCompareTo   []sdk.AccAddress
-		expectedEqual bool
+		expectedMeets bool
 	}{
-		{[]sdk.AccAddress{addr1}, false},               // One missing
-		{[]sdk.AccAddress{addr1, addr2, addr3}, false}, // One extra
-		{[]sdk.AccAddress{addr1, addr3}, false},        // One different
-		{[]sdk.AccAddress{addr2, addr1}, false},        // Incorrect order
-		{[]sdk.AccAddress{addr1, addr2}, true},         // Equal
+		{0, []sdk.AccAddress{addr1, addr2}, false},              // Zero threshold, one missing
+		{0, []sdk.AccAddress{addr3, addr1, addr2}, true},        // Zero threshold, any order
+		{2, []sdk.AccAddress{addr1}, false},                     // Below threshold
+		{2, []sdk.AccAddress{addr1, addr4}, false},              // Non-signer not counted
+		{2, []sdk.AccAddress{addr1, addr1}, false},              // Duplicate not counted
+		{2, []sdk.AccAddress{addr3, addr1}, true},               // Meets threshold, any order
+		{2, []sdk.AccAddress{addr1, addr2, addr3}, true},        // Exceeds threshold
+		{3, []sdk.AccAddress{addr1, addr2, addr3, addr4}, true}, // Meets threshold, with non-signer
 	}
 	for _, tc := range testCases {
-		require.Equal(t, tc.expectedEqual, war.SignersEqualTo(tc.toCompareTo))
+		war.SignerThreshold = tc.threshold
+		require.Equal(t, tc.expectedMeets, war.SignersMeetThreshold(tc.toCompareTo))
 	}
 }
 
@@ -836,3 +947,32 @@ func TestReservesViolateSanityRateReturnsFalseWhenSanityRateIsZero(t *testing.T)
 		require.Equal(t, tc.violates, actualResult)
 	}
 }
+
+func TestPricesExceedMaxPriceMove(t *testing.T) {
+	war := getValidWar()
+	reference := sdk.NewDecCoins(
+		sdk.NewInt64DecCoin(reserveToken, 100),
+		sdk.NewInt64DecCoin(reserveToken2, 200))
+
+	testCases := []struct {
+		prices                 string
+		maxPriceMovePercentage int64
+		exceeds                bool
+	}{
+		{"1000res,1000rez", 0, false}, // no max price move
+		{"120res,200rez", 20, false},  // 20% move
+		{"121res,200rez", 20, true},   // 21% move
+		{"80res,240rez", 20, false},   // -20% and 20% moves
+		{"79res,200rez", 20, true},    // -21% move
+		{"100res,241rez", 20, true},   // 20.5% move
+		{"100res,300abc", 20, false},  // no reference price for abc
+	}
+	for _, tc := range testCases {
+		war.MaxPriceMovePercentage = sdk.NewDec(tc.maxPriceMovePercentage)
+		coins, err := sdk.ParseCoins(tc.prices)
+		require.Nil(t, err)
+		prices := sdk.NewDecCoinsFromCoins(coins...)
+		require.Equal(t, tc.exceeds,
+			war.PricesExceedMaxPriceMove(prices, reference), tc.prices)
+	}
+}
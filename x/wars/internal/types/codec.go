@@ -21,11 +21,37 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(&BuyOrder{}, "wars/BuyOrder", nil)
 	cdc.RegisterConcrete(&SellOrder{}, "wars/SellOrder", nil)
 	cdc.RegisterConcrete(&SwapOrder{}, "wars/SwapOrder", nil)
+	cdc.RegisterConcrete(&RoutedSwapOrder{}, "wars/RoutedSwapOrder", nil)
+	cdc.RegisterConcrete(&LimitOrder{}, "wars/LimitOrder", nil)
+	cdc.RegisterConcrete(&OrderBook{}, "wars/OrderBook", nil)
+	cdc.RegisterConcrete(&HatchContribution{}, "wars/HatchContribution", nil)
+	cdc.RegisterConcrete(&HatchContributions{}, "wars/HatchContributions", nil)
+	cdc.RegisterConcrete(&HatchVesting{}, "wars/HatchVesting", nil)
+	cdc.RegisterConcrete(&HatchVestings{}, "wars/HatchVestings", nil)
+	cdc.RegisterConcrete(&FundingPool{}, "wars/FundingPool", nil)
+	cdc.RegisterConcrete(&WarEdit{}, "wars/WarEdit", nil)
+	cdc.RegisterConcrete(&Proposal{}, "wars/Proposal", nil)
+	cdc.RegisterConcrete(&Proposals{}, "wars/Proposals", nil)
+	cdc.RegisterConcrete(&PendingEdit{}, "wars/PendingEdit", nil)
+	cdc.RegisterConcrete(&PendingEdits{}, "wars/PendingEdits", nil)
 	cdc.RegisterConcrete(MsgCreateWar{}, "wars/MsgCreateWar", nil)
 	cdc.RegisterConcrete(MsgEditWar{}, "wars/MsgEditWar", nil)
+	cdc.RegisterConcrete(MsgUpdateWarSigners{}, "wars/MsgUpdateWarSigners", nil)
+	cdc.RegisterConcrete(MsgCancelPendingEdit{}, "wars/MsgCancelPendingEdit", nil)
+	cdc.RegisterConcrete(MsgSetWarPaused{}, "wars/MsgSetWarPaused", nil)
 	cdc.RegisterConcrete(MsgBuy{}, "wars/MsgBuy", nil)
+	cdc.RegisterConcrete(MsgBuyWithSpend{}, "wars/MsgBuyWithSpend", nil)
 	cdc.RegisterConcrete(MsgSell{}, "wars/MsgSell", nil)
 	cdc.RegisterConcrete(MsgSwap{}, "wars/MsgSwap", nil)
+	cdc.RegisterConcrete(MsgRoutedSwap{}, "wars/MsgRoutedSwap", nil)
+	cdc.RegisterConcrete(MsgLimitBuy{}, "wars/MsgLimitBuy", nil)
+	cdc.RegisterConcrete(MsgLimitSell{}, "wars/MsgLimitSell", nil)
+	cdc.RegisterConcrete(MsgCancelOrder{}, "wars/MsgCancelOrder", nil)
+	cdc.RegisterConcrete(MsgCancelLimitOrder{}, "wars/MsgCancelLimitOrder", nil)
 	cdc.RegisterConcrete(MsgMakeOutcomePayment{}, "wars/MsgMakeOutcomePayment", nil)
 	cdc.RegisterConcrete(MsgWithdrawShare{}, "wars/MsgWithdrawShare", nil)
+	cdc.RegisterConcrete(MsgClaimVested{}, "wars/MsgClaimVested", nil)
+	cdc.RegisterConcrete(MsgSpendFromFundingPool{}, "wars/MsgSpendFromFundingPool", nil)
+	cdc.RegisterConcrete(MsgSubmitProposal{}, "wars/MsgSubmitProposal", nil)
+	cdc.RegisterConcrete(MsgVote{}, "wars/MsgVote", nil)
 }
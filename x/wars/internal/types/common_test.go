@@ -14,22 +14,38 @@ var (
 	reserveToken2               = "rez"
 	reserveToken3               = "rec"
 
-	initToken                  = token
-	initName                   = "test token"
-	initDescription            = "this is a test token"
-	initCreator                = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
-	initFeeAddress             = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
-	initTxFeePercentage        = sdk.MustNewDecFromStr("0.1")
-	initExitFeePercentage      = sdk.MustNewDecFromStr("0.1")
-	initMaxSupply              = sdk.NewInt64Coin(initToken, 10000)
-	initOrderQuantityLimits    = sdk.Coins(nil)
-	initSanityRate             = sdk.MustNewDecFromStr(blankSanityRate)
-	initSanityMarginPercentage = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
-	initAllowSell              = true
-	initSigners                = []sdk.AccAddress{initCreator}
-	initBatchBlocks            = sdk.NewUint(10)
-	initOutcomePayment         = sdk.Coins(nil)
-	initState                  = OpenState
+	initToken                         = token
+	initName                          = "test token"
+	initDescription                   = "this is a test token"
+	initCreator                       = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	initFeeAddress                    = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	initTxFeePercentage               = sdk.MustNewDecFromStr("0.1")
+	initExitFeePercentage             = sdk.MustNewDecFromStr("0.1")
+	initMaxSupply                     = sdk.NewInt64Coin(initToken, 10000)
+	initOrderQuantityLimits           = sdk.Coins(nil)
+	initSanityRate                    = sdk.MustNewDecFromStr(blankSanityRate)
+	initSanityMarginPercentage        = sdk.MustNewDecFromStr(blankSanityMarginPercentage)
+	initAllowSell                     = true
+	initSigners                       = []sdk.AccAddress{initCreator}
+	initSignerThreshold               = uint64(0)
+	initEditDelay                     = int64(0)
+	initBatchBlocks                   = sdk.NewUint(10)
+	initOutcomePayment                = sdk.Coins(nil)
+	initHatchDeadlineHeight           = int64(0)
+	initAllowedHatchers               = []sdk.AccAddress(nil)
+	initHatchMembershipDenom          = ""
+	initMaxHatchContribution          = sdk.ZeroInt()
+	initHatchVestingCliff             = int64(0)
+	initHatchVestingPeriod            = int64(0)
+	initFundingPoolSpenders           = []sdk.AccAddress(nil)
+	initFundingPoolSpendLimit         = sdk.Coins(nil)
+	initFundingPoolEpochBlocks        = int64(0)
+	initGovernanceQuorumPercentage    = sdk.ZeroDec()
+	initGovernanceThresholdPercentage = sdk.ZeroDec()
+	initGovernanceVotingPeriod        = int64(0)
+	initMaxPriceMovePercentage        = sdk.ZeroDec()
+	initPriceMoveCooldown             = int64(0)
+	initState                         = OpenState
 
 	// 9223372036854775807
 	maxInt64 = sdk.NewInt(int64(^uint64(0) >> 1))
@@ -49,6 +65,38 @@ func functionParametersSigmoid() FunctionParams {
 		NewFunctionParam("c", sdk.NewDec(1))}
 }
 
+func functionParametersExponential() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam("a", sdk.NewDec(2)),
+		NewFunctionParam("b", sdk.NewDecWithPrec(1, 2))}
+}
+
+func functionParametersLogarithmic() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam("a", sdk.NewDec(10)),
+		NewFunctionParam("b", sdk.NewDecWithPrec(1, 1)),
+		NewFunctionParam("c", sdk.NewDec(1))}
+}
+
+func functionParametersBancor() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam("r", sdk.NewDecWithPrec(5, 1)),
+		NewFunctionParam("p0", sdk.NewDec(1)),
+		NewFunctionParam("s0", sdk.NewDec(100))}
+}
+
+func functionParametersPiecewiseLinear() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam("x0", sdk.NewDec(0)),
+		NewFunctionParam("p0", sdk.NewDec(1)),
+		NewFunctionParam("x1", sdk.NewDec(100)),
+		NewFunctionParam("p1", sdk.NewDec(1)),
+		NewFunctionParam("x2", sdk.NewDec(200)),
+		NewFunctionParam("p2", sdk.NewDec(3)),
+		NewFunctionParam("x3", sdk.NewDec(300)),
+		NewFunctionParam("p3", sdk.NewDecWithPrec(25, 1))}
+}
+
 func functionParametersAugmented() FunctionParams {
 	return FunctionParams{
 		NewFunctionParam("d0", sdk.MustNewDecFromStr("500.0")),
@@ -98,7 +146,13 @@ func getValidPowerFunctionWar() War {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment, initState)
+		initAllowSell, initSigners, initSignerThreshold, initEditDelay, initBatchBlocks, initOutcomePayment, initHatchDeadlineHeight,
+		initAllowedHatchers, initHatchMembershipDenom, initMaxHatchContribution,
+		initHatchVestingCliff, initHatchVestingPeriod, initFundingPoolSpenders,
+		initFundingPoolSpendLimit, initFundingPoolEpochBlocks,
+		initGovernanceQuorumPercentage, initGovernanceThresholdPercentage,
+		initGovernanceVotingPeriod, initMaxPriceMovePercentage, initPriceMoveCooldown,
+		initState)
 }
 
 func getValidWar() War {
@@ -131,7 +185,12 @@ func newValidMsgCreateWar() MsgCreateWar {
 		functionType, functionParams, reserveTokens, initTxFeePercentage,
 		initExitFeePercentage, initFeeAddress, initMaxSupply,
 		initOrderQuantityLimits, initSanityRate, initSanityMarginPercentage,
-		initAllowSell, initSigners, initBatchBlocks, initOutcomePayment)
+		initAllowSell, initSigners, initSignerThreshold, initEditDelay, initBatchBlocks, initOutcomePayment,
+		initHatchDeadlineHeight, initAllowedHatchers, initHatchMembershipDenom,
+		initMaxHatchContribution, initHatchVestingCliff, initHatchVestingPeriod,
+		initFundingPoolSpenders, initFundingPoolSpendLimit, initFundingPoolEpochBlocks,
+		initGovernanceQuorumPercentage, initGovernanceThresholdPercentage,
+		initGovernanceVotingPeriod, initMaxPriceMovePercentage, initPriceMoveCooldown)
 }
 
 func newValidMsgCreateSwapperWar() MsgCreateWar {
@@ -142,13 +201,45 @@ func newValidMsgCreateSwapperWar() MsgCreateWar {
 	return validMsg
 }
 
+func functionParametersWeightedSwapper() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam(reserveToken, sdk.NewDec(1)),
+		NewFunctionParam(reserveToken2, sdk.NewDec(1)),
+		NewFunctionParam(reserveToken3, sdk.NewDec(2))}
+}
+
+func weightedSwapperReserves() []string {
+	return []string{reserveToken, reserveToken2, reserveToken3}
+}
+
+func newValidMsgCreateWeightedSwapperWar() MsgCreateWar {
+	validMsg := newValidMsgCreateWar()
+	validMsg.FunctionType = WeightedSwapperFunction
+	validMsg.FunctionParameters = functionParametersWeightedSwapper()
+	validMsg.ReserveTokens = weightedSwapperReserves()
+	return validMsg
+}
+
+func functionParametersStableSwap() FunctionParams {
+	return FunctionParams{
+		NewFunctionParam("A", sdk.NewDec(100))}
+}
+
 func newEmptyStringsMsgEditWar() MsgEditWar {
-	return NewMsgEditWar(initToken, "", "", "", "", "",
+	return NewMsgEditWar(initToken, "", "", "", "", "", "", "", "", "", "", "", "",
 		initCreator, initSigners)
 }
 
 func newValidMsgEditWar() MsgEditWar {
 	return NewMsgEditWar(initToken, "newName", "newDescription", "", "0", "0",
+		DoNotModifyField, DoNotModifyField, DoNotModifyField, DoNotModifyField,
+		DoNotModifyField, DoNotModifyField, DoNotModifyField,
+		initCreator, initSigners)
+}
+
+func newValidMsgUpdateWarSigners() MsgUpdateWarSigners {
+	return NewMsgUpdateWarSigners(initToken,
+		[]sdk.AccAddress{initCreator, initFeeAddress}, 1,
 		initCreator, initSigners)
 }
 
@@ -156,17 +247,87 @@ func newValidMsgBuy() MsgBuy {
 	buyer := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	amount, _ := sdk.ParseCoin("10" + initToken)
 	maxPrices, _ := sdk.ParseCoins("50" + initToken)
-	return NewMsgBuy(buyer, amount, maxPrices)
+	return NewMsgBuy(buyer, amount, maxPrices, false)
+}
+
+func newValidMsgBuyWithSpend() MsgBuyWithSpend {
+	buyer := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	spend, _ := sdk.ParseCoins("50" + reserveToken)
+	return NewMsgBuyWithSpend(buyer, initToken, spend)
 }
 
 func newValidMsgSell() MsgSell {
 	seller := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	amount, _ := sdk.ParseCoin("10" + initToken)
-	return NewMsgSell(seller, amount)
+	return NewMsgSell(seller, amount, nil)
 }
 
 func newValidMsgSwap() MsgSwap {
 	swapper := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
 	from := sdk.NewInt64Coin(reserveToken, 10)
-	return NewMsgSwap(swapper, initToken, from, reserveToken2)
+	minOutput := sdk.NewInt64Coin(reserveToken2, 0)
+	return NewMsgSwap(swapper, initToken, from, reserveToken2, minOutput)
+}
+
+func newValidMsgRoutedSwap() MsgRoutedSwap {
+	swapper := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	from := sdk.NewInt64Coin(reserveToken, 10)
+	hops := SwapRoute{
+		NewSwapHop(initToken, reserveToken2),
+		NewSwapHop("othertoken", "rey"),
+	}
+	minOutput := sdk.NewInt64Coin("rey", 0)
+	return NewMsgRoutedSwap(swapper, from, hops, minOutput)
+}
+
+func newValidMsgCancelOrder() MsgCancelOrder {
+	owner := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	return NewMsgCancelOrder(owner, initToken, BuyOrderType, 0)
+}
+
+func newValidMsgLimitBuy() MsgLimitBuy {
+	buyer := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount, _ := sdk.ParseCoin("10" + initToken)
+	maxPrices, _ := sdk.ParseCoins("50" + reserveToken)
+	return NewMsgLimitBuy(buyer, amount, maxPrices, 100)
+}
+
+func newValidMsgLimitSell() MsgLimitSell {
+	seller := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount, _ := sdk.ParseCoin("10" + initToken)
+	minReturns, _ := sdk.ParseCoins("50" + reserveToken)
+	return NewMsgLimitSell(seller, amount, minReturns, 100)
+}
+
+func newValidMsgCancelLimitOrder() MsgCancelLimitOrder {
+	owner := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	return NewMsgCancelLimitOrder(owner, initToken, 0)
+}
+
+func newValidMsgSpendFromFundingPool() MsgSpendFromFundingPool {
+	spender := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	return NewMsgSpendFromFundingPool(spender, initToken, recipient,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)))
+}
+
+func newValidMsgSubmitSpendProposal() MsgSubmitProposal {
+	proposer := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	return NewMsgSubmitSpendProposal(proposer, initToken, recipient,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)))
+}
+
+func newValidMsgSubmitEditProposal() MsgSubmitProposal {
+	proposer := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	edit := NewWarEdit("newName", DoNotModifyField, DoNotModifyField,
+		DoNotModifyField, DoNotModifyField,
+		DoNotModifyField, DoNotModifyField, DoNotModifyField, DoNotModifyField,
+		DoNotModifyField, DoNotModifyField, DoNotModifyField)
+	return NewMsgSubmitEditProposal(proposer, initToken, edit)
+}
+
+func newValidMsgVote() MsgVote {
+	voter := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	return NewMsgVote(voter, initToken, 0, YesVoteOption)
 }
@@ -0,0 +1,303 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"strconv"
+	"strings"
+)
+
+// WarEdit holds the editable fields of a war. Fields that will not be edited
+// are set to DoNotModifyField. A war is edited either by its signers (using
+// MsgEditWar) or by its token holders (using an edit proposal).
+type WarEdit struct {
+	Name                   string `json:"name" yaml:"name"`
+	Description            string `json:"description" yaml:"description"`
+	OrderQuantityLimits    string `json:"order_quantity_limits" yaml:"order_quantity_limits"`
+	SanityRate             string `json:"sanity_rate" yaml:"sanity_rate"`
+	SanityMarginPercentage string `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	TxFeePercentage        string `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage      string `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	FeeAddress             string `json:"fee_address" yaml:"fee_address"`
+	MaxSupply              string `json:"max_supply" yaml:"max_supply"`
+	AllowSells             string `json:"allow_sells" yaml:"allow_sells"`
+	BatchBlocks            string `json:"batch_blocks" yaml:"batch_blocks"`
+	OutcomePayment         string `json:"outcome_payment" yaml:"outcome_payment"`
+}
+
+func NewWarEdit(name, description, orderQuantityLimits, sanityRate,
+	sanityMarginPercentage, txFeePercentage, exitFeePercentage, feeAddress,
+	maxSupply, allowSells, batchBlocks, outcomePayment string) WarEdit {
+	return WarEdit{
+		Name:                   name,
+		Description:            description,
+		OrderQuantityLimits:    orderQuantityLimits,
+		SanityRate:             sanityRate,
+		SanityMarginPercentage: sanityMarginPercentage,
+		TxFeePercentage:        txFeePercentage,
+		ExitFeePercentage:      exitFeePercentage,
+		FeeAddress:             feeAddress,
+		MaxSupply:              maxSupply,
+		AllowSells:             allowSells,
+		BatchBlocks:            batchBlocks,
+		OutcomePayment:         outcomePayment,
+	}
+}
+
+func (edit WarEdit) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(edit.Name) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Name")
+	} else if strings.TrimSpace(edit.Description) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Description")
+	} else if strings.TrimSpace(edit.SanityRate) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SanityRate")
+	} else if strings.TrimSpace(edit.SanityMarginPercentage) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SanityMarginPercentage")
+	} else if strings.TrimSpace(edit.TxFeePercentage) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "TxFeePercentage")
+	} else if strings.TrimSpace(edit.ExitFeePercentage) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "ExitFeePercentage")
+	} else if strings.TrimSpace(edit.FeeAddress) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "FeeAddress")
+	} else if strings.TrimSpace(edit.MaxSupply) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "MaxSupply")
+	} else if strings.TrimSpace(edit.AllowSells) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "AllowSells")
+	} else if strings.TrimSpace(edit.BatchBlocks) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "BatchBlocks")
+	}
+	// Note: order quantity limits and outcome payment can be blank
+
+	// Check that at least one editable was edited. Fields that will not
+	// be edited should be "DoNotModifyField", and not an empty string
+	inputList := []string{
+		edit.Name, edit.Description, edit.OrderQuantityLimits,
+		edit.SanityRate, edit.SanityMarginPercentage, edit.TxFeePercentage,
+		edit.ExitFeePercentage, edit.FeeAddress, edit.MaxSupply,
+		edit.AllowSells, edit.BatchBlocks, edit.OutcomePayment,
+	}
+	for _, e := range inputList {
+		if e != DoNotModifyField {
+			return nil
+		}
+	}
+	return ErrDidNotEditAnything
+}
+
+// Apply returns the war with the edited fields set to their new values, or an
+// error if any of the new values is invalid for the war. Fees can only be
+// decreased and the max supply cannot be less than the current supply. A new
+// batch blocks value only applies from the war's next batch onwards.
+func (edit WarEdit) Apply(war War) (War, error) {
+	if edit.Name != DoNotModifyField {
+		war.Name = edit.Name
+	}
+	if edit.Description != DoNotModifyField {
+		war.Description = edit.Description
+	}
+
+	if edit.OrderQuantityLimits != DoNotModifyField {
+		orderQuantityLimits, err := sdk.ParseCoins(edit.OrderQuantityLimits)
+		if err != nil {
+			return War{}, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+		}
+		war.OrderQuantityLimits = orderQuantityLimits
+	}
+
+	if edit.SanityRate != DoNotModifyField {
+		var sanityRate, sanityMarginPercentage sdk.Dec
+		if edit.SanityRate == "" {
+			sanityRate = sdk.ZeroDec()
+			sanityMarginPercentage = sdk.ZeroDec()
+		} else {
+			parsedSanityRate, err := sdk.NewDecFromStr(edit.SanityRate)
+			if err != nil {
+				return War{}, sdkerrors.Wrap(ErrArgumentMissingOrNonFloat, "sanity rate")
+			} else if parsedSanityRate.IsNegative() {
+				return War{}, sdkerrors.Wrap(ErrArgumentCannotBeNegative, "sanity rate")
+			}
+			parsedSanityMarginPercentage, err := sdk.NewDecFromStr(edit.SanityMarginPercentage)
+			if err != nil {
+				return War{}, sdkerrors.Wrap(ErrArgumentMissingOrNonFloat, "sanity margin percentage")
+			} else if parsedSanityMarginPercentage.IsNegative() {
+				return War{}, sdkerrors.Wrap(ErrArgumentCannotBeNegative, "sanity margin percentage")
+			} else if err = CheckSanityRateForReserveTokens(parsedSanityRate, war.ReserveTokens); err != nil {
+				return War{}, err
+			}
+			sanityRate = parsedSanityRate
+			sanityMarginPercentage = parsedSanityMarginPercentage
+		}
+		war.SanityRate = sanityRate
+		war.SanityMarginPercentage = sanityMarginPercentage
+	}
+
+	if edit.TxFeePercentage != DoNotModifyField {
+		txFeePercentage, err := parseEditedFeePercentage(
+			edit.TxFeePercentage, war.TxFeePercentage, "tx fee percentage")
+		if err != nil {
+			return War{}, err
+		}
+		war.TxFeePercentage = txFeePercentage
+	}
+
+	if edit.ExitFeePercentage != DoNotModifyField {
+		exitFeePercentage, err := parseEditedFeePercentage(
+			edit.ExitFeePercentage, war.ExitFeePercentage, "exit fee percentage")
+		if err != nil {
+			return War{}, err
+		}
+		war.ExitFeePercentage = exitFeePercentage
+	}
+
+	if edit.FeeAddress != DoNotModifyField {
+		feeAddress, err := sdk.AccAddressFromBech32(edit.FeeAddress)
+		if err != nil {
+			return War{}, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+		}
+		war.FeeAddress = feeAddress
+	}
+
+	if edit.MaxSupply != DoNotModifyField {
+		maxSupply, err := sdk.ParseCoin(edit.MaxSupply)
+		if err != nil {
+			return War{}, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+		} else if maxSupply.Denom != war.Token {
+			return War{}, sdkerrors.Wrap(ErrMaxSupplyDenomDoesNotMatchTokenDenom, war.Token)
+		} else if maxSupply.IsLT(war.CurrentSupply) {
+			return War{}, sdkerrors.Wrapf(ErrMaxSupplyBelowCurrentSupply,
+				"%s < %s", maxSupply, war.CurrentSupply)
+		}
+		war.MaxSupply = maxSupply
+	}
+
+	if edit.AllowSells != DoNotModifyField {
+		allowSells, err := strconv.ParseBool(edit.AllowSells)
+		if err != nil {
+			return War{}, sdkerrors.Wrap(ErrArgumentMissingOrNonBoolean, "allow sells")
+		} else if war.State == HatchState {
+			// Sells are always disabled during the hatch phase and are
+			// enabled once the hatch phase succeeds
+			return War{}, ErrCannotEditAllowSellsDuringHatch
+		}
+		war.AllowSells = allowSells
+	}
+
+	if edit.BatchBlocks != DoNotModifyField {
+		batchBlocks, err := sdk.ParseUint(edit.BatchBlocks)
+		if err != nil {
+			return War{}, sdkerrors.Wrap(ErrArgumentMissingOrNonUInteger, "batch blocks")
+		} else if batchBlocks.IsZero() {
+			return War{}, sdkerrors.Wrap(ErrArgumentMustBePositive, "batch blocks")
+		}
+		war.BatchBlocks = batchBlocks
+	}
+
+	if edit.OutcomePayment != DoNotModifyField {
+		outcomePayment, err := sdk.ParseCoins(edit.OutcomePayment)
+		if err != nil {
+			return War{}, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+		}
+		war.OutcomePayment = outcomePayment
+	}
+
+	return war, nil
+}
+
+func parseEditedFeePercentage(newFee string, oldFee sdk.Dec, name string) (sdk.Dec, error) {
+	fee, err := sdk.NewDecFromStr(newFee)
+	if err != nil {
+		return sdk.Dec{}, sdkerrors.Wrap(ErrArgumentMissingOrNonFloat, name)
+	} else if fee.IsNegative() {
+		return sdk.Dec{}, sdkerrors.Wrap(ErrArgumentCannotBeNegative, name)
+	} else if fee.GT(oldFee) {
+		return sdk.Dec{}, sdkerrors.Wrapf(ErrFeeCannotBeIncreased,
+			"%s %s > %s", name, fee, oldFee)
+	}
+	return fee, nil
+}
+
+const (
+	PendingEditResultApplied = "applied"
+	PendingEditResultFailed  = "failed"
+)
+
+// SignersEdit holds the new signers and signer threshold of a war, which
+// replace the war's signers and signer threshold (using MsgUpdateWarSigners)
+type SignersEdit struct {
+	Signers         []sdk.AccAddress `json:"signers" yaml:"signers"`
+	SignerThreshold uint64           `json:"signer_threshold" yaml:"signer_threshold"`
+}
+
+func NewSignersEdit(signers []sdk.AccAddress, signerThreshold uint64) SignersEdit {
+	return SignersEdit{
+		Signers:         signers,
+		SignerThreshold: signerThreshold,
+	}
+}
+
+// PendingEdit is an edit of a war by the war's signers that is applied at the
+// end of the block at its effective height, unless it is cancelled beforehand.
+// This gives traders advance notice of any changes to the war. A pending edit
+// either edits the war (Edit) or updates the war's signers (SignersEdit), in
+// which case Edit is not used.
+type PendingEdit struct {
+	ID              uint64         `json:"id" yaml:"id"`
+	Edit            WarEdit        `json:"edit" yaml:"edit"`
+	SignersEdit     *SignersEdit   `json:"signers_edit,omitempty" yaml:"signers_edit,omitempty"`
+	Editor          sdk.AccAddress `json:"editor" yaml:"editor"`
+	EffectiveHeight int64          `json:"effective_height" yaml:"effective_height"`
+}
+
+func NewPendingEdit(edit WarEdit, editor sdk.AccAddress, effectiveHeight int64) PendingEdit {
+	return PendingEdit{
+		Edit:            edit,
+		SignersEdit:     nil,
+		Editor:          editor,
+		EffectiveHeight: effectiveHeight,
+	}
+}
+
+func NewSignersPendingEdit(signersEdit SignersEdit, editor sdk.AccAddress, effectiveHeight int64) PendingEdit {
+	return PendingEdit{
+		SignersEdit:     &signersEdit,
+		Editor:          editor,
+		EffectiveHeight: effectiveHeight,
+	}
+}
+
+// IsDue returns true if the pending edit's effective height is at or before
+// the specified block height, in which case it is applied at the end of the
+// block and can no longer be cancelled afterwards
+func (pe PendingEdit) IsDue(height int64) bool { return height >= pe.EffectiveHeight }
+
+// PendingEdits holds the pending edits of a war, in the order that they were
+// submitted. Each pending edit is given an ID that is unique within the war.
+type PendingEdits struct {
+	Token        string        `json:"token" yaml:"token"`
+	NextEditID   uint64        `json:"next_edit_id" yaml:"next_edit_id"`
+	PendingEdits []PendingEdit `json:"pending_edits" yaml:"pending_edits"`
+}
+
+func NewPendingEdits(token string) PendingEdits {
+	return PendingEdits{
+		Token:        token,
+		NextEditID:   0,
+		PendingEdits: nil,
+	}
+}
+
+// GetPendingEditIndex returns the index of the pending edit with the specified
+// ID in the list of pending edits, or false if there is no such pending edit
+func (pes PendingEdits) GetPendingEditIndex(id uint64) (int, bool) {
+	for i, pe := range pes.PendingEdits {
+		if pe.ID == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (pes *PendingEdits) RemovePendingEdit(index int) {
+	pes.PendingEdits = append(pes.PendingEdits[:index], pes.PendingEdits[index+1:]...)
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newEmptyWarEdit() WarEdit {
+	return NewWarEdit(DoNotModifyField, DoNotModifyField, DoNotModifyField,
+		DoNotModifyField, DoNotModifyField, DoNotModifyField, DoNotModifyField,
+		DoNotModifyField, DoNotModifyField, DoNotModifyField, DoNotModifyField,
+		DoNotModifyField)
+}
+
+func TestWarEditValidateBasic(t *testing.T) {
+	// No edits
+	edit := newEmptyWarEdit()
+	require.True(t, ErrDidNotEditAnything.Is(edit.ValidateBasic()))
+
+	// Empty tx fee percentage
+	edit.TxFeePercentage = ""
+	require.True(t, ErrArgumentCannotBeEmpty.Is(edit.ValidateBasic()))
+
+	// Outcome payment can be empty
+	edit = newEmptyWarEdit()
+	edit.OutcomePayment = ""
+	require.NoError(t, edit.ValidateBasic())
+}
+
+func TestWarEditApplyEconomicFields(t *testing.T) {
+	war := getValidWar()
+	war.CurrentSupply = sdk.NewInt64Coin(initToken, 100)
+
+	edit := newEmptyWarEdit()
+	edit.TxFeePercentage = "0.05"
+	edit.ExitFeePercentage = "0"
+	edit.FeeAddress = initCreator.String()
+	edit.MaxSupply = "100" + initToken
+	edit.AllowSells = "false"
+	edit.BatchBlocks = "3"
+	edit.OutcomePayment = "50" + reserveToken
+
+	edited, err := edit.Apply(war)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("0.05"), edited.TxFeePercentage)
+	require.Equal(t, sdk.ZeroDec(), edited.ExitFeePercentage)
+	require.Equal(t, initCreator, edited.FeeAddress)
+	require.Equal(t, sdk.NewInt64Coin(initToken, 100), edited.MaxSupply)
+	require.False(t, edited.AllowSells)
+	require.Equal(t, sdk.NewUint(3), edited.BatchBlocks)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50)), edited.OutcomePayment)
+
+	// Fields that are not edited are unchanged
+	require.Equal(t, war.Name, edited.Name)
+	require.Equal(t, war.OrderQuantityLimits, edited.OrderQuantityLimits)
+}
+
+func TestWarEditApplyInvalidEconomicFieldsGivesError(t *testing.T) {
+	war := getValidWar()
+	war.CurrentSupply = sdk.NewInt64Coin(initToken, 100)
+
+	testCases := []struct {
+		edit        func(edit *WarEdit)
+		state       string
+		expectedErr *sdkerrors.Error
+	}{
+		{func(e *WarEdit) { e.TxFeePercentage = "0.2" }, OpenState, ErrFeeCannotBeIncreased},
+		{func(e *WarEdit) { e.ExitFeePercentage = "0.2" }, OpenState, ErrFeeCannotBeIncreased},
+		{func(e *WarEdit) { e.TxFeePercentage = "-0.1" }, OpenState, ErrArgumentCannotBeNegative},
+		{func(e *WarEdit) { e.ExitFeePercentage = "a" }, OpenState, ErrArgumentMissingOrNonFloat},
+		{func(e *WarEdit) { e.FeeAddress = "a" }, OpenState, sdkerrors.ErrInvalidAddress},
+		{func(e *WarEdit) { e.MaxSupply = "99" + initToken }, OpenState, ErrMaxSupplyBelowCurrentSupply},
+		{func(e *WarEdit) { e.MaxSupply = "1000" + reserveToken }, OpenState, ErrMaxSupplyDenomDoesNotMatchTokenDenom},
+		{func(e *WarEdit) { e.AllowSells = "a" }, OpenState, ErrArgumentMissingOrNonBoolean},
+		{func(e *WarEdit) { e.AllowSells = "true" }, HatchState, ErrCannotEditAllowSellsDuringHatch},
+		{func(e *WarEdit) { e.BatchBlocks = "0" }, OpenState, ErrArgumentMustBePositive},
+		{func(e *WarEdit) { e.BatchBlocks = "-1" }, OpenState, ErrArgumentMissingOrNonUInteger},
+		{func(e *WarEdit) { e.OutcomePayment = "a" }, OpenState, sdkerrors.ErrInvalidCoins},
+	}
+	for _, tc := range testCases {
+		edit := newEmptyWarEdit()
+		tc.edit(&edit)
+		war.State = tc.state
+
+		_, err := edit.Apply(war)
+		require.Error(t, err)
+		require.True(t, tc.expectedErr.Is(err), err.Error())
+	}
+}
+
+func TestPendingEditIsDue(t *testing.T) {
+	pe := NewPendingEdit(newEmptyWarEdit(), initCreator, 10)
+	require.False(t, pe.IsDue(9))
+	require.True(t, pe.IsDue(10))
+	require.True(t, pe.IsDue(11))
+}
+
+func TestPendingEditsGetAndRemovePendingEdit(t *testing.T) {
+	pendingEdits := NewPendingEdits(initToken)
+	for i := uint64(0); i < 3; i++ {
+		pe := NewPendingEdit(newEmptyWarEdit(), initCreator, 10)
+		pe.ID = i
+		pendingEdits.PendingEdits = append(pendingEdits.PendingEdits, pe)
+	}
+
+	i, found := pendingEdits.GetPendingEditIndex(1)
+	require.True(t, found)
+	require.Equal(t, 1, i)
+
+	pendingEdits.RemovePendingEdit(i)
+	require.Len(t, pendingEdits.PendingEdits, 2)
+	_, found = pendingEdits.GetPendingEditIndex(1)
+	require.False(t, found)
+
+	i, found = pendingEdits.GetPendingEditIndex(2)
+	require.True(t, found)
+	require.Equal(t, 1, i)
+}
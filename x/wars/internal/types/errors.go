@@ -15,17 +15,17 @@ var (
 	ErrArgumentCannotBeEmpty                = sdkerrors.Register(ModuleName, 304, "argument cannot be empty")
 	ErrArgumentCannotBeNegative             = sdkerrors.Register(ModuleName, 305, "argument cannot be negative")
 	ErrArgumentMissingOrNonFloat            = sdkerrors.Register(ModuleName, 306, "argument is missing or is not a float")
-	ErrWarDoesNotExist                     = sdkerrors.Register(ModuleName, 307, "war does not exist")
-	ErrWarAlreadyExists                    = sdkerrors.Register(ModuleName, 308, "war already exists")
-	ErrWarTokenCannotBeStakingToken        = sdkerrors.Register(ModuleName, 309, "war token cannot be staking token")
+	ErrWarDoesNotExist                      = sdkerrors.Register(ModuleName, 307, "war does not exist")
+	ErrWarAlreadyExists                     = sdkerrors.Register(ModuleName, 308, "war already exists")
+	ErrWarTokenCannotBeStakingToken         = sdkerrors.Register(ModuleName, 309, "war token cannot be staking token")
 	ErrInvalidStateForAction                = sdkerrors.Register(ModuleName, 310, "cannot perform that action at the current state")
 	ErrReserveDenomsMismatch                = sdkerrors.Register(ModuleName, 311, "denom do not match reserve")
 	ErrOrderQuantityLimitExceeded           = sdkerrors.Register(ModuleName, 312, "order quantity limits exceeded")
 	ErrValuesViolateSanityRate              = sdkerrors.Register(ModuleName, 313, "values violate sanity rate")
-	ErrWarDoesNotAllowSelling              = sdkerrors.Register(ModuleName, 314, "war does not allow selling at the moment")
+	ErrWarDoesNotAllowSelling               = sdkerrors.Register(ModuleName, 314, "war does not allow selling at the moment")
 	ErrFunctionNotAvailableForFunctionType  = sdkerrors.Register(ModuleName, 315, "function is not available for the function type")
 	ErrCannotMakeZeroOutcomePayment         = sdkerrors.Register(ModuleName, 316, "cannot make outcome payment because outcome payment is set to nil")
-	ErrNoWarTokensOwned                    = sdkerrors.Register(ModuleName, 317, "no war tokens of this war are owned")
+	ErrNoWarTokensOwned                     = sdkerrors.Register(ModuleName, 317, "no war tokens of this war are owned")
 	ErrCannotBurnMoreThanSupply             = sdkerrors.Register(ModuleName, 318, "cannot burn more tokens than the current supply")
 	ErrFeesCannotBeOrExceed100Percent       = sdkerrors.Register(ModuleName, 319, "sum of fees is or exceeds 100 percent")
 	ErrFromAndToCannotBeTheSameToken        = sdkerrors.Register(ModuleName, 320, "from and to tokens cannot be the same token")
@@ -41,12 +41,52 @@ var (
 	ErrInvalidCoinDenomination              = sdkerrors.Register(ModuleName, 330, "invalid coin denomination")
 	ErrMaxSupplyDenomDoesNotMatchTokenDenom = sdkerrors.Register(ModuleName, 331, "max supply denom does not match token denom")
 	ErrDidNotEditAnything                   = sdkerrors.Register(ModuleName, 332, "did not edit anything from the war")
-	ErrWarTokenCannotAlsoBeReserveToken    = sdkerrors.Register(ModuleName, 333, "token cannot also be a reserve token")
+	ErrWarTokenCannotAlsoBeReserveToken     = sdkerrors.Register(ModuleName, 333, "token cannot also be a reserve token")
 	ErrDuplicateReserveToken                = sdkerrors.Register(ModuleName, 334, "cannot have duplicate tokens in reserve tokens")
 	ErrUnrecognizedFunctionType             = sdkerrors.Register(ModuleName, 335, "unrecognized function type")
 	ErrIncorrectNumberOfReserveTokens       = sdkerrors.Register(ModuleName, 336, "incorrect number of reserve tokens")
 	ErrInvalidFunctionParameter             = sdkerrors.Register(ModuleName, 337, "invalid function parameter")
 	ErrArgumentMissingOrNonUInteger         = sdkerrors.Register(ModuleName, 338, "argument is missing or is not an unsigned integer")
 	ErrArgumentMissingOrNonBoolean          = sdkerrors.Register(ModuleName, 339, "argument is missing or is not true or false")
-	ErrReservedWarToken                    = sdkerrors.Register(ModuleName, 340, "war token is reserved")
+	ErrReservedWarToken                     = sdkerrors.Register(ModuleName, 340, "war token is reserved")
+	ErrFunctionResultTooLarge               = sdkerrors.Register(ModuleName, 341, "function result is too large to be calculated")
+	ErrFunctionDidNotConverge               = sdkerrors.Register(ModuleName, 342, "function calculation did not converge")
+	ErrMinReturnsNotReached                 = sdkerrors.Register(ModuleName, 343, "returns are less than the min returns")
+	ErrOrderDoesNotExist                    = sdkerrors.Register(ModuleName, 344, "order does not exist")
+	ErrOrderAlreadyCancelled                = sdkerrors.Register(ModuleName, 345, "order is already cancelled")
+	ErrInvalidSwapRoute                     = sdkerrors.Register(ModuleName, 346, "invalid swap route")
+	ErrNoSwapRouteFound                     = sdkerrors.Register(ModuleName, 347, "no swap route found")
+	ErrExpiryHeightAlreadyPassed            = sdkerrors.Register(ModuleName, 348, "expiry height has already passed")
+	ErrHatchFieldOnlyForAugmented           = sdkerrors.Register(ModuleName, 349, "hatch fields can only be set for augmented function")
+	ErrHatchDeadlineAlreadyPassed           = sdkerrors.Register(ModuleName, 350, "hatch deadline has already passed")
+	ErrNotAllowedToHatch                    = sdkerrors.Register(ModuleName, 351, "address is not allowed to buy during hatch phase")
+	ErrMaxHatchContributionExceeded         = sdkerrors.Register(ModuleName, 352, "max hatch contribution exceeded")
+	ErrVestingCliffExceedsVestingPeriod     = sdkerrors.Register(ModuleName, 353, "vesting cliff cannot exceed vesting period")
+	ErrNoVestedTokensToClaim                = sdkerrors.Register(ModuleName, 354, "no vested war tokens to claim")
+	ErrWarTokensLocked                      = sdkerrors.Register(ModuleName, 355, "war tokens are locked until vested")
+	ErrNotFundingPoolSpender                = sdkerrors.Register(ModuleName, 356, "address is not a funding pool spender")
+	ErrFundingPoolSpendLimitExceeded        = sdkerrors.Register(ModuleName, 357, "funding pool spend limit exceeded")
+	ErrInsufficientFundingPool              = sdkerrors.Register(ModuleName, 358, "insufficient funding pool balance")
+	ErrInvalidGovernancePercentage          = sdkerrors.Register(ModuleName, 359, "invalid governance percentage")
+	ErrGovernanceNotEnabled                 = sdkerrors.Register(ModuleName, 360, "governance is not enabled for war")
+	ErrProposalDoesNotExist                 = sdkerrors.Register(ModuleName, 361, "proposal does not exist")
+	ErrInvalidProposalType                  = sdkerrors.Register(ModuleName, 362, "invalid proposal type")
+	ErrInvalidVoteOption                    = sdkerrors.Register(ModuleName, 363, "invalid vote option")
+	ErrNoVotingPower                        = sdkerrors.Register(ModuleName, 364, "address has no voting power for proposal")
+	ErrAlreadyVoted                         = sdkerrors.Register(ModuleName, 365, "address has already voted on proposal")
+	ErrDuplicateSigner                      = sdkerrors.Register(ModuleName, 366, "cannot have duplicate signers")
+	ErrInvalidSignerThreshold               = sdkerrors.Register(ModuleName, 367, "signer threshold cannot exceed number of signers")
+	ErrNotWarSigner                         = sdkerrors.Register(ModuleName, 368, "address is not a war signer")
+	ErrSignerThresholdNotMet                = sdkerrors.Register(ModuleName, 369, "number of war signers is below the signer threshold")
+	ErrFeeCannotBeIncreased                 = sdkerrors.Register(ModuleName, 370, "fee percentage cannot be increased")
+	ErrMaxSupplyBelowCurrentSupply          = sdkerrors.Register(ModuleName, 371, "max supply cannot be less than the current supply")
+	ErrCannotEditAllowSellsDuringHatch      = sdkerrors.Register(ModuleName, 372, "allow sells cannot be edited during the hatch phase")
+	ErrPendingEditDoesNotExist              = sdkerrors.Register(ModuleName, 373, "pending edit does not exist")
+	ErrWarPaused                            = sdkerrors.Register(ModuleName, 374, "war is paused")
+	ErrFeeExceedsMax                        = sdkerrors.Register(ModuleName, 375, "fee percentage exceeds the max fee percentage")
+	ErrBatchBlocksOutOfRange                = sdkerrors.Register(ModuleName, 376, "batch blocks are outside of the allowed range")
+	ErrMaxWarsPerCreatorReached             = sdkerrors.Register(ModuleName, 377, "creator has reached the max number of wars")
+	ErrExpiryHeightTooFar                   = sdkerrors.Register(ModuleName, 378, "expiry height is too far in the future")
+	ErrMaxLimitOrdersReached                = sdkerrors.Register(ModuleName, 379, "address has reached the max number of limit orders")
+	ErrMaxProposalsReached                  = sdkerrors.Register(ModuleName, 380, "war has reached the max number of proposals")
 )
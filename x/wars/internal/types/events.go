@@ -1,56 +1,120 @@
 package types
 
 const (
-	EventTypeCreateWar         = "create_war"
-	EventTypeEditWar           = "edit_war"
-	EventTypeInitSwapper        = "init_swapper"
-	EventTypeBuy                = "buy"
-	EventTypeSell               = "sell"
-	EventTypeSwap               = "swap"
-	EventTypeMakeOutcomePayment = "make_outcome_payment"
-	EventTypeWithdrawShare      = "withdraw_share"
-	EventTypeOrderCancel        = "order_cancel"
-	EventTypeOrderFulfill       = "order_fulfill"
-	EventTypeStateChange        = "state_change"
+	EventTypeCreateWar            = "create_war"
+	EventTypeEditWar              = "edit_war"
+	EventTypeUpdateWarSigners     = "update_war_signers"
+	EventTypeInitSwapper          = "init_swapper"
+	EventTypeBuy                  = "buy"
+	EventTypeBuyWithSpend         = "buy_with_spend"
+	EventTypeSell                 = "sell"
+	EventTypeSwap                 = "swap"
+	EventTypeRoutedSwap           = "routed_swap"
+	EventTypeLimitBuy             = "limit_buy"
+	EventTypeLimitSell            = "limit_sell"
+	EventTypeCancelOrder          = "cancel_order"
+	EventTypeCancelLimitOrder     = "cancel_limit_order"
+	EventTypeMakeOutcomePayment   = "make_outcome_payment"
+	EventTypeWithdrawShare        = "withdraw_share"
+	EventTypeClaimVested          = "claim_vested"
+	EventTypeSpendFromFundingPool = "spend_from_funding_pool"
+	EventTypeSubmitProposal       = "submit_proposal"
+	EventTypeVote                 = "vote"
+	EventTypeProposalResult       = "proposal_result"
+	EventTypePendingEdit          = "pending_edit"
+	EventTypeCancelPendingEdit    = "cancel_pending_edit"
+	EventTypePendingEditResult    = "pending_edit_result"
+	EventTypeSetWarPaused         = "set_war_paused"
+	EventTypeMaxPriceMoveExceeded = "max_price_move_exceeded"
+	EventTypeOrderCancel          = "order_cancel"
+	EventTypeOrderFulfill         = "order_fulfill"
+	EventTypePartialFill          = "partial_fill"
+	EventTypeSwapClearing         = "swap_clearing"
+	EventTypeLimitOrderTrigger    = "limit_order_trigger"
+	EventTypeStateChange          = "state_change"
 
-	AttributeKeyWar                   = "war"
-	AttributeKeyName                   = "name"
-	AttributeKeyDescription            = "description"
-	AttributeKeyFunctionType           = "function_type"
-	AttributeKeyFunctionParameters     = "function_parameters"
-	AttributeKeyReserveTokens          = "reserve_tokens"
-	AttributeKeyTxFeePercentage        = "tx_fee_percentage"
-	AttributeKeyExitFeePercentage      = "exit_fee_percentage"
-	AttributeKeyFeeAddress             = "fee_address"
-	AttributeKeyMaxSupply              = "max_supply"
-	AttributeKeyOrderQuantityLimits    = "order_quantity_limits"
-	AttributeKeySanityRate             = "sanity_rate"
-	AttributeKeySanityMarginPercentage = "sanity_margin_percentage"
-	AttributeKeyAllowSells             = "allow_sells"
-	AttributeKeySigners                = "signers"
-	AttributeKeyBatchBlocks            = "batch_blocks"
-	AttributeKeyOutcomePayment         = "outcome_payment"
-	AttributeKeyState                  = "state"
-	AttributeKeyMaxPrices              = "max_prices"
-	AttributeKeySwapFromToken          = "from_token"
-	AttributeKeySwapToToken            = "to_token"
-	AttributeKeyOrderType              = "order_type"
-	AttributeKeyAddress                = "address"
-	AttributeKeyCancelReason           = "cancel_reason"
-	AttributeKeyTokensMinted           = "tokens_minted"
-	AttributeKeyTokensBurned           = "tokens_burned"
-	AttributeKeyTokensSwapped          = "tokens_swapped"
-	AttributeKeyChargedPrices          = "charged_prices"
-	AttributeKeyChargedPricesReserve   = "charged_prices_of_which_reserve"
-	AttributeKeyChargedPricesFunding   = "charged_prices_of_which_funding"
-	AttributeKeyChargedFees            = "charged_fees"
-	AttributeKeyReturnedToAddress      = "returned_to_address"
-	AttributeKeyNewWarTokenBalance    = "new_war_token_balance"
-	AttributeKeyOldState               = "old_state"
-	AttributeKeyNewState               = "new_state"
+	AttributeKeyWar                           = "war"
+	AttributeKeyName                          = "name"
+	AttributeKeyDescription                   = "description"
+	AttributeKeyFunctionType                  = "function_type"
+	AttributeKeyFunctionParameters            = "function_parameters"
+	AttributeKeyReserveTokens                 = "reserve_tokens"
+	AttributeKeyTxFeePercentage               = "tx_fee_percentage"
+	AttributeKeyExitFeePercentage             = "exit_fee_percentage"
+	AttributeKeyFeeAddress                    = "fee_address"
+	AttributeKeyMaxSupply                     = "max_supply"
+	AttributeKeyOrderQuantityLimits           = "order_quantity_limits"
+	AttributeKeySanityRate                    = "sanity_rate"
+	AttributeKeySanityMarginPercentage        = "sanity_margin_percentage"
+	AttributeKeyAllowSells                    = "allow_sells"
+	AttributeKeySigners                       = "signers"
+	AttributeKeySignerThreshold               = "signer_threshold"
+	AttributeKeyBatchBlocks                   = "batch_blocks"
+	AttributeKeyOutcomePayment                = "outcome_payment"
+	AttributeKeyHatchDeadlineHeight           = "hatch_deadline_height"
+	AttributeKeyAllowedHatchers               = "allowed_hatchers"
+	AttributeKeyHatchMembershipDenom          = "hatch_membership_denom"
+	AttributeKeyMaxHatchContribution          = "max_hatch_contribution"
+	AttributeKeyHatchVestingCliff             = "hatch_vesting_cliff"
+	AttributeKeyHatchVestingPeriod            = "hatch_vesting_period"
+	AttributeKeyFundingPoolSpenders           = "funding_pool_spenders"
+	AttributeKeyFundingPoolSpendLimit         = "funding_pool_spend_limit"
+	AttributeKeyFundingPoolEpochBlocks        = "funding_pool_epoch_blocks"
+	AttributeKeyGovernanceQuorumPercentage    = "governance_quorum_percentage"
+	AttributeKeyGovernanceThresholdPercentage = "governance_threshold_percentage"
+	AttributeKeyGovernanceVotingPeriod        = "governance_voting_period"
+	AttributeKeyProposalID                    = "proposal_id"
+	AttributeKeyProposalType                  = "proposal_type"
+	AttributeKeyVotingEndHeight               = "voting_end_height"
+	AttributeKeyOption                        = "option"
+	AttributeKeyVotingPower                   = "voting_power"
+	AttributeKeyProposalResult                = "proposal_result"
+	AttributeKeyEditDelay                     = "edit_delay"
+	AttributeKeyEditID                        = "edit_id"
+	AttributeKeyEffectiveHeight               = "effective_height"
+	AttributeKeyPendingEditResult             = "pending_edit_result"
+	AttributeKeyPaused                        = "paused"
+	AttributeKeyMaxPriceMovePercentage        = "max_price_move_percentage"
+	AttributeKeyPriceMoveCooldown             = "price_move_cooldown"
+	AttributeKeyPausedUntilHeight             = "paused_until_height"
+	AttributeKeyCreationDeposit               = "creation_deposit"
+	AttributeKeyState                         = "state"
+	AttributeKeyMaxPrices                     = "max_prices"
+	AttributeKeyAllowPartialFill              = "allow_partial_fill"
+	AttributeKeySpend                         = "spend"
+	AttributeKeyMinReturns                    = "min_returns"
+	AttributeKeySwapFromToken                 = "from_token"
+	AttributeKeySwapToToken                   = "to_token"
+	AttributeKeyMinOutput                     = "min_output"
+	AttributeKeyRoute                         = "route"
+	AttributeKeyOrderType                     = "order_type"
+	AttributeKeyOrderIndex                    = "order_index"
+	AttributeKeyOrderID                       = "order_id"
+	AttributeKeyExpiryHeight                  = "expiry_height"
+	AttributeKeyAddress                       = "address"
+	AttributeKeyRecipient                     = "recipient"
+	AttributeKeyCancelReason                  = "cancel_reason"
+	AttributeKeyUnfilledAmount                = "unfilled_amount"
+	AttributeKeyTokensMinted                  = "tokens_minted"
+	AttributeKeyTokensBurned                  = "tokens_burned"
+	AttributeKeyTokensSwapped                 = "tokens_swapped"
+	AttributeKeyChargedPrices                 = "charged_prices"
+	AttributeKeyChargedPricesReserve          = "charged_prices_of_which_reserve"
+	AttributeKeyChargedPricesFunding          = "charged_prices_of_which_funding"
+	AttributeKeyChargedFees                   = "charged_fees"
+	AttributeKeyReturnedToAddress             = "returned_to_address"
+	AttributeKeyClearingPrice                 = "clearing_price"
+	AttributeKeyNewWarTokenBalance            = "new_war_token_balance"
+	AttributeKeyOldState                      = "old_state"
+	AttributeKeyOldPrefix                     = "old_"
+	AttributeKeyNewPrefix                     = "new_"
+	AttributeKeyNewState                      = "new_state"
 
-	AttributeValueBuyOrder  = "buy"
-	AttributeValueSellOrder = "sell"
-	AttributeValueSwapOrder = "swap"
-	AttributeValueCategory  = ModuleName
+	AttributeValueBuyOrder        = BuyOrderType
+	AttributeValueSellOrder       = SellOrderType
+	AttributeValueSwapOrder       = SwapOrderType
+	AttributeValueRoutedSwapOrder = RoutedSwapOrderType
+	AttributeValueLimitBuyOrder   = LimitBuyOrderType
+	AttributeValueLimitSellOrder  = LimitSellOrderType
+	AttributeValueCategory        = ModuleName
 )
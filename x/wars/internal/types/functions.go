@@ -0,0 +1,835 @@
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"sort"
+)
+
+// CurveFunction defines the parameters, pricing and reserve logic of a war
+// function type. Function types are looked up by name in a registry, so that
+// a chain built on this module can register its own curve types by calling
+// RegisterCurveFunction (typically when the app is being wired up).
+type CurveFunction interface {
+	// RequiredParams returns the names of the function parameters that
+	// have to be specified when creating a war using the function type
+	RequiredParams() []string
+
+	// NoOfReserveTokens returns the number of reserve tokens that a war
+	// using the function type expects, or AnyNumberOfReserveTokens
+	NoOfReserveTokens() int
+
+	// ValidateParams applies any restrictions on the function parameters
+	// on top of them being present and non-negative
+	ValidateParams(paramsMap map[string]sdk.Dec) error
+
+	// IsSwapper indicates whether the function type is used to swap between
+	// reserve tokens (rather than to mint and burn war tokens along a curve)
+	IsSwapper() bool
+
+	GetPricesAtSupply(war War, supply sdk.Int) (sdk.DecCoins, error)
+	ReserveAtSupply(war War, supply sdk.Int) sdk.Dec
+	GetPricesToMint(war War, mint sdk.Int, reserveBalances sdk.Coins) (sdk.DecCoins, error)
+	GetReturnsForBurn(war War, burn sdk.Int, reserveBalances sdk.Coins) sdk.DecCoins
+	GetReturnsForSwap(war War, from sdk.Coin, toToken string, reserveBalances sdk.Coins) (sdk.Coins, sdk.Coin, error)
+}
+
+// VariableParamsCurveFunction is implemented by function types that accept a
+// variable number of function parameters, such as a list of breakpoints. For
+// these, RequiredParams returns the minimum set of parameters.
+type VariableParamsCurveFunction interface {
+	CurveFunction
+
+	// RequiredParamsFor returns the names of the function parameters that
+	// are expected given the function parameters that were specified
+	RequiredParamsFor(fps FunctionParams) []string
+}
+
+// ReserveTokensCurveFunction is implemented by function types whose function
+// parameters refer to the war's reserve tokens, such as per-token weights.
+type ReserveTokensCurveFunction interface {
+	CurveFunction
+
+	// ValidateParamsForReserveTokens checks that the function parameters
+	// are consistent with the reserve tokens of the war
+	ValidateParamsForReserveTokens(paramsMap map[string]sdk.Dec, reserveTokens []string) error
+}
+
+// SpotPriceCurveFunction is implemented by swapper function types that can
+// give the marginal exchange rate between two of their reserve tokens. It is
+// used to match opposing swaps in a batch before the net amount is swapped
+// along the curve.
+type SpotPriceCurveFunction interface {
+	CurveFunction
+
+	// GetSpotPrice returns the amount of toToken given per fromToken for an
+	// infinitesimally small swap at the given reserve balances
+	GetSpotPrice(war War, fromToken, toToken string, reserveBalances sdk.Coins) (sdk.Dec, error)
+}
+
+var curveFunctions = make(map[string]CurveFunction)
+
+func init() {
+	RegisterCurveFunction(PowerFunction, powerFunction{})
+	RegisterCurveFunction(SigmoidFunction, sigmoidFunction{})
+	RegisterCurveFunction(SwapperFunction, swapperFunction{})
+	RegisterCurveFunction(WeightedSwapperFunction, weightedSwapperFunction{})
+	RegisterCurveFunction(StableSwapFunction, stableSwapFunction{})
+	RegisterCurveFunction(AugmentedFunction, augmentedFunction{})
+	RegisterCurveFunction(ExponentialFunction, exponentialFunction{})
+	RegisterCurveFunction(LogarithmicFunction, logarithmicFunction{})
+	RegisterCurveFunction(BancorFunction, bancorFunction{})
+	RegisterCurveFunction(PiecewiseLinearFunction, piecewiseLinearFunction{})
+}
+
+// RegisterCurveFunction adds a function type to the registry of function
+// types that wars can be created with. It panics if the function type is
+// already registered.
+func RegisterCurveFunction(functionType string, fn CurveFunction) {
+	if _, ok := curveFunctions[functionType]; ok {
+		panic(fmt.Sprintf("curve function %s already registered", functionType))
+	}
+	curveFunctions[functionType] = fn
+}
+
+func GetCurveFunction(functionType string) (CurveFunction, error) {
+	fn, ok := curveFunctions[functionType]
+	if !ok {
+		return nil, sdkerrors.Wrap(ErrUnrecognizedFunctionType, functionType)
+	}
+	return fn, nil
+}
+
+// GetRegisteredFunctionTypes returns the sorted list of registered function types
+func GetRegisteredFunctionTypes() (functionTypes []string) {
+	for t := range curveFunctions {
+		functionTypes = append(functionTypes, t)
+	}
+	sort.Strings(functionTypes)
+	return functionTypes
+}
+
+// BaseCurveFunction can be embedded by any non-swapper function type whose
+// prices to mint and returns for burn are derived from its reserve function.
+// Such a function type only has to implement the parameter-related functions,
+// GetPricesAtSupply and ReserveAtSupply.
+type BaseCurveFunction struct{}
+
+func (BaseCurveFunction) NoOfReserveTokens() int { return AnyNumberOfReserveTokens }
+
+func (BaseCurveFunction) IsSwapper() bool { return false }
+
+func (BaseCurveFunction) GetPricesToMint(war War, mint sdk.Int, reserveBalances sdk.Coins) (sdk.DecCoins, error) {
+	var priceToMint sdk.Dec
+	result := war.ReserveAtSupply(war.CurrentSupply.Amount.Add(mint))
+	if reserveBalances.Empty() {
+		priceToMint = result
+	} else {
+		// Reserve balances should all be equal given that we are always
+		// applying the same additions/subtractions to all reserve balances.
+		// Thus we can pick the first reserve balance as the global balance.
+		commonReserveBalance := reserveBalances[0].Amount.ToDec()
+		priceToMint = result.Sub(commonReserveBalance)
+	}
+	if priceToMint.IsNegative() {
+		// Negative priceToMint means that the previous buyer overpaid
+		// to the point that the price for this buyer is covered. However,
+		// we still charge this buyer at least one token.
+		priceToMint = sdk.OneDec()
+	}
+	return war.GetNewReserveDecCoins(priceToMint), nil
+}
+
+func (BaseCurveFunction) GetReturnsForBurn(war War, burn sdk.Int, reserveBalances sdk.Coins) sdk.DecCoins {
+	result := war.ReserveAtSupply(war.CurrentSupply.Amount.Sub(burn))
+
+	var reserveBalance sdk.Dec
+	if reserveBalances.Empty() {
+		reserveBalance = sdk.ZeroDec()
+	} else {
+		// Reserve balances should all be equal given that we are always
+		// applying the same additions/subtractions to all reserve balances.
+		// Thus we can pick the first reserve balance as the global balance.
+		reserveBalance = reserveBalances[0].Amount.ToDec()
+	}
+
+	if result.GT(reserveBalance) {
+		panic("not enough reserve available for burn")
+	} else {
+		returnForBurn := reserveBalance.Sub(result)
+		return war.GetNewReserveDecCoins(returnForBurn)
+		// TODO: investigate possibility of negative returnForBurn
+	}
+}
+
+func (BaseCurveFunction) GetReturnsForSwap(war War, _ sdk.Coin, _ string, _ sdk.Coins) (sdk.Coins, sdk.Coin, error) {
+	return nil, sdk.Coin{}, sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, war.FunctionType)
+}
+
+type powerFunction struct{ BaseCurveFunction }
+
+func (powerFunction) RequiredParams() []string { return []string{"m", "n", "c"} }
+
+func (powerFunction) ValidateParams(paramsMap map[string]sdk.Dec) error {
+	return powerParameterRestrictions(paramsMap)
+}
+
+func (powerFunction) GetPricesAtSupply(war War, supply sdk.Int) (sdk.DecCoins, error) {
+	args := war.FunctionParameters.AsMap()
+	x := supply.ToDec()
+	m := args["m"]
+	n64 := args["n"].TruncateInt64() // enforced by powerParameterRestrictions
+	c := args["c"]
+	return war.GetNewReserveDecCoins(x.Power(uint64(n64)).Mul(m).Add(c)), nil
+}
+
+func (powerFunction) ReserveAtSupply(war War, supply sdk.Int) sdk.Dec {
+	args := war.FunctionParameters.AsMap()
+	x := supply.ToDec()
+	m := args["m"]
+	n, n64 := args["n"], args["n"].TruncateInt64() // enforced by powerParameterRestrictions
+	c := args["c"]
+	temp1 := x.Power(uint64(n64 + 1))
+	temp2 := temp1.Mul(m).Quo(n.Add(sdk.OneDec()))
+	temp3 := x.Mul(c)
+	return temp2.Add(temp3)
+}
+
+type sigmoidFunction struct{ BaseCurveFunction }
+
+func (sigmoidFunction) RequiredParams() []string { return []string{"a", "b", "c"} }
+
+func (sigmoidFunction) ValidateParams(paramsMap map[string]sdk.Dec) error {
+	return sigmoidParameterRestrictions(paramsMap)
+}
+
+func (sigmoidFunction) GetPricesAtSupply(war War, supply sdk.Int) (sdk.DecCoins, error) {
+	args := war.FunctionParameters.AsMap()
+	x := supply.ToDec()
+	a := args["a"]
+	b := args["b"]
+	c := args["c"]
+	temp1 := x.Sub(b)
+	temp2 := temp1.Mul(temp1).Add(c)
+	temp3, err := temp2.ApproxSqrt()
+	if err != nil {
+		panic(err) // TODO: consider error handling
+	}
+	return war.GetNewReserveDecCoins(
+		a.Mul(temp1.Quo(temp3).Add(sdk.OneDec()))), nil
+}
+
+func (sigmoidFunction) ReserveAtSupply(war War, supply sdk.Int) sdk.Dec {
+	args := war.FunctionParameters.AsMap()
+	x := supply.ToDec()
+	a := args["a"]
+	b := args["b"]
+	c := args["c"]
+	temp1 := x.Sub(b)
+	temp2 := temp1.Mul(temp1).Add(c)
+	temp3, err := temp2.ApproxSqrt()
+	if err != nil {
+		panic(err) // TODO: consider error handling
+	}
+	temp5 := a.Mul(temp3.Add(x))
+	approx, err := (b.Mul(b).Add(c)).ApproxSqrt()
+	if err != nil {
+		panic(err) // TODO: consider error handling
+	}
+	constant := a.Mul(approx)
+
+	return temp5.Sub(constant)
+}
+
+type exponentialFunction struct{ BaseCurveFunction }
+
+func (exponentialFunction) RequiredParams() []string { return []string{"a", "b"} }
+
+func (exponentialFunction) ValidateParams(paramsMap map[string]sdk.Dec) error {
+	return exponentialParameterRestrictions(paramsMap)
+}
+
+func (exponentialFunction) GetPricesAtSupply(war War, supply sdk.Int) (sdk.DecCoins, error) {
+	args := war.FunctionParameters.AsMap()
+	x := supply.ToDec()
+	a := args["a"]
+	b := args["b"]
+	temp1, err := Exp(b.Mul(x))
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "b*x")
+	}
+	return war.GetNewReserveDecCoins(a.Mul(temp1)), nil
+}
+
+func (exponentialFunction) ReserveAtSupply(war War, supply sdk.Int) sdk.Dec {
+	args := war.FunctionParameters.AsMap()
+	x := supply.ToDec()
+	a := args["a"]
+	b := args["b"] // non-zero, enforced by exponentialParameterRestrictions
+	temp1, err := Exp(b.Mul(x))
+	if err != nil {
+		panic(err) // prevented by the check in GetPricesToMint
+	}
+	return a.Quo(b).Mul(temp1.Sub(sdk.OneDec()))
+}
+
+func (fn exponentialFunction) GetPricesToMint(war War, mint sdk.Int, reserveBalances sdk.Coins) (sdk.DecCoins, error) {
+	// Check that the reserve at the resultant supply can be calculated
+	b := war.FunctionParameters.AsMap()["b"]
+	if b.MulInt(war.CurrentSupply.Amount.Add(mint)).GT(MaxExpArgument) {
+		return nil, sdkerrors.Wrap(ErrFunctionResultTooLarge, "b*x")
+	}
+	return fn.BaseCurveFunction.GetPricesToMint(war, mint, reserveBalances)
+}
+
+type logarithmicFunction struct{ BaseCurveFunction }
+
+func (logarithmicFunction) RequiredParams() []string { return []string{"a", "b", "c"} }
+
+func (logarithmicFunction) ValidateParams(paramsMap map[string]sdk.Dec) error {
+	return logarithmicParameterRestrictions(paramsMap)
+}
+
+func (logarithmicFunction) GetPricesAtSupply(war War, supply sdk.Int) (sdk.DecCoins, error) {
+	args := war.FunctionParameters.AsMap()
+	x := supply.ToDec()
+	a := args["a"]
+	b := args["b"]
+	c := args["c"]
+	temp1 := Ln(b.Mul(x).Add(sdk.OneDec()))
+	return war.GetNewReserveDecCoins(a.Mul(temp1).Add(c)), nil
+}
+
+func (logarithmicFunction) ReserveAtSupply(war War, supply sdk.Int) sdk.Dec {
+	args := war.FunctionParameters.AsMap()
+	x := supply.ToDec()
+	a := args["a"]
+	b := args["b"] // non-zero, enforced by logarithmicParameterRestrictions
+	c := args["c"]
+	temp1 := b.Mul(x)
+	temp2 := temp1.Add(sdk.OneDec())
+	temp3 := temp2.Mul(Ln(temp2)).Sub(temp1)
+	temp4 := a.Mul(temp3).Quo(b)
+	return temp4.Add(c.Mul(x))
+}
+
+// bancorFunction is a constant reserve ratio curve, where the reserve is
+// always r*supply*price. Starting from a price p0 at a supply s0, the price
+// is p0*(x/s0)^(1/r-1) and the reserve is r*p0*s0*(x/s0)^(1/r).
+type bancorFunction struct{ BaseCurveFunction }
+
+func (bancorFunction) RequiredParams() []string { return []string{"r", "p0", "s0"} }
+
+func (bancorFunction) ValidateParams(paramsMap map[string]sdk.Dec) error {
+	return bancorParameterRestrictions(paramsMap)
+}
+
+func (bancorFunction) GetPricesAtSupply(war War, supply sdk.Int) (sdk.DecCoins, error) {
+	args := war.FunctionParameters.AsMap()
+	r := args["r"]
+	p0 := args["p0"]
+	s0 := args["s0"]
+	exponent := sdk.OneDec().Quo(r).Sub(sdk.OneDec())
+	temp1, err := Pow(supply.ToDec().Quo(s0), exponent)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "(x/s0)^(1/r-1)")
+	}
+	return war.GetNewReserveDecCoins(p0.Mul(temp1)), nil
+}
+
+func (bancorFunction) ReserveAtSupply(war War, supply sdk.Int) sdk.Dec {
+	args := war.FunctionParameters.AsMap()
+	r := args["r"]
+	p0 := args["p0"]
+	s0 := args["s0"]
+	temp1, err := Pow(supply.ToDec().Quo(s0), sdk.OneDec().Quo(r))
+	if err != nil {
+		panic(err) // prevented by the check in GetPricesToMint
+	}
+	return r.Mul(p0).Mul(s0).Mul(temp1)
+}
+
+func (fn bancorFunction) GetPricesToMint(war War, mint sdk.Int, reserveBalances sdk.Coins) (sdk.DecCoins, error) {
+	args := war.FunctionParameters.AsMap()
+	r := args["r"]
+	s0 := args["s0"]
+	supply := war.CurrentSupply.Amount
+	newSupply := supply.Add(mint)
+
+	// Check that the reserve at the resultant supply can be calculated
+	_, err := Pow(newSupply.ToDec().Quo(s0), sdk.OneDec().Quo(r))
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "(x/s0)^(1/r)")
+	}
+
+	// With no supply or reserve to scale, fall back to the reserve function
+	if supply.IsZero() || reserveBalances.Empty() || reserveBalances[0].IsZero() {
+		return fn.BaseCurveFunction.GetPricesToMint(war, mint, reserveBalances)
+	}
+
+	// Bancor formula: price = R * ((1 + mint/S)^(1/r) - 1), where R is the
+	// common reserve balance and S is the current supply
+	reserveBalance := reserveBalances[0].Amount.ToDec()
+	temp1, err := Pow(newSupply.ToDec().Quo(supply.ToDec()), sdk.OneDec().Quo(r))
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "(1+mint/S)^(1/r)")
+	}
+	return war.GetNewReserveDecCoins(reserveBalance.Mul(temp1.Sub(sdk.OneDec()))), nil
+}
+
+func (fn bancorFunction) GetReturnsForBurn(war War, burn sdk.Int, reserveBalances sdk.Coins) sdk.DecCoins {
+	r := war.FunctionParameters.AsMap()["r"]
+	supply := war.CurrentSupply.Amount
+
+	// With no reserve to scale, fall back to the reserve function
+	if reserveBalances.Empty() || reserveBalances[0].IsZero() {
+		return fn.BaseCurveFunction.GetReturnsForBurn(war, burn, reserveBalances)
+	}
+
+	// Bancor formula: return = R * (1 - (1 - burn/S)^(1/r)), where R is the
+	// common reserve balance and S is the current supply
+	reserveBalance := reserveBalances[0].Amount.ToDec()
+	temp1, err := Pow(supply.Sub(burn).ToDec().Quo(supply.ToDec()), sdk.OneDec().Quo(r))
+	if err != nil {
+		panic(err) // base is at most 1, so the result is at most 1
+	}
+	return war.GetNewReserveDecCoins(reserveBalance.Mul(sdk.OneDec().Sub(temp1)))
+}
+
+// piecewiseLinearFunction is a rule-based curve defined by a list of (supply,
+// price) breakpoints (x0,p0), (x1,p1), ..., with x0=0. The price is linearly
+// interpolated between breakpoints and stays at the last breakpoint's price
+// beyond the last breakpoint. The reserve is the exact (trapezoidal) integral.
+type piecewiseLinearFunction struct{ BaseCurveFunction }
+
+func (piecewiseLinearFunction) RequiredParams() []string {
+	return []string{"x0", "p0", "x1", "p1"}
+}
+
+func (piecewiseLinearFunction) RequiredParamsFor(fps FunctionParams) (params []string) {
+	// Expect one (x, p) pair per two params provided, with at least two pairs
+	noOfBreakpoints := len(fps) / 2
+	if noOfBreakpoints < 2 {
+		noOfBreakpoints = 2
+	}
+	for i := 0; i < noOfBreakpoints; i++ {
+		params = append(params, fmt.Sprintf("x%d", i), fmt.Sprintf("p%d", i))
+	}
+	return params
+}
+
+func (piecewiseLinearFunction) ValidateParams(paramsMap map[string]sdk.Dec) error {
+	return piecewiseLinearParameterRestrictions(paramsMap)
+}
+
+// piecewiseLinearBreakpoints returns the breakpoint supplies (xs) and prices
+// (ps) from consecutively numbered x and p function parameters
+func piecewiseLinearBreakpoints(paramsMap map[string]sdk.Dec) (xs, ps []sdk.Dec) {
+	for i := 0; ; i++ {
+		x, ok1 := paramsMap[fmt.Sprintf("x%d", i)]
+		p, ok2 := paramsMap[fmt.Sprintf("p%d", i)]
+		if !ok1 || !ok2 {
+			return xs, ps
+		}
+		xs = append(xs, x)
+		ps = append(ps, p)
+	}
+}
+
+func (piecewiseLinearFunction) GetPricesAtSupply(war War, supply sdk.Int) (sdk.DecCoins, error) {
+	xs, ps := piecewiseLinearBreakpoints(war.FunctionParameters.AsMap())
+	x := supply.ToDec()
+
+	// Interpolate within the segment that contains x, if any
+	for i := 1; i < len(xs); i++ {
+		if x.LTE(xs[i]) {
+			temp1 := ps[i].Sub(ps[i-1]).Mul(x.Sub(xs[i-1]))
+			temp2 := temp1.Quo(xs[i].Sub(xs[i-1]))
+			return war.GetNewReserveDecCoins(ps[i-1].Add(temp2)), nil
+		}
+	}
+
+	// Beyond the last breakpoint, the price stays at the last price
+	return war.GetNewReserveDecCoins(ps[len(ps)-1]), nil
+}
+
+func (piecewiseLinearFunction) ReserveAtSupply(war War, supply sdk.Int) sdk.Dec {
+	xs, ps := piecewiseLinearBreakpoints(war.FunctionParameters.AsMap())
+	x := supply.ToDec()
+
+	// Add up the area (trapezium) under each segment up to x
+	result := sdk.ZeroDec()
+	for i := 1; i < len(xs); i++ {
+		if x.LTE(xs[i]) {
+			temp1 := ps[i].Sub(ps[i-1]).Mul(x.Sub(xs[i-1]))
+			priceAtX := ps[i-1].Add(temp1.Quo(xs[i].Sub(xs[i-1])))
+			temp2 := ps[i-1].Add(priceAtX).Mul(x.Sub(xs[i-1]))
+			return result.Add(temp2.QuoInt64(2))
+		}
+		temp3 := ps[i-1].Add(ps[i]).Mul(xs[i].Sub(xs[i-1]))
+		result = result.Add(temp3.QuoInt64(2))
+	}
+
+	// Beyond the last breakpoint, add the area (rectangle) at the last price
+	last := len(xs) - 1
+	return result.Add(ps[last].Mul(x.Sub(xs[last])))
+}
+
+type augmentedFunction struct{ BaseCurveFunction }
+
+func (augmentedFunction) RequiredParams() []string {
+	return []string{"d0", "p0", "theta", "kappa"}
+}
+
+func (augmentedFunction) ValidateParams(paramsMap map[string]sdk.Dec) error {
+	return augmentedParameterRestrictions(paramsMap)
+}
+
+func (augmentedFunction) GetPricesAtSupply(war War, supply sdk.Int) (result sdk.DecCoins, err error) {
+	args := war.FunctionParameters.AsMap()
+	x := supply.ToDec()
+
+	// Note: during the hatch phase, this function returns the hatch price
+	// p0 even if the supply argument is greater than the initial supply S0.
+	// A war whose hatch failed never left the hatch phase, so p0 is returned.
+	switch war.State {
+	case HatchState, FailedState:
+		result = war.GetNewReserveDecCoins(args["p0"])
+	case OpenState:
+		kappa := args["kappa"].TruncateInt64()
+		res := Reserve(x, kappa, args["V0"])
+		// If reserve < 1, default to zero price to avoid calculation issues
+		if res.LT(sdk.OneDec()) {
+			result = war.GetNewReserveDecCoins(sdk.ZeroDec())
+		} else {
+			spotPriceDec := SpotPrice(res, kappa, args["V0"])
+			result = war.GetNewReserveDecCoins(spotPriceDec)
+		}
+	default:
+		panic("unrecognized war state")
+	}
+	return result, nil
+}
+
+func (augmentedFunction) ReserveAtSupply(war War, supply sdk.Int) sdk.Dec {
+	args := war.FunctionParameters.AsMap()
+	kappa := args["kappa"].TruncateInt64()
+	V0 := args["V0"]
+	return Reserve(supply.ToDec(), kappa, V0)
+}
+
+func (fn augmentedFunction) GetPricesToMint(war War, mint sdk.Int, reserveBalances sdk.Coins) (sdk.DecCoins, error) {
+	// If hatch phase for augmented function, use fixed p0 price
+	if war.State == HatchState {
+		args := war.FunctionParameters.AsMap()
+		price := args["p0"].Mul(mint.ToDec())
+		return war.GetNewReserveDecCoins(price), nil
+	}
+	return fn.BaseCurveFunction.GetPricesToMint(war, mint, reserveBalances)
+}
+
+type swapperFunction struct{}
+
+func (swapperFunction) RequiredParams() []string { return nil }
+
+func (swapperFunction) NoOfReserveTokens() int { return 2 }
+
+func (swapperFunction) ValidateParams(map[string]sdk.Dec) error { return nil }
+
+func (swapperFunction) IsSwapper() bool { return true }
+
+func (swapperFunction) GetPricesAtSupply(war War, _ sdk.Int) (sdk.DecCoins, error) {
+	return nil, sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, war.FunctionType)
+}
+
+func (swapperFunction) ReserveAtSupply(War, sdk.Int) sdk.Dec {
+	panic("invalid function for function type")
+}
+
+func (swapperFunction) GetPricesToMint(war War, mint sdk.Int, reserveBalances sdk.Coins) (sdk.DecCoins, error) {
+	if war.CurrentSupply.Amount.IsZero() {
+		return nil, sdkerrors.Wrap(ErrFunctionRequiresNonZeroCurrentSupply, war.CurrentSupply.Amount.String())
+	}
+	return war.GetReserveDeltaForLiquidityDelta(mint, reserveBalances), nil
+}
+
+func (swapperFunction) GetReturnsForBurn(war War, burn sdk.Int, reserveBalances sdk.Coins) sdk.DecCoins {
+	return war.GetReserveDeltaForLiquidityDelta(burn, reserveBalances)
+}
+
+func (swapperFunction) GetReturnsForSwap(war War, from sdk.Coin, toToken string, reserveBalances sdk.Coins) (returns sdk.Coins, txFee sdk.Coin, err error) {
+	// Check that from and to are reserve tokens
+	if !war.IsReserveToken(from.Denom) {
+		return nil, sdk.Coin{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, from.Denom)
+	} else if !war.IsReserveToken(toToken) {
+		return nil, sdk.Coin{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, toToken)
+	}
+
+	inAmt := from.Amount
+	inRes := reserveBalances.AmountOf(from.Denom)
+	outRes := reserveBalances.AmountOf(toToken)
+
+	// Calculate fee to get the adjusted input amount
+	txFee = war.GetTxFee(sdk.NewDecCoinFromCoin(from))
+	inAmt = inAmt.Sub(txFee.Amount) // adjusted input
+
+	// Check that at least 1 token is going in
+	if inAmt.IsZero() {
+		return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", from.Denom, toToken)
+	}
+
+	// Calculate output amount using Uniswap formula: Δy = (Δx*y)/(x+Δx)
+	outAmt := inAmt.Mul(outRes).Quo(inRes.Add(inAmt))
+
+	// Check that not giving out all of the available outRes or nothing at all
+	if outAmt.Equal(outRes) {
+		return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountCausesReserveDepletion, "%s - %s", from.Denom, toToken)
+	} else if outAmt.IsZero() {
+		return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", from.Denom, toToken)
+	} else if outAmt.IsNegative() {
+		panic(fmt.Sprintf("negative return for swap result for war %s", war.Token))
+	}
+
+	return sdk.Coins{sdk.NewCoin(toToken, outAmt)}, txFee, nil
+}
+
+func (swapperFunction) GetSpotPrice(war War, fromToken, toToken string, reserveBalances sdk.Coins) (sdk.Dec, error) {
+	inRes, outRes, err := getSpotPriceReserves(war, fromToken, toToken, reserveBalances)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	// Marginal rate of the constant product x*y=k: -dy/dx = y/x
+	return outRes.Quo(inRes), nil
+}
+
+// getSpotPriceReserves returns the reserve balances of the from and to tokens,
+// checking that both are non-empty reserve tokens of the war
+func getSpotPriceReserves(war War, fromToken, toToken string, reserveBalances sdk.Coins) (inRes, outRes sdk.Dec, err error) {
+	if !war.IsReserveToken(fromToken) {
+		return sdk.Dec{}, sdk.Dec{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, fromToken)
+	} else if !war.IsReserveToken(toToken) {
+		return sdk.Dec{}, sdk.Dec{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, toToken)
+	}
+
+	inRes = reserveBalances.AmountOf(fromToken).ToDec()
+	outRes = reserveBalances.AmountOf(toToken).ToDec()
+	if inRes.IsZero() || outRes.IsZero() {
+		return sdk.Dec{}, sdk.Dec{}, sdkerrors.Wrapf(ErrSwapAmountCausesReserveDepletion, "%s - %s", fromToken, toToken)
+	}
+	return inRes, outRes, nil
+}
+
+// weightedSwapperFunction is a Balancer-style swapper for any number (two or
+// more) of reserve tokens, each with a weight. The function parameters are
+// the weights, named after the reserve tokens, e.g. "res:1,rez:2,rex:2".
+// Adding and removing liquidity is proportional to the reserve balances, as
+// for the swapper function.
+type weightedSwapperFunction struct{}
+
+func (weightedSwapperFunction) RequiredParams() []string { return nil }
+
+func (weightedSwapperFunction) RequiredParamsFor(fps FunctionParams) (params []string) {
+	// Weights are named after the reserve tokens, which are checked against
+	// the parameter names in ValidateParamsForReserveTokens
+	for _, fp := range fps {
+		params = append(params, fp.Param)
+	}
+	return params
+}
+
+func (weightedSwapperFunction) NoOfReserveTokens() int { return AnyNumberOfReserveTokens }
+
+func (weightedSwapperFunction) ValidateParams(paramsMap map[string]sdk.Dec) error {
+	return weightedSwapperParameterRestrictions(paramsMap)
+}
+
+func (weightedSwapperFunction) ValidateParamsForReserveTokens(paramsMap map[string]sdk.Dec, reserveTokens []string) error {
+	if len(reserveTokens) != len(paramsMap) {
+		return sdkerrors.Wrapf(ErrIncorrectNumberOfReserveTokens, "expected: %d", len(paramsMap))
+	}
+	for _, r := range reserveTokens {
+		if _, ok := paramsMap[r]; !ok {
+			return sdkerrors.Wrapf(ErrFunctionParameterMissingOrNonFloat, "weight for %s", r)
+		}
+	}
+	return nil
+}
+
+func (weightedSwapperFunction) IsSwapper() bool { return true }
+
+func (weightedSwapperFunction) GetPricesAtSupply(war War, _ sdk.Int) (sdk.DecCoins, error) {
+	return nil, sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, war.FunctionType)
+}
+
+func (weightedSwapperFunction) ReserveAtSupply(War, sdk.Int) sdk.Dec {
+	panic("invalid function for function type")
+}
+
+func (weightedSwapperFunction) GetPricesToMint(war War, mint sdk.Int, reserveBalances sdk.Coins) (sdk.DecCoins, error) {
+	return swapperFunction{}.GetPricesToMint(war, mint, reserveBalances)
+}
+
+func (weightedSwapperFunction) GetReturnsForBurn(war War, burn sdk.Int, reserveBalances sdk.Coins) sdk.DecCoins {
+	return swapperFunction{}.GetReturnsForBurn(war, burn, reserveBalances)
+}
+
+func (weightedSwapperFunction) GetReturnsForSwap(war War, from sdk.Coin, toToken string, reserveBalances sdk.Coins) (returns sdk.Coins, txFee sdk.Coin, err error) {
+	// Check that from and to are reserve tokens
+	if !war.IsReserveToken(from.Denom) {
+		return nil, sdk.Coin{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, from.Denom)
+	} else if !war.IsReserveToken(toToken) {
+		return nil, sdk.Coin{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, toToken)
+	}
+
+	weights := war.FunctionParameters.AsMap()
+	inAmt := from.Amount
+	inRes := reserveBalances.AmountOf(from.Denom)
+	outRes := reserveBalances.AmountOf(toToken)
+
+	// Calculate fee to get the adjusted input amount
+	txFee = war.GetTxFee(sdk.NewDecCoinFromCoin(from))
+	inAmt = inAmt.Sub(txFee.Amount) // adjusted input
+
+	// Check that at least 1 token is going in
+	if inAmt.IsZero() {
+		return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", from.Denom, toToken)
+	}
+
+	// Calculate output amount using Balancer formula:
+	// Δy = y*(1-(x/(x+Δx))^(wx/wy)), where wx and wy are the token weights
+	temp1 := inRes.ToDec().Quo(inRes.Add(inAmt).ToDec())
+	temp2, err := Pow(temp1, weights[from.Denom].Quo(weights[toToken]))
+	if err != nil {
+		return nil, sdk.Coin{}, err
+	}
+	outAmt := outRes.ToDec().Mul(sdk.OneDec().Sub(temp2)).TruncateInt()
+
+	// Check that not giving out all of the available outRes or nothing at all
+	if outAmt.Equal(outRes) {
+		return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountCausesReserveDepletion, "%s - %s", from.Denom, toToken)
+	} else if outAmt.IsZero() {
+		return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", from.Denom, toToken)
+	} else if outAmt.IsNegative() {
+		panic(fmt.Sprintf("negative return for swap result for war %s", war.Token))
+	}
+
+	return sdk.Coins{sdk.NewCoin(toToken, outAmt)}, txFee, nil
+}
+
+func (weightedSwapperFunction) GetSpotPrice(war War, fromToken, toToken string, reserveBalances sdk.Coins) (sdk.Dec, error) {
+	inRes, outRes, err := getSpotPriceReserves(war, fromToken, toToken, reserveBalances)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	// Marginal rate of the Balancer invariant: -dy/dx = (y/wy)/(x/wx)
+	weights := war.FunctionParameters.AsMap()
+	return outRes.Mul(weights[fromToken]).Quo(inRes.Mul(weights[toToken])), nil
+}
+
+// stableSwapFunction is a swapper for two reserve tokens that should trade
+// near 1:1, using the Curve StableSwap invariant with amplification
+// coefficient A. A higher A keeps the price closer to 1:1 for longer, and
+// as A tends to zero the function behaves like the swapper function. Adding
+// and removing liquidity is proportional to the reserve balances, as for the
+// swapper function.
+type stableSwapFunction struct{}
+
+func (stableSwapFunction) RequiredParams() []string { return []string{"A"} }
+
+func (stableSwapFunction) NoOfReserveTokens() int { return 2 }
+
+func (stableSwapFunction) ValidateParams(paramsMap map[string]sdk.Dec) error {
+	return stableSwapParameterRestrictions(paramsMap)
+}
+
+func (stableSwapFunction) IsSwapper() bool { return true }
+
+func (stableSwapFunction) GetPricesAtSupply(war War, _ sdk.Int) (sdk.DecCoins, error) {
+	return nil, sdkerrors.Wrap(ErrFunctionNotAvailableForFunctionType, war.FunctionType)
+}
+
+func (stableSwapFunction) ReserveAtSupply(War, sdk.Int) sdk.Dec {
+	panic("invalid function for function type")
+}
+
+func (stableSwapFunction) GetPricesToMint(war War, mint sdk.Int, reserveBalances sdk.Coins) (sdk.DecCoins, error) {
+	return swapperFunction{}.GetPricesToMint(war, mint, reserveBalances)
+}
+
+func (stableSwapFunction) GetReturnsForBurn(war War, burn sdk.Int, reserveBalances sdk.Coins) sdk.DecCoins {
+	return swapperFunction{}.GetReturnsForBurn(war, burn, reserveBalances)
+}
+
+func (stableSwapFunction) GetReturnsForSwap(war War, from sdk.Coin, toToken string, reserveBalances sdk.Coins) (returns sdk.Coins, txFee sdk.Coin, err error) {
+	// Check that from and to are reserve tokens
+	if !war.IsReserveToken(from.Denom) {
+		return nil, sdk.Coin{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, from.Denom)
+	} else if !war.IsReserveToken(toToken) {
+		return nil, sdk.Coin{}, sdkerrors.Wrap(ErrTokenIsNotAValidReserveToken, toToken)
+	}
+
+	A := war.FunctionParameters.AsMap()["A"]
+	inAmt := from.Amount
+	inRes := reserveBalances.AmountOf(from.Denom)
+	outRes := reserveBalances.AmountOf(toToken)
+
+	// Calculate fee to get the adjusted input amount
+	txFee = war.GetTxFee(sdk.NewDecCoinFromCoin(from))
+	inAmt = inAmt.Sub(txFee.Amount) // adjusted input
+
+	// Check that at least 1 token is going in
+	if inAmt.IsZero() {
+		return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", from.Denom, toToken)
+	}
+
+	// The invariant is only defined if both reserves are non-empty
+	if inRes.IsZero() || outRes.IsZero() {
+		return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountCausesReserveDepletion, "%s - %s", from.Denom, toToken)
+	}
+
+	// Calculate output amount by keeping the StableSwap invariant D constant:
+	// Δy = y - y', where y' is the balance of y given x+Δx and D
+	d, err := StableSwapInvariant(inRes.ToDec(), outRes.ToDec(), A)
+	if err != nil {
+		return nil, sdk.Coin{}, err
+	}
+	newOutRes, err := StableSwapBalance(inRes.Add(inAmt).ToDec(), d, A)
+	if err != nil {
+		return nil, sdk.Coin{}, err
+	}
+	outAmt := outRes.ToDec().Sub(newOutRes).TruncateInt()
+
+	// Check that not giving out all of the available outRes or nothing at all
+	if outAmt.GTE(outRes) {
+		return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountCausesReserveDepletion, "%s - %s", from.Denom, toToken)
+	} else if outAmt.IsZero() {
+		return nil, sdk.Coin{}, sdkerrors.Wrapf(ErrSwapAmountTooSmallToGiveAnyReturn, "%s - %s", from.Denom, toToken)
+	} else if outAmt.IsNegative() {
+		panic(fmt.Sprintf("negative return for swap result for war %s", war.Token))
+	}
+
+	return sdk.Coins{sdk.NewCoin(toToken, outAmt)}, txFee, nil
+}
+
+func (stableSwapFunction) GetSpotPrice(war War, fromToken, toToken string, reserveBalances sdk.Coins) (sdk.Dec, error) {
+	inRes, outRes, err := getSpotPriceReserves(war, fromToken, toToken, reserveBalances)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	A := war.FunctionParameters.AsMap()["A"]
+	d, err := StableSwapInvariant(inRes, outRes, A)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	// Marginal rate from differentiating the invariant
+	// 4A(x+y) + D = 4AD + D^3/(4xy) implicitly:
+	// -dy/dx = (4A + dP/x) / (4A + dP/y), where dP = D^3/(4xy)
+	ann := A.MulInt64(4)
+	dP := d.Mul(d).Quo(inRes.MulInt64(2)).Mul(d).Quo(outRes.MulInt64(2))
+	return ann.Add(dP.Quo(inRes)).Quo(ann.Add(dP.Quo(outRes))), nil
+}
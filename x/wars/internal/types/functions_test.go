@@ -0,0 +1,544 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const constantFunction = "constant_function"
+
+// constantFunctionType is a custom function type with a fixed price "p"
+type constantFunctionType struct{ BaseCurveFunction }
+
+func (constantFunctionType) RequiredParams() []string { return []string{"p"} }
+
+func (constantFunctionType) ValidateParams(paramsMap map[string]sdk.Dec) error {
+	if !paramsMap["p"].IsPositive() {
+		return ErrArgumentMustBePositive
+	}
+	return nil
+}
+
+func (constantFunctionType) GetPricesAtSupply(war War, _ sdk.Int) (sdk.DecCoins, error) {
+	return war.GetNewReserveDecCoins(war.FunctionParameters.AsMap()["p"]), nil
+}
+
+func (constantFunctionType) ReserveAtSupply(war War, supply sdk.Int) sdk.Dec {
+	return war.FunctionParameters.AsMap()["p"].MulInt(supply)
+}
+
+func registerConstantFunction() (deregister func()) {
+	RegisterCurveFunction(constantFunction, constantFunctionType{})
+	return func() { delete(curveFunctions, constantFunction) }
+}
+
+func TestBuiltInFunctionTypesRegistered(t *testing.T) {
+	for _, fnType := range []string{PowerFunction, SigmoidFunction,
+		SwapperFunction, AugmentedFunction, ExponentialFunction,
+		LogarithmicFunction, BancorFunction, PiecewiseLinearFunction,
+		WeightedSwapperFunction, StableSwapFunction} {
+		_, err := GetCurveFunction(fnType)
+		require.Nil(t, err)
+	}
+
+	_, err := GetCurveFunction("invalid_function_type")
+	require.Error(t, err)
+}
+
+func TestRegisterCurveFunctionTwicePanics(t *testing.T) {
+	require.Panics(t, func() {
+		RegisterCurveFunction(PowerFunction, powerFunction{})
+	})
+}
+
+func TestRegisteredFunctionTypeValidation(t *testing.T) {
+	defer registerConstantFunction()()
+	require.Contains(t, GetRegisteredFunctionTypes(), constantFunction)
+
+	valid := FunctionParams{NewFunctionParam("p", sdk.NewDec(2))}
+	require.Nil(t, valid.Validate(constantFunction))
+
+	zero := FunctionParams{NewFunctionParam("p", sdk.ZeroDec())}
+	require.Error(t, zero.Validate(constantFunction))
+
+	missing := FunctionParams{NewFunctionParam("q", sdk.NewDec(2))}
+	require.Error(t, missing.Validate(constantFunction))
+
+	require.Nil(t, CheckNoOfReserveTokens(multitokenReserve(), constantFunction))
+}
+
+func TestRegisteredFunctionTypePricing(t *testing.T) {
+	defer registerConstantFunction()()
+
+	war := getValidWar()
+	war.FunctionType = constantFunction
+	war.FunctionParameters = FunctionParams{NewFunctionParam("p", sdk.NewDec(2))}
+	war.ReserveTokens = multitokenReserve()
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 100)
+	reserveBalances := newDecMultitokenReserveFromInt(200)
+	reserveBalancesInt, _ := reserveBalances.TruncateDecimal()
+
+	require.False(t, war.IsSwapper())
+
+	prices, err := war.GetCurrentPricesPT(reserveBalancesInt)
+	require.Nil(t, err)
+	require.Equal(t, newDecMultitokenReserveFromInt(2), prices)
+
+	prices, err = war.GetPricesToMint(sdk.NewInt(10), reserveBalancesInt)
+	require.Nil(t, err)
+	require.Equal(t, newDecMultitokenReserveFromInt(20), prices)
+
+	returns := war.GetReturnsForBurn(sdk.NewInt(10), reserveBalancesInt)
+	require.Equal(t, newDecMultitokenReserveFromInt(20), returns)
+
+	_, _, err = war.GetReturnsForSwap(sdk.NewInt64Coin(reserveToken, 10),
+		reserveToken2, reserveBalancesInt)
+	require.Error(t, err)
+}
+
+func TestExponentialFunctionPricing(t *testing.T) {
+	war := getValidWar()
+	war.FunctionType = ExponentialFunction
+	war.FunctionParameters = functionParametersExponential()
+	war.ReserveTokens = multitokenReserve()
+
+	// Price at supply 100 is 2*e^(0.01*100) = 2e
+	prices, err := war.GetPricesAtSupply(sdk.NewInt(100))
+	require.Nil(t, err)
+	requireApproxEqual(t, "5.436563656918090470", prices[0].Amount)
+
+	// Reserve at supply 100 is (2/0.01)*(e^(0.01*100)-1) = 200(e-1)
+	reserve := war.ReserveAtSupply(sdk.NewInt(100))
+	requireApproxEqual(t, "343.656365691809047072", reserve)
+	require.Equal(t, sdk.ZeroDec(), war.ReserveAtSupply(sdk.ZeroInt()))
+
+	// Minting up to a supply for which b*x exceeds the max argument fails
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 100)
+	_, err = war.GetPricesToMint(sdk.NewInt(100000), nil)
+	require.Error(t, err)
+	_, err = war.GetPricesAtSupply(sdk.NewInt(100000))
+	require.Error(t, err)
+
+	prices, err = war.GetPricesToMint(sdk.NewInt(100), nil)
+	require.Nil(t, err)
+	require.Equal(t, war.GetNewReserveDecCoins(
+		war.ReserveAtSupply(sdk.NewInt(200))), prices)
+}
+
+func TestLogarithmicFunctionPricing(t *testing.T) {
+	war := getValidWar()
+	war.FunctionType = LogarithmicFunction
+	war.FunctionParameters = functionParametersLogarithmic()
+	war.ReserveTokens = multitokenReserve()
+
+	// Price at supply 90 is 10*ln(1+0.1*90)+1 = 10*ln(10)+1
+	prices, err := war.GetPricesAtSupply(sdk.NewInt(90))
+	require.Nil(t, err)
+	requireApproxEqual(t, "24.025850929940456840", prices[0].Amount)
+
+	// Reserve at supply 90 is 10*(10*ln(10)-9)/0.1 + 1*90
+	reserve := war.ReserveAtSupply(sdk.NewInt(90))
+	requireApproxEqual(t, "1492.585092994045684018", reserve)
+	require.Equal(t, sdk.ZeroDec(), war.ReserveAtSupply(sdk.ZeroInt()))
+
+	// Price at supply 0 is just c
+	prices, err = war.GetPricesAtSupply(sdk.ZeroInt())
+	require.Nil(t, err)
+	require.Equal(t, newDecMultitokenReserveFromInt(1), prices)
+}
+
+func TestBancorFunctionPricing(t *testing.T) {
+	war := getValidWar()
+	war.FunctionType = BancorFunction
+	war.FunctionParameters = functionParametersBancor() // r=0.5, p0=1, s0=100
+	war.ReserveTokens = multitokenReserve()
+
+	// Price at s0 is p0, and with r=0.5 the price at 2*s0 is 2*p0
+	prices, err := war.GetPricesAtSupply(sdk.NewInt(100))
+	require.Nil(t, err)
+	requireApproxEqual(t, "1", prices[0].Amount)
+	prices, err = war.GetPricesAtSupply(sdk.NewInt(200))
+	require.Nil(t, err)
+	requireApproxEqual(t, "2", prices[0].Amount)
+
+	// Reserve at supply 200 is 0.5*1*100*(200/100)^2
+	requireApproxEqual(t, "200", war.ReserveAtSupply(sdk.NewInt(200)))
+	require.Equal(t, sdk.ZeroDec(), war.ReserveAtSupply(sdk.ZeroInt()))
+
+	// First buy is priced using the reserve function
+	prices, err = war.GetPricesToMint(sdk.NewInt(100), nil)
+	require.Nil(t, err)
+	requireApproxEqual(t, "50", prices[0].Amount)
+
+	// Buys and sells are priced from the reserve balance: 50*((200/100)^2-1)
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 100)
+	reserveBalances, _ := newDecMultitokenReserveFromInt(50).TruncateDecimal()
+	prices, err = war.GetPricesToMint(sdk.NewInt(100), reserveBalances)
+	require.Nil(t, err)
+	requireApproxEqual(t, "150", prices[0].Amount)
+
+	// Overpaid reserve is shared proportionally: 100*(1-(50/100)^2)
+	reserveBalances, _ = newDecMultitokenReserveFromInt(100).TruncateDecimal()
+	returns := war.GetReturnsForBurn(sdk.NewInt(50), reserveBalances)
+	requireApproxEqual(t, "75", returns[0].Amount)
+	returns = war.GetReturnsForBurn(sdk.NewInt(100), reserveBalances)
+	requireApproxEqual(t, "100", returns[0].Amount)
+}
+
+func TestBancorFunctionFractionalReserveRatio(t *testing.T) {
+	war := getValidWar()
+	war.FunctionType = BancorFunction
+	war.FunctionParameters = FunctionParams{
+		NewFunctionParam("r", sdk.NewDecWithPrec(3, 1)),
+		NewFunctionParam("p0", sdk.NewDec(1)),
+		NewFunctionParam("s0", sdk.NewDec(100))}
+	war.ReserveTokens = multitokenReserve()
+	supply := sdk.NewInt(200)
+
+	// Price at supply 200 is (200/100)^(1/0.3-1)
+	prices, err := war.GetPricesAtSupply(supply)
+	require.Nil(t, err)
+	requireApproxEqual(t, "5.039684199579492659", prices[0].Amount)
+
+	// Reserve at supply 200 is 0.3*1*100*(200/100)^(1/0.3)
+	reserve := war.ReserveAtSupply(supply)
+	requireApproxEqual(t, "302.381051974769559544", reserve)
+
+	// The reserve is always r*supply*price
+	requireApproxEqual(t, reserve.String(),
+		sdk.NewDecWithPrec(3, 1).MulInt(supply).Mul(prices[0].Amount))
+}
+
+func TestPiecewiseLinearFunctionValidation(t *testing.T) {
+	// Any number (at least two) of breakpoints is accepted
+	require.Nil(t, functionParametersPiecewiseLinear().Validate(PiecewiseLinearFunction))
+	require.Nil(t, functionParametersPiecewiseLinear()[:4].Validate(PiecewiseLinearFunction))
+
+	testCases := []struct {
+		params      []string
+		expectError bool
+	}{
+		{[]string{"x0", "0", "p0", "1", "x1", "10", "p1", "0"}, false},  // zero price allowed
+		{[]string{"x0", "0", "p0", "1"}, true},                          // single breakpoint
+		{[]string{"x0", "0", "p0", "1", "x1", "10"}, true},              // missing p1
+		{[]string{"x0", "0", "p0", "1", "x1", "10", "q1", "1"}, true},   // q1 instead of p1
+		{[]string{"x0", "1", "p0", "1", "x1", "10", "p1", "2"}, true},   // non-zero x0
+		{[]string{"x0", "0", "p0", "1", "x1", "0", "p1", "2"}, true},    // x1 == x0
+		{[]string{"x0", "0", "p0", "1", "x2", "10", "p2", "2"}, true},   // x1 skipped
+		{[]string{"x0", "0", "p0", "1", "x1", "10", "p1", "-2"}, true},  // negative price
+		{[]string{"x0", "0", "p0", "1", "x1", "0.5", "p1", "2"}, false}, // float supply
+	}
+	for _, tc := range testCases {
+		var fps FunctionParams
+		for i := 0; i < len(tc.params); i += 2 {
+			fps = append(fps, NewFunctionParam(
+				tc.params[i], sdk.MustNewDecFromStr(tc.params[i+1])))
+		}
+
+		err := fps.Validate(PiecewiseLinearFunction)
+		if tc.expectError {
+			require.Error(t, err)
+		} else {
+			require.Nil(t, err)
+		}
+	}
+}
+
+func TestPiecewiseLinearFunctionPricing(t *testing.T) {
+	war := getValidWar()
+	war.FunctionType = PiecewiseLinearFunction
+	war.FunctionParameters = functionParametersPiecewiseLinear()
+	war.ReserveTokens = multitokenReserve()
+
+	testCases := []struct {
+		supply          int64
+		expectedPrice   string
+		expectedReserve string
+	}{
+		{0, "1", "0"},
+		{50, "1", "50"},     // plateau
+		{100, "1", "100"},   // breakpoint
+		{150, "2", "175"},   // 100 + 50*(1+2)/2
+		{200, "3", "300"},   // 100 + 100*(1+3)/2
+		{260, "2.7", "471"}, // 300 + 60*(3+2.7)/2
+		{300, "2.5", "575"}, // 300 + 100*(3+2.5)/2
+		{400, "2.5", "825"}, // 575 + 100*2.5
+	}
+	for _, tc := range testCases {
+		prices, err := war.GetPricesAtSupply(sdk.NewInt(tc.supply))
+		require.Nil(t, err)
+		require.Equal(t, newDecMultitokenReserveFromDec(
+			sdk.MustNewDecFromStr(tc.expectedPrice)), prices)
+
+		reserve := war.ReserveAtSupply(sdk.NewInt(tc.supply))
+		require.Equal(t, sdk.MustNewDecFromStr(tc.expectedReserve), reserve)
+	}
+
+	// Prices to mint and returns for burn are derived from the reserve
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 100)
+	reserveBalances, _ := newDecMultitokenReserveFromInt(100).TruncateDecimal()
+	prices, err := war.GetPricesToMint(sdk.NewInt(100), reserveBalances)
+	require.Nil(t, err)
+	require.Equal(t, newDecMultitokenReserveFromInt(200), prices)
+
+	returns := war.GetReturnsForBurn(sdk.NewInt(50), reserveBalances)
+	require.Equal(t, newDecMultitokenReserveFromInt(50), returns)
+}
+
+func TestWeightedSwapperFunctionValidation(t *testing.T) {
+	require.Nil(t, functionParametersWeightedSwapper().Validate(WeightedSwapperFunction))
+
+	// At least two weights are required and all weights must be positive
+	require.Error(t, FunctionParams{}.Validate(WeightedSwapperFunction))
+	require.Error(t, functionParametersWeightedSwapper()[:1].Validate(WeightedSwapperFunction))
+	zeroWeight := FunctionParams{
+		NewFunctionParam(reserveToken, sdk.NewDec(1)),
+		NewFunctionParam(reserveToken2, sdk.ZeroDec())}
+	require.Error(t, zeroWeight.Validate(WeightedSwapperFunction))
+
+	// Weights must match the reserve tokens
+	fps := functionParametersWeightedSwapper()
+	require.Nil(t, CheckFunctionParamsForReserveTokens(
+		fps, weightedSwapperReserves(), WeightedSwapperFunction))
+	require.Error(t, CheckFunctionParamsForReserveTokens(
+		fps, swapperReserves(), WeightedSwapperFunction))
+	require.Error(t, CheckFunctionParamsForReserveTokens(
+		fps, []string{reserveToken, reserveToken2, "rex"}, WeightedSwapperFunction))
+}
+
+func TestWeightedSwapperFunctionLiquidity(t *testing.T) {
+	war := getValidWar()
+	war.FunctionType = WeightedSwapperFunction
+	war.FunctionParameters = functionParametersWeightedSwapper()
+	war.ReserveTokens = weightedSwapperReserves()
+	war.CurrentSupply = sdk.NewInt64Coin(war.Token, 10)
+
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 2000),
+		sdk.NewInt64Coin(reserveToken3, 3000))
+
+	require.True(t, war.IsSwapper())
+	_, err := war.GetPricesAtSupply(sdk.NewInt(10))
+	require.Error(t, err)
+
+	// Adding and removing liquidity is proportional to all reserve balances
+	expected := sdk.NewDecCoinsFromCoins(
+		sdk.NewInt64Coin(reserveToken, 100),
+		sdk.NewInt64Coin(reserveToken2, 200),
+		sdk.NewInt64Coin(reserveToken3, 300))
+	prices, err := war.GetPricesToMint(sdk.OneInt(), reserveBalances)
+	require.Nil(t, err)
+	require.Equal(t, expected, prices)
+	require.Equal(t, expected, war.GetReturnsForBurn(sdk.OneInt(), reserveBalances))
+}
+
+func TestWeightedSwapperFunctionSwap(t *testing.T) {
+	war := getValidWar()
+	war.FunctionType = WeightedSwapperFunction
+	war.FunctionParameters = functionParametersWeightedSwapper()
+	war.ReserveTokens = weightedSwapperReserves()
+	war.TxFeePercentage = sdk.ZeroDec()
+
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 2000),
+		sdk.NewInt64Coin(reserveToken3, 3000))
+
+	testCases := []struct {
+		from           string
+		to             string
+		amount         int64
+		expectedReturn int64
+	}{
+		// Equal weights: 2000*(1-1000/1100), same as swapper function
+		{reserveToken, reserveToken2, 100, 181},
+		// Weights 1:2 : 3000*(1-(1000/1100)^(1/2))
+		{reserveToken, reserveToken3, 100, 139},
+		// Weights 2:1 : 1000*(1-(3000/3200)^2)
+		{reserveToken3, reserveToken, 200, 121},
+	}
+	for _, tc := range testCases {
+		returns, fee, err := war.GetReturnsForSwap(
+			sdk.NewInt64Coin(tc.from, tc.amount), tc.to, reserveBalances)
+		require.Nil(t, err)
+		require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(tc.to, tc.expectedReturn)), returns)
+		require.True(t, fee.IsZero())
+	}
+
+	// Swapping to or from a non-reserve token fails
+	_, _, err := war.GetReturnsForSwap(
+		sdk.NewInt64Coin(reserveToken, 100), "dummytoken", reserveBalances)
+	require.Error(t, err)
+	_, _, err = war.GetReturnsForSwap(
+		sdk.NewInt64Coin("dummytoken", 100), reserveToken, reserveBalances)
+	require.Error(t, err)
+
+	// Swapping too little to give any return fails
+	_, _, err = war.GetReturnsForSwap(
+		sdk.NewInt64Coin(reserveToken3, 1), reserveToken, reserveBalances)
+	require.Error(t, err)
+
+	// Sanity rate is not applied for more than two reserve tokens
+	war.SanityRate = sdk.OneDec()
+	require.False(t, war.ReservesViolateSanityRate(reserveBalances))
+}
+
+func TestStableSwapFunctionValidation(t *testing.T) {
+	require.Nil(t, functionParametersStableSwap().Validate(StableSwapFunction))
+
+	// A must be positive
+	zeroA := FunctionParams{NewFunctionParam("A", sdk.ZeroDec())}
+	require.Error(t, zeroA.Validate(StableSwapFunction))
+
+	// Exactly two reserve tokens are required
+	require.Nil(t, CheckNoOfReserveTokens(swapperReserves(), StableSwapFunction))
+	require.Error(t, CheckNoOfReserveTokens(weightedSwapperReserves(), StableSwapFunction))
+}
+
+func TestStableSwapFunctionSwap(t *testing.T) {
+	war := getValidWar()
+	war.FunctionType = StableSwapFunction
+	war.FunctionParameters = functionParametersStableSwap()
+	war.ReserveTokens = swapperReserves()
+	war.TxFeePercentage = sdk.ZeroDec()
+
+	require.True(t, war.IsSwapper())
+
+	testCases := []struct {
+		reserve1       int64
+		reserve2       int64
+		A              int64
+		amount         int64
+		expectedReturn int64
+	}{
+		// Balanced pool with high A gives close to 1:1, compared to 909 for
+		// the swapper function
+		{10000, 10000, 100, 1000, 999},
+		// Lower A gives more slippage
+		{10000, 10000, 1, 1000, 967},
+		// Imbalanced pool
+		{10000, 5000, 10, 1000, 948},
+		// Large swaps still give increasing slippage
+		{10000, 10000, 100, 9000, 8825},
+	}
+	for _, tc := range testCases {
+		war.FunctionParameters = FunctionParams{
+			NewFunctionParam("A", sdk.NewDec(tc.A))}
+		reserveBalances := sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, tc.reserve1),
+			sdk.NewInt64Coin(reserveToken2, tc.reserve2))
+
+		returns, fee, err := war.GetReturnsForSwap(
+			sdk.NewInt64Coin(reserveToken, tc.amount), reserveToken2, reserveBalances)
+		require.Nil(t, err)
+		require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, tc.expectedReturn)), returns)
+		require.True(t, fee.IsZero())
+	}
+
+	// Swapping from an empty reserve or to a non-reserve token fails
+	_, _, err := war.GetReturnsForSwap(sdk.NewInt64Coin(reserveToken, 100),
+		reserveToken2, sdk.NewCoins(sdk.NewInt64Coin(reserveToken2, 100)))
+	require.Error(t, err)
+	_, _, err = war.GetReturnsForSwap(sdk.NewInt64Coin(reserveToken, 100),
+		"dummytoken", sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100)))
+	require.Error(t, err)
+}
+
+func TestSwapperFunctionsSpotPrice(t *testing.T) {
+	testCases := []struct {
+		functionType  string
+		params        FunctionParams
+		reserveTokens []string
+		from          string
+		to            string
+		expectedPrice sdk.Dec
+	}{
+		// Swapper function: 2000/1000
+		{SwapperFunction, nil, swapperReserves(),
+			reserveToken, reserveToken2, sdk.NewDec(2)},
+		// Weighted swapper with weights 1:2 : (3000/2)/(1000/1)
+		{WeightedSwapperFunction, functionParametersWeightedSwapper(),
+			weightedSwapperReserves(), reserveToken, reserveToken3, sdk.NewDecWithPrec(15, 1)},
+	}
+	for _, tc := range testCases {
+		war := getValidWar()
+		war.FunctionType = tc.functionType
+		war.FunctionParameters = tc.params
+		war.ReserveTokens = tc.reserveTokens
+		war.TxFeePercentage = sdk.ZeroDec()
+
+		reserveBalances := sdk.NewCoins(
+			sdk.NewInt64Coin(reserveToken, 1000),
+			sdk.NewInt64Coin(reserveToken2, 2000),
+			sdk.NewInt64Coin(reserveToken3, 3000))
+		price, err := war.GetSpotPrice(tc.from, tc.to, reserveBalances)
+		require.Nil(t, err)
+		require.Equal(t, tc.expectedPrice, price)
+	}
+
+	// The spot price approximates the rate for small swaps in large pools
+	war := getValidWar()
+	war.FunctionType = StableSwapFunction
+	war.FunctionParameters = functionParametersStableSwap()
+	war.ReserveTokens = swapperReserves()
+	war.TxFeePercentage = sdk.ZeroDec()
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000000000000),
+		sdk.NewInt64Coin(reserveToken2, 500000000000))
+	price, err := war.GetSpotPrice(reserveToken, reserveToken2, reserveBalances)
+	require.Nil(t, err)
+	require.True(t, price.LT(sdk.OneDec()))
+	returns, _, err := war.GetReturnsForSwap(
+		sdk.NewInt64Coin(reserveToken, 1000000), reserveToken2, reserveBalances)
+	require.Nil(t, err)
+	rate := returns.AmountOf(reserveToken2).ToDec().QuoInt64(1000000)
+	require.True(t, price.Sub(rate).Abs().LT(sdk.NewDecWithPrec(1, 4)))
+
+	// Balanced stable swap pool gives a price of 1
+	balanced := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 1000))
+	price, err = war.GetSpotPrice(reserveToken, reserveToken2, balanced)
+	require.Nil(t, err)
+	require.True(t, price.Sub(sdk.OneDec()).Abs().LT(sdk.NewDecWithPrec(1, 9)))
+
+	// Empty reserves or non-swapper function types give no spot price
+	_, err = war.GetSpotPrice(reserveToken, reserveToken2,
+		sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000)))
+	require.Error(t, err)
+	_, err = getValidPowerFunctionWar().GetSpotPrice(reserveToken, reserveToken2, balanced)
+	require.Error(t, err)
+}
+
+func TestSwapperFunctionsSpotPrices(t *testing.T) {
+	reserveBalances := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 1000),
+		sdk.NewInt64Coin(reserveToken2, 2000),
+		sdk.NewInt64Coin(reserveToken3, 3000))
+
+	// Swapper function: price of res in terms of rez
+	war := getValidWar()
+	war.FunctionType = SwapperFunction
+	war.FunctionParameters = nil
+	war.ReserveTokens = swapperReserves()
+	prices, err := war.GetSpotPrices(reserveBalances)
+	require.Nil(t, err)
+	require.Equal(t, sdk.DecCoins{sdk.NewDecCoin(reserveToken2, sdk.NewInt(2))}, prices)
+
+	// Weighted swapper function: prices of res in terms of rez and rec, with
+	// weights 1:1:2, i.e. (2000/1)/(1000/1) and (3000/2)/(1000/1)
+	war.FunctionType = WeightedSwapperFunction
+	war.FunctionParameters = functionParametersWeightedSwapper()
+	war.ReserveTokens = weightedSwapperReserves()
+	prices, err = war.GetSpotPrices(reserveBalances)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDecCoins(
+		sdk.NewDecCoin(reserveToken2, sdk.NewInt(2)),
+		sdk.NewDecCoinFromDec(reserveToken3, sdk.NewDecWithPrec(15, 1))), prices)
+
+	// Empty reserves give no spot prices
+	_, err = war.GetSpotPrices(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000)))
+	require.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FundingPool holds the funding raised by a war that has a funding pool, i.e.
+// the funding released at the end of a successful hatch and the fees charged
+// by the war, together with the amount spent in the current spending epoch.
+// The coins themselves are held by the module until spent by a spender.
+type FundingPool struct {
+	Token            string    `json:"token" yaml:"token"`
+	Balance          sdk.Coins `json:"balance" yaml:"balance"`
+	EpochStartHeight int64     `json:"epoch_start_height" yaml:"epoch_start_height"`
+	SpentInEpoch     sdk.Coins `json:"spent_in_epoch" yaml:"spent_in_epoch"`
+}
+
+func NewFundingPool(token string) FundingPool {
+	return FundingPool{
+		Token:            token,
+		Balance:          nil,
+		EpochStartHeight: 0,
+		SpentInEpoch:     nil,
+	}
+}
+
+// SpentInEpochStartingAt returns the amount spent in the epoch that starts at
+// the specified height, which is zero if nothing was spent in that epoch yet
+func (fp FundingPool) SpentInEpochStartingAt(epochStartHeight int64) sdk.Coins {
+	if fp.EpochStartHeight != epochStartHeight {
+		return nil
+	}
+	return fp.SpentInEpoch
+}
+
+// Allowance returns the amount that can still be spent in the epoch that
+// starts at the specified height, given the spend limit per epoch
+func (fp FundingPool) Allowance(spendLimit sdk.Coins, epochStartHeight int64) sdk.Coins {
+	spent := fp.SpentInEpochStartingAt(epochStartHeight)
+	allowance := sdk.NewCoins()
+	for _, l := range spendLimit {
+		remaining := l.Amount.Sub(spent.AmountOf(l.Denom))
+		if remaining.IsPositive() {
+			allowance = allowance.Add(sdk.NewCoin(l.Denom, remaining))
+		}
+	}
+	return allowance
+}
+
+// Spend returns the funding pool with the amount removed from the balance and
+// added to the amount spent in the epoch that starts at the specified height
+func (fp FundingPool) Spend(amount sdk.Coins, epochStartHeight int64) FundingPool {
+	fp.SpentInEpoch = fp.SpentInEpochStartingAt(epochStartHeight).Add(amount...)
+	fp.EpochStartHeight = epochStartHeight
+	fp.Balance = fp.Balance.Sub(amount)
+	return fp
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+func TestFundingPoolAllowanceAndSpend(t *testing.T) {
+	spendLimit := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+
+	fundingPool := NewFundingPool(initToken)
+	fundingPool.Balance = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000))
+	require.Equal(t, spendLimit, fundingPool.Allowance(spendLimit, 10))
+
+	// Spending reduces the balance and the allowance for the epoch
+	spent := fundingPool.Spend(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 30)), 10)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 970)), spent.Balance)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 70)), spent.Allowance(spendLimit, 10))
+
+	spent = spent.Spend(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 70)), 10)
+	require.True(t, spent.Allowance(spendLimit, 10).IsZero())
+
+	// Allowance is reset in the next epoch
+	require.Equal(t, spendLimit, spent.Allowance(spendLimit, 20))
+	spent = spent.Spend(sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10)), 20)
+	require.Equal(t, int64(20), spent.EpochStartHeight)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10)), spent.SpentInEpoch)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 890)), spent.Balance)
+
+	// Original funding pool is left unchanged
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000)), fundingPool.Balance)
+	require.True(t, fundingPool.SpentInEpoch.IsZero())
+}
+
+func TestWarFundingPoolSpendersAndEpochs(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+
+	war := getValidWar()
+	require.False(t, war.HasFundingPool())
+
+	war.FundingPoolSpenders = []sdk.AccAddress{initCreator}
+	war.FundingPoolEpochBlocks = 10
+	require.True(t, war.HasFundingPool())
+	require.True(t, war.IsFundingPoolSpender(initCreator))
+	require.False(t, war.IsFundingPoolSpender(address))
+
+	require.Equal(t, int64(0), war.GetFundingPoolEpochStart(0))
+	require.Equal(t, int64(0), war.GetFundingPoolEpochStart(9))
+	require.Equal(t, int64(10), war.GetFundingPoolEpochStart(10))
+	require.Equal(t, int64(20), war.GetFundingPoolEpochStart(25))
+}
@@ -1,16 +1,31 @@
 package types
 
 type GenesisState struct {
-	Wars   []War  `json:"wars" yaml:"wars"`
-	Batches []Batch `json:"batches" yaml:"batches"`
-	Params  Params  `json:"params" yaml:"params"`
+	Wars               []War                `json:"wars" yaml:"wars"`
+	Batches            []Batch              `json:"batches" yaml:"batches"`
+	OrderBooks         []OrderBook          `json:"order_books" yaml:"order_books"`
+	HatchContributions []HatchContributions `json:"hatch_contributions" yaml:"hatch_contributions"`
+	HatchVestings      []HatchVestings      `json:"hatch_vestings" yaml:"hatch_vestings"`
+	FundingPools       []FundingPool        `json:"funding_pools" yaml:"funding_pools"`
+	Proposals          []Proposals          `json:"proposals" yaml:"proposals"`
+	PendingEdits       []PendingEdits       `json:"pending_edits" yaml:"pending_edits"`
+	Params             Params               `json:"params" yaml:"params"`
 }
 
-func NewGenesisState(wars []War, batches []Batch, params Params) GenesisState {
+func NewGenesisState(wars []War, batches []Batch, orderBooks []OrderBook,
+	hatchContributions []HatchContributions, hatchVestings []HatchVestings,
+	fundingPools []FundingPool, proposals []Proposals,
+	pendingEdits []PendingEdits, params Params) GenesisState {
 	return GenesisState{
-		Wars:   wars,
-		Batches: batches,
-		Params:  params,
+		Wars:               wars,
+		Batches:            batches,
+		OrderBooks:         orderBooks,
+		HatchContributions: hatchContributions,
+		HatchVestings:      hatchVestings,
+		FundingPools:       fundingPools,
+		Proposals:          proposals,
+		PendingEdits:       pendingEdits,
+		Params:             params,
 	}
 }
 
@@ -24,8 +39,14 @@ func ValidateGenesis(data GenesisState) error {
 
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		Wars:   nil,
-		Batches: nil,
-		Params:  DefaultParams(),
+		Wars:               nil,
+		Batches:            nil,
+		OrderBooks:         nil,
+		HatchContributions: nil,
+		HatchVestings:      nil,
+		FundingPools:       nil,
+		Proposals:          nil,
+		PendingEdits:       nil,
+		Params:             DefaultParams(),
 	}
 }
@@ -0,0 +1,167 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	SpendProposalType = "spend"
+	EditProposalType  = "edit"
+
+	YesVoteOption = "yes"
+	NoVoteOption  = "no"
+
+	ProposalResultPassed   = "passed"
+	ProposalResultRejected = "rejected"
+	ProposalResultFailed   = "failed"
+)
+
+// Vote is a vote on a proposal, which is weighted by the voter's voting power
+// when the vote was cast
+type Vote struct {
+	ProposalID uint64         `json:"proposal_id" yaml:"proposal_id"`
+	Voter      sdk.AccAddress `json:"voter" yaml:"voter"`
+	Option     string         `json:"option" yaml:"option"`
+	Power      sdk.Int        `json:"power" yaml:"power"`
+}
+
+func NewVote(proposalID uint64, voter sdk.AccAddress, option string, power sdk.Int) Vote {
+	return Vote{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Option:     option,
+		Power:      power,
+	}
+}
+
+// VotingEscrow holds the war tokens of a voter that are escrowed until all of
+// the proposals that the voter voted on are tallied, so that the tokens that
+// were counted in a vote cannot be transferred and counted again in a vote by
+// another address
+type VotingEscrow struct {
+	Voter  sdk.AccAddress `json:"voter" yaml:"voter"`
+	Amount sdk.Int        `json:"amount" yaml:"amount"`
+}
+
+func NewVotingEscrow(voter sdk.AccAddress, amount sdk.Int) VotingEscrow {
+	return VotingEscrow{
+		Voter:  voter,
+		Amount: amount,
+	}
+}
+
+func IsValidVoteOption(option string) bool {
+	return option == YesVoteOption || option == NoVoteOption
+}
+
+// Proposal is a proposal by a war token holder to either spend from the war's
+// funding pool (spend proposal) or to edit the war (edit proposal), which the
+// war's token holders vote on until the voting end height. The votes on the
+// proposal are stored separately from the proposal.
+type Proposal struct {
+	ID              uint64         `json:"id" yaml:"id"`
+	ProposalType    string         `json:"proposal_type" yaml:"proposal_type"`
+	Proposer        sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	Recipient       sdk.AccAddress `json:"recipient" yaml:"recipient"`
+	Amount          sdk.Coins      `json:"amount" yaml:"amount"`
+	Edit            WarEdit        `json:"edit" yaml:"edit"`
+	VotingEndHeight int64          `json:"voting_end_height" yaml:"voting_end_height"`
+}
+
+func NewSpendProposal(proposer, recipient sdk.AccAddress, amount sdk.Coins,
+	votingEndHeight int64) Proposal {
+	return newProposal(SpendProposalType, proposer, recipient, amount,
+		WarEdit{}, votingEndHeight)
+}
+
+func NewEditProposal(proposer sdk.AccAddress, edit WarEdit,
+	votingEndHeight int64) Proposal {
+	return newProposal(EditProposalType, proposer, nil, nil,
+		edit, votingEndHeight)
+}
+
+func newProposal(proposalType string, proposer, recipient sdk.AccAddress,
+	amount sdk.Coins, edit WarEdit, votingEndHeight int64) Proposal {
+	return Proposal{
+		ProposalType:    proposalType,
+		Proposer:        proposer,
+		Recipient:       recipient,
+		Amount:          amount,
+		Edit:            edit,
+		VotingEndHeight: votingEndHeight,
+	}
+}
+
+// IsVotingEnded returns true if the proposal's voting period ends at or before
+// the specified block height, in which case the proposal is tallied at the end
+// of the block and can no longer be voted on afterwards
+func (p Proposal) IsVotingEnded(height int64) bool { return height >= p.VotingEndHeight }
+
+// TallyResult is the total voting power of the yes votes and of the no votes
+// cast on a proposal
+type TallyResult struct {
+	Yes sdk.Int `json:"yes" yaml:"yes"`
+	No  sdk.Int `json:"no" yaml:"no"`
+}
+
+func NewTallyResult() TallyResult {
+	return TallyResult{
+		Yes: sdk.ZeroInt(),
+		No:  sdk.ZeroInt(),
+	}
+}
+
+// Add returns the tally result with the vote's voting power added to the
+// voting power of the vote's option
+func (t TallyResult) Add(vote Vote) TallyResult {
+	if vote.Option == YesVoteOption {
+		t.Yes = t.Yes.Add(vote.Power)
+	} else {
+		t.No = t.No.Add(vote.Power)
+	}
+	return t
+}
+
+// IsPassed returns true if the votes cast are at least the quorum percentage
+// of the total voting power and if the yes votes exceed the threshold
+// percentage of the votes cast
+func (t TallyResult) IsPassed(totalVotingPower sdk.Int,
+	quorumPercentage, thresholdPercentage sdk.Dec) bool {
+	votesCast := t.Yes.Add(t.No)
+	if votesCast.IsZero() {
+		return false
+	}
+
+	hundred := sdk.NewDec(100)
+	quorum := totalVotingPower.ToDec().Mul(quorumPercentage).Quo(hundred)
+	if votesCast.ToDec().LT(quorum) {
+		return false
+	}
+
+	threshold := votesCast.ToDec().Mul(thresholdPercentage).Quo(hundred)
+	return t.Yes.ToDec().GT(threshold)
+}
+
+// Proposals holds the proposals of a war that are still being voted on, in
+// the order that they were submitted, the votes cast on them, and the voting
+// escrows of the voters. Each proposal is given an ID that is unique within
+// the war. In the store, each proposal, vote, and voting escrow is kept under
+// its own key, so Proposals is only used to export the proposals to genesis
+// and to return them in queries.
+type Proposals struct {
+	Token          string         `json:"token" yaml:"token"`
+	NextProposalID uint64         `json:"next_proposal_id" yaml:"next_proposal_id"`
+	Proposals      []Proposal     `json:"proposals" yaml:"proposals"`
+	Votes          []Vote         `json:"votes" yaml:"votes"`
+	VotingEscrows  []VotingEscrow `json:"voting_escrows" yaml:"voting_escrows"`
+}
+
+func NewProposals(token string) Proposals {
+	return Proposals{
+		Token:          token,
+		NextProposalID: 0,
+		Proposals:      nil,
+		Votes:          nil,
+		VotingEscrows:  nil,
+	}
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+func TestProposalIsVotingEnded(t *testing.T) {
+	p := NewEditProposal(initCreator, WarEdit{}, 10)
+	require.False(t, p.IsVotingEnded(9))
+	require.True(t, p.IsVotingEnded(10))
+	require.True(t, p.IsVotingEnded(11))
+}
+
+func TestTallyResultAddAndIsPassed(t *testing.T) {
+	vote := func(result TallyResult, power int64, option string) TallyResult {
+		voter := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+		return result.Add(NewVote(0, voter, option, sdk.NewInt(power)))
+	}
+
+	totalVotingPower := sdk.NewInt(100)
+	quorum := sdk.NewDec(50)    // 50% of voting power must vote
+	threshold := sdk.NewDec(50) // more than 50% of votes cast must be yes
+
+	// No votes cast
+	result := NewTallyResult()
+	require.False(t, result.IsPassed(totalVotingPower, quorum, threshold))
+	require.False(t, result.IsPassed(totalVotingPower, sdk.ZeroDec(), sdk.ZeroDec()))
+
+	// Only 40% voted (yes), which is below the quorum
+	result = vote(result, 40, YesVoteOption)
+	require.False(t, result.IsPassed(totalVotingPower, quorum, threshold))
+
+	// 70% voted (40 yes, 30 no)
+	result = vote(result, 30, NoVoteOption)
+	require.Equal(t, sdk.NewInt(40), result.Yes)
+	require.Equal(t, sdk.NewInt(30), result.No)
+	require.True(t, result.IsPassed(totalVotingPower, quorum, threshold))
+
+	// Yes votes (40 of 70) do not exceed a 60% threshold
+	require.False(t, result.IsPassed(totalVotingPower, quorum, sdk.NewDec(60)))
+
+	// Quorum is measured against the total voting power
+	require.False(t, result.IsPassed(sdk.NewInt(200), quorum, threshold))
+
+	// 100% voted (40 yes, 60 no)
+	result = vote(result, 20, NoVoteOption)
+	result = vote(result, 10, NoVoteOption)
+	require.False(t, result.IsPassed(totalVotingPower, quorum, threshold))
+	require.True(t, result.IsPassed(totalVotingPower, quorum, sdk.NewDec(39)))
+}
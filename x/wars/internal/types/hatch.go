@@ -0,0 +1,92 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// HatchContribution is the number of war tokens bought by an address during
+// the hatch phase of an augmented war.
+type HatchContribution struct {
+	Address sdk.AccAddress `json:"address" yaml:"address"`
+	Amount  sdk.Int        `json:"amount" yaml:"amount"`
+}
+
+func NewHatchContribution(address sdk.AccAddress, amount sdk.Int) HatchContribution {
+	return HatchContribution{
+		Address: address,
+		Amount:  amount,
+	}
+}
+
+// HatchContributions holds the hatch contributions of a war. These are used to
+// limit the number of war tokens that each address can buy during the hatch
+// phase. Each contribution is stored under its own key, so this is only used
+// for the genesis state.
+type HatchContributions struct {
+	Token         string              `json:"token" yaml:"token"`
+	Contributions []HatchContribution `json:"contributions" yaml:"contributions"`
+}
+
+func NewHatchContributions(token string) HatchContributions {
+	return HatchContributions{
+		Token:         token,
+		Contributions: nil,
+	}
+}
+
+// HatchVesting is the number of war tokens bought by an address during the
+// hatch phase of a war that has a vesting schedule, which are held by the
+// module until vested, and the number of these war tokens already claimed.
+type HatchVesting struct {
+	Address sdk.AccAddress `json:"address" yaml:"address"`
+	Amount  sdk.Int        `json:"amount" yaml:"amount"`
+	Claimed sdk.Int        `json:"claimed" yaml:"claimed"`
+}
+
+func NewHatchVesting(address sdk.AccAddress, amount sdk.Int) HatchVesting {
+	return HatchVesting{
+		Address: address,
+		Amount:  amount,
+		Claimed: sdk.ZeroInt(),
+	}
+}
+
+// Locked returns the number of war tokens that were not claimed yet
+func (hv HatchVesting) Locked() sdk.Int {
+	return hv.Amount.Sub(hv.Claimed)
+}
+
+// Vested returns the number of war tokens vested after the specified number of
+// blocks have elapsed since vesting started. No tokens are vested before the
+// cliff, after which the tokens vest linearly until the end of the period.
+func (hv HatchVesting) Vested(elapsed, cliff, period int64) sdk.Int {
+	if elapsed < 0 || elapsed < cliff {
+		return sdk.ZeroInt()
+	} else if elapsed >= period {
+		return hv.Amount
+	}
+	return hv.Amount.MulRaw(elapsed).QuoRaw(period)
+}
+
+// Claimable returns the number of vested war tokens that were not claimed yet
+func (hv HatchVesting) Claimable(elapsed, cliff, period int64) sdk.Int {
+	return hv.Vested(elapsed, cliff, period).Sub(hv.Claimed)
+}
+
+// HatchVestings holds the hatch vestings of a war and the block height at
+// which vesting started, which is set once the war's hatch phase succeeds.
+// Each vesting and the start height are stored under their own keys, so this
+// is only used for the genesis state.
+type HatchVestings struct {
+	Token       string         `json:"token" yaml:"token"`
+	StartHeight int64          `json:"start_height" yaml:"start_height"`
+	Vestings    []HatchVesting `json:"vestings" yaml:"vestings"`
+}
+
+func NewHatchVestings(token string) HatchVestings {
+	return HatchVestings{
+		Token:       token,
+		StartHeight: 0,
+		Vestings:    nil,
+	}
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+func TestWarIsAllowedHatcher(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+
+	war := getValidWar()
+	require.False(t, war.IsHatchRestricted())
+
+	war.AllowedHatchers = []sdk.AccAddress{initCreator}
+	require.True(t, war.IsHatchRestricted())
+	require.True(t, war.IsAllowedHatcher(initCreator))
+	require.False(t, war.IsAllowedHatcher(address))
+
+	war.AllowedHatchers = nil
+	war.HatchMembershipDenom = "membertoken"
+	require.True(t, war.IsHatchRestricted())
+	require.False(t, war.IsAllowedHatcher(initCreator))
+}
+
+func TestHatchVestingVestedAndClaimable(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	hv := NewHatchVesting(address, sdk.NewInt(1000))
+
+	testCases := []struct {
+		elapsed        int64
+		expectedVested sdk.Int
+	}{
+		{-1, sdk.ZeroInt()},      // vesting did not start yet
+		{0, sdk.ZeroInt()},       // before cliff
+		{9, sdk.ZeroInt()},       // before cliff
+		{10, sdk.NewInt(100)},    // at cliff
+		{55, sdk.NewInt(550)},    // during linear vesting
+		{99, sdk.NewInt(990)},    // during linear vesting
+		{100, sdk.NewInt(1000)},  // end of period
+		{1000, sdk.NewInt(1000)}, // after end of period
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expectedVested, hv.Vested(tc.elapsed, 10, 100), tc.elapsed)
+	}
+
+	// Claimable excludes claimed amount
+	hv.Claimed = sdk.NewInt(300)
+	require.Equal(t, sdk.NewInt(700), hv.Locked())
+	require.Equal(t, sdk.NewInt(250), hv.Claimable(55, 10, 100))
+	require.Equal(t, sdk.NewInt(700), hv.Claimable(100, 10, 100))
+}
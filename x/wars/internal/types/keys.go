@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of this module
 	ModuleName = "wars"
@@ -19,6 +23,18 @@ const (
 	// WarsReserveAccount the root string for the wars reserve account address
 	WarsReserveAccount = "wars_reserve_account"
 
+	// WarsVestingAccount the root string for the wars vesting account address
+	WarsVestingAccount = "wars_vesting_account"
+
+	// WarsFundingPoolAccount the root string for the wars funding pool account address
+	WarsFundingPoolAccount = "wars_funding_pool_account"
+
+	// WarsVotingEscrowAccount the root string for the wars voting escrow account address
+	WarsVotingEscrowAccount = "wars_voting_escrow_account"
+
+	// WarsDepositAccount the root string for the wars deposit account address
+	WarsDepositAccount = "wars_deposit_account"
+
 	// QuerierRoute is the querier route for this module's store.
 	QuerierRoute = ModuleName
 
@@ -26,17 +42,62 @@ const (
 	RouterKey = ModuleName
 )
 
-// Wars and batches are stored as follow:
+// Wars, batches, limit orders, hatch contributions, hatch vestings, funding
+// pools, proposals, pending edits, votes, next proposal IDs, the number of
+// wars created by each creator, voting escrows, the limit order expiry and
+// price indexes, next limit order IDs, the number of limit orders of each war
+// and of each address, and hatch vesting start heights are stored as follow,
+// where the war token in the keys of limit orders, hatch contributions, hatch
+// vestings, proposals, votes, voting escrows, the limit order indexes, and the
+// limit order counts by address is prefixed by its length so that the keys of
+// one war are not a prefix of another's:
 //
 // - Wars: 0x00<war_token_bytes>
 // - Batches: 0x01<war_token_bytes>
 // - Last batches: 0x02<war_token_bytes>
+// - Limit orders: 0x03<war_token_length><war_token_bytes><order_id_bytes>
+// - Hatch contributions: 0x04<war_token_length><war_token_bytes><address_bytes>
+// - Hatch vestings: 0x05<war_token_length><war_token_bytes><address_bytes>
+// - Funding pools: 0x06<war_token_bytes>
+// - Proposals: 0x07<war_token_length><war_token_bytes><proposal_id_bytes>
+// - Pending edits: 0x08<war_token_bytes>
+// - Votes: 0x09<war_token_length><war_token_bytes><proposal_id_bytes><voter_bytes>
+// - Next proposal IDs: 0x0A<war_token_bytes>
+// - War counts by creator: 0x0B<creator_address_bytes>
+// - Voting escrows: 0x0C<war_token_length><war_token_bytes><voter_bytes>
+// - Limit order expiries: 0x0D<war_token_length><war_token_bytes><expiry_height_bytes><order_id_bytes>
+// - Limit order prices: 0x0E<war_token_length><war_token_bytes><order_type_byte><limit_price_bytes><order_id_bytes>
+// - Next limit order IDs: 0x0F<war_token_bytes>
+// - Limit order counts: 0x10<war_token_bytes>
+// - Limit order counts by address: 0x11<war_token_length><war_token_bytes><address_bytes>
+// - Hatch vesting start heights: 0x12<war_token_bytes>
 var (
-	WarsKeyPrefix       = []byte{0x00} // key for wars
-	BatchesKeyPrefix     = []byte{0x01} // key for batches
-	LastBatchesKeyPrefix = []byte{0x02} // key for last batches
+	WarsKeyPrefix               = []byte{0x00} // key for wars
+	BatchesKeyPrefix            = []byte{0x01} // key for batches
+	LastBatchesKeyPrefix        = []byte{0x02} // key for last batches
+	LimitOrdersKeyPrefix        = []byte{0x03} // key for limit orders
+	HatchContributionsKeyPrefix = []byte{0x04} // key for hatch contributions
+	HatchVestingsKeyPrefix      = []byte{0x05} // key for hatch vestings
+	FundingPoolsKeyPrefix       = []byte{0x06} // key for funding pools
+	ProposalsKeyPrefix          = []byte{0x07} // key for proposals
+	PendingEditsKeyPrefix       = []byte{0x08} // key for pending edits
+	VotesKeyPrefix              = []byte{0x09} // key for votes
+	NextProposalIDsKeyPrefix    = []byte{0x0A} // key for next proposal IDs
+	WarCountsByCreatorKeyPrefix = []byte{0x0B} // key for war counts by creator
+	VotingEscrowsKeyPrefix      = []byte{0x0C} // key for voting escrows
+
+	LimitOrderExpiriesKeyPrefix        = []byte{0x0D} // key for limit order expiry index
+	LimitOrderPricesKeyPrefix          = []byte{0x0E} // key for limit order price index
+	NextLimitOrderIDsKeyPrefix         = []byte{0x0F} // key for next limit order IDs
+	LimitOrderCountsKeyPrefix          = []byte{0x10} // key for limit order counts
+	LimitOrderCountsByAddressKeyPrefix = []byte{0x11} // key for limit order counts by address
+	HatchVestingStartHeightsKeyPrefix  = []byte{0x12} // key for hatch vesting start heights
 )
 
+// limitPriceLength is the length of the limit prices in the keys of the limit
+// order price index, which fits any 256-bit amount with 18 decimal places
+const limitPriceLength = 40
+
 func GetWarKey(token string) []byte {
 	return append(WarsKeyPrefix, []byte(token)...)
 }
@@ -48,3 +109,153 @@ func GetBatchKey(token string) []byte {
 func GetLastBatchKey(token string) []byte {
 	return append(LastBatchesKeyPrefix, []byte(token)...)
 }
+
+// GetLimitOrdersKey returns the prefix of the keys of the war's limit orders
+func GetLimitOrdersKey(token string) []byte {
+	return getLengthPrefixedTokenKey(LimitOrdersKeyPrefix, token)
+}
+
+func GetLimitOrderKey(token string, id uint64) []byte {
+	return append(GetLimitOrdersKey(token), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetHatchContributionsKey returns the prefix of the keys of the war's hatch
+// contributions
+func GetHatchContributionsKey(token string) []byte {
+	return getLengthPrefixedTokenKey(HatchContributionsKeyPrefix, token)
+}
+
+func GetHatchContributionKey(token string, address sdk.AccAddress) []byte {
+	return append(GetHatchContributionsKey(token), address.Bytes()...)
+}
+
+// GetHatchVestingsKey returns the prefix of the keys of the war's hatch
+// vestings
+func GetHatchVestingsKey(token string) []byte {
+	return getLengthPrefixedTokenKey(HatchVestingsKeyPrefix, token)
+}
+
+func GetHatchVestingKey(token string, address sdk.AccAddress) []byte {
+	return append(GetHatchVestingsKey(token), address.Bytes()...)
+}
+
+func GetHatchVestingStartHeightKey(token string) []byte {
+	return append(HatchVestingStartHeightsKeyPrefix, []byte(token)...)
+}
+
+func GetFundingPoolKey(token string) []byte {
+	return append(FundingPoolsKeyPrefix, []byte(token)...)
+}
+
+// GetProposalsKey returns the prefix of the keys of the war's proposals
+func GetProposalsKey(token string) []byte {
+	return getLengthPrefixedTokenKey(ProposalsKeyPrefix, token)
+}
+
+func GetProposalKey(token string, id uint64) []byte {
+	return append(GetProposalsKey(token), sdk.Uint64ToBigEndian(id)...)
+}
+
+func GetPendingEditsKey(token string) []byte {
+	return append(PendingEditsKeyPrefix, []byte(token)...)
+}
+
+// GetVotesKey returns the prefix of the keys of the votes on the proposal
+func GetVotesKey(token string, id uint64) []byte {
+	return append(getLengthPrefixedTokenKey(VotesKeyPrefix, token),
+		sdk.Uint64ToBigEndian(id)...)
+}
+
+func GetVoteKey(token string, id uint64, voter sdk.AccAddress) []byte {
+	return append(GetVotesKey(token, id), voter.Bytes()...)
+}
+
+func GetNextProposalIDKey(token string) []byte {
+	return append(NextProposalIDsKeyPrefix, []byte(token)...)
+}
+
+func GetWarCountByCreatorKey(creator sdk.AccAddress) []byte {
+	return append(WarCountsByCreatorKeyPrefix, creator.Bytes()...)
+}
+
+// GetVotingEscrowsKey returns the prefix of the keys of the war's voting escrows
+func GetVotingEscrowsKey(token string) []byte {
+	return getLengthPrefixedTokenKey(VotingEscrowsKeyPrefix, token)
+}
+
+func GetVotingEscrowKey(token string, voter sdk.AccAddress) []byte {
+	return append(GetVotingEscrowsKey(token), voter.Bytes()...)
+}
+
+// GetLimitOrderExpiriesKey returns the prefix of the keys of the war's limit
+// order expiry index, which orders the limit orders by their expiry height
+func GetLimitOrderExpiriesKey(token string) []byte {
+	return getLengthPrefixedTokenKey(LimitOrderExpiriesKeyPrefix, token)
+}
+
+// GetLimitOrderExpiriesUntilKey returns the end of the range of the war's
+// limit order expiry index that holds the orders with an expiry height before
+// the specified height
+func GetLimitOrderExpiriesUntilKey(token string, height int64) []byte {
+	return append(GetLimitOrderExpiriesKey(token), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+func GetLimitOrderExpiryKey(token string, lo LimitOrder) []byte {
+	return append(GetLimitOrderExpiriesUntilKey(token, lo.ExpiryHeight),
+		sdk.Uint64ToBigEndian(lo.ID)...)
+}
+
+// GetLimitOrderPricesKey returns the prefix of the keys of the war's limit
+// order price index for the order type, which orders limit buys from the
+// highest to the lowest limit price and limit sells from the lowest to the
+// highest limit price, per war token and in the reserve token, and orders with
+// the same limit price in the order that they were placed
+func GetLimitOrderPricesKey(token, orderType string) []byte {
+	typeByte := byte(0x00)
+	if orderType == LimitSellOrderType {
+		typeByte = 0x01
+	}
+	return append(getLengthPrefixedTokenKey(LimitOrderPricesKeyPrefix, token), typeByte)
+}
+
+func GetLimitOrderPriceKey(token string, lo LimitOrder, reserveToken string) []byte {
+	key := append(GetLimitOrderPricesKey(token, lo.OrderType),
+		getLimitPriceBytes(lo, reserveToken)...)
+	return append(key, sdk.Uint64ToBigEndian(lo.ID)...)
+}
+
+func GetNextLimitOrderIDKey(token string) []byte {
+	return append(NextLimitOrderIDsKeyPrefix, []byte(token)...)
+}
+
+func GetLimitOrderCountKey(token string) []byte {
+	return append(LimitOrderCountsKeyPrefix, []byte(token)...)
+}
+
+func GetLimitOrderCountByAddressKey(token string, address sdk.AccAddress) []byte {
+	return append(getLengthPrefixedTokenKey(LimitOrderCountsByAddressKeyPrefix, token),
+		address.Bytes()...)
+}
+
+// getLimitPriceBytes returns the order's limit price per war token in the
+// reserve token as a fixed length big-endian integer, so that the bytes sort
+// in the same order as the prices. The bytes are inverted for limit buys, so
+// that they sort from the highest to the lowest price.
+func getLimitPriceBytes(lo LimitOrder, reserveToken string) []byte {
+	price := lo.LimitPrices.AmountOf(reserveToken).ToDec().QuoInt(lo.Amount.Amount)
+	bz := make([]byte, limitPriceLength)
+	priceBz := price.Int.Bytes()
+	copy(bz[limitPriceLength-len(priceBz):], priceBz)
+	if lo.IsBuy() {
+		for i := range bz {
+			bz[i] = ^bz[i]
+		}
+	}
+	return bz
+}
+
+func getLengthPrefixedTokenKey(prefix []byte, token string) []byte {
+	key := append([]byte{}, prefix...)
+	key = append(key, byte(len(token)))
+	return append(key, []byte(token)...)
+}
@@ -0,0 +1,144 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	// Euler's number e and the natural logarithm of 2, to 18 decimal places
+	eulerNumber = sdk.MustNewDecFromStr("2.718281828459045235")
+	ln2         = sdk.MustNewDecFromStr("0.693147180559945309")
+
+	// MaxExpArgument is the largest x for which Exp(x) is calculated. This
+	// keeps e^x (~1.9e52 at x=120) well within the range of an sdk.Dec.
+	MaxExpArgument = sdk.NewDec(120)
+
+	// Precision to which the StableSwap invariant and balances are calculated
+	stableSwapPrecision = sdk.NewDecWithPrec(1, 15)
+)
+
+const stableSwapMaxIterations = 255
+
+// Exp returns e^x for a non-negative x that does not exceed MaxExpArgument.
+func Exp(x sdk.Dec) (sdk.Dec, error) {
+	if x.IsNegative() {
+		return sdk.Dec{}, ErrArgumentCannotBeNegative
+	} else if x.GT(MaxExpArgument) {
+		return sdk.Dec{}, ErrFunctionResultTooLarge
+	}
+
+	// Split x into integer part n and fractional part f, so e^x = e^n * e^f
+	n := x.TruncateInt64()
+	f := x.Sub(sdk.NewDec(n))
+
+	// Taylor series for e^f (f in [0,1)) until terms become negligible
+	result := sdk.OneDec()
+	term := sdk.OneDec()
+	for i := int64(1); !term.IsZero(); i++ {
+		term = term.Mul(f).QuoInt64(i)
+		result = result.Add(term)
+	}
+
+	return eulerNumber.Power(uint64(n)).Mul(result), nil
+}
+
+// Ln returns the natural logarithm of x for x >= 1.
+func Ln(x sdk.Dec) sdk.Dec {
+	if x.LT(sdk.OneDec()) {
+		panic("Ln is only implemented for values greater than or equal to 1")
+	}
+
+	// Reduce x to m in [1,2) such that x = m * 2^k, so ln(x) = k*ln(2) + ln(m)
+	k := int64(0)
+	m := x
+	two := sdk.NewDec(2)
+	for m.GTE(two) {
+		m = m.Quo(two)
+		k++
+	}
+
+	// ln(m) = 2*atanh(t) = 2*(t + t^3/3 + t^5/5 + ...) where t = (m-1)/(m+1)
+	// Since m is in [1,2), t is in [0,1/3) and the series converges quickly
+	t := m.Sub(sdk.OneDec()).Quo(m.Add(sdk.OneDec()))
+	tSquared := t.Mul(t)
+	sum := sdk.ZeroDec()
+	power := t
+	for i := int64(1); !power.IsZero(); i += 2 {
+		sum = sum.Add(power.QuoInt64(i))
+		power = power.Mul(tSquared)
+	}
+
+	return ln2.MulInt64(k).Add(sum.MulInt64(2))
+}
+
+// Pow returns base^exponent for a non-negative base and exponent, where the
+// exponent does not have to be an integer. It fails if the result is too
+// large to be calculated, i.e. if exponent*ln(base) exceeds MaxExpArgument.
+func Pow(base, exponent sdk.Dec) (sdk.Dec, error) {
+	if base.IsNegative() || exponent.IsNegative() {
+		return sdk.Dec{}, ErrArgumentCannotBeNegative
+	} else if exponent.IsZero() {
+		return sdk.OneDec(), nil
+	} else if base.IsZero() {
+		return sdk.ZeroDec(), nil
+	}
+
+	// For base >= 1, base^exponent = e^(exponent*ln(base))
+	if base.GTE(sdk.OneDec()) {
+		return Exp(exponent.Mul(Ln(base)))
+	}
+
+	// For base < 1, base^exponent = 1 / e^(exponent*ln(1/base)), which is
+	// too small to be represented if the denominator cannot be calculated
+	denominator, err := Exp(exponent.Mul(Ln(sdk.OneDec().Quo(base))))
+	if err != nil {
+		return sdk.ZeroDec(), nil
+	}
+	return sdk.OneDec().Quo(denominator), nil
+}
+
+// StableSwapInvariant returns the invariant D of a two-token StableSwap pool
+// with balances x and y and amplification coefficient A, i.e. the D that
+// satisfies 4A(x+y) + D = 4AD + D^3/(4xy). It is found by Newton's method.
+func StableSwapInvariant(x, y, A sdk.Dec) (sdk.Dec, error) {
+	sum := x.Add(y)
+	if sum.IsZero() {
+		return sdk.ZeroDec(), nil
+	}
+	ann := A.MulInt64(4)
+
+	d := sum
+	for i := 0; i < stableSwapMaxIterations; i++ {
+		// dP = D^3/(4xy), calculated in steps to keep intermediate values small
+		dP := d.Mul(d).Quo(x.MulInt64(2)).Mul(d).Quo(y.MulInt64(2))
+		prev := d
+		// D = (4A(x+y) + 2dP)*D / ((4A-1)*D + 3dP)
+		d = ann.Mul(sum).Add(dP.MulInt64(2)).Mul(d).Quo(
+			ann.Sub(sdk.OneDec()).Mul(d).Add(dP.MulInt64(3)))
+		if d.Sub(prev).Abs().LTE(stableSwapPrecision) {
+			return d, nil
+		}
+	}
+	return sdk.Dec{}, ErrFunctionDidNotConverge
+}
+
+// StableSwapBalance returns the balance y of one token in a two-token
+// StableSwap pool with invariant D and amplification coefficient A, given
+// that the balance of the other token is x. It is found by Newton's method.
+func StableSwapBalance(x, D, A sdk.Dec) (sdk.Dec, error) {
+	ann := A.MulInt64(4)
+
+	// Solve y^2 + (b-D)y = c, where b = x + D/4A and c = D^3/(16Ax)
+	c := D.Mul(D).Quo(x.MulInt64(2)).Mul(D).Quo(ann.MulInt64(2))
+	b := x.Add(D.Quo(ann))
+
+	y := D
+	for i := 0; i < stableSwapMaxIterations; i++ {
+		prev := y
+		y = y.Mul(y).Add(c).Quo(y.MulInt64(2).Add(b).Sub(D))
+		if y.Sub(prev).Abs().LTE(stableSwapPrecision) {
+			return y, nil
+		}
+	}
+	return sdk.Dec{}, ErrFunctionDidNotConverge
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// requireApproxEqual checks that actual is within 1e-12 (relative) of expected
+func requireApproxEqual(t *testing.T, expected string, actual sdk.Dec) {
+	expectedDec := sdk.MustNewDecFromStr(expected)
+	tolerance := expectedDec.Abs().Mul(sdk.NewDecWithPrec(1, 12))
+	if tolerance.LT(sdk.NewDecWithPrec(1, 12)) {
+		tolerance = sdk.NewDecWithPrec(1, 12)
+	}
+	require.True(t, expectedDec.Sub(actual).Abs().LTE(tolerance),
+		"expected %s, got %s", expectedDec, actual)
+}
+
+func TestExp(t *testing.T) {
+	testCases := []struct {
+		x        string
+		expected string
+	}{
+		{"0", "1"},
+		{"1", "2.718281828459045235"},
+		{"0.5", "1.648721270700128147"},
+		{"2.5", "12.182493960703473438"},
+		{"10", "22026.465794806716517"},
+		{"50.75", "10976021690150658605098.729161110089387509"},
+	}
+	for _, tc := range testCases {
+		actual, err := Exp(sdk.MustNewDecFromStr(tc.x))
+		require.Nil(t, err)
+		requireApproxEqual(t, tc.expected, actual)
+	}
+
+	_, err := Exp(sdk.NewDec(-1))
+	require.Error(t, err)
+
+	_, err = Exp(MaxExpArgument.Add(sdk.SmallestDec()))
+	require.Error(t, err)
+
+	_, err = Exp(MaxExpArgument)
+	require.Nil(t, err)
+}
+
+func TestLn(t *testing.T) {
+	testCases := []struct {
+		x        string
+		expected string
+	}{
+		{"1", "0"},
+		{"1.5", "0.405465108108164382"},
+		{"2", "0.693147180559945309"},
+		{"10", "2.302585092994045684"},
+		{"1000000", "13.815510557964274104"},
+	}
+	for _, tc := range testCases {
+		actual := Ln(sdk.MustNewDecFromStr(tc.x))
+		requireApproxEqual(t, tc.expected, actual)
+	}
+
+	require.Panics(t, func() { Ln(sdk.NewDecWithPrec(5, 1)) })
+}
+
+func TestPow(t *testing.T) {
+	testCases := []struct {
+		base     string
+		exponent string
+		expected string
+	}{
+		{"0", "0", "1"},
+		{"0", "2.5", "0"},
+		{"1", "2.5", "1"},
+		{"1.5", "2.5", "2.755675960631075360"},
+		{"0.5", "0.3", "0.812252396356235523"},
+		{"10", "0.5", "3.162277660168379332"},
+		{"2", "10", "1024"},
+	}
+	for _, tc := range testCases {
+		actual, err := Pow(sdk.MustNewDecFromStr(tc.base), sdk.MustNewDecFromStr(tc.exponent))
+		require.Nil(t, err)
+		requireApproxEqual(t, tc.expected, actual)
+	}
+
+	// Results too small to be represented are zero
+	actual, err := Pow(sdk.NewDecWithPrec(1, 3), sdk.NewDec(100))
+	require.Nil(t, err)
+	require.Equal(t, sdk.ZeroDec(), actual)
+
+	_, err = Pow(sdk.NewDec(1000), sdk.NewDec(100))
+	require.Error(t, err)
+
+	_, err = Pow(sdk.NewDec(-1), sdk.NewDec(2))
+	require.Error(t, err)
+}
+
+func TestStableSwapInvariant(t *testing.T) {
+	A := sdk.NewDec(10)
+
+	// Balanced pool: D is the sum of the balances
+	d, err := StableSwapInvariant(sdk.NewDec(10000), sdk.NewDec(10000), A)
+	require.Nil(t, err)
+	requireApproxEqual(t, "20000", d)
+
+	// Imbalanced pool: D is less than the sum of the balances
+	d, err = StableSwapInvariant(sdk.NewDec(10000), sdk.NewDec(5000), A)
+	require.Nil(t, err)
+	requireApproxEqual(t, "14955.741821983227411540", d)
+
+	// Balance of y given x and D is the inverse of the invariant
+	y, err := StableSwapBalance(sdk.NewDec(10000), d, A)
+	require.Nil(t, err)
+	requireApproxEqual(t, "5000", y)
+}
@@ -7,61 +7,114 @@ import (
 )
 
 const (
-	TypeMsgCreateWar         = "create_war"
-	TypeMsgEditWar           = "edit_war"
-	TypeMsgBuy                = "buy"
-	TypeMsgSell               = "sell"
-	TypeMsgSwap               = "swap"
-	TypeMsgMakeOutcomePayment = "make_outcome_payment"
-	TypeMsgWithdrawShare      = "withdraw_share"
+	TypeMsgCreateWar            = "create_war"
+	TypeMsgEditWar              = "edit_war"
+	TypeMsgUpdateWarSigners     = "update_war_signers"
+	TypeMsgCancelPendingEdit    = "cancel_pending_edit"
+	TypeMsgSetWarPaused         = "set_war_paused"
+	TypeMsgBuy                  = "buy"
+	TypeMsgBuyWithSpend         = "buy_with_spend"
+	TypeMsgSell                 = "sell"
+	TypeMsgSwap                 = "swap"
+	TypeMsgRoutedSwap           = "routed_swap"
+	TypeMsgLimitBuy             = "limit_buy"
+	TypeMsgLimitSell            = "limit_sell"
+	TypeMsgCancelOrder          = "cancel_order"
+	TypeMsgCancelLimitOrder     = "cancel_limit_order"
+	TypeMsgMakeOutcomePayment   = "make_outcome_payment"
+	TypeMsgWithdrawShare        = "withdraw_share"
+	TypeMsgClaimVested          = "claim_vested"
+	TypeMsgSpendFromFundingPool = "spend_from_funding_pool"
+	TypeMsgSubmitProposal       = "submit_proposal"
+	TypeMsgVote                 = "vote"
 )
 
 type MsgCreateWar struct {
-	Token                  string           `json:"token" yaml:"token"`
-	Name                   string           `json:"name" yaml:"name"`
-	Description            string           `json:"description" yaml:"description"`
-	FunctionType           string           `json:"function_type" yaml:"function_type"`
-	FunctionParameters     FunctionParams   `json:"function_parameters" yaml:"function_parameters"`
-	Creator                sdk.AccAddress   `json:"creator" yaml:"creator"`
-	ReserveTokens          []string         `json:"reserve_tokens" yaml:"reserve_tokens"`
-	TxFeePercentage        sdk.Dec          `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
-	ExitFeePercentage      sdk.Dec          `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
-	FeeAddress             sdk.AccAddress   `json:"fee_address" yaml:"fee_address"`
-	MaxSupply              sdk.Coin         `json:"max_supply" yaml:"max_supply"`
-	OrderQuantityLimits    sdk.Coins        `json:"order_quantity_limits" yaml:"order_quantity_limits"`
-	SanityRate             sdk.Dec          `json:"sanity_rate" yaml:"sanity_rate"`
-	SanityMarginPercentage sdk.Dec          `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
-	AllowSells             bool             `json:"allow_sells" yaml:"allow_sells"`
-	Signers                []sdk.AccAddress `json:"signers" yaml:"signers"`
-	BatchBlocks            sdk.Uint         `json:"batch_blocks" yaml:"batch_blocks"`
-	OutcomePayment         sdk.Coins        `json:"outcome_payment" yaml:"outcome_payment"`
+	Token                         string           `json:"token" yaml:"token"`
+	Name                          string           `json:"name" yaml:"name"`
+	Description                   string           `json:"description" yaml:"description"`
+	FunctionType                  string           `json:"function_type" yaml:"function_type"`
+	FunctionParameters            FunctionParams   `json:"function_parameters" yaml:"function_parameters"`
+	Creator                       sdk.AccAddress   `json:"creator" yaml:"creator"`
+	ReserveTokens                 []string         `json:"reserve_tokens" yaml:"reserve_tokens"`
+	TxFeePercentage               sdk.Dec          `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage             sdk.Dec          `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	FeeAddress                    sdk.AccAddress   `json:"fee_address" yaml:"fee_address"`
+	MaxSupply                     sdk.Coin         `json:"max_supply" yaml:"max_supply"`
+	OrderQuantityLimits           sdk.Coins        `json:"order_quantity_limits" yaml:"order_quantity_limits"`
+	SanityRate                    sdk.Dec          `json:"sanity_rate" yaml:"sanity_rate"`
+	SanityMarginPercentage        sdk.Dec          `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	AllowSells                    bool             `json:"allow_sells" yaml:"allow_sells"`
+	Signers                       []sdk.AccAddress `json:"signers" yaml:"signers"`
+	SignerThreshold               uint64           `json:"signer_threshold" yaml:"signer_threshold"`
+	EditDelay                     int64            `json:"edit_delay" yaml:"edit_delay"`
+	BatchBlocks                   sdk.Uint         `json:"batch_blocks" yaml:"batch_blocks"`
+	OutcomePayment                sdk.Coins        `json:"outcome_payment" yaml:"outcome_payment"`
+	HatchDeadlineHeight           int64            `json:"hatch_deadline_height" yaml:"hatch_deadline_height"`
+	AllowedHatchers               []sdk.AccAddress `json:"allowed_hatchers" yaml:"allowed_hatchers"`
+	HatchMembershipDenom          string           `json:"hatch_membership_denom" yaml:"hatch_membership_denom"`
+	MaxHatchContribution          sdk.Int          `json:"max_hatch_contribution" yaml:"max_hatch_contribution"`
+	HatchVestingCliff             int64            `json:"hatch_vesting_cliff" yaml:"hatch_vesting_cliff"`
+	HatchVestingPeriod            int64            `json:"hatch_vesting_period" yaml:"hatch_vesting_period"`
+	FundingPoolSpenders           []sdk.AccAddress `json:"funding_pool_spenders" yaml:"funding_pool_spenders"`
+	FundingPoolSpendLimit         sdk.Coins        `json:"funding_pool_spend_limit" yaml:"funding_pool_spend_limit"`
+	FundingPoolEpochBlocks        int64            `json:"funding_pool_epoch_blocks" yaml:"funding_pool_epoch_blocks"`
+	GovernanceQuorumPercentage    sdk.Dec          `json:"governance_quorum_percentage" yaml:"governance_quorum_percentage"`
+	GovernanceThresholdPercentage sdk.Dec          `json:"governance_threshold_percentage" yaml:"governance_threshold_percentage"`
+	GovernanceVotingPeriod        int64            `json:"governance_voting_period" yaml:"governance_voting_period"`
+	MaxPriceMovePercentage        sdk.Dec          `json:"max_price_move_percentage" yaml:"max_price_move_percentage"`
+	PriceMoveCooldown             int64            `json:"price_move_cooldown" yaml:"price_move_cooldown"`
 }
 
 func NewMsgCreateWar(token, name, description string, creator sdk.AccAddress,
 	functionType string, functionParameters FunctionParams, reserveTokens []string,
 	txFeePercentage, exitFeePercentage sdk.Dec, feeAddress sdk.AccAddress, maxSupply sdk.Coin,
 	orderQuantityLimits sdk.Coins, sanityRate, sanityMarginPercentage sdk.Dec,
-	allowSell bool, signers []sdk.AccAddress, batchBlocks sdk.Uint,
-	outcomePayment sdk.Coins) MsgCreateWar {
+	allowSell bool, signers []sdk.AccAddress, signerThreshold uint64,
+	editDelay int64, batchBlocks sdk.Uint,
+	outcomePayment sdk.Coins, hatchDeadlineHeight int64,
+	allowedHatchers []sdk.AccAddress, hatchMembershipDenom string,
+	maxHatchContribution sdk.Int, hatchVestingCliff,
+	hatchVestingPeriod int64, fundingPoolSpenders []sdk.AccAddress,
+	fundingPoolSpendLimit sdk.Coins, fundingPoolEpochBlocks int64,
+	governanceQuorumPercentage, governanceThresholdPercentage sdk.Dec,
+	governanceVotingPeriod int64, maxPriceMovePercentage sdk.Dec,
+	priceMoveCooldown int64) MsgCreateWar {
 	return MsgCreateWar{
-		Token:                  token,
-		Name:                   name,
-		Description:            description,
-		Creator:                creator,
-		FunctionType:           functionType,
-		FunctionParameters:     functionParameters,
-		ReserveTokens:          reserveTokens,
-		TxFeePercentage:        txFeePercentage,
-		ExitFeePercentage:      exitFeePercentage,
-		FeeAddress:             feeAddress,
-		MaxSupply:              maxSupply,
-		OrderQuantityLimits:    orderQuantityLimits,
-		SanityRate:             sanityRate,
-		SanityMarginPercentage: sanityMarginPercentage,
-		AllowSells:             allowSell,
-		Signers:                signers,
-		BatchBlocks:            batchBlocks,
-		OutcomePayment:         outcomePayment,
+		Token:                         token,
+		Name:                          name,
+		Description:                   description,
+		Creator:                       creator,
+		FunctionType:                  functionType,
+		FunctionParameters:            functionParameters,
+		ReserveTokens:                 reserveTokens,
+		TxFeePercentage:               txFeePercentage,
+		ExitFeePercentage:             exitFeePercentage,
+		FeeAddress:                    feeAddress,
+		MaxSupply:                     maxSupply,
+		OrderQuantityLimits:           orderQuantityLimits,
+		SanityRate:                    sanityRate,
+		SanityMarginPercentage:        sanityMarginPercentage,
+		AllowSells:                    allowSell,
+		Signers:                       signers,
+		SignerThreshold:               signerThreshold,
+		EditDelay:                     editDelay,
+		BatchBlocks:                   batchBlocks,
+		OutcomePayment:                outcomePayment,
+		HatchDeadlineHeight:           hatchDeadlineHeight,
+		AllowedHatchers:               allowedHatchers,
+		HatchMembershipDenom:          hatchMembershipDenom,
+		MaxHatchContribution:          maxHatchContribution,
+		HatchVestingCliff:             hatchVestingCliff,
+		HatchVestingPeriod:            hatchVestingPeriod,
+		FundingPoolSpenders:           fundingPoolSpenders,
+		FundingPoolSpendLimit:         fundingPoolSpendLimit,
+		FundingPoolEpochBlocks:        fundingPoolEpochBlocks,
+		GovernanceQuorumPercentage:    governanceQuorumPercentage,
+		GovernanceThresholdPercentage: governanceThresholdPercentage,
+		GovernanceVotingPeriod:        governanceVotingPeriod,
+		MaxPriceMovePercentage:        maxPriceMovePercentage,
+		PriceMoveCooldown:             priceMoveCooldown,
 	}
 }
 
@@ -100,6 +153,8 @@ func (msg MsgCreateWar) ValidateBasic() error {
 		return err
 	} else if err = CheckNoOfReserveTokens(msg.ReserveTokens, msg.FunctionType); err != nil {
 		return err
+	} else if err = CheckFunctionParamsForReserveTokens(msg.FunctionParameters, msg.ReserveTokens, msg.FunctionType); err != nil {
+		return err
 	}
 
 	// Validate coins
@@ -109,6 +164,8 @@ func (msg MsgCreateWar) ValidateBasic() error {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "order quantity limits are invalid")
 	} else if !msg.OutcomePayment.IsValid() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "outcome payment is invalid")
+	} else if !msg.FundingPoolSpendLimit.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "funding pool spend limit is invalid")
 	}
 
 	// Check that max supply denom matches token denom
@@ -121,6 +178,8 @@ func (msg MsgCreateWar) ValidateBasic() error {
 		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "SanityRate")
 	} else if msg.SanityMarginPercentage.IsNegative() {
 		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "SanityMarginPercentage")
+	} else if err = CheckSanityRateForReserveTokens(msg.SanityRate, msg.ReserveTokens); err != nil {
+		return err
 	}
 
 	// Check FeePercentages not negative and don't add up to 100
@@ -139,6 +198,95 @@ func (msg MsgCreateWar) ValidateBasic() error {
 		return sdkerrors.Wrap(ErrArgumentMustBePositive, "MaxSupply")
 	}
 
+	// Check that hatch fields are valid and only set for augmented
+	if msg.HatchDeadlineHeight < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "HatchDeadlineHeight")
+	} else if msg.MaxHatchContribution.IsNegative() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "MaxHatchContribution")
+	} else if msg.HatchMembershipDenom != "" && CheckCoinDenom(msg.HatchMembershipDenom) != nil {
+		return sdkerrors.Wrap(ErrInvalidCoinDenomination, msg.HatchMembershipDenom)
+	} else if msg.HatchVestingCliff < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "HatchVestingCliff")
+	} else if msg.HatchVestingPeriod < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "HatchVestingPeriod")
+	} else if msg.HatchVestingCliff > msg.HatchVestingPeriod {
+		return sdkerrors.Wrapf(ErrVestingCliffExceedsVestingPeriod,
+			"%d > %d", msg.HatchVestingCliff, msg.HatchVestingPeriod)
+	}
+	for _, h := range msg.AllowedHatchers {
+		if h.Empty() {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "AllowedHatchers")
+		}
+	}
+	if msg.FunctionType != AugmentedFunction {
+		if msg.HatchDeadlineHeight != 0 {
+			return sdkerrors.Wrap(ErrHatchFieldOnlyForAugmented, "HatchDeadlineHeight")
+		} else if len(msg.AllowedHatchers) != 0 {
+			return sdkerrors.Wrap(ErrHatchFieldOnlyForAugmented, "AllowedHatchers")
+		} else if msg.HatchMembershipDenom != "" {
+			return sdkerrors.Wrap(ErrHatchFieldOnlyForAugmented, "HatchMembershipDenom")
+		} else if !msg.MaxHatchContribution.IsZero() {
+			return sdkerrors.Wrap(ErrHatchFieldOnlyForAugmented, "MaxHatchContribution")
+		} else if msg.HatchVestingPeriod != 0 {
+			return sdkerrors.Wrap(ErrHatchFieldOnlyForAugmented, "HatchVestingPeriod")
+		}
+	}
+
+	// Check that funding pool fields are valid and only set with spenders
+	for _, s := range msg.FundingPoolSpenders {
+		if s.Empty() {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "FundingPoolSpenders")
+		}
+	}
+	if msg.FundingPoolEpochBlocks < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "FundingPoolEpochBlocks")
+	} else if len(msg.FundingPoolSpenders) != 0 {
+		if msg.FundingPoolSpendLimit.IsZero() {
+			return sdkerrors.Wrap(ErrArgumentMustBePositive, "FundingPoolSpendLimit")
+		} else if msg.FundingPoolEpochBlocks == 0 {
+			return sdkerrors.Wrap(ErrArgumentMustBePositive, "FundingPoolEpochBlocks")
+		}
+	} else if !msg.FundingPoolSpendLimit.IsZero() || msg.FundingPoolEpochBlocks != 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "FundingPoolSpenders")
+	}
+
+	// Validate signers and signer threshold
+	if err = CheckSigners(msg.Signers, msg.SignerThreshold); err != nil {
+		return err
+	} else if msg.EditDelay < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "EditDelay")
+	}
+
+	// Check that governance fields are valid and only set with a voting period
+	if msg.GovernanceVotingPeriod < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "GovernanceVotingPeriod")
+	} else if msg.GovernanceQuorumPercentage.IsNegative() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "GovernanceQuorumPercentage")
+	} else if msg.GovernanceThresholdPercentage.IsNegative() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "GovernanceThresholdPercentage")
+	} else if msg.GovernanceVotingPeriod != 0 {
+		if !msg.GovernanceQuorumPercentage.IsPositive() {
+			return sdkerrors.Wrap(ErrArgumentMustBePositive, "GovernanceQuorumPercentage")
+		} else if msg.GovernanceQuorumPercentage.GT(sdk.NewDec(100)) {
+			return sdkerrors.Wrapf(ErrInvalidGovernancePercentage,
+				"quorum %s exceeds 100", msg.GovernanceQuorumPercentage)
+		} else if msg.GovernanceThresholdPercentage.GTE(sdk.NewDec(100)) {
+			return sdkerrors.Wrapf(ErrInvalidGovernancePercentage,
+				"threshold %s is or exceeds 100", msg.GovernanceThresholdPercentage)
+		}
+	} else if !msg.GovernanceQuorumPercentage.IsZero() || !msg.GovernanceThresholdPercentage.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "GovernanceVotingPeriod")
+	}
+
+	// Check that price move fields are valid and cooldown only set with a max
+	if msg.MaxPriceMovePercentage.IsNegative() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "MaxPriceMovePercentage")
+	} else if msg.PriceMoveCooldown < 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeNegative, "PriceMoveCooldown")
+	} else if msg.MaxPriceMovePercentage.IsZero() && msg.PriceMoveCooldown != 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "MaxPriceMovePercentage")
+	}
+
 	// Note: uniqueness of reserve tokens checked when parsing
 
 	return nil
@@ -163,13 +311,21 @@ type MsgEditWar struct {
 	OrderQuantityLimits    string           `json:"order_quantity_limits" yaml:"order_quantity_limits"`
 	SanityRate             string           `json:"sanity_rate" yaml:"sanity_rate"`
 	SanityMarginPercentage string           `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	TxFeePercentage        string           `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage      string           `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	FeeAddress             string           `json:"fee_address" yaml:"fee_address"`
+	MaxSupply              string           `json:"max_supply" yaml:"max_supply"`
+	AllowSells             string           `json:"allow_sells" yaml:"allow_sells"`
+	BatchBlocks            string           `json:"batch_blocks" yaml:"batch_blocks"`
+	OutcomePayment         string           `json:"outcome_payment" yaml:"outcome_payment"`
 	Editor                 sdk.AccAddress   `json:"editor" yaml:"editor"`
 	Signers                []sdk.AccAddress `json:"signers" yaml:"signers"`
 }
 
 func NewMsgEditWar(token, name, description, orderQuantityLimits, sanityRate,
-	sanityMarginPercentage string, editor sdk.AccAddress,
-	signers []sdk.AccAddress) MsgEditWar {
+	sanityMarginPercentage, txFeePercentage, exitFeePercentage, feeAddress,
+	maxSupply, allowSells, batchBlocks, outcomePayment string,
+	editor sdk.AccAddress, signers []sdk.AccAddress) MsgEditWar {
 	return MsgEditWar{
 		Token:                  token,
 		Name:                   name,
@@ -177,6 +333,13 @@ func NewMsgEditWar(token, name, description, orderQuantityLimits, sanityRate,
 		OrderQuantityLimits:    orderQuantityLimits,
 		SanityRate:             sanityRate,
 		SanityMarginPercentage: sanityMarginPercentage,
+		TxFeePercentage:        txFeePercentage,
+		ExitFeePercentage:      exitFeePercentage,
+		FeeAddress:             feeAddress,
+		MaxSupply:              maxSupply,
+		AllowSells:             allowSells,
+		BatchBlocks:            batchBlocks,
+		OutcomePayment:         outcomePayment,
 		Editor:                 editor,
 		Signers:                signers,
 	}
@@ -186,37 +349,23 @@ func (msg MsgEditWar) ValidateBasic() error {
 	// Check if empty
 	if strings.TrimSpace(msg.Token) == "" {
 		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Token")
-	} else if strings.TrimSpace(msg.Name) == "" {
-		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Name")
-	} else if strings.TrimSpace(msg.Description) == "" {
-		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Description")
-	} else if strings.TrimSpace(msg.SanityRate) == "" {
-		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SanityRate")
-	} else if strings.TrimSpace(msg.SanityMarginPercentage) == "" {
-		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "SanityMarginPercentage")
 	} else if msg.Editor.Empty() {
 		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
 	}
-	// Note: order quantity limits can be blank
 
-	// Check that at least one editable was edited. Fields that will not
-	// be edited should be "DoNotModifyField", and not an empty string
-	inputList := []string{
-		msg.Name, msg.Description, msg.OrderQuantityLimits,
-		msg.SanityRate, msg.SanityMarginPercentage,
-	}
-	atLeaseOneEdit := false
-	for _, e := range inputList {
-		if e != DoNotModifyField {
-			atLeaseOneEdit = true
-			break
-		}
-	}
-	if !atLeaseOneEdit {
-		return ErrDidNotEditAnything
+	// Validate signers
+	if err := CheckSigners(msg.Signers, 0); err != nil {
+		return err
 	}
 
-	return nil
+	return msg.GetWarEdit().ValidateBasic()
+}
+
+func (msg MsgEditWar) GetWarEdit() WarEdit {
+	return NewWarEdit(msg.Name, msg.Description, msg.OrderQuantityLimits,
+		msg.SanityRate, msg.SanityMarginPercentage, msg.TxFeePercentage,
+		msg.ExitFeePercentage, msg.FeeAddress, msg.MaxSupply, msg.AllowSells,
+		msg.BatchBlocks, msg.OutcomePayment)
 }
 
 func (msg MsgEditWar) GetSignBytes() []byte {
@@ -231,17 +380,151 @@ func (msg MsgEditWar) Route() string { return RouterKey }
 
 func (msg MsgEditWar) Type() string { return TypeMsgEditWar }
 
+type MsgUpdateWarSigners struct {
+	Token              string           `json:"token" yaml:"token"`
+	NewSigners         []sdk.AccAddress `json:"new_signers" yaml:"new_signers"`
+	NewSignerThreshold uint64           `json:"new_signer_threshold" yaml:"new_signer_threshold"`
+	Editor             sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers            []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgUpdateWarSigners(token string, newSigners []sdk.AccAddress,
+	newSignerThreshold uint64, editor sdk.AccAddress,
+	signers []sdk.AccAddress) MsgUpdateWarSigners {
+	return MsgUpdateWarSigners{
+		Token:              token,
+		NewSigners:         newSigners,
+		NewSignerThreshold: newSignerThreshold,
+		Editor:             editor,
+		Signers:            signers,
+	}
+}
+
+func (msg MsgUpdateWarSigners) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.Token) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Token")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	}
+
+	// Validate new signers and new signer threshold
+	if err := CheckSigners(msg.NewSigners, msg.NewSignerThreshold); err != nil {
+		return err
+	}
+
+	// Validate signers
+	return CheckSigners(msg.Signers, 0)
+}
+
+func (msg MsgUpdateWarSigners) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgUpdateWarSigners) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgUpdateWarSigners) Route() string { return RouterKey }
+
+func (msg MsgUpdateWarSigners) Type() string { return TypeMsgUpdateWarSigners }
+
+type MsgCancelPendingEdit struct {
+	Token   string           `json:"token" yaml:"token"`
+	EditID  uint64           `json:"edit_id" yaml:"edit_id"`
+	Editor  sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgCancelPendingEdit(token string, editID uint64,
+	editor sdk.AccAddress, signers []sdk.AccAddress) MsgCancelPendingEdit {
+	return MsgCancelPendingEdit{
+		Token:   token,
+		EditID:  editID,
+		Editor:  editor,
+		Signers: signers,
+	}
+}
+
+func (msg MsgCancelPendingEdit) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.Token) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Token")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	}
+
+	// Validate signers
+	return CheckSigners(msg.Signers, 0)
+}
+
+func (msg MsgCancelPendingEdit) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCancelPendingEdit) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgCancelPendingEdit) Route() string { return RouterKey }
+
+func (msg MsgCancelPendingEdit) Type() string { return TypeMsgCancelPendingEdit }
+
+type MsgSetWarPaused struct {
+	Token   string           `json:"token" yaml:"token"`
+	Paused  bool             `json:"paused" yaml:"paused"`
+	Editor  sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgSetWarPaused(token string, paused bool,
+	editor sdk.AccAddress, signers []sdk.AccAddress) MsgSetWarPaused {
+	return MsgSetWarPaused{
+		Token:   token,
+		Paused:  paused,
+		Editor:  editor,
+		Signers: signers,
+	}
+}
+
+func (msg MsgSetWarPaused) ValidateBasic() error {
+	// Check if empty
+	if strings.TrimSpace(msg.Token) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Token")
+	} else if msg.Editor.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Editor")
+	}
+
+	// Validate signers
+	return CheckSigners(msg.Signers, 0)
+}
+
+func (msg MsgSetWarPaused) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetWarPaused) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgSetWarPaused) Route() string { return RouterKey }
+
+func (msg MsgSetWarPaused) Type() string { return TypeMsgSetWarPaused }
+
 type MsgBuy struct {
-	Buyer     sdk.AccAddress `json:"buyer" yaml:"buyer"`
-	Amount    sdk.Coin       `json:"amount" yaml:"amount"`
-	MaxPrices sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	Buyer            sdk.AccAddress `json:"buyer" yaml:"buyer"`
+	Amount           sdk.Coin       `json:"amount" yaml:"amount"`
+	MaxPrices        sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	AllowPartialFill bool           `json:"allow_partial_fill" yaml:"allow_partial_fill"`
 }
 
-func NewMsgBuy(buyer sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins) MsgBuy {
+func NewMsgBuy(buyer sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins,
+	allowPartialFill bool) MsgBuy {
 	return MsgBuy{
-		Buyer:     buyer,
-		Amount:    amount,
-		MaxPrices: maxPrices,
+		Buyer:            buyer,
+		Amount:           amount,
+		MaxPrices:        maxPrices,
+		AllowPartialFill: allowPartialFill,
 	}
 }
 
@@ -278,15 +561,67 @@ func (msg MsgBuy) Route() string { return RouterKey }
 
 func (msg MsgBuy) Type() string { return TypeMsgBuy }
 
+type MsgBuyWithSpend struct {
+	Buyer    sdk.AccAddress `json:"buyer" yaml:"buyer"`
+	WarToken string         `json:"war_token" yaml:"war_token"`
+	Spend    sdk.Coins      `json:"spend" yaml:"spend"`
+}
+
+func NewMsgBuyWithSpend(buyer sdk.AccAddress, warToken string, spend sdk.Coins) MsgBuyWithSpend {
+	return MsgBuyWithSpend{
+		Buyer:    buyer,
+		WarToken: warToken,
+		Spend:    spend,
+	}
+}
+
+func (msg MsgBuyWithSpend) ValidateBasic() error {
+	// Check if empty
+	if msg.Buyer.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Buyer")
+	} else if strings.TrimSpace(msg.WarToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "WarToken")
+	} else if msg.Spend.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Spend")
+	}
+
+	// Check that war token is a valid token name
+	err := CheckCoinDenom(msg.WarToken)
+	if err != nil {
+		return sdkerrors.Wrap(ErrInvalidCoinDenomination, msg.WarToken)
+	}
+
+	// Check that spend valid
+	if !msg.Spend.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "spend is invalid")
+	}
+
+	return nil
+}
+
+func (msg MsgBuyWithSpend) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgBuyWithSpend) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Buyer}
+}
+
+func (msg MsgBuyWithSpend) Route() string { return RouterKey }
+
+func (msg MsgBuyWithSpend) Type() string { return TypeMsgBuyWithSpend }
+
 type MsgSell struct {
-	Seller sdk.AccAddress `json:"seller" yaml:"seller"`
-	Amount sdk.Coin       `json:"amount" yaml:"amount"`
+	Seller     sdk.AccAddress `json:"seller" yaml:"seller"`
+	Amount     sdk.Coin       `json:"amount" yaml:"amount"`
+	MinReturns sdk.Coins      `json:"min_returns" yaml:"min_returns"`
 }
 
-func NewMsgSell(seller sdk.AccAddress, amount sdk.Coin) MsgSell {
+func NewMsgSell(seller sdk.AccAddress, amount sdk.Coin, minReturns sdk.Coins) MsgSell {
 	return MsgSell{
-		Seller: seller,
-		Amount: amount,
+		Seller:     seller,
+		Amount:     amount,
+		MinReturns: minReturns,
 	}
 }
 
@@ -303,6 +638,11 @@ func (msg MsgSell) ValidateBasic() error {
 		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
 	}
 
+	// Check that minReturns valid (can be empty)
+	if !msg.MinReturns.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "minreturns is invalid")
+	}
+
 	return nil
 }
 
@@ -320,17 +660,20 @@ func (msg MsgSell) Type() string { return TypeMsgSell }
 
 type MsgSwap struct {
 	Swapper   sdk.AccAddress `json:"swapper" yaml:"swapper"`
-	WarToken string         `json:"war_token" yaml:"war_token"`
+	WarToken  string         `json:"war_token" yaml:"war_token"`
 	From      sdk.Coin       `json:"from" yaml:"from"`
 	ToToken   string         `json:"to_token" yaml:"to_token"`
+	MinOutput sdk.Coin       `json:"min_output" yaml:"min_output"`
 }
 
-func NewMsgSwap(swapper sdk.AccAddress, warToken string, from sdk.Coin, toToken string) MsgSwap {
+func NewMsgSwap(swapper sdk.AccAddress, warToken string, from sdk.Coin,
+	toToken string, minOutput sdk.Coin) MsgSwap {
 	return MsgSwap{
 		Swapper:   swapper,
-		WarToken: warToken,
+		WarToken:  warToken,
 		From:      from,
 		ToToken:   toToken,
+		MinOutput: minOutput,
 	}
 }
 
@@ -365,6 +708,13 @@ func (msg MsgSwap) ValidateBasic() error {
 		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FromAmount")
 	}
 
+	// Validate min output (can be zero) and check that it is in to token
+	if !msg.MinOutput.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "min output is invalid")
+	} else if msg.MinOutput.Denom != msg.ToToken {
+		return sdkerrors.Wrapf(ErrInvalidCoinDenomination, "min output must be in %s", msg.ToToken)
+	}
+
 	// Note: From denom and amount must be valid since sdk.Coin
 	return nil
 }
@@ -381,14 +731,285 @@ func (msg MsgSwap) Route() string { return RouterKey }
 
 func (msg MsgSwap) Type() string { return TypeMsgSwap }
 
+type MsgRoutedSwap struct {
+	Swapper   sdk.AccAddress `json:"swapper" yaml:"swapper"`
+	From      sdk.Coin       `json:"from" yaml:"from"`
+	Hops      SwapRoute      `json:"hops" yaml:"hops"`
+	MinOutput sdk.Coin       `json:"min_output" yaml:"min_output"`
+}
+
+func NewMsgRoutedSwap(swapper sdk.AccAddress, from sdk.Coin,
+	hops SwapRoute, minOutput sdk.Coin) MsgRoutedSwap {
+	return MsgRoutedSwap{
+		Swapper:   swapper,
+		From:      from,
+		Hops:      hops,
+		MinOutput: minOutput,
+	}
+}
+
+func (msg MsgRoutedSwap) ValidateBasic() error {
+	// Check if empty
+	if msg.Swapper.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Swapper")
+	}
+
+	// Validate from amount
+	if !msg.From.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "from amount is invalid")
+	}
+
+	// Check that non zero
+	if msg.From.Amount.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "FromAmount")
+	}
+
+	// Validate hops
+	err := msg.Hops.Validate(msg.From.Denom)
+	if err != nil {
+		return err
+	}
+
+	// Validate min output (can be zero) and check that it is in the token
+	// that the route ends at
+	if !msg.MinOutput.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "min output is invalid")
+	} else if msg.MinOutput.Denom != msg.Hops.ToToken() {
+		return sdkerrors.Wrapf(ErrInvalidCoinDenomination, "min output must be in %s", msg.Hops.ToToken())
+	}
+
+	return nil
+}
+
+func (msg MsgRoutedSwap) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRoutedSwap) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Swapper}
+}
+
+func (msg MsgRoutedSwap) Route() string { return RouterKey }
+
+func (msg MsgRoutedSwap) Type() string { return TypeMsgRoutedSwap }
+
+type MsgLimitBuy struct {
+	Buyer        sdk.AccAddress `json:"buyer" yaml:"buyer"`
+	Amount       sdk.Coin       `json:"amount" yaml:"amount"`
+	MaxPrices    sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	ExpiryHeight int64          `json:"expiry_height" yaml:"expiry_height"`
+}
+
+func NewMsgLimitBuy(buyer sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins,
+	expiryHeight int64) MsgLimitBuy {
+	return MsgLimitBuy{
+		Buyer:        buyer,
+		Amount:       amount,
+		MaxPrices:    maxPrices,
+		ExpiryHeight: expiryHeight,
+	}
+}
+
+func (msg MsgLimitBuy) ValidateBasic() error {
+	// Check if empty
+	if msg.Buyer.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Buyer")
+	}
+
+	// Check that amount valid and non zero
+	if !msg.Amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
+	} else if msg.Amount.Amount.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
+	}
+
+	// Check that maxPrices valid
+	if !msg.MaxPrices.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "maxprices is invalid")
+	}
+
+	// Check that expiry height is positive
+	if msg.ExpiryHeight <= 0 {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "ExpiryHeight")
+	}
+
+	return nil
+}
+
+func (msg MsgLimitBuy) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgLimitBuy) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Buyer}
+}
+
+func (msg MsgLimitBuy) Route() string { return RouterKey }
+
+func (msg MsgLimitBuy) Type() string { return TypeMsgLimitBuy }
+
+type MsgLimitSell struct {
+	Seller       sdk.AccAddress `json:"seller" yaml:"seller"`
+	Amount       sdk.Coin       `json:"amount" yaml:"amount"`
+	MinReturns   sdk.Coins      `json:"min_returns" yaml:"min_returns"`
+	ExpiryHeight int64          `json:"expiry_height" yaml:"expiry_height"`
+}
+
+func NewMsgLimitSell(seller sdk.AccAddress, amount sdk.Coin, minReturns sdk.Coins,
+	expiryHeight int64) MsgLimitSell {
+	return MsgLimitSell{
+		Seller:       seller,
+		Amount:       amount,
+		MinReturns:   minReturns,
+		ExpiryHeight: expiryHeight,
+	}
+}
+
+func (msg MsgLimitSell) ValidateBasic() error {
+	// Check if empty
+	if msg.Seller.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Seller")
+	} else if msg.MinReturns.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "MinReturns")
+	}
+
+	// Check that amount valid and non zero
+	if !msg.Amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount is invalid")
+	} else if msg.Amount.Amount.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
+	}
+
+	// Check that minReturns valid
+	if !msg.MinReturns.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "minreturns is invalid")
+	}
+
+	// Check that expiry height is positive
+	if msg.ExpiryHeight <= 0 {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "ExpiryHeight")
+	}
+
+	return nil
+}
+
+func (msg MsgLimitSell) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgLimitSell) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Seller}
+}
+
+func (msg MsgLimitSell) Route() string { return RouterKey }
+
+func (msg MsgLimitSell) Type() string { return TypeMsgLimitSell }
+
+type MsgCancelOrder struct {
+	Owner      sdk.AccAddress `json:"owner" yaml:"owner"`
+	WarToken   string         `json:"war_token" yaml:"war_token"`
+	OrderType  string         `json:"order_type" yaml:"order_type"`
+	OrderIndex uint64         `json:"order_index" yaml:"order_index"`
+}
+
+func NewMsgCancelOrder(owner sdk.AccAddress, warToken, orderType string,
+	orderIndex uint64) MsgCancelOrder {
+	return MsgCancelOrder{
+		Owner:      owner,
+		WarToken:   warToken,
+		OrderType:  orderType,
+		OrderIndex: orderIndex,
+	}
+}
+
+func (msg MsgCancelOrder) ValidateBasic() error {
+	// Check if empty
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Owner")
+	} else if strings.TrimSpace(msg.WarToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "WarToken")
+	} else if strings.TrimSpace(msg.OrderType) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "OrderType")
+	}
+
+	// Check that war token is a valid token name
+	err := CheckCoinDenom(msg.WarToken)
+	if err != nil {
+		return sdkerrors.Wrap(ErrInvalidCoinDenomination, msg.WarToken)
+	}
+
+	// Check that order type is buy, sell, or swap
+	if !IsValidOrderType(msg.OrderType) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest,
+			"order type must be one of %s, %s, %s", BuyOrderType, SellOrderType, SwapOrderType)
+	}
+
+	return nil
+}
+
+func (msg MsgCancelOrder) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCancelOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+func (msg MsgCancelOrder) Route() string { return RouterKey }
+
+func (msg MsgCancelOrder) Type() string { return TypeMsgCancelOrder }
+
+type MsgCancelLimitOrder struct {
+	Owner    sdk.AccAddress `json:"owner" yaml:"owner"`
+	WarToken string         `json:"war_token" yaml:"war_token"`
+	OrderID  uint64         `json:"order_id" yaml:"order_id"`
+}
+
+func NewMsgCancelLimitOrder(owner sdk.AccAddress, warToken string, orderID uint64) MsgCancelLimitOrder {
+	return MsgCancelLimitOrder{
+		Owner:    owner,
+		WarToken: warToken,
+		OrderID:  orderID,
+	}
+}
+
+func (msg MsgCancelLimitOrder) ValidateBasic() error {
+	// Check if empty
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Owner")
+	} else if strings.TrimSpace(msg.WarToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "WarToken")
+	}
+
+	// Check that war token is a valid token name
+	err := CheckCoinDenom(msg.WarToken)
+	if err != nil {
+		return sdkerrors.Wrap(ErrInvalidCoinDenomination, msg.WarToken)
+	}
+
+	return nil
+}
+
+func (msg MsgCancelLimitOrder) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCancelLimitOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+func (msg MsgCancelLimitOrder) Route() string { return RouterKey }
+
+func (msg MsgCancelLimitOrder) Type() string { return TypeMsgCancelLimitOrder }
+
 type MsgMakeOutcomePayment struct {
-	Sender    sdk.AccAddress `json:"sender" yaml:"sender"`
+	Sender   sdk.AccAddress `json:"sender" yaml:"sender"`
 	WarToken string         `json:"war_token" yaml:"war_token"`
 }
 
 func NewMsgMakeOutcomePayment(sender sdk.AccAddress, warToken string) MsgMakeOutcomePayment {
 	return MsgMakeOutcomePayment{
-		Sender:    sender,
+		Sender:   sender,
 		WarToken: warToken,
 	}
 }
@@ -424,13 +1045,13 @@ func (msg MsgMakeOutcomePayment) Type() string { return TypeMsgMakeOutcomePaymen
 
 type MsgWithdrawShare struct {
 	Recipient sdk.AccAddress `json:"recipient" yaml:"recipient"`
-	WarToken string         `json:"war_token" yaml:"war_token"`
+	WarToken  string         `json:"war_token" yaml:"war_token"`
 }
 
 func NewMsgWithdrawShare(recipient sdk.AccAddress, warToken string) MsgWithdrawShare {
 	return MsgWithdrawShare{
 		Recipient: recipient,
-		WarToken: warToken,
+		WarToken:  warToken,
 	}
 }
 
@@ -462,3 +1083,227 @@ func (msg MsgWithdrawShare) GetSigners() []sdk.AccAddress {
 func (msg MsgWithdrawShare) Route() string { return RouterKey }
 
 func (msg MsgWithdrawShare) Type() string { return TypeMsgWithdrawShare }
+
+type MsgClaimVested struct {
+	Claimer  sdk.AccAddress `json:"claimer" yaml:"claimer"`
+	WarToken string         `json:"war_token" yaml:"war_token"`
+}
+
+func NewMsgClaimVested(claimer sdk.AccAddress, warToken string) MsgClaimVested {
+	return MsgClaimVested{
+		Claimer:  claimer,
+		WarToken: warToken,
+	}
+}
+
+func (msg MsgClaimVested) ValidateBasic() error {
+	// Check if empty
+	if msg.Claimer.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Claimer")
+	} else if strings.TrimSpace(msg.WarToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "WarToken")
+	}
+
+	// Validate war token
+	err := CheckCoinDenom(msg.WarToken)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msg MsgClaimVested) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgClaimVested) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Claimer}
+}
+
+func (msg MsgClaimVested) Route() string { return RouterKey }
+
+func (msg MsgClaimVested) Type() string { return TypeMsgClaimVested }
+
+type MsgSpendFromFundingPool struct {
+	Spender   sdk.AccAddress `json:"spender" yaml:"spender"`
+	WarToken  string         `json:"war_token" yaml:"war_token"`
+	Recipient sdk.AccAddress `json:"recipient" yaml:"recipient"`
+	Amount    sdk.Coins      `json:"amount" yaml:"amount"`
+}
+
+func NewMsgSpendFromFundingPool(spender sdk.AccAddress, warToken string,
+	recipient sdk.AccAddress, amount sdk.Coins) MsgSpendFromFundingPool {
+	return MsgSpendFromFundingPool{
+		Spender:   spender,
+		WarToken:  warToken,
+		Recipient: recipient,
+		Amount:    amount,
+	}
+}
+
+func (msg MsgSpendFromFundingPool) ValidateBasic() error {
+	// Check if empty
+	if msg.Spender.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Spender")
+	} else if strings.TrimSpace(msg.WarToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "WarToken")
+	} else if msg.Recipient.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Recipient")
+	}
+
+	// Validate war token
+	err := CheckCoinDenom(msg.WarToken)
+	if err != nil {
+		return err
+	}
+
+	// Validate amount
+	if !msg.Amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Amount.String())
+	} else if msg.Amount.IsZero() {
+		return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
+	}
+
+	return nil
+}
+
+func (msg MsgSpendFromFundingPool) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSpendFromFundingPool) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Spender}
+}
+
+func (msg MsgSpendFromFundingPool) Route() string { return RouterKey }
+
+func (msg MsgSpendFromFundingPool) Type() string { return TypeMsgSpendFromFundingPool }
+
+type MsgSubmitProposal struct {
+	Proposer     sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	WarToken     string         `json:"war_token" yaml:"war_token"`
+	ProposalType string         `json:"proposal_type" yaml:"proposal_type"`
+	Recipient    sdk.AccAddress `json:"recipient" yaml:"recipient"`
+	Amount       sdk.Coins      `json:"amount" yaml:"amount"`
+	Edit         WarEdit        `json:"edit" yaml:"edit"`
+}
+
+func NewMsgSubmitSpendProposal(proposer sdk.AccAddress, warToken string,
+	recipient sdk.AccAddress, amount sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Proposer:     proposer,
+		WarToken:     warToken,
+		ProposalType: SpendProposalType,
+		Recipient:    recipient,
+		Amount:       amount,
+	}
+}
+
+func NewMsgSubmitEditProposal(proposer sdk.AccAddress, warToken string,
+	edit WarEdit) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Proposer:     proposer,
+		WarToken:     warToken,
+		ProposalType: EditProposalType,
+		Edit:         edit,
+	}
+}
+
+func (msg MsgSubmitProposal) ValidateBasic() error {
+	// Check if empty
+	if msg.Proposer.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Proposer")
+	} else if strings.TrimSpace(msg.WarToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "WarToken")
+	}
+
+	// Validate war token
+	err := CheckCoinDenom(msg.WarToken)
+	if err != nil {
+		return err
+	}
+
+	// Validate proposal contents
+	switch msg.ProposalType {
+	case SpendProposalType:
+		if msg.Recipient.Empty() {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Recipient")
+		} else if !msg.Amount.IsValid() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Amount.String())
+		} else if msg.Amount.IsZero() {
+			return sdkerrors.Wrap(ErrArgumentMustBePositive, "Amount")
+		}
+	case EditProposalType:
+		if err := msg.Edit.ValidateBasic(); err != nil {
+			return err
+		}
+	default:
+		return sdkerrors.Wrap(ErrInvalidProposalType, msg.ProposalType)
+	}
+
+	return nil
+}
+
+func (msg MsgSubmitProposal) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSubmitProposal) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Proposer}
+}
+
+func (msg MsgSubmitProposal) Route() string { return RouterKey }
+
+func (msg MsgSubmitProposal) Type() string { return TypeMsgSubmitProposal }
+
+type MsgVote struct {
+	Voter      sdk.AccAddress `json:"voter" yaml:"voter"`
+	WarToken   string         `json:"war_token" yaml:"war_token"`
+	ProposalID uint64         `json:"proposal_id" yaml:"proposal_id"`
+	Option     string         `json:"option" yaml:"option"`
+}
+
+func NewMsgVote(voter sdk.AccAddress, warToken string, proposalID uint64,
+	option string) MsgVote {
+	return MsgVote{
+		Voter:      voter,
+		WarToken:   warToken,
+		ProposalID: proposalID,
+		Option:     option,
+	}
+}
+
+func (msg MsgVote) ValidateBasic() error {
+	// Check if empty
+	if msg.Voter.Empty() {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Voter")
+	} else if strings.TrimSpace(msg.WarToken) == "" {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "WarToken")
+	}
+
+	// Validate war token
+	err := CheckCoinDenom(msg.WarToken)
+	if err != nil {
+		return err
+	}
+
+	// Validate vote option
+	if !IsValidVoteOption(msg.Option) {
+		return sdkerrors.Wrap(ErrInvalidVoteOption, msg.Option)
+	}
+
+	return nil
+}
+
+func (msg MsgVote) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgVote) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Voter}
+}
+
+func (msg MsgVote) Route() string { return RouterKey }
+
+func (msg MsgVote) Type() string { return TypeMsgVote }
@@ -146,6 +146,36 @@ func TestValidateBasicMsgCreateReserveTokensWrongAmountInvalidGivesError(t *test
 
 // MsgCreateWar: Max supply validity
 
+func TestValidateBasicMsgCreateWeightedSwapperWarCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCreateWeightedSwapperWar()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgCreateWeightedSwapperWeightsMismatchGivesError(t *testing.T) {
+	message := newValidMsgCreateWeightedSwapperWar()
+	message.ReserveTokens = []string{reserveToken, reserveToken2, "rex"}
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgCreateWeightedSwapperWar()
+	message.ReserveTokens = swapperReserves() // missing reserveToken3
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgCreateWeightedSwapperWar()
+	message.FunctionParameters = message.FunctionParameters[:1] // one weight
+	message.ReserveTokens = message.ReserveTokens[:1]
+	require.NotNil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateSanityRateWithMoreThanTwoReservesGivesError(t *testing.T) {
+	message := newValidMsgCreateWeightedSwapperWar()
+	message.SanityRate = sdk.OneDec()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
 func TestValidateBasicMsgCreateInvalidMaxSupplyGivesError(t *testing.T) {
 	message := newValidMsgCreateWar()
 	message.MaxSupply.Amount = message.MaxSupply.Amount.Neg() // negate
@@ -272,6 +302,298 @@ func TestValidateBasicMsgCreateZeroMaxSupplyGivesError(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+// MsgCreateWar: Hatch deadline cannot be negative or set for non-augmented
+
+func TestValidateBasicMsgCreateNegativeHatchDeadlineGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FunctionType = AugmentedFunction
+	message.FunctionParameters = functionParametersAugmented()
+	message.HatchDeadlineHeight = -1
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateHatchDeadlineForNonAugmentedGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.HatchDeadlineHeight = 100
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateHatchDeadlineForAugmentedGivesNoError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FunctionType = AugmentedFunction
+	message.FunctionParameters = functionParametersAugmented()
+	message.HatchDeadlineHeight = 100
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCreateWar: Hatch restrictions must be valid and only set for augmented
+
+func TestValidateBasicMsgCreateNegativeMaxHatchContributionGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FunctionType = AugmentedFunction
+	message.FunctionParameters = functionParametersAugmented()
+	message.MaxHatchContribution = sdk.NewInt(-1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateInvalidHatchMembershipDenomGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FunctionType = AugmentedFunction
+	message.FunctionParameters = functionParametersAugmented()
+	message.HatchMembershipDenom = "123abc"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateEmptyAllowedHatcherGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FunctionType = AugmentedFunction
+	message.FunctionParameters = functionParametersAugmented()
+	message.AllowedHatchers = []sdk.AccAddress{initCreator, {}}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateHatchRestrictionsForNonAugmentedGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.AllowedHatchers = []sdk.AccAddress{initCreator}
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgCreateWar()
+	message.HatchMembershipDenom = "membertoken"
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgCreateWar()
+	message.MaxHatchContribution = sdk.NewInt(100)
+	require.NotNil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateHatchRestrictionsForAugmentedGivesNoError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FunctionType = AugmentedFunction
+	message.FunctionParameters = functionParametersAugmented()
+	message.AllowedHatchers = []sdk.AccAddress{initCreator}
+	message.HatchMembershipDenom = "membertoken"
+	message.MaxHatchContribution = sdk.NewInt(100)
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCreateWar: Hatch vesting must be valid and only set for augmented
+
+func TestValidateBasicMsgCreateNegativeHatchVestingGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FunctionType = AugmentedFunction
+	message.FunctionParameters = functionParametersAugmented()
+	message.HatchVestingCliff = -1
+	message.HatchVestingPeriod = 100
+	require.NotNil(t, message.ValidateBasic())
+
+	message.HatchVestingCliff = 0
+	message.HatchVestingPeriod = -1
+	require.NotNil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateHatchVestingCliffExceedsPeriodGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FunctionType = AugmentedFunction
+	message.FunctionParameters = functionParametersAugmented()
+	message.HatchVestingCliff = 101
+	message.HatchVestingPeriod = 100
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrVestingCliffExceedsVestingPeriod.Is(err))
+}
+
+func TestValidateBasicMsgCreateHatchVestingForNonAugmentedGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.HatchVestingPeriod = 100
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateHatchVestingForAugmentedGivesNoError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FunctionType = AugmentedFunction
+	message.FunctionParameters = functionParametersAugmented()
+	message.HatchVestingCliff = 100
+	message.HatchVestingPeriod = 100
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCreateWar: Funding pool fields must be valid and only set with spenders
+
+func TestValidateBasicMsgCreateFundingPoolWithoutSpendLimitOrEpochGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FundingPoolSpenders = []sdk.AccAddress{initCreator}
+	message.FundingPoolEpochBlocks = 10
+	require.NotNil(t, message.ValidateBasic())
+
+	message.FundingPoolSpendLimit = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	message.FundingPoolEpochBlocks = 0
+	require.NotNil(t, message.ValidateBasic())
+
+	message.FundingPoolEpochBlocks = -1
+	require.NotNil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateEmptyFundingPoolSpenderGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FundingPoolSpenders = []sdk.AccAddress{initCreator, {}}
+	message.FundingPoolSpendLimit = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	message.FundingPoolEpochBlocks = 10
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCreateFundingPoolFieldsWithoutSpendersGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FundingPoolSpendLimit = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgCreateWar()
+	message.FundingPoolEpochBlocks = 10
+	require.NotNil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateFundingPoolGivesNoError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.FundingPoolSpenders = []sdk.AccAddress{initCreator}
+	message.FundingPoolSpendLimit = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	message.FundingPoolEpochBlocks = 10
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgCreateNegativeGovernanceFieldsGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.GovernanceQuorumPercentage = sdk.NewDec(-1)
+	message.GovernanceVotingPeriod = 100
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgCreateWar()
+	message.GovernanceThresholdPercentage = sdk.NewDec(-1)
+	message.GovernanceVotingPeriod = 100
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgCreateWar()
+	message.GovernanceVotingPeriod = -1
+	require.NotNil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateNegativeMaxPriceMoveFieldsGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.MaxPriceMovePercentage = sdk.NewDec(-1)
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgCreateWar()
+	message.MaxPriceMovePercentage = sdk.NewDec(20)
+	message.PriceMoveCooldown = -1
+	require.NotNil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreatePriceMoveCooldownWithoutMaxGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.PriceMoveCooldown = 10
+	require.NotNil(t, message.ValidateBasic())
+
+	message.MaxPriceMovePercentage = sdk.NewDec(20)
+	require.Nil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateInvalidGovernancePercentagesGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.GovernanceQuorumPercentage = sdk.ZeroDec()
+	message.GovernanceVotingPeriod = 100
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgCreateWar()
+	message.GovernanceQuorumPercentage = sdk.NewDec(101)
+	message.GovernanceVotingPeriod = 100
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgCreateWar()
+	message.GovernanceQuorumPercentage = sdk.NewDec(50)
+	message.GovernanceThresholdPercentage = sdk.NewDec(100)
+	message.GovernanceVotingPeriod = 100
+	require.NotNil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateGovernanceFieldsWithoutVotingPeriodGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.GovernanceQuorumPercentage = sdk.NewDec(50)
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgCreateWar()
+	message.GovernanceThresholdPercentage = sdk.NewDec(50)
+	require.NotNil(t, message.ValidateBasic())
+}
+
+func TestValidateBasicMsgCreateGovernanceGivesNoError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.GovernanceQuorumPercentage = sdk.NewDec(100)
+	message.GovernanceThresholdPercentage = sdk.ZeroDec()
+	message.GovernanceVotingPeriod = 100
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCreateWar: Signers and signer threshold
+
+func TestValidateBasicMsgCreateDuplicateSignerGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.Signers = []sdk.AccAddress{initCreator, initCreator}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrDuplicateSigner.Is(err))
+}
+
+func TestValidateBasicMsgCreateSignerThresholdAboveSignersGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.SignerThreshold = uint64(len(message.Signers) + 1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrInvalidSignerThreshold.Is(err))
+}
+
+func TestValidateBasicMsgCreateSignerThresholdGivesNoError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.Signers = []sdk.AccAddress{initCreator, initFeeAddress}
+	message.SignerThreshold = 1
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+func TestValidateBasicMsgCreateNegativeEditDelayGivesError(t *testing.T) {
+	message := newValidMsgCreateWar()
+	message.EditDelay = -1
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrArgumentCannotBeNegative.Is(err))
+}
+
 // MsgCreateWar: Valid war creation
 
 func TestValidateBasicMsgCreateWarCorrectlyGivesNoError(t *testing.T) {
@@ -339,12 +661,23 @@ func TestValidateBasicMsgEditWarEditorArgumentMissingGivesError(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestValidateBasicMsgEditWarDuplicateSignerGivesError(t *testing.T) {
+	message := newValidMsgEditWar()
+	message.Signers = []sdk.AccAddress{initCreator, initCreator}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrDuplicateSigner.Is(err))
+}
+
 // MsgEditWar: no edits
 
 func TestValidateBasicMsgEditWarNoEditsGivesError(t *testing.T) {
 	message := NewMsgEditWar(DoNotModifyField, DoNotModifyField,
 		DoNotModifyField, DoNotModifyField, DoNotModifyField,
-		DoNotModifyField, initCreator, initSigners)
+		DoNotModifyField, DoNotModifyField, DoNotModifyField, DoNotModifyField,
+		DoNotModifyField, DoNotModifyField, DoNotModifyField, DoNotModifyField,
+		initCreator, initSigners)
 
 	err := message.ValidateBasic()
 	require.NotNil(t, err)
@@ -359,6 +692,90 @@ func TestValidateBasicMsgEditWarCorrectlyGivesNoError(t *testing.T) {
 	require.Nil(t, err)
 }
 
+// MsgUpdateWarSigners
+
+func TestValidateBasicMsgUpdateWarSignersTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgUpdateWarSigners()
+	message.Token = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgUpdateWarSignersNewSignersMissingGivesError(t *testing.T) {
+	message := newValidMsgUpdateWarSigners()
+	message.NewSigners = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgUpdateWarSignersNewSignerThresholdAboveSignersGivesError(t *testing.T) {
+	message := newValidMsgUpdateWarSigners()
+	message.NewSignerThreshold = uint64(len(message.NewSigners) + 1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrInvalidSignerThreshold.Is(err))
+}
+
+func TestValidateBasicMsgUpdateWarSignersEditorArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgUpdateWarSigners()
+	message.Editor = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgUpdateWarSignersCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgUpdateWarSigners()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCancelPendingEdit
+
+func TestValidateBasicMsgCancelPendingEditTokenArgumentMissingGivesError(t *testing.T) {
+	message := NewMsgCancelPendingEdit("", 0, initCreator, initSigners)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCancelPendingEditDuplicateSignerGivesError(t *testing.T) {
+	message := NewMsgCancelPendingEdit(initToken, 0, initCreator,
+		[]sdk.AccAddress{initCreator, initCreator})
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrDuplicateSigner.Is(err))
+}
+
+func TestValidateBasicMsgCancelPendingEditCorrectlyGivesNoError(t *testing.T) {
+	message := NewMsgCancelPendingEdit(initToken, 0, initCreator, initSigners)
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgSetWarPaused
+
+func TestValidateBasicMsgSetWarPausedEditorArgumentMissingGivesError(t *testing.T) {
+	message := NewMsgSetWarPaused(initToken, true, sdk.AccAddress{}, initSigners)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrArgumentCannotBeEmpty.Is(err))
+}
+
+func TestValidateBasicMsgSetWarPausedCorrectlyGivesNoError(t *testing.T) {
+	message := NewMsgSetWarPaused(initToken, true, initCreator, initSigners)
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
 // MsgBuy: missing arguments
 
 func TestValidateBasicMsgBuyBuyerArgumentMissingGivesError(t *testing.T) {
@@ -404,6 +821,59 @@ func TestValidateBasicMsgBuyCorrectlyGivesNoError(t *testing.T) {
 	require.Nil(t, err)
 }
 
+// MsgBuyWithSpend: missing arguments
+
+func TestValidateBasicMsgBuyWithSpendBuyerArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgBuyWithSpend()
+	message.Buyer = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyWithSpendWarTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgBuyWithSpend()
+	message.WarToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyWithSpendSpendArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgBuyWithSpend()
+	message.Spend = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgBuyWithSpend: invalid arguments
+
+func TestValidateBasicMsgBuyWithSpendInvalidWarTokenGivesError(t *testing.T) {
+	message := newValidMsgBuyWithSpend()
+	message.WarToken = "123abc" // starts with number
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgBuyWithSpendSpendInvalidGivesError(t *testing.T) {
+	message := newValidMsgBuyWithSpend()
+	message.Spend[0].Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgBuyWithSpend: correct buy
+
+func TestValidateBasicMsgBuyWithSpendCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgBuyWithSpend()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
 // MsgSell: missing arguments
 
 func TestValidateBasicMsgSellSellerArgumentMissingGivesError(t *testing.T) {
@@ -432,6 +902,14 @@ func TestValidateBasicMsgSellZeroAmountGivesError(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestValidateBasicMsgSellMinReturnsInvalidGivesError(t *testing.T) {
+	message := newValidMsgSell()
+	message.MinReturns = sdk.Coins{sdk.NewInt64Coin(reserveToken, 0)}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
 // MsgSell: correct sell
 
 func TestValidateBasicMsgSellCorrectlyGivesNoError(t *testing.T) {
@@ -439,6 +917,10 @@ func TestValidateBasicMsgSellCorrectlyGivesNoError(t *testing.T) {
 
 	err := message.ValidateBasic()
 	require.Nil(t, err)
+
+	message.MinReturns = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 100))
+	err = message.ValidateBasic()
+	require.Nil(t, err)
 }
 
 // MsgSwap: missing arguments
@@ -493,6 +975,22 @@ func TestValidateBasicMsgSwapZeroFromAmountGivesError(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestValidateBasicMsgSwapInvalidMinOutputGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.MinOutput.Amount = sdk.NewInt(-1)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSwapMinOutputNotInToTokenGivesError(t *testing.T) {
+	message := newValidMsgSwap()
+	message.MinOutput = sdk.NewInt64Coin(reserveToken, 10)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
 // MsgSwap: fromToken==toToken
 
 func TestValidateBasicMsgSwapFromAndToSameTokenGivesError(t *testing.T) {
@@ -512,3 +1010,380 @@ func TestValidateBasicMsgSwapCorrectlyGivesNoError(t *testing.T) {
 	err := message.ValidateBasic()
 	require.Nil(t, err)
 }
+
+// MsgRoutedSwap: missing arguments
+
+func TestValidateBasicMsgRoutedSwapSwapperArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgRoutedSwap()
+	message.Swapper = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgRoutedSwapHopsArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgRoutedSwap()
+	message.Hops = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgRoutedSwap: invalid arguments
+
+func TestValidateBasicMsgRoutedSwapZeroFromAmountGivesError(t *testing.T) {
+	message := newValidMsgRoutedSwap()
+	message.From.Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgRoutedSwapInvalidHopGivesError(t *testing.T) {
+	message := newValidMsgRoutedSwap()
+	message.Hops[1].ToToken = "123abc"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgRoutedSwapTooManyHopsGivesError(t *testing.T) {
+	message := newValidMsgRoutedSwap()
+	message.Hops = append(message.Hops,
+		NewSwapHop("war3", "token3"),
+		NewSwapHop("war4", "token4"),
+		NewSwapHop("war5", "token5"))
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgRoutedSwapMinOutputNotInToTokenGivesError(t *testing.T) {
+	message := newValidMsgRoutedSwap()
+	message.MinOutput = sdk.NewInt64Coin(reserveToken2, 10)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgRoutedSwap: route back to fromToken
+
+func TestValidateBasicMsgRoutedSwapRouteEndsAtFromTokenGivesError(t *testing.T) {
+	message := newValidMsgRoutedSwap()
+	message.Hops[1].ToToken = message.From.Denom
+	message.MinOutput = sdk.NewInt64Coin(message.From.Denom, 0)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgRoutedSwap: correct routed swap
+
+func TestValidateBasicMsgRoutedSwapCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgRoutedSwap()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCancelOrder: missing arguments
+
+func TestValidateBasicMsgCancelOrderOwnerArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelOrder()
+	message.Owner = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCancelOrderWarTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelOrder()
+	message.WarToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCancelOrderOrderTypeArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelOrder()
+	message.OrderType = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCancelOrder: invalid arguments
+
+func TestValidateBasicMsgCancelOrderInvalidWarTokenGivesError(t *testing.T) {
+	message := newValidMsgCancelOrder()
+	message.WarToken = "123abc" // starts with number
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCancelOrderInvalidOrderTypeGivesError(t *testing.T) {
+	message := newValidMsgCancelOrder()
+	message.OrderType = "invalid"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCancelOrder: correct cancellation
+
+func TestValidateBasicMsgCancelOrderCorrectlyGivesNoError(t *testing.T) {
+	for _, orderType := range []string{BuyOrderType, SellOrderType, SwapOrderType} {
+		message := newValidMsgCancelOrder()
+		message.OrderType = orderType
+
+		err := message.ValidateBasic()
+		require.Nil(t, err)
+	}
+}
+
+// MsgLimitBuy: missing arguments
+
+func TestValidateBasicMsgLimitBuyBuyerArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgLimitBuy()
+	message.Buyer = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgLimitBuy: invalid arguments
+
+func TestValidateBasicMsgLimitBuyZeroAmountGivesError(t *testing.T) {
+	message := newValidMsgLimitBuy()
+	message.Amount.Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgLimitBuyNonPositiveExpiryHeightGivesError(t *testing.T) {
+	message := newValidMsgLimitBuy()
+
+	message.ExpiryHeight = 0
+	require.NotNil(t, message.ValidateBasic())
+
+	message.ExpiryHeight = -1
+	require.NotNil(t, message.ValidateBasic())
+}
+
+// MsgLimitBuy: correct limit buy
+
+func TestValidateBasicMsgLimitBuyCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgLimitBuy()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgLimitSell: missing arguments
+
+func TestValidateBasicMsgLimitSellSellerArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgLimitSell()
+	message.Seller = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgLimitSellMinReturnsArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgLimitSell()
+	message.MinReturns = nil
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgLimitSell: invalid arguments
+
+func TestValidateBasicMsgLimitSellZeroAmountGivesError(t *testing.T) {
+	message := newValidMsgLimitSell()
+	message.Amount.Amount = sdk.ZeroInt()
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgLimitSellNonPositiveExpiryHeightGivesError(t *testing.T) {
+	message := newValidMsgLimitSell()
+
+	message.ExpiryHeight = 0
+	require.NotNil(t, message.ValidateBasic())
+
+	message.ExpiryHeight = -1
+	require.NotNil(t, message.ValidateBasic())
+}
+
+// MsgLimitSell: correct limit sell
+
+func TestValidateBasicMsgLimitSellCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgLimitSell()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgCancelLimitOrder: missing arguments
+
+func TestValidateBasicMsgCancelLimitOrderOwnerArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelLimitOrder()
+	message.Owner = sdk.AccAddress{}
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgCancelLimitOrderWarTokenArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgCancelLimitOrder()
+	message.WarToken = ""
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCancelLimitOrder: invalid arguments
+
+func TestValidateBasicMsgCancelLimitOrderInvalidWarTokenGivesError(t *testing.T) {
+	message := newValidMsgCancelLimitOrder()
+	message.WarToken = "123abc" // starts with number
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgCancelLimitOrder: correct cancellation
+
+func TestValidateBasicMsgCancelLimitOrderCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgCancelLimitOrder()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgSpendFromFundingPool: missing arguments
+
+func TestValidateBasicMsgSpendFromFundingPoolArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgSpendFromFundingPool()
+	message.Spender = sdk.AccAddress{}
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgSpendFromFundingPool()
+	message.WarToken = ""
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgSpendFromFundingPool()
+	message.Recipient = sdk.AccAddress{}
+	require.NotNil(t, message.ValidateBasic())
+}
+
+// MsgSpendFromFundingPool: invalid arguments
+
+func TestValidateBasicMsgSpendFromFundingPoolZeroAmountGivesError(t *testing.T) {
+	message := newValidMsgSpendFromFundingPool()
+	message.Amount = sdk.Coins(nil)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+// MsgSpendFromFundingPool: correct spend
+
+func TestValidateBasicMsgSpendFromFundingPoolCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgSpendFromFundingPool()
+
+	err := message.ValidateBasic()
+	require.Nil(t, err)
+}
+
+// MsgSubmitProposal: missing arguments
+
+func TestValidateBasicMsgSubmitProposalArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgSubmitSpendProposal()
+	message.Proposer = sdk.AccAddress{}
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgSubmitSpendProposal()
+	message.WarToken = ""
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgSubmitSpendProposal()
+	message.Recipient = sdk.AccAddress{}
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgSubmitEditProposal()
+	message.Edit.Description = ""
+	require.NotNil(t, message.ValidateBasic())
+}
+
+// MsgSubmitProposal: invalid arguments
+
+func TestValidateBasicMsgSubmitProposalInvalidProposalTypeGivesError(t *testing.T) {
+	message := newValidMsgSubmitSpendProposal()
+	message.ProposalType = "invalid"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrInvalidProposalType.Is(err))
+}
+
+func TestValidateBasicMsgSubmitSpendProposalZeroAmountGivesError(t *testing.T) {
+	message := newValidMsgSubmitSpendProposal()
+	message.Amount = sdk.Coins(nil)
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+}
+
+func TestValidateBasicMsgSubmitEditProposalNothingEditedGivesError(t *testing.T) {
+	message := newValidMsgSubmitEditProposal()
+	message.Edit.Name = DoNotModifyField
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrDidNotEditAnything.Is(err))
+}
+
+// MsgSubmitProposal: correct proposal
+
+func TestValidateBasicMsgSubmitProposalCorrectlyGivesNoError(t *testing.T) {
+	require.Nil(t, newValidMsgSubmitSpendProposal().ValidateBasic())
+	require.Nil(t, newValidMsgSubmitEditProposal().ValidateBasic())
+}
+
+// MsgVote: missing arguments
+
+func TestValidateBasicMsgVoteArgumentMissingGivesError(t *testing.T) {
+	message := newValidMsgVote()
+	message.Voter = sdk.AccAddress{}
+	require.NotNil(t, message.ValidateBasic())
+
+	message = newValidMsgVote()
+	message.WarToken = ""
+	require.NotNil(t, message.ValidateBasic())
+}
+
+// MsgVote: invalid arguments
+
+func TestValidateBasicMsgVoteInvalidOptionGivesError(t *testing.T) {
+	message := newValidMsgVote()
+	message.Option = "abstain"
+
+	err := message.ValidateBasic()
+	require.NotNil(t, err)
+	require.True(t, ErrInvalidVoteOption.Is(err))
+}
+
+// MsgVote: correct vote
+
+func TestValidateBasicMsgVoteCorrectlyGivesNoError(t *testing.T) {
+	message := newValidMsgVote()
+	require.Nil(t, message.ValidateBasic())
+
+	message.Option = NoVoteOption
+	require.Nil(t, message.ValidateBasic())
+}
@@ -0,0 +1,112 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	LimitBuyOrderType  = "limit_buy"
+	LimitSellOrderType = "limit_sell"
+
+	CancelReasonExpired = "expired"
+)
+
+// LimitOrder is a buy or sell order that stands in a war's order book across
+// batches until its limit price is met by the current price of the war, at
+// which point it is added to the war's current batch as a regular order.
+// For limit buys, the limit prices are the max prices that the buyer is
+// willing to pay. For limit sells, they are the min returns that the seller
+// is willing to accept.
+type LimitOrder struct {
+	ID           uint64         `json:"id" yaml:"id"`
+	OrderType    string         `json:"order_type" yaml:"order_type"`
+	Address      sdk.AccAddress `json:"address" yaml:"address"`
+	Amount       sdk.Coin       `json:"amount" yaml:"amount"`
+	LimitPrices  sdk.Coins      `json:"limit_prices" yaml:"limit_prices"`
+	ExpiryHeight int64          `json:"expiry_height" yaml:"expiry_height"`
+}
+
+func NewLimitOrder(orderType string, address sdk.AccAddress, amount sdk.Coin,
+	limitPrices sdk.Coins, expiryHeight int64) LimitOrder {
+	return LimitOrder{
+		OrderType:    orderType,
+		Address:      address,
+		Amount:       amount,
+		LimitPrices:  limitPrices,
+		ExpiryHeight: expiryHeight,
+	}
+}
+
+func (lo LimitOrder) IsBuy() bool { return lo.OrderType == LimitBuyOrderType }
+
+// IsExpired returns true if the order can no longer be added to a batch at the
+// specified block height, i.e. if the height is past the order's expiry height
+func (lo LimitOrder) IsExpired(height int64) bool { return height > lo.ExpiryHeight }
+
+// GetEscrow returns the coins held by the batches intermediary account for the
+// order, which are the max prices for limit buys and the war tokens to be
+// sold for limit sells
+func (lo LimitOrder) GetEscrow() sdk.Coins {
+	if lo.IsBuy() {
+		return lo.LimitPrices
+	}
+	return sdk.Coins{lo.Amount}
+}
+
+// GetLimitPriceRatio returns the ratio of the order's limit prices to the
+// specified prices per token multiplied by the order's amount. This is the
+// least ratio across the denominations for limit buys, and the greatest for
+// limit sells. Prices that are not positive are not considered. The limit
+// prices are only met by the prices if the ratio is at least one for limit
+// buys or at most one for limit sells.
+func (lo LimitOrder) GetLimitPriceRatio(pricesPT sdk.DecCoins) sdk.Dec {
+	ratio := sdk.OneDec()
+	first := true
+	for _, p := range pricesPT {
+		if !p.Amount.IsPositive() {
+			continue
+		}
+		price := p.Amount.MulInt(lo.Amount.Amount)
+		r := lo.LimitPrices.AmountOf(p.Denom).ToDec().Quo(price)
+		if first || (lo.IsBuy() && r.LT(ratio)) || (!lo.IsBuy() && r.GT(ratio)) {
+			ratio = r
+			first = false
+		}
+	}
+	return ratio
+}
+
+// LimitPricesMetBy returns true if the order's limit prices are met by the
+// specified prices per token
+func (lo LimitOrder) LimitPricesMetBy(pricesPT sdk.DecCoins) bool {
+	if lo.IsBuy() {
+		return lo.GetLimitPriceRatio(pricesPT).GTE(sdk.OneDec())
+	}
+	return lo.GetLimitPriceRatio(pricesPT).LTE(sdk.OneDec())
+}
+
+func (lo LimitOrder) ToBuyOrder() BuyOrder {
+	return NewBuyOrder(lo.Address, lo.Amount, lo.LimitPrices, false)
+}
+
+func (lo LimitOrder) ToSellOrder() SellOrder {
+	return NewSellOrder(lo.Address, lo.Amount, lo.LimitPrices)
+}
+
+// OrderBook holds the standing limit orders of a war, in the order that they
+// were placed, and the ID to be given to the next order. Each order is given an
+// ID that is unique within the order book. Each order is stored under its own
+// key, so this is only used for the genesis state and for queries.
+type OrderBook struct {
+	Token       string       `json:"token" yaml:"token"`
+	NextOrderID uint64       `json:"next_order_id" yaml:"next_order_id"`
+	Orders      []LimitOrder `json:"orders" yaml:"orders"`
+}
+
+func NewOrderBook(token string) OrderBook {
+	return OrderBook{
+		Token:       token,
+		NextOrderID: 0,
+		Orders:      nil,
+	}
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+)
+
+func TestLimitOrderIsExpired(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount := sdk.NewInt64Coin(initToken, 10)
+	limitPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50))
+	lo := NewLimitOrder(LimitBuyOrderType, address, amount, limitPrices, 10)
+
+	require.False(t, lo.IsExpired(9))
+	require.False(t, lo.IsExpired(10))
+	require.True(t, lo.IsExpired(11))
+}
+
+func TestLimitOrderGetEscrow(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount := sdk.NewInt64Coin(initToken, 10)
+	limitPrices := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 50))
+
+	buy := NewLimitOrder(LimitBuyOrderType, address, amount, limitPrices, 10)
+	require.True(t, buy.IsBuy())
+	require.Equal(t, limitPrices, buy.GetEscrow())
+
+	sell := NewLimitOrder(LimitSellOrderType, address, amount, limitPrices, 10)
+	require.False(t, sell.IsBuy())
+	require.Equal(t, sdk.Coins{amount}, sell.GetEscrow())
+}
+
+func TestLimitOrderGetLimitPriceRatio(t *testing.T) {
+	address := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	amount := sdk.NewInt64Coin(initToken, 10)
+	limitPrices := sdk.NewCoins(
+		sdk.NewInt64Coin(reserveToken, 50),
+		sdk.NewInt64Coin(reserveToken2, 100))
+	pricesPT := sdk.NewDecCoins(
+		sdk.NewInt64DecCoin(reserveToken, 5),
+		sdk.NewInt64DecCoin(reserveToken2, 8))
+
+	// Limit buy uses the least ratio (50/50=1 rather than 100/80=1.25)
+	buy := NewLimitOrder(LimitBuyOrderType, address, amount, limitPrices, 10)
+	require.Equal(t, sdk.OneDec(), buy.GetLimitPriceRatio(pricesPT))
+	require.True(t, buy.LimitPricesMetBy(pricesPT))
+	require.False(t, buy.LimitPricesMetBy(sdk.NewDecCoins(
+		sdk.NewInt64DecCoin(reserveToken, 6))))
+
+	// Limit sell uses the greatest ratio (100/80=1.25 rather than 50/50=1)
+	sell := NewLimitOrder(LimitSellOrderType, address, amount, limitPrices, 10)
+	require.Equal(t, sdk.NewDecWithPrec(125, 2), sell.GetLimitPriceRatio(pricesPT))
+	require.False(t, sell.LimitPricesMetBy(pricesPT))
+	require.True(t, sell.LimitPricesMetBy(sdk.NewDecCoins(
+		sdk.NewInt64DecCoin(reserveToken, 5))))
+
+	// Prices that are not positive are not considered
+	require.Equal(t, sdk.OneDec(), buy.GetLimitPriceRatio(sdk.DecCoins{}))
+}
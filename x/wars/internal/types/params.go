@@ -1,18 +1,43 @@
 package types
 
 import (
+	"errors"
 	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"reflect"
 )
 
 // Parameter store keys
 var (
-	KeyReservedWarTokens = []byte("ReservedWarTokens")
+	KeyReservedWarTokens         = []byte("ReservedWarTokens")
+	KeyPauseAuthority            = []byte("PauseAuthority")
+	KeyMaxTxFeePercentage        = []byte("MaxTxFeePercentage")
+	KeyMaxExitFeePercentage      = []byte("MaxExitFeePercentage")
+	KeyMinBatchBlocks            = []byte("MinBatchBlocks")
+	KeyMaxBatchBlocks            = []byte("MaxBatchBlocks")
+	KeyMaxWarsPerCreator         = []byte("MaxWarsPerCreator")
+	KeyWarCreationDeposit        = []byte("WarCreationDeposit")
+	KeyMaxLimitOrderExpiryBlocks = []byte("MaxLimitOrderExpiryBlocks")
+	KeyMaxLimitOrdersPerAddress  = []byte("MaxLimitOrdersPerAddress")
+	KeyMaxLimitOrdersPerWar      = []byte("MaxLimitOrdersPerWar")
+	KeyMaxProposalsPerWar        = []byte("MaxProposalsPerWar")
 )
 
 // wars parameters
 type Params struct {
-	ReservedWarTokens []string `json:"reserved_war_tokens" yaml:"reserved_war_tokens"`
+	ReservedWarTokens         []string       `json:"reserved_war_tokens" yaml:"reserved_war_tokens"`
+	PauseAuthority            sdk.AccAddress `json:"pause_authority" yaml:"pause_authority"`
+	MaxTxFeePercentage        sdk.Dec        `json:"max_tx_fee_percentage" yaml:"max_tx_fee_percentage"`
+	MaxExitFeePercentage      sdk.Dec        `json:"max_exit_fee_percentage" yaml:"max_exit_fee_percentage"`
+	MinBatchBlocks            sdk.Uint       `json:"min_batch_blocks" yaml:"min_batch_blocks"`
+	MaxBatchBlocks            sdk.Uint       `json:"max_batch_blocks" yaml:"max_batch_blocks"`
+	MaxWarsPerCreator         uint64         `json:"max_wars_per_creator" yaml:"max_wars_per_creator"`
+	WarCreationDeposit        sdk.Coins      `json:"war_creation_deposit" yaml:"war_creation_deposit"`
+	MaxLimitOrderExpiryBlocks uint64         `json:"max_limit_order_expiry_blocks" yaml:"max_limit_order_expiry_blocks"`
+	MaxLimitOrdersPerAddress  uint64         `json:"max_limit_orders_per_address" yaml:"max_limit_orders_per_address"`
+	MaxLimitOrdersPerWar      uint64         `json:"max_limit_orders_per_war" yaml:"max_limit_orders_per_war"`
+	MaxProposalsPerWar        uint64         `json:"max_proposals_per_war" yaml:"max_proposals_per_war"`
 }
 
 // ParamTable for wars module.
@@ -20,9 +45,25 @@ func ParamKeyTable() params.KeyTable {
 	return params.NewKeyTable().RegisterParamSet(&Params{})
 }
 
-func NewParams(reservedWarTokens []string) Params {
+func NewParams(reservedWarTokens []string, pauseAuthority sdk.AccAddress,
+	maxTxFeePercentage, maxExitFeePercentage sdk.Dec, minBatchBlocks,
+	maxBatchBlocks sdk.Uint, maxWarsPerCreator uint64,
+	warCreationDeposit sdk.Coins, maxLimitOrderExpiryBlocks,
+	maxLimitOrdersPerAddress, maxLimitOrdersPerWar,
+	maxProposalsPerWar uint64) Params {
 	return Params{
-		ReservedWarTokens: reservedWarTokens,
+		ReservedWarTokens:         reservedWarTokens,
+		PauseAuthority:            pauseAuthority,
+		MaxTxFeePercentage:        maxTxFeePercentage,
+		MaxExitFeePercentage:      maxExitFeePercentage,
+		MinBatchBlocks:            minBatchBlocks,
+		MaxBatchBlocks:            maxBatchBlocks,
+		MaxWarsPerCreator:         maxWarsPerCreator,
+		WarCreationDeposit:        warCreationDeposit,
+		MaxLimitOrderExpiryBlocks: maxLimitOrderExpiryBlocks,
+		MaxLimitOrdersPerAddress:  maxLimitOrdersPerAddress,
+		MaxLimitOrdersPerWar:      maxLimitOrdersPerWar,
+		MaxProposalsPerWar:        maxProposalsPerWar,
 	}
 
 }
@@ -30,33 +71,190 @@ func NewParams(reservedWarTokens []string) Params {
 // default wars module parameters
 func DefaultParams() Params {
 	return Params{
-		ReservedWarTokens: []string{}, // no reserved war tokens
+		ReservedWarTokens:         []string{},      // no reserved war tokens
+		PauseAuthority:            nil,             // only war signers can pause wars
+		MaxTxFeePercentage:        sdk.NewDec(100), // no max tx fee
+		MaxExitFeePercentage:      sdk.NewDec(100), // no max exit fee
+		MinBatchBlocks:            sdk.OneUint(),   // batches can be one block long
+		MaxBatchBlocks:            sdk.ZeroUint(),  // no max batch blocks
+		MaxWarsPerCreator:         0,               // no max wars per creator
+		WarCreationDeposit:        sdk.Coins{},     // no war creation deposit
+		MaxLimitOrderExpiryBlocks: 100000,          // limit orders expire within 100000 blocks
+		MaxLimitOrdersPerAddress:  10,              // 10 open limit orders per address per war
+		MaxLimitOrdersPerWar:      1000,            // 1000 open limit orders per war
+		MaxProposalsPerWar:        10,              // 10 proposals being voted on per war
 	}
 }
 
-// validate params
+// validate params, including the constraints between params that cannot be
+// checked by the validator of each individual param
 func ValidateParams(params Params) error {
+	for _, pair := range params.ParamSetPairs() {
+		value := reflect.Indirect(reflect.ValueOf(pair.Value)).Interface()
+		if err := pair.ValidatorFn(value); err != nil {
+			return fmt.Errorf("invalid %s: %s", pair.Key, err)
+		}
+	}
+
+	if !params.MaxBatchBlocks.IsZero() &&
+		params.MaxBatchBlocks.LT(params.MinBatchBlocks) {
+		return fmt.Errorf("max batch blocks %s is less than min batch blocks %s",
+			params.MaxBatchBlocks, params.MinBatchBlocks)
+	}
+
 	return nil
 }
 
 func (p Params) String() string {
 	return fmt.Sprintf(`Wars Params:
-  Reserved War Tokens: %s
+  Reserved War Tokens:           %s
+  Pause Authority:               %s
+  Max Tx Fee Percentage:         %s
+  Max Exit Fee Percentage:       %s
+  Min Batch Blocks:              %s
+  Max Batch Blocks:              %s
+  Max Wars Per Creator:          %d
+  War Creation Deposit:          %s
+  Max Limit Order Expiry Blocks: %d
+  Max Limit Orders Per Address:  %d
+  Max Limit Orders Per War:      %d
+  Max Proposals Per War:         %d
 `,
-		p.ReservedWarTokens)
+		p.ReservedWarTokens, p.PauseAuthority, p.MaxTxFeePercentage,
+		p.MaxExitFeePercentage, p.MinBatchBlocks, p.MaxBatchBlocks,
+		p.MaxWarsPerCreator, p.WarCreationDeposit,
+		p.MaxLimitOrderExpiryBlocks, p.MaxLimitOrdersPerAddress,
+		p.MaxLimitOrdersPerWar, p.MaxProposalsPerWar)
 }
 
 func validateReservedWarTokens(i interface{}) error {
-	_, ok := i.([]string)
+	v, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	for _, token := range v {
+		if err := sdk.ValidateDenom(token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePauseAuthority(i interface{}) error {
+	v, ok := i.(sdk.AccAddress)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.Empty() {
+		return nil // no pause authority
+	}
+	return sdk.VerifyAddressFormat(v)
+}
+
+func validateMaxFeePercentage(i interface{}) error {
+	v, ok := i.(sdk.Dec)
 	if !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
+
+	if v.IsNil() {
+		return errors.New("max fee percentage cannot be nil")
+	} else if v.IsNegative() {
+		return fmt.Errorf("max fee percentage cannot be negative: %s", v)
+	} else if v.GT(sdk.NewDec(100)) {
+		return fmt.Errorf("max fee percentage cannot exceed 100: %s", v)
+	}
 	return nil
 }
 
+func validateMinBatchBlocks(i interface{}) error {
+	v, ok := i.(sdk.Uint)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsZero() {
+		return errors.New("min batch blocks must be positive")
+	}
+	return nil
+}
+
+func validateMaxBatchBlocks(i interface{}) error {
+	_, ok := i.(sdk.Uint)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil // zero for no max batch blocks
+}
+
+func validateMaxWarsPerCreator(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil // zero for no max wars per creator
+}
+
+func validateWarCreationDeposit(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsValid() {
+		return fmt.Errorf("invalid war creation deposit: %s", v)
+	}
+	return nil
+}
+
+func validateMaxLimitOrderExpiryBlocks(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil // zero for no max limit order expiry blocks
+}
+
+func validateMaxLimitOrdersPerAddress(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil // zero for no max limit orders per address
+}
+
+func validateMaxLimitOrdersPerWar(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil // zero for no max limit orders per war
+}
+
+func validateMaxProposalsPerWar(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil // zero for no max proposals per war
+}
+
 // Implements params.ParamSet
 func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
-		{KeyReservedWarTokens, &p.ReservedWarTokens, validateReservedWarTokens},
+		params.NewParamSetPair(KeyReservedWarTokens, &p.ReservedWarTokens, validateReservedWarTokens),
+		params.NewParamSetPair(KeyPauseAuthority, &p.PauseAuthority, validatePauseAuthority),
+		params.NewParamSetPair(KeyMaxTxFeePercentage, &p.MaxTxFeePercentage, validateMaxFeePercentage),
+		params.NewParamSetPair(KeyMaxExitFeePercentage, &p.MaxExitFeePercentage, validateMaxFeePercentage),
+		params.NewParamSetPair(KeyMinBatchBlocks, &p.MinBatchBlocks, validateMinBatchBlocks),
+		params.NewParamSetPair(KeyMaxBatchBlocks, &p.MaxBatchBlocks, validateMaxBatchBlocks),
+		params.NewParamSetPair(KeyMaxWarsPerCreator, &p.MaxWarsPerCreator, validateMaxWarsPerCreator),
+		params.NewParamSetPair(KeyWarCreationDeposit, &p.WarCreationDeposit, validateWarCreationDeposit),
+		params.NewParamSetPair(KeyMaxLimitOrderExpiryBlocks, &p.MaxLimitOrderExpiryBlocks, validateMaxLimitOrderExpiryBlocks),
+		params.NewParamSetPair(KeyMaxLimitOrdersPerAddress, &p.MaxLimitOrdersPerAddress, validateMaxLimitOrdersPerAddress),
+		params.NewParamSetPair(KeyMaxLimitOrdersPerWar, &p.MaxLimitOrdersPerWar, validateMaxLimitOrdersPerWar),
+		params.NewParamSetPair(KeyMaxProposalsPerWar, &p.MaxProposalsPerWar, validateMaxProposalsPerWar),
 	}
 }
@@ -0,0 +1,45 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestValidateParams(t *testing.T) {
+	require.NoError(t, ValidateParams(DefaultParams()))
+
+	testCases := []struct {
+		edit  func(p *Params)
+		valid bool
+	}{
+		{func(p *Params) { p.ReservedWarTokens = []string{"abc"} }, true},
+		{func(p *Params) { p.ReservedWarTokens = []string{"a"} }, false},
+		{func(p *Params) { p.PauseAuthority = initCreator }, true},
+		{func(p *Params) { p.MaxTxFeePercentage = sdk.ZeroDec() }, true},
+		{func(p *Params) { p.MaxTxFeePercentage = sdk.NewDec(-1) }, false},
+		{func(p *Params) { p.MaxExitFeePercentage = sdk.NewDec(101) }, false},
+		{func(p *Params) { p.MaxExitFeePercentage = sdk.Dec{} }, false},
+		{func(p *Params) { p.MinBatchBlocks = sdk.ZeroUint() }, false},
+		{func(p *Params) { p.MinBatchBlocks = sdk.NewUint(5) }, true},
+		{func(p *Params) { p.MaxBatchBlocks = sdk.NewUint(5) }, true},
+		{func(p *Params) {
+			p.MinBatchBlocks = sdk.NewUint(5)
+			p.MaxBatchBlocks = sdk.NewUint(4)
+		}, false},
+		{func(p *Params) { p.MaxWarsPerCreator = 3 }, true},
+		{func(p *Params) { p.MaxLimitOrderExpiryBlocks = 0 }, true},
+		{func(p *Params) { p.MaxLimitOrdersPerAddress = 0 }, true},
+		{func(p *Params) { p.MaxLimitOrdersPerWar = 0 }, true},
+		{func(p *Params) { p.MaxProposalsPerWar = 0 }, true},
+		{func(p *Params) { p.WarCreationDeposit = sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 10)) }, true},
+		{func(p *Params) {
+			p.WarCreationDeposit = sdk.Coins{sdk.Coin{Denom: reserveToken, Amount: sdk.NewInt(-1)}}
+		}, false},
+	}
+	for i, tc := range testCases {
+		params := DefaultParams()
+		tc.edit(&params)
+		require.Equal(t, tc.valid, ValidateParams(params) == nil, i)
+	}
+}
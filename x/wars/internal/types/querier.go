@@ -32,3 +32,16 @@ type QuerySwapReturn struct {
 	TotalReturns sdk.Coins `json:"total_returns" yaml:"total_returns"`
 	TotalFees    sdk.Coins `json:"total_fees" yaml:"total_fees"`
 }
+
+type QuerySwapRoute struct {
+	Hops         SwapRoute `json:"hops" yaml:"hops"`
+	TotalReturns sdk.Coins `json:"total_returns" yaml:"total_returns"`
+	TotalFees    sdk.Coins `json:"total_fees" yaml:"total_fees"`
+}
+
+type QueryFundingPool struct {
+	Balance            sdk.Coins `json:"balance" yaml:"balance"`
+	SpentInEpoch       sdk.Coins `json:"spent_in_epoch" yaml:"spent_in_epoch"`
+	RemainingAllowance sdk.Coins `json:"remaining_allowance" yaml:"remaining_allowance"`
+	NextEpochHeight    int64     `json:"next_epoch_height" yaml:"next_epoch_height"`
+}
@@ -33,10 +33,11 @@ func CheckReserveTokenNames(resTokens []string, token string) error {
 
 func CheckNoOfReserveTokens(resTokens []string, fnType string) error {
 	// Come up with number of expected reserve tokens
-	expectedNoOfTokens, ok := NoOfReserveTokensForFunctionType[fnType]
-	if !ok {
-		return sdkerrors.Wrap(ErrUnrecognizedFunctionType, fnType)
+	fn, err := GetCurveFunction(fnType)
+	if err != nil {
+		return err
 	}
+	expectedNoOfTokens := fn.NoOfReserveTokens()
 
 	// Check that number of reserve tokens is correct (if expecting a specific number of tokens)
 	if expectedNoOfTokens != AnyNumberOfReserveTokens && len(resTokens) != expectedNoOfTokens {
@@ -46,6 +47,53 @@ func CheckNoOfReserveTokens(resTokens []string, fnType string) error {
 	return nil
 }
 
+func CheckFunctionParamsForReserveTokens(fnParams FunctionParams, resTokens []string, fnType string) error {
+	fn, err := GetCurveFunction(fnType)
+	if err != nil {
+		return err
+	}
+
+	// Check function parameters that refer to reserve tokens (if any)
+	if rfn, ok := fn.(ReserveTokensCurveFunction); ok {
+		return rfn.ValidateParamsForReserveTokens(fnParams.AsMap(), resTokens)
+	}
+	return nil
+}
+
+func CheckSanityRateForReserveTokens(sanityRate sdk.Dec, resTokens []string) error {
+	// Sanity rate is the rate between two reserve tokens, so it cannot be
+	// set for wars with more than two reserve tokens
+	if !sanityRate.IsZero() && len(resTokens) > 2 {
+		return sdkerrors.Wrap(ErrIncorrectNumberOfReserveTokens, "sanity rate requires at most 2 reserve tokens")
+	}
+	return nil
+}
+
+// CheckSigners checks that there is at least one signer, that no signer is
+// empty or duplicate, and that the threshold does not exceed the number of
+// signers. A zero threshold means that all signers are required.
+func CheckSigners(signers []sdk.AccAddress, threshold uint64) error {
+	if len(signers) == 0 {
+		return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Signers")
+	}
+
+	uniqueSigners := make(map[string]bool)
+	for _, s := range signers {
+		if s.Empty() {
+			return sdkerrors.Wrap(ErrArgumentCannotBeEmpty, "Signers")
+		} else if uniqueSigners[s.String()] {
+			return sdkerrors.Wrap(ErrDuplicateSigner, s.String())
+		}
+		uniqueSigners[s.String()] = true
+	}
+
+	if threshold > uint64(len(signers)) {
+		return sdkerrors.Wrapf(ErrInvalidSignerThreshold,
+			"threshold %d > %d signers", threshold, len(signers))
+	}
+	return nil
+}
+
 func CheckCoinDenom(denom string) (err error) {
 	coin, err2 := sdk.ParseCoin("0" + denom)
 	if err2 != nil {
@@ -57,17 +105,31 @@ func CheckCoinDenom(denom string) (err error) {
 }
 
 func GetRequiredParamsForFunctionType(fnType string) (fnParams []string, err error) {
-	expectedParams, ok := RequiredParamsForFunctionType[fnType]
-	if !ok {
-		return nil, sdkerrors.Wrap(ErrUnrecognizedFunctionType, fnType)
+	fn, err := GetCurveFunction(fnType)
+	if err != nil {
+		return nil, err
+	}
+	return fn.RequiredParams(), nil
+}
+
+// GetRequiredParamsForFunctionParams returns the parameters expected for the
+// function type given the parameters provided, which only makes a difference
+// for function types that accept a variable number of parameters.
+func GetRequiredParamsForFunctionParams(fnType string, fps FunctionParams) (fnParams []string, err error) {
+	fn, err := GetCurveFunction(fnType)
+	if err != nil {
+		return nil, err
+	}
+	if vfn, ok := fn.(VariableParamsCurveFunction); ok {
+		return vfn.RequiredParamsFor(fps), nil
 	}
-	return expectedParams, nil
+	return fn.RequiredParams(), nil
 }
 
 func GetExceptionsForFunctionType(fnType string) (restrictions FunctionParamRestrictions, err error) {
-	restrictions, ok := ExtraParameterRestrictions[fnType]
-	if !ok {
-		return nil, sdkerrors.Wrap(ErrUnrecognizedFunctionType, fnType)
+	fn, err := GetCurveFunction(fnType)
+	if err != nil {
+		return nil, err
 	}
-	return restrictions, nil
+	return fn.ValidateParams, nil
 }
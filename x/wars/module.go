@@ -138,10 +138,9 @@ func (AppModule) ProposalContents(_ module.SimulationState) []sim.WeightedPropos
 	return nil
 }
 
-// RandomizedParams doesn't create any randomized wars param changes for the simulator.
-//noinspection GoUnusedParameter
+// RandomizedParams creates randomized wars param changes for the simulator.
 func (AppModule) RandomizedParams(r *rand.Rand) []sim.ParamChange {
-	return nil
+	return simulation.ParamChanges(r)
 }
 
 // RegisterStoreDecoder registers a decoder for war module's types
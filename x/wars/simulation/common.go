@@ -5,17 +5,33 @@ import (
 )
 
 var (
-	defaultReserveTokens = []string{sdk.DefaultWarDenom}
+	defaultReserveTokens = []string{sdk.DefaultBondDenom}
 
-	blankOrderQuantityLimits    = sdk.Coins{}
-	blankOutcomePayment         = sdk.Coins{}
-	blankSanityRate             = sdk.MustNewDecFromStr("0")
-	blankSanityMarginPercentage = sdk.MustNewDecFromStr("0")
+	blankOrderQuantityLimits           = sdk.Coins{}
+	blankOutcomePayment                = sdk.Coins{}
+	blankSanityRate                    = sdk.MustNewDecFromStr("0")
+	blankSanityMarginPercentage        = sdk.MustNewDecFromStr("0")
+	blankSignerThreshold               = uint64(0)
+	blankEditDelay                     = int64(0)
+	blankHatchDeadlineHeight           = int64(0)
+	blankAllowedHatchers               = []sdk.AccAddress(nil)
+	blankHatchMembershipDenom          = ""
+	blankMaxHatchContribution          = sdk.ZeroInt()
+	blankHatchVestingCliff             = int64(0)
+	blankHatchVestingPeriod            = int64(0)
+	blankFundingPoolSpenders           = []sdk.AccAddress(nil)
+	blankFundingPoolSpendLimit         = sdk.Coins(nil)
+	blankFundingPoolEpochBlocks        = int64(0)
+	blankGovernanceQuorumPercentage    = sdk.MustNewDecFromStr("0")
+	blankGovernanceThresholdPercentage = sdk.MustNewDecFromStr("0")
+	blankGovernanceVotingPeriod        = int64(0)
+	blankMaxPriceMovePercentage        = sdk.MustNewDecFromStr("0")
+	blankPriceMoveCooldown             = int64(0)
 
-	tokenPrefix    = "token"
+	tokenPrefix   = "token"
 	totalWarCount = 0 // Updated for each war created
 	maxWarCount   = 0 // Set during genesis creation
-	gas            = uint64(100000000)
+	gas           = uint64(100000000)
 
 	swapperWars []string
 )
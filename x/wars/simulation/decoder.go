@@ -2,6 +2,7 @@ package simulation
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 
 	tmkv "github.com/tendermint/tendermint/libs/kv"
@@ -31,6 +32,74 @@ func DecodeStore(cdc *codec.Codec, kvA, kvB tmkv.Pair) string {
 		cdc.MustUnmarshalBinaryBare(kvB.Value, &batchB)
 		return fmt.Sprintf("%v\n%v", batchA, batchB)
 
+	case bytes.Equal(kvA.Key[:1], types.LimitOrdersKeyPrefix):
+		var limitOrderA, limitOrderB types.LimitOrder
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &limitOrderA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &limitOrderB)
+		return fmt.Sprintf("%v\n%v", limitOrderA, limitOrderB)
+
+	case bytes.Equal(kvA.Key[:1], types.HatchContributionsKeyPrefix):
+		var hatchContributionA, hatchContributionB types.HatchContribution
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &hatchContributionA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &hatchContributionB)
+		return fmt.Sprintf("%v\n%v", hatchContributionA, hatchContributionB)
+
+	case bytes.Equal(kvA.Key[:1], types.HatchVestingsKeyPrefix):
+		var hatchVestingA, hatchVestingB types.HatchVesting
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &hatchVestingA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &hatchVestingB)
+		return fmt.Sprintf("%v\n%v", hatchVestingA, hatchVestingB)
+
+	case bytes.Equal(kvA.Key[:1], types.FundingPoolsKeyPrefix):
+		var fundingPoolA, fundingPoolB types.FundingPool
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &fundingPoolA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &fundingPoolB)
+		return fmt.Sprintf("%v\n%v", fundingPoolA, fundingPoolB)
+
+	case bytes.Equal(kvA.Key[:1], types.ProposalsKeyPrefix):
+		var proposalA, proposalB types.Proposal
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &proposalA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &proposalB)
+		return fmt.Sprintf("%v\n%v", proposalA, proposalB)
+
+	case bytes.Equal(kvA.Key[:1], types.PendingEditsKeyPrefix):
+		var pendingEditsA, pendingEditsB types.PendingEdits
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &pendingEditsA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &pendingEditsB)
+		return fmt.Sprintf("%v\n%v", pendingEditsA, pendingEditsB)
+
+	case bytes.Equal(kvA.Key[:1], types.VotesKeyPrefix):
+		var voteA, voteB types.Vote
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &voteA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &voteB)
+		return fmt.Sprintf("%v\n%v", voteA, voteB)
+
+	case bytes.Equal(kvA.Key[:1], types.NextProposalIDsKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.NextLimitOrderIDsKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.LimitOrderExpiriesKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.LimitOrderPricesKeyPrefix):
+		idA := binary.BigEndian.Uint64(kvA.Value)
+		idB := binary.BigEndian.Uint64(kvB.Value)
+		return fmt.Sprintf("%d\n%d", idA, idB)
+
+	case bytes.Equal(kvA.Key[:1], types.VotingEscrowsKeyPrefix):
+		var escrowA, escrowB types.VotingEscrow
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &escrowA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &escrowB)
+		return fmt.Sprintf("%v\n%v", escrowA, escrowB)
+
+	case bytes.Equal(kvA.Key[:1], types.WarCountsByCreatorKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.LimitOrderCountsKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.LimitOrderCountsByAddressKeyPrefix):
+		countA := binary.BigEndian.Uint64(kvA.Value)
+		countB := binary.BigEndian.Uint64(kvB.Value)
+		return fmt.Sprintf("%d\n%d", countA, countB)
+
+	case bytes.Equal(kvA.Key[:1], types.HatchVestingStartHeightsKeyPrefix):
+		heightA := int64(binary.BigEndian.Uint64(kvA.Value))
+		heightB := int64(binary.BigEndian.Uint64(kvB.Value))
+		return fmt.Sprintf("%d\n%d", heightA, heightB)
+
 	default:
 		panic(fmt.Sprintf("invalid %s key prefix %X", types.ModuleName, kvA.Key[:1]))
 	}
@@ -47,20 +47,57 @@ func TestDecodeStore(t *testing.T) {
 	sanityMarginPercentage := sdk.MustNewDecFromStr("0.4")
 	allowSell := true
 	signers := []sdk.AccAddress{creator}
+	signerThreshold := uint64(1)
+	editDelay := int64(0)
 	batchBlocks := sdk.NewUint(10)
 	outcomePayment := sdk.NewCoins(
 		sdk.NewInt64Coin("token1", 1),
 		sdk.NewInt64Coin("token2", 2),
 		sdk.NewInt64Coin("token3", 3),
 	)
+	hatchDeadlineHeight := int64(100)
+	allowedHatchers := []sdk.AccAddress{creator}
+	hatchMembershipDenom := "membertoken"
+	maxHatchContribution := sdk.NewInt(1000)
+	hatchVestingCliff := int64(10)
+	hatchVestingPeriod := int64(100)
+	fundingPoolSpenders := []sdk.AccAddress{creator}
+	fundingPoolSpendLimit := sdk.NewCoins(sdk.NewInt64Coin("reservetoken", 100))
+	fundingPoolEpochBlocks := int64(10)
+	governanceQuorumPercentage := sdk.MustNewDecFromStr("40")
+	governanceThresholdPercentage := sdk.MustNewDecFromStr("50")
+	governanceVotingPeriod := int64(100)
+	maxPriceMovePercentage := sdk.MustNewDecFromStr("20")
+	priceMoveCooldown := int64(10)
 	state := "dummy_state"
 
 	war := types.NewWar(token, name, description, creator, functionType,
 		functionParameters, reserveTokens, txFeePercentage, exitFeePercentage,
 		feeAddress, maxSupply, orderQuantityLimits, sanityRate, sanityMarginPercentage,
-		allowSell, signers, batchBlocks, outcomePayment, state)
+		allowSell, signers, signerThreshold, editDelay, batchBlocks, outcomePayment, hatchDeadlineHeight,
+		allowedHatchers, hatchMembershipDenom, maxHatchContribution,
+		hatchVestingCliff, hatchVestingPeriod, fundingPoolSpenders,
+		fundingPoolSpendLimit, fundingPoolEpochBlocks, governanceQuorumPercentage,
+		governanceThresholdPercentage, governanceVotingPeriod, maxPriceMovePercentage,
+		priceMoveCooldown, state)
 	batch := types.NewBatch(war.Token, war.BatchBlocks)
 	lastBatch := types.NewBatch(war.Token, war.BatchBlocks)
+	limitOrder := types.NewLimitOrder(types.LimitBuyOrderType, creator,
+		sdk.NewInt64Coin(token, 10), sdk.NewCoins(sdk.NewInt64Coin("reservetoken", 100)), 100)
+	hatchContribution := types.NewHatchContribution(creator, sdk.NewInt(10))
+	hatchVesting := types.NewHatchVesting(creator, sdk.NewInt(10))
+	fundingPool := types.NewFundingPool(war.Token)
+	proposal := types.NewSpendProposal(creator, creator,
+		sdk.NewCoins(sdk.NewInt64Coin("reservetoken", 10)), 100)
+	pendingEdits := types.NewPendingEdits(war.Token)
+	vote := types.NewVote(proposal.ID, creator, types.YesVoteOption, sdk.NewInt(10))
+	nextProposalID := uint64(1)
+	warCount := uint64(1)
+	votingEscrow := types.NewVotingEscrow(creator, sdk.NewInt(10))
+	limitOrderID := limitOrder.ID
+	nextLimitOrderID := uint64(1)
+	limitOrderCount := uint64(1)
+	hatchVestingStartHeight := int64(10)
 
 	kvPairs := tmkv.Pairs{
 		tmkv.Pair{Key: types.GetWarKey(token),
@@ -69,6 +106,38 @@ func TestDecodeStore(t *testing.T) {
 			Value: cdc.MustMarshalBinaryBare(batch)},
 		tmkv.Pair{Key: types.GetLastBatchKey(token),
 			Value: cdc.MustMarshalBinaryBare(lastBatch)},
+		tmkv.Pair{Key: types.GetLimitOrderKey(token, limitOrder.ID),
+			Value: cdc.MustMarshalBinaryBare(limitOrder)},
+		tmkv.Pair{Key: types.GetHatchContributionKey(token, creator),
+			Value: cdc.MustMarshalBinaryBare(hatchContribution)},
+		tmkv.Pair{Key: types.GetHatchVestingKey(token, creator),
+			Value: cdc.MustMarshalBinaryBare(hatchVesting)},
+		tmkv.Pair{Key: types.GetFundingPoolKey(token),
+			Value: cdc.MustMarshalBinaryBare(fundingPool)},
+		tmkv.Pair{Key: types.GetProposalKey(token, proposal.ID),
+			Value: cdc.MustMarshalBinaryBare(proposal)},
+		tmkv.Pair{Key: types.GetPendingEditsKey(token),
+			Value: cdc.MustMarshalBinaryBare(pendingEdits)},
+		tmkv.Pair{Key: types.GetVoteKey(token, proposal.ID, creator),
+			Value: cdc.MustMarshalBinaryBare(vote)},
+		tmkv.Pair{Key: types.GetNextProposalIDKey(token),
+			Value: sdk.Uint64ToBigEndian(nextProposalID)},
+		tmkv.Pair{Key: types.GetWarCountByCreatorKey(creator),
+			Value: sdk.Uint64ToBigEndian(warCount)},
+		tmkv.Pair{Key: types.GetVotingEscrowKey(token, creator),
+			Value: cdc.MustMarshalBinaryBare(votingEscrow)},
+		tmkv.Pair{Key: types.GetLimitOrderExpiryKey(token, limitOrder),
+			Value: sdk.Uint64ToBigEndian(limitOrderID)},
+		tmkv.Pair{Key: types.GetLimitOrderPriceKey(token, limitOrder, "reservetoken"),
+			Value: sdk.Uint64ToBigEndian(limitOrderID)},
+		tmkv.Pair{Key: types.GetNextLimitOrderIDKey(token),
+			Value: sdk.Uint64ToBigEndian(nextLimitOrderID)},
+		tmkv.Pair{Key: types.GetLimitOrderCountKey(token),
+			Value: sdk.Uint64ToBigEndian(limitOrderCount)},
+		tmkv.Pair{Key: types.GetLimitOrderCountByAddressKey(token, creator),
+			Value: sdk.Uint64ToBigEndian(limitOrderCount)},
+		tmkv.Pair{Key: types.GetHatchVestingStartHeightKey(token),
+			Value: sdk.Uint64ToBigEndian(uint64(hatchVestingStartHeight))},
 		tmkv.Pair{Key: []byte{0x99}, Value: []byte{0x99}},
 	}
 
@@ -79,6 +148,22 @@ func TestDecodeStore(t *testing.T) {
 		{"wars", fmt.Sprintf("%v\n%v", war, war)},
 		{"batches", fmt.Sprintf("%v\n%v", batch, batch)},
 		{"lastBatches", fmt.Sprintf("%v\n%v", lastBatch, lastBatch)},
+		{"limitOrders", fmt.Sprintf("%v\n%v", limitOrder, limitOrder)},
+		{"hatchContributions", fmt.Sprintf("%v\n%v", hatchContribution, hatchContribution)},
+		{"hatchVestings", fmt.Sprintf("%v\n%v", hatchVesting, hatchVesting)},
+		{"fundingPools", fmt.Sprintf("%v\n%v", fundingPool, fundingPool)},
+		{"proposals", fmt.Sprintf("%v\n%v", proposal, proposal)},
+		{"pendingEdits", fmt.Sprintf("%v\n%v", pendingEdits, pendingEdits)},
+		{"votes", fmt.Sprintf("%v\n%v", vote, vote)},
+		{"nextProposalIDs", fmt.Sprintf("%d\n%d", nextProposalID, nextProposalID)},
+		{"warCountsByCreator", fmt.Sprintf("%d\n%d", warCount, warCount)},
+		{"votingEscrows", fmt.Sprintf("%v\n%v", votingEscrow, votingEscrow)},
+		{"limitOrderExpiries", fmt.Sprintf("%d\n%d", limitOrderID, limitOrderID)},
+		{"limitOrderPrices", fmt.Sprintf("%d\n%d", limitOrderID, limitOrderID)},
+		{"nextLimitOrderIDs", fmt.Sprintf("%d\n%d", nextLimitOrderID, nextLimitOrderID)},
+		{"limitOrderCounts", fmt.Sprintf("%d\n%d", limitOrderCount, limitOrderCount)},
+		{"limitOrderCountsByAddress", fmt.Sprintf("%d\n%d", limitOrderCount, limitOrderCount)},
+		{"hatchVestingStartHeights", fmt.Sprintf("%d\n%d", hatchVestingStartHeight, hatchVestingStartHeight)},
 		{"other", ""},
 	}
 
@@ -66,7 +66,7 @@ func RandomizedGenState(simState *module.SimulationState) {
 
 		var reserveTokens []string
 		switch functionType {
-		case types.SwapperFunction:
+		case types.SwapperFunction, types.StableSwapFunction:
 			reserveToken1, ok1 := getRandomWarName(r)
 			reserveToken2, ok2 := getRandomWarNameExcept(r, reserveToken1)
 			if !ok1 || !ok2 {
@@ -74,10 +74,17 @@ func RandomizedGenState(simState *module.SimulationState) {
 				continue
 			}
 			reserveTokens = []string{reserveToken1, reserveToken2}
+		case types.WeightedSwapperFunction:
+			var ok bool
+			reserveTokens, ok = getRandomDistinctWarNames(r, simulation.RandIntBetween(r, 2, 5))
+			if !ok {
+				initialWars -= 1 // Ignore this iteration
+				continue
+			}
 		default:
 			reserveTokens = defaultReserveTokens
 		}
-		functionParameters := getRandomFunctionParameters(r, functionType, true)
+		functionParameters := getRandomFunctionParameters(r, functionType, reserveTokens, true)
 
 		// Max fee is 100, so exit fee uses 100-txFee as max
 		txFeePercentage := simulation.RandomDecAmount(r, sdk.NewDec(100))
@@ -99,19 +106,27 @@ func RandomizedGenState(simState *module.SimulationState) {
 			functionParameters, reserveTokens, txFeePercentage,
 			exitFeePercentage, feeAddress, maxSupply, blankOrderQuantityLimits,
 			blankSanityRate, blankSanityMarginPercentage, allowSells, signers,
-			batchBlocks, outcomePayment, state)
+			blankSignerThreshold, blankEditDelay, batchBlocks, outcomePayment, blankHatchDeadlineHeight,
+			blankAllowedHatchers, blankHatchMembershipDenom,
+			blankMaxHatchContribution, blankHatchVestingCliff,
+			blankHatchVestingPeriod, blankFundingPoolSpenders,
+			blankFundingPoolSpendLimit, blankFundingPoolEpochBlocks,
+			blankGovernanceQuorumPercentage, blankGovernanceThresholdPercentage,
+			blankGovernanceVotingPeriod, blankMaxPriceMovePercentage,
+			blankPriceMoveCooldown, state)
 		batch := types.NewBatch(war.Token, war.BatchBlocks)
 
 		wars = append(wars, war)
 		batches = append(batches, batch)
 		incrementWarCount()
-		if war.FunctionType == types.SwapperFunction {
+		if war.IsSwapper() {
 			newSwapperWar(war.Token)
 		}
 	}
 
-	warsGenesis := types.NewGenesisState(wars, batches,
-		types.Params{ReservedWarTokens: defaultReserveTokens})
+	params := types.DefaultParams()
+	params.ReservedWarTokens = defaultReserveTokens
+	warsGenesis := types.NewGenesisState(wars, batches, nil, nil, nil, nil, nil, nil, params)
 
 	fmt.Printf("Selected randomly generated wars genesis state:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, warsGenesis))
 	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(warsGenesis)
@@ -19,15 +19,15 @@ import (
 const (
 	OpWeightMsgCreateWar = "op_weight_msg_create_war"
 	OpWeightMsgEditWar   = "op_weight_msg_edit_war"
-	OpWeightMsgBuy        = "op_weight_msg_buy"
-	OpWeightMsgSell       = "op_weight_msg_sell"
-	OpWeightMsgSwap       = "op_weight_msg_swap"
+	OpWeightMsgBuy       = "op_weight_msg_buy"
+	OpWeightMsgSell      = "op_weight_msg_sell"
+	OpWeightMsgSwap      = "op_weight_msg_swap"
 
 	DefaultWeightMsgCreateWar = 5
 	DefaultWeightMsgEditWar   = 5
-	DefaultWeightMsgBuy        = 100
-	DefaultWeightMsgSell       = 100
-	DefaultWeightMsgSwap       = 100
+	DefaultWeightMsgBuy       = 100
+	DefaultWeightMsgSell      = 100
+	DefaultWeightMsgSwap      = 100
 )
 
 // WeightedOperations returns all the operations from the module with their respective weights
@@ -74,7 +74,7 @@ func WeightedOperations(
 	return simulation.WeightedOperations{
 		simulation.NewWeightedOperation(
 			weightMsgCreateWar,
-			SimulateMsgCreateWar(ak),
+			SimulateMsgCreateWar(ak, k),
 		),
 		simulation.NewWeightedOperation(
 			weightMsgEditWar,
@@ -95,7 +95,7 @@ func WeightedOperations(
 	}
 }
 
-func SimulateMsgCreateWar(ak auth.AccountKeeper) simulation.Operation {
+func SimulateMsgCreateWar(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation {
 	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
 		accs []simulation.Account, chainID string) (opMsg simulation.OperationMsg, fOpt []simulation.FutureOperation, err error) {
 
@@ -107,6 +107,14 @@ func SimulateMsgCreateWar(ak auth.AccountKeeper) simulation.Operation {
 		address := simAccount.Address
 		account := ak.GetAccount(ctx, address)
 
+		// Check that account can create a war given the module params
+		params := k.GetParams(ctx)
+		spendable := account.SpendableCoins(ctx.BlockTime())
+		if k.CheckWarsPerCreatorWithinParams(ctx, address) != nil ||
+			!spendable.IsAllGTE(params.WarCreationDeposit) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
 		token := getNextWarName()
 		name := getRandomNonEmptyString(r)
 		desc := getRandomNonEmptyString(r)
@@ -118,21 +126,29 @@ func SimulateMsgCreateWar(ak auth.AccountKeeper) simulation.Operation {
 
 		var reserveTokens []string
 		switch functionType {
-		case types.SwapperFunction:
+		case types.SwapperFunction, types.StableSwapFunction:
 			reserveToken1, ok1 := getRandomWarName(r)
 			reserveToken2, ok2 := getRandomWarNameExcept(r, reserveToken1)
 			if !ok1 || !ok2 {
 				return simulation.NoOpMsg(types.ModuleName), nil, nil
 			}
 			reserveTokens = []string{reserveToken1, reserveToken2}
+		case types.WeightedSwapperFunction:
+			var ok bool
+			reserveTokens, ok = getRandomDistinctWarNames(r, simulation.RandIntBetween(r, 2, 5))
+			if !ok {
+				return simulation.NoOpMsg(types.ModuleName), nil, nil
+			}
 		default:
 			reserveTokens = defaultReserveTokens
 		}
-		functionParameters := getRandomFunctionParameters(r, functionType, false)
+		functionParameters := getRandomFunctionParameters(r, functionType, reserveTokens, false)
 
-		// Max fee is 100, so exit fee uses 100-txFee as max
-		txFeePercentage := simulation.RandomDecAmount(r, sdk.NewDec(100))
-		exitFeePercentage := simulation.RandomDecAmount(r, sdk.NewDec(100).Sub(txFeePercentage))
+		// Max fee is 100, so exit fee uses 100-txFee as max (unless the max
+		// fees in the module params are lower)
+		txFeePercentage := simulation.RandomDecAmount(r, params.MaxTxFeePercentage)
+		exitFeePercentage := simulation.RandomDecAmount(r, sdk.MinDec(
+			sdk.NewDec(100).Sub(txFeePercentage), params.MaxExitFeePercentage))
 
 		// Since 100 is not allowed, a small number is subtracted from one of the fees
 		if txFeePercentage.Add(exitFeePercentage).Equal(sdk.NewDec(100)) {
@@ -150,13 +166,26 @@ func SimulateMsgCreateWar(ak auth.AccountKeeper) simulation.Operation {
 		maxSupply := sdk.NewCoin(token, sdk.NewInt(int64(
 			simulation.RandIntBetween(r, 1000000, 1000000000))))
 		allowSells := getRandomAllowSellsValue(r)
+		minBatchBlocks := int(params.MinBatchBlocks.Uint64())
+		maxBatchBlocks := int(params.MaxBatchBlocks.Uint64())
+		if maxBatchBlocks == 0 {
+			maxBatchBlocks = minBatchBlocks + 8
+		}
 		batchBlocks := sdk.NewUint(uint64(
-			simulation.RandIntBetween(r, 1, 10)))
+			simulation.RandIntBetween(r, minBatchBlocks, maxBatchBlocks+1)))
+		hatchDeadlineHeight := getRandomHatchDeadlineHeight(r, ctx, functionType)
 
 		msg := types.NewMsgCreateWar(token, name, desc, creator, functionType,
 			functionParameters, reserveTokens, txFeePercentage, exitFeePercentage,
 			feeAddress, maxSupply, blankOrderQuantityLimits, blankSanityRate,
-			blankSanityMarginPercentage, allowSells, signers, batchBlocks, blankOutcomePayment)
+			blankSanityMarginPercentage, allowSells, signers, blankSignerThreshold,
+			blankEditDelay, batchBlocks, blankOutcomePayment, hatchDeadlineHeight,
+			blankAllowedHatchers, blankHatchMembershipDenom, blankMaxHatchContribution,
+			blankHatchVestingCliff, blankHatchVestingPeriod,
+			blankFundingPoolSpenders, blankFundingPoolSpendLimit,
+			blankFundingPoolEpochBlocks, blankGovernanceQuorumPercentage,
+			blankGovernanceThresholdPercentage, blankGovernanceVotingPeriod,
+			blankMaxPriceMovePercentage, blankPriceMoveCooldown)
 		if msg.ValidateBasic() != nil {
 			return simulation.NoOpMsg(types.ModuleName), nil,
 				fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
@@ -178,7 +207,9 @@ func SimulateMsgCreateWar(ak auth.AccountKeeper) simulation.Operation {
 		}
 
 		incrementWarCount() // since successfully created
-		if msg.FunctionType == types.SwapperFunction {
+		if msg.FunctionType == types.SwapperFunction ||
+			msg.FunctionType == types.WeightedSwapperFunction ||
+			msg.FunctionType == types.StableSwapFunction {
 			newSwapperWar(msg.Token)
 		}
 		return simulation.NewOperationMsg(msg, true, ""), nil, nil
@@ -211,7 +242,10 @@ func SimulateMsgEditWar(ak auth.AccountKeeper, k keeper.Keeper) simulation.Opera
 
 		msg := types.NewMsgEditWar(token, name, desc,
 			types.DoNotModifyField, types.DoNotModifyField,
-			types.DoNotModifyField, editor, signers)
+			types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField,
+			types.DoNotModifyField, types.DoNotModifyField, editor, signers)
 		if msg.ValidateBasic() != nil {
 			return simulation.NoOpMsg(types.ModuleName), nil,
 				fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
@@ -242,20 +276,15 @@ func getBuyIntoSwapper(r *rand.Rand, ctx sdk.Context, k keeper.Keeper,
 	spendable := account.SpendableCoins(ctx.BlockTime())
 
 	// Come up with max prices based on what is spendable
-	spendableReserve1 := spendable.AmountOf(war.ReserveTokens[0])
-	spendableReserve2 := spendable.AmountOf(war.ReserveTokens[1])
-	maxPriceInt1, err := simulation.RandPositiveInt(r, spendableReserve1)
-	if err != nil {
-		return types.MsgBuy{}, err, false
-	}
-	maxPriceInt2, err := simulation.RandPositiveInt(r, spendableReserve2)
-	if err != nil {
-		return types.MsgBuy{}, err, false
+	var maxPrices sdk.Coins
+	for _, reserveToken := range war.ReserveTokens {
+		spendableReserve := spendable.AmountOf(reserveToken)
+		maxPriceInt, err := simulation.RandPositiveInt(r, spendableReserve)
+		if err != nil {
+			return types.MsgBuy{}, err, false
+		}
+		maxPrices = maxPrices.Add(sdk.NewCoin(reserveToken, maxPriceInt))
 	}
-	maxPrices := sdk.NewCoins(
-		sdk.NewCoin(war.ReserveTokens[0], maxPriceInt1),
-		sdk.NewCoin(war.ReserveTokens[1], maxPriceInt2),
-	)
 
 	// Get lesser of max possible increase in supply and max order quantity
 	var maxBuyAmount sdk.Int
@@ -280,13 +309,13 @@ func getBuyIntoSwapper(r *rand.Rand, ctx sdk.Context, k keeper.Keeper,
 	// If not the first buy, create order and check if can afford
 	if war.CurrentSupply.IsPositive() {
 		_, _, err = k.GetUpdatedBatchPricesAfterBuy(ctx, war.Token,
-			types.NewBuyOrder(address, amountToBuy, maxPrices))
+			types.NewBuyOrder(address, amountToBuy, maxPrices, false))
 		if err != nil {
 			return types.MsgBuy{}, err, true
 		}
 	}
 
-	return types.NewMsgBuy(address, amountToBuy, maxPrices), nil, true
+	return types.NewMsgBuy(address, amountToBuy, maxPrices, false), nil, true
 }
 
 func getBuyIntoNonSwapper(r *rand.Rand, ctx sdk.Context, k keeper.Keeper,
@@ -346,12 +375,12 @@ func getBuyIntoNonSwapper(r *rand.Rand, ctx sdk.Context, k keeper.Keeper,
 
 	// Create order and check if can afford
 	_, _, err = k.GetUpdatedBatchPricesAfterBuy(ctx, war.Token,
-		types.NewBuyOrder(address, toBuy, maxPrices))
+		types.NewBuyOrder(address, toBuy, maxPrices, false))
 	if err != nil {
 		return types.MsgBuy{}, err, true
 	}
 
-	return types.NewMsgBuy(address, toBuy, maxPrices), nil, true
+	return types.NewMsgBuy(address, toBuy, maxPrices, false), nil, true
 }
 
 func SimulateMsgBuy(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation {
@@ -364,7 +393,7 @@ func SimulateMsgBuy(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation
 			return simulation.NoOpMsg(types.ModuleName), nil, nil
 		}
 		war, found := k.GetWar(ctx, token)
-		if !found {
+		if !found || war.State == types.FailedState {
 			return simulation.NoOpMsg(types.ModuleName), nil, nil
 		}
 
@@ -386,7 +415,7 @@ func SimulateMsgBuy(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operation
 		account := ak.GetAccount(ctx, simAccount.Address)
 
 		var msg types.MsgBuy
-		if war.FunctionType == types.SwapperFunction {
+		if war.IsSwapper() {
 			msg, err, ok = getBuyIntoSwapper(r, ctx, k, war, account)
 		} else {
 			msg, err, ok = getBuyIntoNonSwapper(r, ctx, k, war, account)
@@ -460,7 +489,7 @@ func SimulateMsgSell(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operatio
 		}
 		amountToSell := sdk.NewCoin(war.Token, toSellInt)
 
-		msg := types.NewMsgSell(address, amountToSell)
+		msg := types.NewMsgSell(address, amountToSell, nil)
 		if msg.ValidateBasic() != nil {
 			return simulation.NoOpMsg(types.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
 		}
@@ -504,8 +533,11 @@ func SimulateMsgSwap(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operatio
 		token := filteredWars[simulation.RandIntBetween(r, 0, len(filteredWars))]
 		war := k.MustGetWar(ctx, token)
 
-		fromIndex := simulation.RandIntBetween(r, 0, 1)
-		toIndex := 1 - fromIndex
+		fromIndex := simulation.RandIntBetween(r, 0, len(war.ReserveTokens))
+		toIndex := simulation.RandIntBetween(r, 0, len(war.ReserveTokens)-1)
+		if toIndex >= fromIndex {
+			toIndex++ // skip fromIndex
+		}
 
 		fromToken := war.ReserveTokens[fromIndex]
 		toToken := war.ReserveTokens[toIndex]
@@ -534,7 +566,9 @@ func SimulateMsgSwap(ak auth.AccountKeeper, k keeper.Keeper) simulation.Operatio
 		}
 		amountToSwap := sdk.NewCoin(fromToken, toSwapInt)
 
-		msg := types.NewMsgSwap(address, token, amountToSwap, toToken)
+		minOutput := sdk.NewCoin(toToken, sdk.ZeroInt())
+
+		msg := types.NewMsgSwap(address, token, amountToSwap, toToken, minOutput)
 		if msg.ValidateBasic() != nil {
 			return simulation.NoOpMsg(types.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
 		}
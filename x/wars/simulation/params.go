@@ -0,0 +1,70 @@
+package simulation
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/mage-war/wars/x/wars/internal/types"
+	"math/rand"
+)
+
+// ParamChanges defines the parameters that can be modified by param change
+// proposals on the simulation. The min and max batch blocks are generated from
+// ranges that do not overlap, so that changing either one of them never makes
+// the max batch blocks less than the min batch blocks.
+func ParamChanges(r *rand.Rand) []simulation.ParamChange {
+	return []simulation.ParamChange{
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxTxFeePercentage),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%s\"", genMaxFeePercentage(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxExitFeePercentage),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%s\"", genMaxFeePercentage(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMinBatchBlocks),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", simulation.RandIntBetween(r, 1, 5))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxBatchBlocks),
+			func(r *rand.Rand) string {
+				if r.Intn(2) == 0 {
+					return "\"0\"" // no max batch blocks
+				}
+				return fmt.Sprintf("\"%d\"", simulation.RandIntBetween(r, 5, 20))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxWarsPerCreator),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", simulation.RandIntBetween(r, 0, 10))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxLimitOrderExpiryBlocks),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", simulation.RandIntBetween(r, 0, 1000))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxLimitOrdersPerAddress),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", simulation.RandIntBetween(r, 0, 20))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxLimitOrdersPerWar),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", simulation.RandIntBetween(r, 0, 100))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxProposalsPerWar),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", simulation.RandIntBetween(r, 0, 20))
+			},
+		),
+	}
+}
+
+func genMaxFeePercentage(r *rand.Rand) sdk.Dec {
+	return sdk.NewDec(int64(simulation.RandIntBetween(r, 1, 101)))
+}
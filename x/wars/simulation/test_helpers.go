@@ -1,6 +1,7 @@
 package simulation
 
 import (
+	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/simulation"
 	"github.com/mage-war/wars/x/wars/internal/types"
@@ -51,12 +52,22 @@ func getRandomWarNameExcept(r *rand.Rand, except string) (warName string, ok boo
 	}
 }
 
+func getRandomDistinctWarNames(r *rand.Rand, n int) (warNames []string, ok bool) {
+	if totalWarCount < n {
+		return nil, false
+	}
+	for _, i := range r.Perm(totalWarCount)[:n] {
+		warNames = append(warNames, tokenPrefix+strconv.Itoa(i+1))
+	}
+	return warNames, true
+}
+
 func getRandomNonEmptyString(r *rand.Rand) string {
 	return simulation.RandStringOfLength(r, simulation.RandIntBetween(r, 1, 100))
 }
 
 func getRandomFunctionType(r *rand.Rand) string {
-	switch simulation.RandIntBetween(r, 0, 4) {
+	switch simulation.RandIntBetween(r, 0, 10) {
 	case 0:
 		return types.PowerFunction
 	case 1:
@@ -65,12 +76,24 @@ func getRandomFunctionType(r *rand.Rand) string {
 		return types.SwapperFunction
 	case 3:
 		return types.AugmentedFunction
+	case 4:
+		return types.ExponentialFunction
+	case 5:
+		return types.LogarithmicFunction
+	case 6:
+		return types.BancorFunction
+	case 7:
+		return types.PiecewiseLinearFunction
+	case 8:
+		return types.WeightedSwapperFunction
+	case 9:
+		return types.StableSwapFunction
 	default:
 		panic("function type integer out of bounds")
 	}
 }
 
-func getRandomFunctionParameters(r *rand.Rand, functionType string, genesis bool) types.FunctionParams {
+func getRandomFunctionParameters(r *rand.Rand, functionType string, reserveTokens []string, genesis bool) types.FunctionParams {
 	switch functionType {
 	case types.PowerFunction:
 		m := simulation.RandIntBetween(r, 1, 100)
@@ -111,8 +134,55 @@ func getRandomFunctionParameters(r *rand.Rand, functionType string, genesis bool
 				}...)
 		}
 		return functionParams
+	case types.ExponentialFunction:
+		a := simulation.RandIntBetween(r, 1, 100)
+		b := simulation.RandIntBetween(r, 1, 100) // b in (0, 0.0001)
+		return types.FunctionParams{
+			types.NewFunctionParam("a", sdk.NewDec(int64(a))),
+			types.NewFunctionParam("b", sdk.NewDecWithPrec(int64(b), 6))}
+	case types.LogarithmicFunction:
+		a := simulation.RandIntBetween(r, 1, 100)
+		b := simulation.RandIntBetween(r, 1, 1000) // b in (0, 1)
+		c := simulation.RandIntBetween(r, 1, 1000)
+		return types.FunctionParams{
+			types.NewFunctionParam("a", sdk.NewDec(int64(a))),
+			types.NewFunctionParam("b", sdk.NewDecWithPrec(int64(b), 3)),
+			types.NewFunctionParam("c", sdk.NewDec(int64(c)))}
+	case types.BancorFunction:
+		ratio := simulation.RandIntBetween(r, 1, 101) // r in (0, 1]
+		p0 := simulation.RandomDecAmount(r, sdk.NewDec(10)).Add(sdk.SmallestDec())
+		s0 := simulation.RandIntBetween(r, 1, 1000000)
+		return types.FunctionParams{
+			types.NewFunctionParam("r", sdk.NewDecWithPrec(int64(ratio), 2)),
+			types.NewFunctionParam("p0", p0),
+			types.NewFunctionParam("s0", sdk.NewDec(int64(s0)))}
+	case types.PiecewiseLinearFunction:
+		// Between 2 and 5 breakpoints, with x0=0 and increasing supplies
+		var functionParams types.FunctionParams
+		noOfBreakpoints := simulation.RandIntBetween(r, 2, 6)
+		x := 0
+		for i := 0; i < noOfBreakpoints; i++ {
+			p := simulation.RandIntBetween(r, 0, 1000)
+			functionParams = append(functionParams,
+				types.NewFunctionParam(fmt.Sprintf("x%d", i), sdk.NewDec(int64(x))),
+				types.NewFunctionParam(fmt.Sprintf("p%d", i), sdk.NewDec(int64(p))))
+			x += simulation.RandIntBetween(r, 1, 1000000)
+		}
+		return functionParams
 	case types.SwapperFunction:
 		return nil
+	case types.WeightedSwapperFunction:
+		var functionParams types.FunctionParams
+		for _, reserveToken := range reserveTokens {
+			w := simulation.RandIntBetween(r, 1, 100)
+			functionParams = append(functionParams,
+				types.NewFunctionParam(reserveToken, sdk.NewDec(int64(w))))
+		}
+		return functionParams
+	case types.StableSwapFunction:
+		A := simulation.RandIntBetween(r, 1, 1000)
+		return types.FunctionParams{
+			types.NewFunctionParam("A", sdk.NewDec(int64(A)))}
 	default:
 		panic("unrecognized function type")
 	}
@@ -126,6 +196,15 @@ func getRandomAllowSellsValue(r *rand.Rand) bool {
 	}
 }
 
+func getRandomHatchDeadlineHeight(r *rand.Rand, ctx sdk.Context, functionType string) int64 {
+	if functionType != types.AugmentedFunction {
+		return 0
+	} else if simulation.RandIntBetween(r, 1, 3) == 1 {
+		return 0 // 1 time out of 2, there is no hatch deadline
+	}
+	return ctx.BlockHeight() + int64(simulation.RandIntBetween(r, 1, 50))
+}
+
 func getInitialWarState(functionType string) string {
 	switch functionType {
 	case types.AugmentedFunction:
@@ -135,7 +214,7 @@ func getInitialWarState(functionType string) string {
 	}
 }
 
-//noinspection GoNilness
+// noinspection GoNilness
 func getDummyNonZeroReserve(reserveTokens []string) (reserve sdk.Coins) {
 	for _, token := range reserveTokens {
 		reserve = reserve.Add(sdk.NewCoin(token, sdk.OneInt()))